@@ -23,7 +23,9 @@ var (
 			cli.NewListCommand(workflowHistoryCmd, workflowHistoryRun, nil, withAllCommandModifiers()...),
 			cli.NewGetCommand(workflowShowCmd, workflowShowRun, nil, withAllCommandModifiers()...),
 			cli.NewGetCommand(workflowStatusCmd, workflowStatusRun, nil, withAllCommandModifiers()...),
-			cli.NewCommand(workflowRunManualCmd, workflowRunManualRun, nil, withAllCommandModifiers()...),
+			cli.NewCommand(workflowRunManualCmd, workflowRunManualRun, []*cobra.Command{
+				cli.NewListCommand(workflowRunDiffCmd, workflowRunDiffRun, nil, withAllCommandModifiers()...),
+			}, withAllCommandModifiers()...),
 			cli.NewCommand(workflowStopCmd, workflowStopRun, nil, withAllCommandModifiers()...),
 			cli.NewCommand(workflowExportCmd, workflowExportRun, nil, withAllCommandModifiers()...),
 			cli.NewCommand(workflowImportCmd, workflowImportRun, nil, withAllCommandModifiers()...),
@@ -66,6 +66,15 @@ var workflowRunManualCmd = cli.Command{
 			},
 			Kind: reflect.String,
 		},
+		{
+			Name:  "replay",
+			Usage: "Existing Workflow RUN Number to replay in a new run; Flag parameter overrides its parameters",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`[0-9]?`, s)
+				return match
+			},
+			Kind: reflect.String,
+		},
 		{
 			Name:  "node-name",
 			Usage: "Node Name to relaunch; Flag run-number is mandatory",
@@ -152,7 +161,23 @@ func workflowRunManualRun(v cli.Values) error {
 		}
 	}
 
-	w, err := client.WorkflowRunFromManual(v[_ProjectKey], v[_WorkflowName], manual, runNumber, fromNodeID)
+	var w *sdk.WorkflowRun
+	var err error
+	if v.GetString("replay") != "" {
+		if runNumber > 0 || fromNodeID > 0 {
+			return fmt.Errorf("You can't use flag replay with flags run-number or node-name")
+		}
+		replayNumber, errp := strconv.ParseInt(v.GetString("replay"), 10, 64)
+		if errp != nil {
+			return fmt.Errorf("replay invalid: not a integer")
+		}
+		w, err = client.WorkflowRunReplay(v[_ProjectKey], v[_WorkflowName], sdk.WorkflowRunReplay{
+			Number:    replayNumber,
+			Overrides: manual.PipelineParameters,
+		})
+	} else {
+		w, err = client.WorkflowRunFromManual(v[_ProjectKey], v[_WorkflowName], manual, runNumber, fromNodeID)
+	}
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ovh/cds/cli"
+)
+
+var workflowRunDiffCmd = cli.Command{
+	Name:  "diff",
+	Short: "Display the differences between two CDS workflow runs",
+	Long: `Display the differences between two runs of a workflow: parameters, commits, workflow definition, status and duration of each node.
+
+	cdsctl workflow run diff MYPROJECT myworkflow 41 42
+`,
+	Ctx: []cli.Arg{
+		{Name: _ProjectKey},
+		{Name: _WorkflowName},
+	},
+	Args: []cli.Arg{
+		{
+			Name: "from",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`^[0-9]+$`, s)
+				return match
+			},
+		},
+		{
+			Name: "to",
+			IsValid: func(s string) bool {
+				match, _ := regexp.MatchString(`^[0-9]+$`, s)
+				return match
+			},
+		},
+	},
+}
+
+type workflowRunDiffLine struct {
+	Kind string `cli:"kind"`
+	Name string `cli:"name"`
+	From string `cli:"from"`
+	To   string `cli:"to"`
+}
+
+func workflowRunDiffRun(v cli.Values) (cli.ListResult, error) {
+	from, err := v.GetInt64("from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := v.GetInt64("to")
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := client.WorkflowRunDiff(v[_ProjectKey], v[_WorkflowName], from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []workflowRunDiffLine{}
+	for _, p := range diff.Parameters {
+		lines = append(lines, workflowRunDiffLine{Kind: "parameter", Name: p.Name, From: p.From, To: p.To})
+	}
+	for _, c := range diff.Commits {
+		lines = append(lines, workflowRunDiffLine{Kind: "commit", Name: c.Name, From: c.From, To: c.To})
+	}
+	for _, w := range diff.Workflow {
+		lines = append(lines, workflowRunDiffLine{Kind: "workflow", Name: w.Name, From: w.From, To: w.To})
+	}
+	for _, n := range diff.Nodes {
+		lines = append(lines, workflowRunDiffLine{
+			Kind: "node",
+			Name: n.Name,
+			From: fmt.Sprintf("%s (%s)", n.FromStatus, n.FromDuration),
+			To:   fmt.Sprintf("%s (%s)", n.ToStatus, n.ToDuration),
+		})
+	}
+	return cli.AsListResult(lines), nil
+}
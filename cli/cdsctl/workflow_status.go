@@ -65,27 +65,55 @@ func workflowStatusRunWithTrack(v cli.Values) (interface{}, error) {
 		runNumber, _ = workflowNodeForCurrentRepo(v[_ProjectKey], v.GetString(_WorkflowName))
 	}
 
-	run, err := client.WorkflowRunGet(v[_ProjectKey], v.GetString(_WorkflowName), runNumber)
-	if err != nil {
-		return nil, err
-	}
+	// Listen the events of the run, the run is reloaded on each event.
+	// If the events stream is not available, the run is polled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan sdk.Event)
+	errListen := make(chan error, 1)
+	go func() {
+		errListen <- client.EventsListen(ctx, v[_ProjectKey], v.GetString(_WorkflowName), runNumber, events)
+	}()
+	streaming := true
 
 	for {
-		run, err = client.WorkflowRunGet(v[_ProjectKey], v.GetString(_WorkflowName), runNumber)
+		run, err := client.WorkflowRunGet(v[_ProjectKey], v.GetString(_WorkflowName), runNumber)
 		if err != nil {
 			return nil, err
 		}
 
 		workflowRunFormatDisplay(run, latestCommit, currentDisplay)
-		time.Sleep(500 * time.Millisecond)
 		if sdk.StatusIsTerminated(run.Status) {
 			break
 		}
+
+		if !streaming {
+			time.Sleep(500 * time.Millisecond)
+			continue
+		}
+		select {
+		case <-events:
+			workflowStatusDrainEvents(events)
+		case <-errListen:
+			streaming = false
+		case <-time.After(30 * time.Second):
+		}
 	}
 	fmt.Println()
 	return nil, nil
 }
 
+// workflowStatusDrainEvents discards the pending events, to reload the run only once for a burst of events
+func workflowStatusDrainEvents(events <-chan sdk.Event) {
+	for {
+		select {
+		case <-events:
+		case <-time.After(100 * time.Millisecond):
+			return
+		}
+	}
+}
+
 //w-cds #10.0 ✓root -> ✓build -> ✓test -> ✗deploy
 func workflowRunFormatDisplay(run *sdk.WorkflowRun, commit repo.Commit, currentDisplay *cli.Display) {
 	var output = "%s [%s | %s] " + cli.Cyan("#%d.%d", run.Number, run.LastSubNumber)
@@ -153,6 +181,7 @@ func workflowStatusRunWithoutTrack(v cli.Values) (interface{}, error) {
 		sdk.WorkflowRun
 		Payload string `cli:"payload"`
 		Tags    string `cli:"tags"`
+		Stages  string `cli:"stages"`
 	}
 
 	var payload []string
@@ -175,6 +204,49 @@ func workflowStatusRunWithoutTrack(v cli.Values) (interface{}, error) {
 		}
 	}
 
-	wt := &wtags{*run, strings.Join(payload, " "), strings.Join(tags, " ")}
+	wt := &wtags{*run, strings.Join(payload, " "), strings.Join(tags, " "), workflowRunFormatStages(run)}
 	return *wt, nil
 }
+
+//build/compile:✓ build/lint:✗(needs:compile) build/package:-(needs:compile,lint)
+func workflowRunFormatStages(run *sdk.WorkflowRun) string {
+	nodeIDs := []int64{}
+	for id := range run.WorkflowNodeRuns {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return nodeIDs[i] < nodeIDs[j]
+	})
+
+	var stages []string
+	for _, nodeID := range nodeIDs {
+		nodeRuns := run.WorkflowNodeRuns[nodeID]
+		if len(nodeRuns) == 0 {
+			continue
+		}
+		sort.Slice(nodeRuns, func(i, j int) bool {
+			return nodeRuns[i].SubNumber > nodeRuns[j].SubNumber
+		})
+
+		nodeRun := nodeRuns[0]
+		for _, s := range nodeRun.Stages {
+			var status string
+			switch s.Status {
+			case sdk.StatusSuccess:
+				status = cli.OKChar
+			case sdk.StatusFail, sdk.StatusStopped:
+				status = cli.KOChar
+			case sdk.StatusSkipped, sdk.StatusDisabled:
+				status = "-"
+			default:
+				status = cli.BuildingChar
+			}
+			stage := fmt.Sprintf("%s/%s:%s", nodeRun.WorkflowNodeName, s.Name, status)
+			if len(s.Needs) > 0 {
+				stage += fmt.Sprintf("(needs:%s)", strings.Join(s.Needs, ","))
+			}
+			stages = append(stages, stage)
+		}
+	}
+	return strings.Join(stages, " ")
+}
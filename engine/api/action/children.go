@@ -1,20 +1,37 @@
 package action
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
 
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
 
-func insertEdge(db gorp.SqlExecutor, parentID, childID int64, execOrder int, stepName string, optional, alwaysExecuted, enabled bool) (int64, error) {
-	query := `INSERT INTO action_edge (parent_id, child_id, exec_order, step_name, optional, always_executed, enabled) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+func insertEdge(db gorp.SqlExecutor, parentID, childID int64, execOrder int, stepName string, optional, alwaysExecuted, enabled bool, retryPolicy *sdk.RetryPolicy, timeout string) (int64, error) {
+	var policy sql.NullString
+	if retryPolicy != nil {
+		if err := retryPolicy.IsValid(); err != nil {
+			return 0, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("invalid retry policy on step %s: %v", stepName, err))
+		}
+		var err error
+		policy, err = gorpmapping.JSONToNullString(retryPolicy)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if err := sdk.IsValidTimeout(timeout); err != nil {
+		return 0, sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("invalid timeout on step %s: %v", stepName, err))
+	}
+
+	query := `INSERT INTO action_edge (parent_id, child_id, exec_order, step_name, optional, always_executed, enabled, retry_policy, timeout) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 
 	var id int64
-	err := db.QueryRow(query, parentID, childID, execOrder, stepName, optional, alwaysExecuted, enabled).Scan(&id)
+	err := db.QueryRow(query, parentID, childID, execOrder, stepName, optional, alwaysExecuted, enabled, policy, timeout).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
@@ -32,7 +49,7 @@ func insertActionChild(db gorp.SqlExecutor, actionID int64, child sdk.Action, ex
 		child.StepName = ""
 	}
 
-	id, err := insertEdge(db, actionID, child.ID, execOrder, child.StepName, child.Optional, child.AlwaysExecuted, child.Enabled)
+	id, err := insertEdge(db, actionID, child.ID, execOrder, child.StepName, child.Optional, child.AlwaysExecuted, child.Enabled, child.RetryPolicy, child.Timeout)
 	if err != nil {
 		return err
 	}
@@ -73,7 +90,7 @@ func loadActionChildren(db gorp.SqlExecutor, actionID int64) ([]sdk.Action, erro
 	var children []sdk.Action
 	var edgeIDs []int64
 	var childrenIDs []int64
-	query := `SELECT id, child_id, exec_order, step_name, optional, always_executed, enabled FROM action_edge WHERE parent_id = $1 ORDER BY exec_order ASC`
+	query := `SELECT id, child_id, exec_order, step_name, optional, always_executed, enabled, retry_policy, timeout FROM action_edge WHERE parent_id = $1 ORDER BY exec_order ASC`
 
 	rows, err := db.Query(query, actionID)
 	if err != nil {
@@ -85,22 +102,31 @@ func loadActionChildren(db gorp.SqlExecutor, actionID int64) ([]sdk.Action, erro
 	var execOrder int
 	var stepName string
 	var optional, alwaysExecuted, enabled bool
+	var retryPolicy, timeout sql.NullString
 	var mapStepName = make(map[int64]string)
 	var mapOptional = make(map[int64]bool)
 	var mapAlwaysExecuted = make(map[int64]bool)
 	var mapEnabled = make(map[int64]bool)
+	var mapRetryPolicy = make(map[int64]*sdk.RetryPolicy)
+	var mapTimeout = make(map[int64]string)
 
 	for rows.Next() {
-		err = rows.Scan(&edgeID, &childID, &execOrder, &stepName, &optional, &alwaysExecuted, &enabled)
+		err = rows.Scan(&edgeID, &childID, &execOrder, &stepName, &optional, &alwaysExecuted, &enabled, &retryPolicy, &timeout)
 		if err != nil {
 			return nil, err
 		}
+		var policy *sdk.RetryPolicy
+		if err := gorpmapping.JSONNullString(retryPolicy, &policy); err != nil {
+			return nil, fmt.Errorf("cannot unmarshal retry policy> %s", err)
+		}
+		mapRetryPolicy[edgeID] = policy
 		edgeIDs = append(edgeIDs, edgeID)
 		childrenIDs = append(childrenIDs, childID)
 		mapStepName[edgeID] = stepName
 		mapOptional[edgeID] = optional
 		mapAlwaysExecuted[edgeID] = alwaysExecuted
 		mapEnabled[edgeID] = enabled
+		mapTimeout[edgeID] = timeout.String
 	}
 	rows.Close()
 
@@ -128,6 +154,10 @@ func loadActionChildren(db gorp.SqlExecutor, actionID int64) ([]sdk.Action, erro
 		children[i].AlwaysExecuted = mapAlwaysExecuted[edgeIDs[i]]
 		// Get enable flag
 		children[i].Enabled = mapEnabled[edgeIDs[i]]
+		// Get retry policy
+		children[i].RetryPolicy = mapRetryPolicy[edgeIDs[i]]
+		// Get timeout
+		children[i].Timeout = mapTimeout[edgeIDs[i]]
 	}
 
 	return children, nil
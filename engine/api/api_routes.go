@@ -84,6 +84,8 @@ func (api *API) InitRouter() {
 	// Hooks
 	r.Handle("/hook", r.POST(api.receiveHookHandler, Auth(false) /* Public handler called by third parties */))
 	r.Handle("/hook/{uuid}/workflow/{workflowID}/vcsevent/{vcsServer}", r.GET(api.getHookPollingVCSEvents))
+	r.Handle("/hook/{uuid}/gitrefs", r.GET(api.getHookGitRefsHandler, NeedService()))
+	r.Handle("/hook/{uuid}/changedfiles", r.GET(api.getHookChangedFilesHandler, NeedService()))
 
 	// Platform
 	r.Handle("/platform/models", r.GET(api.getPlatformModelsHandler), r.POST(api.postPlatformModelHandler, NeedAdmin(true)))
@@ -94,6 +96,13 @@ func (api *API) InitRouter() {
 	r.Handle("/broadcast/{id}", r.GET(api.getBroadcastHandler), r.PUT(api.updateBroadcastHandler, NeedAdmin(true)), r.DELETE(api.deleteBroadcastHandler, NeedAdmin(true)))
 	r.Handle("/broadcast/{id}/mark", r.POST(api.postMarkAsReadBroadcastHandler))
 
+	// Workflow templates
+	r.Handle("/template", r.GET(api.getWorkflowTemplatesHandler), r.POST(api.postWorkflowTemplateHandler))
+	r.Handle("/template/{id}", r.GET(api.getWorkflowTemplateHandler), r.PUT(api.putWorkflowTemplateHandler), r.DELETE(api.deleteWorkflowTemplateHandler))
+	r.Handle("/template/{id}/instance", r.GET(api.getWorkflowTemplateInstancesHandler))
+	r.Handle("/template/{id}/instance/{permProjectKey}", r.POST(api.postWorkflowTemplateApplyHandler))
+	r.Handle("/template/{id}/bulk", r.POST(api.postWorkflowTemplateBulkHandler))
+
 	// Overall health
 	r.Handle("/mon/status", r.GET(api.statusHandler, Auth(false)))
 	r.Handle("/mon/smtp/ping", r.GET(api.smtpPingHandler, Auth(true)))
@@ -126,10 +135,16 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{permProjectKey}/applications", r.GET(api.getApplicationsHandler, AllowProvider(true)), r.POST(api.addApplicationHandler))
 	r.Handle("/project/{permProjectKey}/platforms", r.GET(api.getProjectPlatformsHandler), r.POST(api.postProjectPlatformHandler))
 	r.Handle("/project/{permProjectKey}/platforms/{platformName}", r.GET(api.getProjectPlatformHandler, AllowServices(true)), r.PUT(api.putProjectPlatformHandler), r.DELETE(api.deleteProjectPlatformHandler))
+	r.Handle("/project/{permProjectKey}/webhooks", r.GET(api.getProjectWebhooksHandler), r.POST(api.postProjectWebhookHandler))
+	r.Handle("/project/{permProjectKey}/webhooks/{webhookID}", r.GET(api.getProjectWebhookHandler), r.PUT(api.putProjectWebhookHandler), r.DELETE(api.deleteProjectWebhookHandler))
+	r.Handle("/project/{permProjectKey}/webhooks/{webhookID}/deliveries", r.GET(api.getProjectWebhookDeliveriesHandler))
+	r.Handle("/project/{permProjectKey}/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver", r.POST(api.postProjectWebhookRedeliverHandler))
 	r.Handle("/project/{permProjectKey}/notifications", r.GET(api.getProjectNotificationsHandler))
 	r.Handle("/project/{permProjectKey}/all/keys", r.GET(api.getAllKeysProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys", r.GET(api.getKeysInProjectHandler), r.POST(api.addKeyInProjectHandler))
 	r.Handle("/project/{permProjectKey}/keys/{name}", r.DELETE(api.deleteKeyInProjectHandler))
+	r.Handle("/project/{permProjectKey}/retention", r.GET(api.getArtifactRetentionPolicyHandler), r.PUT(api.putArtifactRetentionPolicyHandler), r.DELETE(api.deleteArtifactRetentionPolicyHandler))
+	r.Handle("/project/{permProjectKey}/retention/report", r.GET(api.getArtifactRetentionReportHandler))
 	// Import Application
 	r.Handle("/project/{permProjectKey}/import/application", r.POST(api.postApplicationImportHandler))
 	// Export Application
@@ -226,6 +241,7 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups", r.POST(api.postWorkflowGroupHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/groups/{groupName}", r.PUT(api.putWorkflowGroupHandler), r.DELETE(api.deleteWorkflowGroupHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/hooks/{uuid}", r.GET(api.getWorkflowHookHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/retention", r.GET(api.getArtifactRetentionPolicyHandler), r.PUT(api.putArtifactRetentionPolicyHandler), r.DELETE(api.deleteArtifactRetentionPolicyHandler))
 	r.Handle("/project/{key}/workflow/{permWorkflowName}/node/{nodeID}/hook/model", r.GET(api.getWorkflowHookModelsHandler))
 
 	// Preview workflows
@@ -248,12 +264,14 @@ func (api *API) InitRouter() {
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/tags", r.GET(api.getWorkflowRunTagsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/num", r.GET(api.getWorkflowRunNumHandler), r.POST(api.postWorkflowRunNumHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}", r.GET(api.getWorkflowRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/diff/{to}", r.GET(api.getWorkflowRunDiffHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/stop", r.POSTEXECUTE(api.stopWorkflowRunHandler, EnableTracing()))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/vcs/resync", r.POSTEXECUTE(api.postResyncVCSWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/resync", r.POST(api.resyncWorkflowRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/artifacts", r.GET(api.getWorkflowRunArtifactsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}", r.GET(api.getWorkflowNodeRunHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/stop", r.POSTEXECUTE(api.stopWorkflowNodeRunHandler))
+	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/approve", r.POSTEXECUTE(api.postWorkflowNodeRunApproveHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeID}/history", r.GET(api.getWorkflowNodeRunHistoryHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/{nodeName}/commits", r.GET(api.getWorkflowCommitsHandler))
 	r.Handle("/project/{key}/workflows/{permWorkflowName}/runs/{number}/nodes/{nodeRunID}/job/{runJobId}/log/service", r.GET(api.getWorkflowNodeRunJobServiceLogsHandler))
@@ -302,6 +320,7 @@ func (api *API) InitRouter() {
 	// Cache
 	r.Handle("/project/{permProjectKey}/cache/{tag}", r.POSTEXECUTE(api.postPushCacheHandler, NeedWorker()), r.GET(api.getPullCacheHandler, NeedWorker()))
 	r.Handle("/project/{permProjectKey}/cache/{tag}/url", r.POSTEXECUTE(api.postPushCacheWithTempURLHandler, NeedWorker()), r.GET(api.getPullCacheWithTempURLHandler, NeedWorker()))
+	r.Handle("/project/{permProjectKey}/cache/{tag}/url/callback", r.POSTEXECUTE(api.postPushCacheWithTempURLCallbackHandler, NeedWorker()))
 
 	// Hooks
 	r.Handle("/project/{key}/application/{permApplicationName}/hook", r.GET(api.getApplicationHooksHandler))
@@ -340,6 +359,7 @@ func (api *API) InitRouter() {
 	r.Handle("/queue/workflows/{permID}/variable", r.POSTEXECUTE(api.postWorkflowJobVariableHandler, NeedWorker(), EnableTracing()))
 	r.Handle("/queue/workflows/{permID}/step", r.POSTEXECUTE(api.postWorkflowJobStepStatusHandler, NeedWorker(), EnableTracing()))
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}", r.POSTEXECUTE(api.postWorkflowJobArtifactHandler, NeedWorker(), EnableTracing()))
+	r.Handle("/queue/workflows/{permID}/artifact/{ref}/blob", r.POSTEXECUTE(api.postWorkflowJobArtifactBlobHandler, NeedWorker(), EnableTracing()))
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}/url", r.POSTEXECUTE(api.postWorkflowJobArtifacWithTempURLHandler, NeedWorker(), EnableTracing()))
 	r.Handle("/queue/workflows/{permID}/artifact/{ref}/url/callback", r.POSTEXECUTE(api.postWorkflowJobArtifactWithTempURLCallbackHandler, NeedWorker(), EnableTracing()))
 
@@ -418,6 +438,8 @@ func (api *API) InitRouter() {
 	// Workflows
 	r.Handle("/workflow/hook", r.GET(api.getWorkflowHooksHandler, NeedService()))
 	r.Handle("/workflow/hook/model/{model}", r.GET(api.getWorkflowHookModelHandler), r.POST(api.postWorkflowHookModelHandler, NeedAdmin(true)), r.PUT(api.putWorkflowHookModelHandler, NeedAdmin(true)))
+	r.Handle("/workflow/concurrency/group", r.GET(api.getSharedConcurrencyGroupsHandler), r.POST(api.postSharedConcurrencyGroupHandler, NeedAdmin(true)))
+	r.Handle("/workflow/concurrency/group/{name}", r.DELETE(api.deleteSharedConcurrencyGroupHandler, NeedAdmin(true)))
 
 	// SSE
 	r.Handle("/events", r.GET(api.eventsBroker.ServeHTTP))
@@ -31,7 +31,7 @@ func newTestAPI(t *testing.T, bootstrapFunc ...test.Bootstrapf) (*API, *gorp.DbM
 		Config:              Configuration{},
 		Cache:               cache,
 	}
-	_ = event.Initialize(event.KafkaConfig{}, api.Cache)
+	_ = event.Initialize(event.Config{}, api.Cache)
 	api.InitRouter()
 	api.warnChan = make(chan sdk.Event)
 	event.Subscribe(api.warnChan)
@@ -53,7 +53,7 @@ func newTestServer(t *testing.T, bootstrapFunc ...test.Bootstrapf) (*API, string
 		Config:              Configuration{},
 		Cache:               cache,
 	}
-	_ = event.Initialize(event.KafkaConfig{}, api.Cache)
+	_ = event.Initialize(event.Config{}, api.Cache)
 	api.InitRouter()
 	ts := httptest.NewServer(router.Mux)
 	url, _ := url.Parse(ts.URL)
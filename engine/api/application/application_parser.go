@@ -122,10 +122,11 @@ func ParseAndImport(db gorp.SqlExecutor, cache cache.Store, proj *sdk.Project, e
 
 	// VCS Strategy
 	app.RepositoryStrategy = sdk.RepositoryStrategy{
-		ConnectionType: eapp.VCSConnectionType,
-		User:           eapp.VCSUser,
-		SSHKey:         eapp.VCSSSHKey,
-		PGPKey:         eapp.VCSPGPKey,
+		ConnectionType:     eapp.VCSConnectionType,
+		User:               eapp.VCSUser,
+		SSHKey:             eapp.VCSSSHKey,
+		PGPKey:             eapp.VCSPGPKey,
+		PullRequestComment: eapp.VCSPullRequestReport,
 	}
 	if app.RepositoryStrategy.ConnectionType == "" {
 		app.RepositoryStrategy.ConnectionType = "https"
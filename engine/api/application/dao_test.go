@@ -18,7 +18,7 @@ import (
 
 func TestLoadByNameAsAdmin(t *testing.T) {
 	db, cache := test.SetupPG(t, bootstrap.InitiliazeDB)
-	event.Initialize(event.KafkaConfig{}, cache)
+	event.Initialize(event.Config{}, cache)
 	key := sdk.RandomString(10)
 	proj := assets.InsertTestProject(t, db, cache, key, key, nil)
 	app := sdk.Application{
@@ -0,0 +1,177 @@
+package artifact
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// hashReadCloser computes the hash and the size of the content while it is read
+type hashReadCloser struct {
+	io.ReadCloser
+	hash hash.Hash
+	n    int64
+}
+
+func (r *hashReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	r.n += int64(n)
+	return n, err
+}
+
+// blobUpload is the temporary object receiving the content of an upload before it is checked and copied to its blob.
+// Each upload has its own object, so a content which does not match its SHA512 can be deleted without touching the blob.
+type blobUpload struct {
+	key string
+	id  string
+}
+
+func newBlobUpload(key string) *blobUpload {
+	return &blobUpload{key: key, id: sdk.UUID()}
+}
+
+// GetName returns the name of the upload in the objectstore
+func (u *blobUpload) GetName() string {
+	return u.key + ".upload-" + u.id
+}
+
+// GetPath returns the container of the upload in the objectstore
+func (u *blobUpload) GetPath() string {
+	return sdk.ArtifactBlobContainer
+}
+
+// storeBlob checks the content of an artifact against its SHA512 and its size, then copies it to the blob of the artifact.
+// The content is written to a temporary object first, the blob is shared with other artifacts and never receives an unchecked content.
+func storeBlob(art *sdk.WorkflowNodeRunArtifact, key string, content io.ReadCloser) (string, error) {
+	upload := newBlobUpload(key)
+	defer func() {
+		if err := objectstore.Delete(upload); err != nil {
+			log.Warning("storeBlob> Cannot delete upload %s: %v", upload.GetName(), err)
+		}
+	}()
+
+	r := &hashReadCloser{ReadCloser: content, hash: sha512.New()}
+	if _, err := objectstore.Store(upload, r); err != nil {
+		return "", sdk.WrapError(err, "storeBlob> Cannot store upload of artifact %s", art.Name)
+	}
+	if sum := hex.EncodeToString(r.hash.Sum(nil)); sum != art.SHA512sum || r.n != art.Size {
+		return "", sdk.WrapError(sdk.ErrWrongRequest, "storeBlob> Content of artifact %s does not match: got SHA512 %s and size %d", art.Name, sum, r.n)
+	}
+
+	checked, err := objectstore.Fetch(upload)
+	if err != nil {
+		return "", sdk.WrapError(err, "storeBlob> Cannot fetch upload of artifact %s", art.Name)
+	}
+	defer checked.Close()
+
+	blobArt := *art
+	blobArt.BlobKey = key
+	objectPath, err := objectstore.Store(&blobArt, checked)
+	if err != nil {
+		return "", sdk.WrapError(err, "storeBlob> Cannot store blob %s", key)
+	}
+	return objectPath, nil
+}
+
+// SaveWorkflowBlob stores the content of a workflow artifact as a blob of the project.
+// If the project already has a blob with the same SHA512, the content is not stored again.
+// The SHA512 and the size of the content are checked against the ones declared by the artifact.
+func SaveWorkflowBlob(db gorp.SqlExecutor, projectID int64, art *sdk.WorkflowNodeRunArtifact, content io.ReadCloser) error {
+	key := sdk.ArtifactBlobKey(projectID, art.SHA512sum)
+	if key == "" {
+		return SaveWorkflowFile(art, content)
+	}
+
+	exists, err := UseBlob(db, key)
+	if err != nil {
+		return sdk.WrapError(err, "SaveWorkflowBlob> Cannot check blob %s", key)
+	}
+	if exists {
+		log.Debug("SaveWorkflowBlob> blob %s already stored, skipping artifact %s", key, art.Name)
+		art.BlobKey = key
+		return nil
+	}
+
+	objectPath, err := storeBlob(art, key, content)
+	if err != nil {
+		return sdk.WrapError(err, "SaveWorkflowBlob> Cannot save artifact %s", art.Name)
+	}
+	art.BlobKey = key
+	art.ObjectPath = objectPath
+
+	return InsertBlob(db, &sdk.ArtifactBlob{
+		Key:       key,
+		ProjectID: projectID,
+		SHA512sum: art.SHA512sum,
+		Size:      art.Size,
+	})
+}
+
+// PromoteWorkflowBlob moves the content of a workflow artifact uploaded with a temporary url to the blob of its SHA512.
+// The worker uploads the content to the object of the artifact, never to a blob shared with other artifacts. If the project
+// has no blob with this SHA512, the content is copied to a new blob once its SHA512 and its size are checked.
+func PromoteWorkflowBlob(db gorp.SqlExecutor, projectID int64, art *sdk.WorkflowNodeRunArtifact) error {
+	key := sdk.ArtifactBlobKey(projectID, art.SHA512sum)
+	if key == "" || art.BlobKey != "" {
+		return nil
+	}
+
+	b, err := LoadBlob(db, key)
+	if err != nil && !sdk.ErrorIs(err, sdk.ErrNotFound) {
+		return sdk.WrapError(err, "PromoteWorkflowBlob> Cannot load blob %s", key)
+	}
+	if b != nil {
+		log.Debug("PromoteWorkflowBlob> blob %s already stored, dropping upload of artifact %s", key, art.Name)
+		if _, err := UseBlob(db, key); err != nil {
+			return sdk.WrapError(err, "PromoteWorkflowBlob> Cannot use blob %s", key)
+		}
+		if err := objectstore.Delete(art); err != nil {
+			log.Warning("PromoteWorkflowBlob> Cannot delete upload of artifact %s: %v", art.Name, err)
+		}
+		art.BlobKey = key
+		art.Size = b.Size
+		art.ObjectPath = ""
+		return nil
+	}
+
+	content, err := objectstore.Fetch(art)
+	if err != nil {
+		return sdk.WrapError(err, "PromoteWorkflowBlob> Cannot fetch upload of artifact %s", art.Name)
+	}
+	// The upload is copied to a temporary object of the API before being checked: the worker may still
+	// write to the object of the artifact with its temporary url.
+	objectPath, errS := storeBlob(art, key, content)
+	content.Close()
+	if err := objectstore.Delete(art); err != nil {
+		log.Warning("PromoteWorkflowBlob> Cannot delete upload of artifact %s: %v", art.Name, err)
+	}
+	if errS != nil {
+		return sdk.WrapError(errS, "PromoteWorkflowBlob> Cannot save artifact %s", art.Name)
+	}
+
+	art.BlobKey = key
+	art.ObjectPath = objectPath
+	return InsertBlob(db, &sdk.ArtifactBlob{
+		Key:       key,
+		ProjectID: projectID,
+		SHA512sum: art.SHA512sum,
+		Size:      art.Size,
+	})
+}
+
+// DeleteWorkflowArtifactObject deletes the object of a workflow artifact from the objectstore.
+// Blobs are shared between artifacts, they are deleted by the purge once they are not referenced anymore.
+func DeleteWorkflowArtifactObject(art *sdk.WorkflowNodeRunArtifact) error {
+	if art.BlobKey != "" {
+		return nil
+	}
+	return objectstore.Delete(art)
+}
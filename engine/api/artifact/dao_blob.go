@@ -0,0 +1,103 @@
+package artifact
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database"
+	"github.com/ovh/cds/sdk"
+)
+
+const blobFields = `blob_key, project_id, sha512sum, size, created, last_used`
+
+func scanBlob(s interface {
+	Scan(dest ...interface{}) error
+}) (*sdk.ArtifactBlob, error) {
+	var b sdk.ArtifactBlob
+	if err := s.Scan(&b.Key, &b.ProjectID, &b.SHA512sum, &b.Size, &b.Created, &b.LastUsed); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// LoadBlob loads a blob by its key
+func LoadBlob(db gorp.SqlExecutor, key string) (*sdk.ArtifactBlob, error) {
+	b, err := scanBlob(db.QueryRow(`SELECT `+blobFields+` FROM artifact_blob WHERE blob_key = $1`, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrNotFound
+		}
+		return nil, sdk.WrapError(err, "LoadBlob> Unable to load blob %s", key)
+	}
+	return b, nil
+}
+
+// UseBlob marks a blob as used by a new artifact. It returns false if the blob does not exist.
+func UseBlob(db gorp.SqlExecutor, key string) (bool, error) {
+	res, err := db.Exec(`UPDATE artifact_blob SET last_used = $2 WHERE blob_key = $1`, key, time.Now())
+	if err != nil {
+		return false, sdk.WrapError(err, "UseBlob> Unable to update blob %s", key)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WrapError(err, "UseBlob> Unable to update blob %s", key)
+	}
+	return n > 0, nil
+}
+
+// InsertBlob inserts a blob, or marks it as used if it already exists.
+// It must not be called in a transaction: the blob may be inserted concurrently by another upload of the same content
+func InsertBlob(db gorp.SqlExecutor, b *sdk.ArtifactBlob) error {
+	exists, err := UseBlob(db, b.Key)
+	if err != nil || exists {
+		return err
+	}
+
+	now := time.Now()
+	b.Created = now
+	b.LastUsed = now
+	query := `INSERT INTO artifact_blob (` + blobFields + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	if _, err := db.Exec(query, b.Key, b.ProjectID, b.SHA512sum, b.Size, b.Created, b.LastUsed); err != nil {
+		if errPG, ok := err.(*pq.Error); ok && errPG.Code == database.ViolateUniqueKeyPGCode {
+			// The same content has been uploaded concurrently
+			_, err := UseBlob(db, b.Key)
+			return err
+		}
+		return sdk.WrapError(err, "InsertBlob> Unable to insert blob %s", b.Key)
+	}
+	return nil
+}
+
+// LoadUnreferencedBlobs loads the blobs which are not referenced by any artifact and not used since the given date
+func LoadUnreferencedBlobs(db gorp.SqlExecutor, before time.Time) ([]sdk.ArtifactBlob, error) {
+	query := `SELECT ` + blobFields + ` FROM artifact_blob
+		WHERE last_used < $1
+		AND NOT EXISTS (SELECT 1 FROM workflow_node_run_artifacts WHERE workflow_node_run_artifacts.blob_key = artifact_blob.blob_key)
+		ORDER BY last_used`
+	rows, err := db.Query(query, before)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadUnreferencedBlobs> Unable to load blobs")
+	}
+	defer rows.Close()
+
+	blobs := []sdk.ArtifactBlob{}
+	for rows.Next() {
+		b, err := scanBlob(rows)
+		if err != nil {
+			return nil, sdk.WrapError(err, "LoadUnreferencedBlobs> Unable to scan blob")
+		}
+		blobs = append(blobs, *b)
+	}
+	return blobs, nil
+}
+
+// DeleteBlob deletes a blob entry, the object has to be deleted from the objectstore by the caller
+func DeleteBlob(db gorp.SqlExecutor, key string) error {
+	if _, err := db.Exec(`DELETE FROM artifact_blob WHERE blob_key = $1`, key); err != nil {
+		return sdk.WrapError(err, "DeleteBlob> Unable to delete blob %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package artifact
+
+import (
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/database"
+	"github.com/ovh/cds/sdk"
+)
+
+// UpsertWorkerCache keeps track of a worker cache pushed by a project.
+// It must not be called in a transaction: the same tag may be pushed concurrently
+func UpsertWorkerCache(db gorp.SqlExecutor, c sdk.WorkerCache) error {
+	if c.LastModified.IsZero() {
+		c.LastModified = time.Now()
+	}
+	updated, err := updateWorkerCache(db, c)
+	if err != nil || updated {
+		return err
+	}
+
+	query := `INSERT INTO worker_cache (project_id, tag, size, last_modified) VALUES ($1, $2, $3, $4)`
+	if _, err := db.Exec(query, c.ProjectID, c.Tag, c.Size, c.LastModified); err != nil {
+		if errPG, ok := err.(*pq.Error); ok && errPG.Code == database.ViolateUniqueKeyPGCode {
+			// The same tag has been pushed concurrently
+			_, err := updateWorkerCache(db, c)
+			return err
+		}
+		return sdk.WrapError(err, "UpsertWorkerCache> Unable to insert worker cache %s", c.Tag)
+	}
+	return nil
+}
+
+// updateWorkerCache updates a worker cache. It returns false if the worker cache does not exist
+func updateWorkerCache(db gorp.SqlExecutor, c sdk.WorkerCache) (bool, error) {
+	res, err := db.Exec(`UPDATE worker_cache SET size = $3, last_modified = $4 WHERE project_id = $1 AND tag = $2`, c.ProjectID, c.Tag, c.Size, c.LastModified)
+	if err != nil {
+		return false, sdk.WrapError(err, "updateWorkerCache> Unable to update worker cache %s", c.Tag)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, sdk.WrapError(err, "updateWorkerCache> Unable to update worker cache %s", c.Tag)
+	}
+	return n > 0, nil
+}
+
+// LoadWorkerCaches loads all the worker caches of a project, the oldest first
+func LoadWorkerCaches(db gorp.SqlExecutor, projectID int64) ([]sdk.WorkerCache, error) {
+	rows, err := db.Query(`SELECT project_id, tag, size, last_modified FROM worker_cache WHERE project_id = $1 ORDER BY last_modified ASC`, projectID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadWorkerCaches> Unable to load worker caches")
+	}
+	defer rows.Close()
+
+	caches := []sdk.WorkerCache{}
+	for rows.Next() {
+		var c sdk.WorkerCache
+		if err := rows.Scan(&c.ProjectID, &c.Tag, &c.Size, &c.LastModified); err != nil {
+			return nil, sdk.WrapError(err, "LoadWorkerCaches> Unable to scan worker cache")
+		}
+		caches = append(caches, c)
+	}
+	return caches, nil
+}
+
+// DeleteWorkerCache deletes a worker cache entry
+func DeleteWorkerCache(db gorp.SqlExecutor, projectID int64, tag string) error {
+	if _, err := db.Exec(`DELETE FROM worker_cache WHERE project_id = $1 AND tag = $2`, projectID, tag); err != nil {
+		return sdk.WrapError(err, "DeleteWorkerCache> Unable to delete worker cache %s", tag)
+	}
+	return nil
+}
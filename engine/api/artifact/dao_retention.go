@@ -0,0 +1,89 @@
+package artifact
+
+import (
+	"database/sql"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+const retentionPolicyFields = `id, project_id, coalesce(workflow_id, 0), max_age, max_total_size, keep_last_success, keep_tags`
+
+func scanRetentionPolicy(s interface {
+	Scan(dest ...interface{}) error
+}) (*sdk.ArtifactRetentionPolicy, error) {
+	var p sdk.ArtifactRetentionPolicy
+	var keepTags sql.NullString
+	if err := s.Scan(&p.ID, &p.ProjectID, &p.WorkflowID, &p.MaxAge, &p.MaxTotalSize, &p.KeepLastSuccessfulRuns, &keepTags); err != nil {
+		return nil, err
+	}
+	if err := gorpmapping.JSONNullString(keepTags, &p.KeepTaggedRuns); err != nil {
+		return nil, sdk.WrapError(err, "scanRetentionPolicy> Unable to unmarshal keep_tags")
+	}
+	return &p, nil
+}
+
+// LoadRetentionPolicy loads the retention policy of a project (workflowID = 0) or of a workflow
+func LoadRetentionPolicy(db gorp.SqlExecutor, projectID, workflowID int64) (*sdk.ArtifactRetentionPolicy, error) {
+	query := `SELECT ` + retentionPolicyFields + ` FROM artifact_retention_policy
+		WHERE project_id = $1 AND coalesce(workflow_id, 0) = $2`
+	p, err := scanRetentionPolicy(db.QueryRow(query, projectID, workflowID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrNotFound
+		}
+		return nil, sdk.WrapError(err, "LoadRetentionPolicy> Unable to load retention policy for project %d and workflow %d", projectID, workflowID)
+	}
+	return p, nil
+}
+
+// LoadRetentionPolicies loads all the retention policies
+func LoadRetentionPolicies(db gorp.SqlExecutor) ([]sdk.ArtifactRetentionPolicy, error) {
+	query := `SELECT ` + retentionPolicyFields + ` FROM artifact_retention_policy ORDER BY project_id, workflow_id NULLS FIRST`
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadRetentionPolicies> Unable to load retention policies")
+	}
+	defer rows.Close()
+
+	policies := []sdk.ArtifactRetentionPolicy{}
+	for rows.Next() {
+		p, err := scanRetentionPolicy(rows)
+		if err != nil {
+			return nil, sdk.WrapError(err, "LoadRetentionPolicies> Unable to scan retention policy")
+		}
+		policies = append(policies, *p)
+	}
+	return policies, nil
+}
+
+// UpsertRetentionPolicy inserts or replaces the retention policy of a project or a workflow
+func UpsertRetentionPolicy(db gorp.SqlExecutor, p *sdk.ArtifactRetentionPolicy) error {
+	if err := DeleteRetentionPolicy(db, p.ProjectID, p.WorkflowID); err != nil {
+		return err
+	}
+
+	keepTags, err := gorpmapping.JSONToNullString(p.KeepTaggedRuns)
+	if err != nil {
+		return sdk.WrapError(err, "UpsertRetentionPolicy> Unable to marshal keep_tags")
+	}
+
+	workflowID := sql.NullInt64{Int64: p.WorkflowID, Valid: p.WorkflowID != 0}
+	query := `INSERT INTO artifact_retention_policy (project_id, workflow_id, max_age, max_total_size, keep_last_success, keep_tags)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	if err := db.QueryRow(query, p.ProjectID, workflowID, p.MaxAge, p.MaxTotalSize, p.KeepLastSuccessfulRuns, keepTags).Scan(&p.ID); err != nil {
+		return sdk.WrapError(err, "UpsertRetentionPolicy> Unable to insert retention policy")
+	}
+	return nil
+}
+
+// DeleteRetentionPolicy deletes the retention policy of a project (workflowID = 0) or of a workflow
+func DeleteRetentionPolicy(db gorp.SqlExecutor, projectID, workflowID int64) error {
+	query := `DELETE FROM artifact_retention_policy WHERE project_id = $1 AND coalesce(workflow_id, 0) = $2`
+	if _, err := db.Exec(query, projectID, workflowID); err != nil {
+		return sdk.WrapError(err, "DeleteRetentionPolicy> Unable to delete retention policy")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/artifact"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/purge"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// loadRetentionPolicyTarget returns the project and the workflow ID (0 for a project policy) of a retention policy route
+func (api *API) loadRetentionPolicyTarget(ctx context.Context, r *http.Request) (*sdk.Project, int64, error) {
+	vars := mux.Vars(r)
+	key := vars["permProjectKey"]
+	if key == "" {
+		key = vars["key"]
+	}
+
+	proj, err := project.Load(api.mustDB(), api.Cache, key, getUser(ctx))
+	if err != nil {
+		return nil, 0, sdk.WrapError(err, "loadRetentionPolicyTarget> Unable to load project %s", key)
+	}
+
+	name, has := vars["permWorkflowName"]
+	if !has {
+		return proj, 0, nil
+	}
+	wf, err := workflow.Load(ctx, api.mustDB(), api.Cache, proj, name, getUser(ctx), workflow.LoadOptions{WithoutNode: true})
+	if err != nil {
+		return nil, 0, sdk.WrapError(err, "loadRetentionPolicyTarget> Unable to load workflow %s", name)
+	}
+	return proj, wf.ID, nil
+}
+
+func (api *API) getArtifactRetentionPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, workflowID, err := api.loadRetentionPolicyTarget(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		p, err := artifact.LoadRetentionPolicy(api.mustDB(), proj.ID, workflowID)
+		if err != nil {
+			return sdk.WrapError(err, "getArtifactRetentionPolicyHandler> Unable to load retention policy")
+		}
+		return service.WriteJSON(w, p, http.StatusOK)
+	}
+}
+
+func (api *API) putArtifactRetentionPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, workflowID, err := api.loadRetentionPolicyTarget(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		var p sdk.ArtifactRetentionPolicy
+		if err := UnmarshalBody(r, &p); err != nil {
+			return sdk.WrapError(err, "putArtifactRetentionPolicyHandler> Unable to read body")
+		}
+		if p.MaxAge < 0 || p.MaxTotalSize < 0 || p.KeepLastSuccessfulRuns < 0 {
+			return sdk.WrapError(sdk.ErrWrongRequest, "putArtifactRetentionPolicyHandler> Negative values are not allowed")
+		}
+		p.ProjectID = proj.ID
+		p.WorkflowID = workflowID
+
+		if err := artifact.UpsertRetentionPolicy(api.mustDB(), &p); err != nil {
+			return sdk.WrapError(err, "putArtifactRetentionPolicyHandler> Unable to save retention policy")
+		}
+		return service.WriteJSON(w, p, http.StatusOK)
+	}
+}
+
+func (api *API) deleteArtifactRetentionPolicyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, workflowID, err := api.loadRetentionPolicyTarget(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		if err := artifact.DeleteRetentionPolicy(api.mustDB(), proj.ID, workflowID); err != nil {
+			return sdk.WrapError(err, "deleteArtifactRetentionPolicyHandler> Unable to delete retention policy")
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+// getArtifactRetentionReportHandler returns what the purge would delete for the project, without deleting anything
+func (api *API) getArtifactRetentionReportHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, _, err := api.loadRetentionPolicyTarget(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		report, err := purge.ProjectArtifacts(api.mustDB(), proj, true)
+		if err != nil {
+			return sdk.WrapError(err, "getArtifactRetentionReportHandler> Unable to compute report")
+		}
+		return service.WriteJSON(w, report, http.StatusOK)
+	}
+}
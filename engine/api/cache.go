@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	"github.com/ovh/cds/engine/api/artifact"
 	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 )
@@ -35,11 +38,16 @@ func (api *API) postPushCacheHandler() service.Handler {
 			Tag:     tag,
 		}
 
-		_, errO := objectstore.Store(&cacheObject, r.Body)
+		body := &sdk.CountingReader{Reader: r.Body}
+		_, errO := objectstore.Store(&cacheObject, ioutil.NopCloser(body))
 		if errO != nil {
 			return sdk.WrapError(errO, "postPushCacheHandler>Cannot store cache")
 		}
 
+		if err := api.trackWorkerCache(projectKey, tag, body.N); err != nil {
+			return sdk.WrapError(err, "postPushCacheHandler> Cannot track cache")
+		}
+
 		return nil
 	}
 }
@@ -119,10 +127,43 @@ func (api *API) postPushCacheWithTempURLHandler() service.Handler {
 		cacheObject.TmpURL = url
 		cacheObject.SecretKey = key
 
+		// The size of the cache is unknown until the worker calls back after the upload, track it anyway so the purge is able to expire it
+		if err := api.trackWorkerCache(projectKey, tag, 0); err != nil {
+			return sdk.WrapError(err, "postPushCacheWithTempURLHandler> Cannot track cache")
+		}
+
 		return service.WriteJSON(w, cacheObject, http.StatusOK)
 	}
 }
 
+// postPushCacheWithTempURLCallbackHandler records the size of a cache uploaded with a temporary url
+func (api *API) postPushCacheWithTempURLCallbackHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		projectKey := vars["permProjectKey"]
+		tag := vars["tag"]
+
+		// check tag name pattern
+		regexp := sdk.NamePatternRegex
+		if !regexp.MatchString(tag) {
+			return sdk.ErrInvalidName
+		}
+
+		var cacheObject sdk.Cache
+		if err := UnmarshalBody(r, &cacheObject); err != nil {
+			return sdk.WrapError(err, "postPushCacheWithTempURLCallbackHandler> Cannot read body")
+		}
+		if cacheObject.Size < 0 {
+			return sdk.WrapError(sdk.ErrWrongRequest, "postPushCacheWithTempURLCallbackHandler> Invalid size %d", cacheObject.Size)
+		}
+
+		if err := api.trackWorkerCache(projectKey, tag, cacheObject.Size); err != nil {
+			return sdk.WrapError(err, "postPushCacheWithTempURLCallbackHandler> Cannot track cache")
+		}
+		return nil
+	}
+}
+
 func (api *API) getPullCacheWithTempURLHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -156,3 +197,12 @@ func (api *API) getPullCacheWithTempURLHandler() service.Handler {
 		return service.WriteJSON(w, cacheObject, http.StatusOK)
 	}
 }
+
+// trackWorkerCache keeps track of the pushed cache, so the purge is able to expire it
+func (api *API) trackWorkerCache(projectKey, tag string, size int64) error {
+	proj, err := project.Load(api.mustDB(), api.Cache, projectKey, nil)
+	if err != nil {
+		return sdk.WrapError(err, "trackWorkerCache> Cannot load project %s", projectKey)
+	}
+	return artifact.UpsertWorkerCache(api.mustDB(), sdk.WorkerCache{ProjectID: proj.ID, Tag: tag, Size: size})
+}
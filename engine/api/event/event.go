@@ -29,17 +29,34 @@ type Broker interface {
 	close()
 }
 
+// Config handles the configuration of all the event brokers
+type Config struct {
+	Kafka    KafkaConfig
+	RabbitMQ RabbitMQConfig
+	NATS     NATSConfig
+	Webhook  WebhookConfig
+}
+
 func getBroker(t string, option interface{}) (Broker, error) {
 	switch t {
 	case "kafka":
 		k := &KafkaClient{}
 		return k.initialize(option)
+	case "rabbitmq":
+		r := &RabbitMQClient{}
+		return r.initialize(option)
+	case "nats":
+		n := &NATSClient{}
+		return n.initialize(option)
+	case "webhook":
+		w := &WebhookClient{}
+		return w.initialize(option)
 	}
 	return nil, fmt.Errorf("Invalid Broker Type %s", t)
 }
 
 // Initialize initializes event system
-func Initialize(k KafkaConfig, cache cache.Store) error {
+func Initialize(c Config, cache cache.Store) error {
 	store = cache
 	var err error
 	hostname, err = os.Hostname()
@@ -56,14 +73,48 @@ func Initialize(k KafkaConfig, cache cache.Store) error {
 		}
 	}
 
+	// A broker which fails to initialize does not prevent the others to receive the events
 	brokers = []Broker{}
-	if k.Enabled {
+	errs := sdk.MultiError{}
+	if c.Kafka.Enabled {
 		var errk error
-		kafkaBroker, errk = getBroker("kafka", k)
+		kafkaBroker, errk = getBroker("kafka", c.Kafka)
 		if errk != nil {
-			return errk
+			log.Error("event.Initialize> Unable to initialize kafka broker: %v", errk)
+			errs.Append(errk)
+		} else {
+			brokers = append(brokers, kafkaBroker)
 		}
-		brokers = append(brokers, kafkaBroker)
+	}
+	if c.RabbitMQ.Enabled {
+		b, err := getBroker("rabbitmq", c.RabbitMQ)
+		if err != nil {
+			log.Error("event.Initialize> Unable to initialize rabbitmq broker: %v", err)
+			errs.Append(err)
+		} else {
+			brokers = append(brokers, b)
+		}
+	}
+	if c.NATS.Enabled {
+		b, err := getBroker("nats", c.NATS)
+		if err != nil {
+			log.Error("event.Initialize> Unable to initialize nats broker: %v", err)
+			errs.Append(err)
+		} else {
+			brokers = append(brokers, b)
+		}
+	}
+	if c.Webhook.Enabled {
+		b, err := getBroker("webhook", c.Webhook)
+		if err != nil {
+			log.Error("event.Initialize> Unable to initialize webhook broker: %v", err)
+			errs.Append(err)
+		} else {
+			brokers = append(brokers, b)
+		}
+	}
+	if !errs.IsEmpty() {
+		return &errs
 	}
 	return nil
 }
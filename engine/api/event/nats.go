@@ -0,0 +1,260 @@
+package event
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const natsDialTimeout = 10 * time.Second
+
+// natsWriteTimeout is the maximum duration of a write on the connection, a stalled server does not block the events
+const natsWriteTimeout = 5 * time.Second
+
+// NATSClient publishes events on a NATS subject.
+// It implements the publishing part of the NATS client protocol: https://nats.io/documentation/internals/nats-protocol/
+type NATSClient struct {
+	options    NATSConfig
+	mutex      sync.Mutex
+	conn       net.Conn
+	writer     *bufio.Writer
+	maxPayload int64
+	lastErr    error
+}
+
+// NATSConfig handles all config to connect to NATS
+type NATSConfig struct {
+	Enabled bool
+	URL     string
+	Subject string
+	Token   string
+}
+
+// natsServerInfo is the INFO message sent by the server on connection
+type natsServerInfo struct {
+	TLSRequired bool  `json:"tls_required"`
+	MaxPayload  int64 `json:"max_payload"`
+}
+
+// natsConnectOptions is the CONNECT message sent by the client
+type natsConnectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// initialize checks the configuration and connects to NATS.
+// If NATS is not reachable, the connection will be retried on the next event.
+func (c *NATSClient) initialize(options interface{}) (Broker, error) {
+	conf, ok := options.(NATSConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid NATS Initialization")
+	}
+
+	if conf.URL == "" || conf.Subject == "" {
+		return nil, fmt.Errorf("initNATS> Invalid NATS Configuration")
+	}
+	if _, err := url.Parse(conf.URL); err != nil {
+		return nil, fmt.Errorf("initNATS> Invalid NATS URL: %v", err)
+	}
+	c.options = conf
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.connect(); err != nil {
+		log.Warning("initNATS> %v", err)
+	}
+	return c, nil
+}
+
+// connect opens the connection and does the handshake, the mutex must be held
+func (c *NATSClient) connect() error {
+	if err := c.handshake(); err != nil {
+		c.lastErr = err
+		c.disconnect()
+		return fmt.Errorf("initNATS> Unable to connect to %s: %v", c.options.URL, err)
+	}
+	log.Debug("initNATS> NATS used on subject:%s", c.options.Subject)
+	c.lastErr = nil
+	return nil
+}
+
+func (c *NATSClient) handshake() error {
+	u, _ := url.Parse(c.options.URL)
+	conn, err := net.DialTimeout("tcp", u.Host, natsDialTimeout)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	_ = conn.SetDeadline(time.Now().Add(natsDialTimeout))
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected message %q", strings.TrimSpace(line))
+	}
+	var info natsServerInfo
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		return fmt.Errorf("invalid INFO message: %v", err)
+	}
+	c.maxPayload = info.MaxPayload
+
+	if info.TLSRequired || u.Scheme == "tls" {
+		host, _, _ := net.SplitHostPort(u.Host)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		if err := tlsConn.Handshake(); err != nil {
+			return err
+		}
+		c.conn = tlsConn
+		reader = bufio.NewReader(tlsConn)
+	}
+
+	opts := natsConnectOptions{
+		Name:    "cds-api-" + GetCDSName(),
+		Lang:    "go",
+		Version: sdk.VERSION,
+		Token:   c.options.Token,
+	}
+	if u.User != nil {
+		opts.User = u.User.Username()
+		opts.Pass, _ = u.User.Password()
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+
+	c.writer = bufio.NewWriter(c.conn)
+	if _, err := fmt.Fprintf(c.writer, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+	if err := c.writer.Flush(); err != nil {
+		return err
+	}
+
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if line = strings.TrimSpace(line); line != "PONG" {
+		return fmt.Errorf("unexpected message %q", line)
+	}
+	_ = c.conn.SetDeadline(time.Time{})
+
+	go c.read(c.conn, reader)
+	return nil
+}
+
+// read answers to the server pings and watches for errors until the connection is closed
+func (c *NATSClient) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		c.mutex.Lock()
+		if c.conn != conn {
+			c.mutex.Unlock()
+			return
+		}
+		switch {
+		case err != nil:
+			c.lastErr = err
+			c.disconnect()
+		case strings.HasPrefix(line, "PING"):
+			_ = c.conn.SetWriteDeadline(time.Now().Add(natsWriteTimeout))
+			c.writer.WriteString("PONG\r\n")
+			if err := c.writer.Flush(); err != nil {
+				c.lastErr = err
+				c.disconnect()
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			c.lastErr = fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+			c.disconnect()
+		}
+		stop := c.conn != conn
+		c.mutex.Unlock()
+		if stop {
+			return
+		}
+	}
+}
+
+// disconnect closes the connection, the mutex must be held
+func (c *NATSClient) disconnect() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+	c.conn = nil
+	c.writer = nil
+}
+
+// close closes the connection
+func (c *NATSClient) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.writer != nil {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(natsWriteTimeout))
+		_ = c.writer.Flush()
+	}
+	c.disconnect()
+}
+
+// sendEvent publishes the event on the subject, reconnecting if needed
+func (c *NATSClient) sendEvent(event *sdk.Event) error {
+	data, errm := json.Marshal(event)
+	if errm != nil {
+		return errm
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.conn == nil {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+	if c.maxPayload > 0 && int64(len(data)) > c.maxPayload {
+		return fmt.Errorf("event too large for NATS: %d bytes, max payload is %d", len(data), c.maxPayload)
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(natsWriteTimeout))
+	if _, err := fmt.Fprintf(c.writer, "PUB %s %d\r\n", c.options.Subject, len(data)); err != nil {
+		c.lastErr = err
+		c.disconnect()
+		return err
+	}
+	c.writer.Write(data)
+	c.writer.WriteString("\r\n")
+	if err := c.writer.Flush(); err != nil {
+		c.lastErr = err
+		c.disconnect()
+		return err
+	}
+	return nil
+}
+
+// status returns KO if the connection is lost or the last publication failed
+func (c *NATSClient) status() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.lastErr != nil {
+		return fmt.Sprintf("NATS KO (%v)", c.lastErr)
+	}
+	return "NATS OK"
+}
@@ -0,0 +1,74 @@
+package event
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// fakeNATS accepts one connection and sends the published messages on a channel
+func fakeNATS(t *testing.T, published chan<- string) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"max_payload\":1048576}\r\n")
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "PING"):
+				fmt.Fprintf(conn, "PONG\r\n")
+			case strings.HasPrefix(line, "PUB"):
+				var subject string
+				var size int
+				fmt.Sscanf(line, "PUB %s %d", &subject, &size)
+				payload := make([]byte, size+2)
+				if _, err := io.ReadFull(r, payload); err != nil {
+					return
+				}
+				published <- subject + " " + string(payload[:size])
+			}
+		}
+	}()
+	return l
+}
+
+func TestNATSClient(t *testing.T) {
+	published := make(chan string, 1)
+	l := fakeNATS(t, published)
+	defer l.Close()
+
+	b, err := getBroker("nats", NATSConfig{URL: "nats://" + l.Addr().String(), Subject: "cds.events"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer b.close()
+	assert.Equal(t, "NATS OK", b.status())
+
+	assert.NoError(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+	select {
+	case msg := <-published:
+		assert.True(t, strings.HasPrefix(msg, "cds.events {"))
+		assert.Contains(t, msg, `"type_event":"sdk.EventRunWorkflow"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not published")
+	}
+}
@@ -16,9 +16,17 @@ import (
 
 var store cache.Store
 
+// WebhooksQueue is the queue of the events to send to the project webhooks
+const WebhooksQueue = "events_webhooks"
+
 func publishEvent(e sdk.Event) {
 	store.Enqueue("events", e)
 
+	// send to cache for the project webhooks
+	if e.ProjectKey != "" {
+		store.Enqueue(WebhooksQueue, e)
+	}
+
 	// send to cache for cds repositories manager
 	var toSkipSendReposManager bool
 	// the StatusWaiting is not useful to be sent on repomanager.
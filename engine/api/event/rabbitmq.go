@@ -0,0 +1,148 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// RabbitMQClient publishes events on a RabbitMQ exchange
+type RabbitMQClient struct {
+	options RabbitMQConfig
+	mutex   sync.Mutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+	lastErr error
+}
+
+// RabbitMQConfig handles all config to connect to RabbitMQ
+type RabbitMQConfig struct {
+	Enabled      bool
+	URL          string
+	Exchange     string
+	ExchangeType string
+	RoutingKey   string
+}
+
+// initialize checks the configuration and connects to RabbitMQ.
+// If RabbitMQ is not reachable, the connection will be retried on the next event.
+func (c *RabbitMQClient) initialize(options interface{}) (Broker, error) {
+	conf, ok := options.(RabbitMQConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid RabbitMQ Initialization")
+	}
+
+	if conf.URL == "" || conf.Exchange == "" {
+		return nil, fmt.Errorf("initRabbitMQ> Invalid RabbitMQ Configuration")
+	}
+	if conf.ExchangeType == "" {
+		conf.ExchangeType = amqp.ExchangeTopic
+	}
+	c.options = conf
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.connect(); err != nil {
+		log.Warning("initRabbitMQ> %v", err)
+	}
+	return c, nil
+}
+
+// connect opens the connection and declares the exchange, the mutex must be held
+func (c *RabbitMQClient) connect() error {
+	conn, err := amqp.Dial(c.options.URL)
+	if err != nil {
+		c.lastErr = err
+		return fmt.Errorf("initRabbitMQ> Unable to connect: %v", err)
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		c.lastErr = err
+		return fmt.Errorf("initRabbitMQ> Unable to open channel: %v", err)
+	}
+	if err := channel.ExchangeDeclare(c.options.Exchange, c.options.ExchangeType, true, false, false, false, nil); err != nil {
+		conn.Close()
+		c.lastErr = err
+		return fmt.Errorf("initRabbitMQ> Unable to declare exchange %s: %v", c.options.Exchange, err)
+	}
+
+	log.Debug("initRabbitMQ> RabbitMQ used on exchange:%s", c.options.Exchange)
+	c.conn = conn
+	c.channel = channel
+	c.lastErr = nil
+	return nil
+}
+
+// disconnect closes the connection, the mutex must be held
+func (c *RabbitMQClient) disconnect() {
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil && err != amqp.ErrClosed {
+			log.Warning("closeRabbitMQ> Error while closing connection:%s", err)
+		}
+	}
+	c.conn = nil
+	c.channel = nil
+}
+
+// close closes the connection
+func (c *RabbitMQClient) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.disconnect()
+}
+
+// routingKey returns the configured routing key, or the type of the event
+func (c *RabbitMQClient) routingKey(event *sdk.Event) string {
+	if c.options.RoutingKey != "" {
+		return c.options.RoutingKey
+	}
+	return event.EventType
+}
+
+// sendEvent publishes the event on the exchange, reconnecting if needed
+func (c *RabbitMQClient) sendEvent(event *sdk.Event) error {
+	data, errm := json.Marshal(event)
+	if errm != nil {
+		return errm
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.channel == nil {
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+
+	msg := amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Type:         event.EventType,
+		Body:         data,
+	}
+	if err := c.channel.Publish(c.options.Exchange, c.routingKey(event), false, false, msg); err != nil {
+		c.lastErr = err
+		c.disconnect()
+		return err
+	}
+	return nil
+}
+
+// status returns KO if the last connection or publication failed
+func (c *RabbitMQClient) status() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.lastErr != nil {
+		return fmt.Sprintf("RabbitMQ KO (%v)", c.lastErr)
+	}
+	return "RabbitMQ OK"
+}
@@ -0,0 +1,197 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	webhookDefaultQueueSize  = 1000
+	webhookDefaultMaxRetries = 5
+	webhookDefaultRetryDelay = time.Second
+	webhookMaxRetryDelay     = 5 * time.Minute
+	webhookDefaultTimeout    = 10 * time.Second
+)
+
+// WebhookClient sends events to an HTTP endpoint.
+// Events are queued and sent by a goroutine, so a slow endpoint does not slow down the other brokers.
+type WebhookClient struct {
+	options    WebhookConfig
+	httpClient *http.Client
+	queue      chan webhookMessage
+	done       chan struct{}
+	closeOnce  sync.Once
+	mutex      sync.Mutex
+	closed     bool
+	lastErr    error
+	dropped    int64
+}
+
+// WebhookConfig handles all config to send events to a webhook
+type WebhookConfig struct {
+	Enabled    bool
+	URL        string
+	Secret     string
+	MaxRetries int
+	RetryDelay time.Duration
+	Timeout    time.Duration
+	QueueSize  int
+}
+
+type webhookMessage struct {
+	eventType string
+	body      []byte
+}
+
+// initialize checks the configuration and starts the sending goroutine
+func (c *WebhookClient) initialize(options interface{}) (Broker, error) {
+	conf, ok := options.(WebhookConfig)
+	if !ok {
+		return nil, fmt.Errorf("Invalid Webhook Initialization")
+	}
+
+	if conf.URL == "" || conf.Secret == "" {
+		return nil, fmt.Errorf("initWebhook> Invalid Webhook Configuration")
+	}
+	if conf.MaxRetries <= 0 {
+		conf.MaxRetries = webhookDefaultMaxRetries
+	}
+	if conf.RetryDelay <= 0 {
+		conf.RetryDelay = webhookDefaultRetryDelay
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = webhookDefaultTimeout
+	}
+	if conf.QueueSize <= 0 {
+		conf.QueueSize = webhookDefaultQueueSize
+	}
+	c.options = conf
+	c.httpClient = &http.Client{Timeout: conf.Timeout}
+	c.queue = make(chan webhookMessage, conf.QueueSize)
+	c.done = make(chan struct{})
+
+	go c.run()
+	log.Debug("initWebhook> Webhook used at %s", conf.URL)
+	return c, nil
+}
+
+func (c *WebhookClient) run() {
+	defer close(c.done)
+	for m := range c.queue {
+		_, _, err := DeliverWebhook(c.httpClient, c.options.URL, c.options.Secret, m.eventType, m.body, c.options.MaxRetries, c.options.RetryDelay)
+		if err != nil {
+			log.Warning("Webhook> Unable to send event %s to %s: %v", m.eventType, c.options.URL, err)
+		}
+		c.mutex.Lock()
+		c.lastErr = err
+		c.mutex.Unlock()
+	}
+}
+
+// close stops accepting events and waits a little for the queued events to be sent
+func (c *WebhookClient) close() {
+	c.closeOnce.Do(func() {
+		// The queue is closed under the mutex, so sendEvent never sends on the closed queue
+		c.mutex.Lock()
+		c.closed = true
+		close(c.queue)
+		c.mutex.Unlock()
+		select {
+		case <-c.done:
+		case <-time.After(c.options.Timeout):
+			log.Warning("closeWebhook> %d events not sent", len(c.queue))
+		}
+	})
+}
+
+// sendEvent queues the event, it is dropped if the queue is full or if the client is closed
+func (c *WebhookClient) sendEvent(event *sdk.Event) error {
+	data, errm := json.Marshal(event)
+	if errm != nil {
+		return errm
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return fmt.Errorf("webhook client is closed, event dropped")
+	}
+	select {
+	case c.queue <- webhookMessage{eventType: event.EventType, body: data}:
+		return nil
+	default:
+		c.dropped++
+		return fmt.Errorf("webhook queue is full, event dropped")
+	}
+}
+
+// status returns KO if the last delivery failed
+func (c *WebhookClient) status() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.lastErr != nil {
+		return fmt.Sprintf("Webhook KO (%v, %d queued, %d dropped)", c.lastErr, len(c.queue), c.dropped)
+	}
+	return fmt.Sprintf("Webhook OK (%d queued, %d dropped)", len(c.queue), c.dropped)
+}
+
+// DeliverWebhook posts a signed event to an HTTP endpoint. Network errors, 429 and 5xx responses are retried
+// with an exponential backoff starting at retryDelay. It returns the number of attempts and the last HTTP status code.
+func DeliverWebhook(client *http.Client, url, secret, eventType string, body []byte, maxRetries int, retryDelay time.Duration) (int, int, error) {
+	var attempts, code int
+	var err error
+	delay := retryDelay
+	for attempts <= maxRetries {
+		if attempts > 0 {
+			time.Sleep(delay)
+			delay *= 2
+			if delay > webhookMaxRetryDelay {
+				delay = webhookMaxRetryDelay
+			}
+		}
+		attempts++
+
+		var retry bool
+		code, retry, err = postWebhook(client, url, secret, eventType, body)
+		if err == nil || !retry {
+			break
+		}
+	}
+	return attempts, code, err
+}
+
+func postWebhook(client *http.Client, url, secret, eventType string, body []byte) (int, bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "CDS/"+sdk.VERSION)
+	req.Header.Set(sdk.WebhookHeaderEventType, eventType)
+	req.Header.Set(sdk.WebhookHeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(sdk.WebhookHeaderSignature, sdk.SignWebhookPayload(secret, ts, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return resp.StatusCode, retry, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, false, nil
+}
@@ -0,0 +1,71 @@
+package event
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestDeliverWebhook(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := sdk.VerifyWebhookPayload("secret", r.Header.Get(sdk.WebhookHeaderTimestamp), r.Header.Get(sdk.WebhookHeaderSignature), body); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "sdk.EventRunWorkflow", r.Header.Get(sdk.WebhookHeaderEventType))
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	attempts, code, err := DeliverWebhook(http.DefaultClient, srv.URL, "secret", "sdk.EventRunWorkflow", []byte(`{}`), 5, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// A wrong secret is rejected and client errors are not retried
+	calls = 0
+	attempts, code, err = DeliverWebhook(http.DefaultClient, srv.URL, "wrong", "sdk.EventRunWorkflow", []byte(`{}`), 5, time.Millisecond)
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestWebhookClient(t *testing.T) {
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get(sdk.WebhookHeaderEventType)
+	}))
+	defer srv.Close()
+
+	b, err := getBroker("webhook", WebhookConfig{URL: srv.URL, Secret: "secret"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer b.close()
+
+	assert.NoError(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+	select {
+	case typ := <-received:
+		assert.Equal(t, "sdk.EventRunWorkflow", typ)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+	assert.Contains(t, b.status(), "Webhook OK")
+
+	// The events sent after the close are dropped
+	b.close()
+	assert.Error(t, b.sendEvent(&sdk.Event{EventType: "sdk.EventRunWorkflow"}))
+}
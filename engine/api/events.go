@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -22,9 +23,51 @@ import (
 
 // eventsBrokerSubscribe is the information needed to subscribe
 type eventsBrokerSubscribe struct {
-	UUID  string
-	User  *sdk.User
-	Queue chan sdk.Event
+	UUID   string
+	User   *sdk.User
+	Queue  chan sdk.Event
+	Filter eventsBrokerFilter
+}
+
+// eventsBrokerFilter restricts the events sent to a client, empty fields match all the events
+type eventsBrokerFilter struct {
+	ProjectKey     string
+	WorkflowName   string
+	WorkflowRunNum int64
+}
+
+// newEventsBrokerFilter reads the filter from the query parameters project, workflow and run
+func newEventsBrokerFilter(r *http.Request) (eventsBrokerFilter, error) {
+	q := r.URL.Query()
+	f := eventsBrokerFilter{
+		ProjectKey:   q.Get("project"),
+		WorkflowName: q.Get("workflow"),
+	}
+	if run := q.Get("run"); run != "" {
+		n, err := strconv.ParseInt(run, 10, 64)
+		if err != nil {
+			return f, sdk.WrapError(sdk.ErrWrongRequest, "newEventsBrokerFilter> run is not an integer: %s", run)
+		}
+		f.WorkflowRunNum = n
+	}
+	if (f.WorkflowName != "" && f.ProjectKey == "") || (f.WorkflowRunNum != 0 && f.WorkflowName == "") {
+		return f, sdk.WrapError(sdk.ErrWrongRequest, "newEventsBrokerFilter> workflow needs project and run needs workflow")
+	}
+	return f, nil
+}
+
+// match returns true if the event has to be sent to the client
+func (f eventsBrokerFilter) match(e sdk.Event) bool {
+	if f.ProjectKey != "" && e.ProjectKey != f.ProjectKey {
+		return false
+	}
+	if f.WorkflowName != "" && e.WorkflowName != f.WorkflowName {
+		return false
+	}
+	if f.WorkflowRunNum != 0 && e.WorkflowRunNum != f.WorkflowRunNum {
+		return false
+	}
+	return true
 }
 
 // lastUpdateBroker keeps connected client of the current route,
@@ -137,6 +180,11 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 			return sdk.WrapError(fmt.Errorf("streaming unsupported"), "")
 		}
 
+		filter, errF := newEventsBrokerFilter(r)
+		if errF != nil {
+			return errF
+		}
+
 		uuidSK, errS := sessionstore.NewSessionKey()
 		if errS != nil {
 			return sdk.WrapError(errS, "eventsBroker.Serve> Cannot generate UUID")
@@ -148,9 +196,10 @@ func (b *eventsBroker) ServeHTTP() service.Handler {
 		}
 
 		client := eventsBrokerSubscribe{
-			UUID:  uuid,
-			User:  user,
-			Queue: make(chan sdk.Event, 10), // chan buffered, to avoid goroutine Start() wait on push in queue
+			UUID:   uuid,
+			User:   user,
+			Queue:  make(chan sdk.Event, 10), // chan buffered, to avoid goroutine Start() wait on push in queue
+			Filter: filter,
 		}
 
 		// Add this client to the map of those that should receive updates
@@ -217,7 +266,7 @@ func (b *eventsBroker) manageEvent(receivedEvent sdk.Event) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 	for _, i := range b.clients {
-		if b.canSend(i) {
+		if b.canSend(i) && i.Filter.match(receivedEvent) {
 			i.Queue <- receivedEvent
 		}
 	}
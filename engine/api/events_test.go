@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_eventsBrokerFilter(t *testing.T) {
+	r := httptest.NewRequest("GET", "/events?project=KEY&workflow=w&run=3", nil)
+	f, err := newEventsBrokerFilter(r)
+	assert.NoError(t, err)
+	assert.True(t, f.match(sdk.Event{ProjectKey: "KEY", WorkflowName: "w", WorkflowRunNum: 3}))
+	assert.False(t, f.match(sdk.Event{ProjectKey: "KEY", WorkflowName: "w", WorkflowRunNum: 4}))
+	assert.False(t, f.match(sdk.Event{ProjectKey: "KEY", WorkflowName: "w2", WorkflowRunNum: 3}))
+	assert.False(t, f.match(sdk.Event{ProjectKey: "OTHER"}))
+
+	f, err = newEventsBrokerFilter(httptest.NewRequest("GET", "/events", nil))
+	assert.NoError(t, err)
+	assert.True(t, f.match(sdk.Event{ProjectKey: "OTHER"}))
+
+	_, err = newEventsBrokerFilter(httptest.NewRequest("GET", "/events?run=3", nil))
+	assert.Error(t, err)
+	_, err = newEventsBrokerFilter(httptest.NewRequest("GET", "/events?project=KEY&workflow=w&run=abc", nil))
+	assert.Error(t, err)
+}
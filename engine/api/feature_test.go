@@ -30,7 +30,7 @@ func newTestAPIWithIzanamiToken(t *testing.T, token string, bootstrapFunc ...tes
 		Cache:               cache,
 	}
 	api.Config.Features.Izanami.Token = token
-	_ = event.Initialize(event.KafkaConfig{}, api.Cache)
+	_ = event.Initialize(event.Config{}, api.Cache)
 	api.InitRouter()
 	return api, db, router
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
 func processStashHook(w http.ResponseWriter, r *http.Request, data []byte) hook.ReceivedHook {
@@ -475,3 +476,144 @@ func (api *API) getHookPollingVCSEvents() service.Handler {
 		return service.WriteJSON(w, repoEvents, http.StatusOK)
 	}
 }
+
+// getHookGitRefsHandler lists the branches and the tags of the repository of a git ref poller, with the SSH key of the project
+func (api *API) getHookGitRefsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		uuid := mux.Vars(r)["uuid"]
+
+		h, errL := workflow.LoadHookByUUID(api.mustDB(), uuid)
+		if errL != nil {
+			return sdk.WrapError(errL, "getHookGitRefsHandler> cannot load hook")
+		}
+		if h == nil || h.WorkflowHookModel.Name != sdk.GitRefPollerModelName {
+			return sdk.ErrNotFound
+		}
+
+		repo := h.Config[sdk.GitRefPollerModelURL].Value
+		keyName := h.Config[sdk.GitRefPollerModelKey].Value
+		if err := git.CheckRemoteURL(repo, keyName != ""); err != nil {
+			return sdk.WrapError(sdk.ErrWrongRequest, "getHookGitRefsHandler> invalid url of hook %s: %v", uuid, err)
+		}
+
+		proj, errP := project.Load(api.mustDB(), api.Cache, h.Config[sdk.HookConfigProject].Value, nil, project.LoadOptions.WithClearKeys)
+		if errP != nil {
+			return sdk.WrapError(errP, "getHookGitRefsHandler> cannot load project")
+		}
+		if keyName != "" && proj.GetSSHKey(keyName) == nil {
+			return sdk.WrapError(sdk.ErrKeyNotFound, "getHookGitRefsHandler> ssh key %s not found in project %s", keyName, proj.Key)
+		}
+
+		// The references are listed by the repositories service, the API does not run git
+		refs, err := workflow.LsRemote(ctx, api.mustDB(), api.Cache, *proj, repo, keyName)
+		if err != nil {
+			return sdk.WrapError(sdk.ErrWrongRequest, "getHookGitRefsHandler> cannot list references of %s: %v", repo, err)
+		}
+
+		return service.WriteJSON(w, refs, http.StatusOK)
+	}
+}
+
+// getHookChangedFilesHandler lists the files changed on a branch between the base commit and the head commit of an event of a repository hook.
+// Without base commit, the changes are computed since the last commit built by the workflow on the branch. It returns null if there is no such commit
+func (api *API) getHookChangedFilesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		uuid := mux.Vars(r)["uuid"]
+		branch := r.FormValue("branch")
+		base := r.FormValue("base")
+		head := r.FormValue("head")
+		if branch == "" || head == "" {
+			return sdk.WrapError(sdk.ErrWrongRequest, "getHookChangedFilesHandler> branch and head are mandatory")
+		}
+		if !sdk.IsGitHash(head) || (base != "" && !sdk.IsGitHash(base)) {
+			return sdk.WrapError(sdk.ErrWrongRequest, "getHookChangedFilesHandler> base and head must be commit hashes")
+		}
+
+		h, errL := workflow.LoadHookByUUID(api.mustDB(), uuid)
+		if errL != nil {
+			return sdk.WrapError(errL, "getHookChangedFilesHandler> cannot load hook")
+		}
+		if h == nil || (h.WorkflowHookModel.Name != sdk.RepositoryWebHookModelName && h.WorkflowHookModel.Name != sdk.GitPollerModelName && h.WorkflowHookModel.Name != sdk.GitRefPollerModelName) {
+			return sdk.ErrNotFound
+		}
+
+		proj, errP := project.Load(api.mustDB(), api.Cache, h.Config[sdk.HookConfigProject].Value, nil, project.LoadOptions.WithClearKeys)
+		if errP != nil {
+			return sdk.WrapError(errP, "getHookChangedFilesHandler> cannot load project")
+		}
+
+		nodeCtx, errN := workflow.LoadNodeContext(api.mustDB(), api.Cache, proj, h.WorkflowNodeID, nil, workflow.LoadOptions{})
+		if errN != nil {
+			return sdk.WrapError(errN, "getHookChangedFilesHandler> cannot load context of node %d", h.WorkflowNodeID)
+		}
+		app := nodeCtx.Application
+		if (app == nil || app.VCSServer == "" || app.RepositoryFullname == "") && h.WorkflowHookModel.Name == sdk.GitRefPollerModelName {
+			return api.writeGitRefPollerChangedFiles(ctx, w, h, proj, branch, base, head)
+		}
+		if app == nil || app.VCSServer == "" || app.RepositoryFullname == "" {
+			return sdk.WrapError(sdk.ErrNoReposManager, "getHookChangedFilesHandler> no repository attached to node %d", h.WorkflowNodeID)
+		}
+
+		// The repositories managers send a zero hash as base commit of a new branch
+		if strings.Trim(base, "0") == "" {
+			workflowID, errI := strconv.ParseInt(h.Config[sdk.HookConfigWorkflowID].Value, 10, 64)
+			if errI != nil {
+				return sdk.WrapError(sdk.ErrWrongRequest, "getHookChangedFilesHandler> invalid workflow id %s", h.Config[sdk.HookConfigWorkflowID].Value)
+			}
+			var errH error
+			base, errH = workflow.LoadLastRunVCSHash(api.mustDB(), workflowID, app.ID, branch)
+			if errH != nil {
+				return sdk.WrapError(errH, "getHookChangedFilesHandler> cannot load last commit built on branch %s", branch)
+			}
+			if base == "" {
+				return service.WriteJSON(w, nil, http.StatusOK)
+			}
+		}
+		if base == head {
+			return service.WriteJSON(w, []string{}, http.StatusOK)
+		}
+
+		if err := application.DecryptVCSStrategyPassword(app); err != nil {
+			return sdk.WrapError(err, "getHookChangedFilesHandler> cannot decrypt vcs password of application %s", app.Name)
+		}
+
+		vcsServer := repositoriesmanager.GetProjectVCSServer(proj, app.VCSServer)
+		client, errR := repositoriesmanager.AuthorizedClient(ctx, api.mustDB(), api.Cache, vcsServer)
+		if errR != nil {
+			return sdk.WrapError(errR, "getHookChangedFilesHandler> Unable to get client for %s %s", proj.Key, app.VCSServer)
+		}
+
+		files, err := workflow.ChangedFiles(ctx, api.mustDB(), api.Cache, client, *proj, app, branch, base, head)
+		if err != nil {
+			return sdk.WrapError(err, "getHookChangedFilesHandler> cannot list changed files of %s", app.RepositoryFullname)
+		}
+
+		return service.WriteJSON(w, files, http.StatusOK)
+	}
+}
+
+// writeGitRefPollerChangedFiles writes the files changed on a branch of the repository of a git ref poller whose node has no repositories manager.
+// The diff is computed by the repositories service from the url and the ssh key of the poller. It writes null for a new branch
+func (api *API) writeGitRefPollerChangedFiles(ctx context.Context, w http.ResponseWriter, h *sdk.WorkflowNodeHook, proj *sdk.Project, branch, base, head string) error {
+	if strings.Trim(base, "0") == "" {
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+	if base == head {
+		return service.WriteJSON(w, []string{}, http.StatusOK)
+	}
+
+	repo := h.Config[sdk.GitRefPollerModelURL].Value
+	keyName := h.Config[sdk.GitRefPollerModelKey].Value
+	if err := git.CheckRemoteURL(repo, keyName != ""); err != nil {
+		return sdk.WrapError(sdk.ErrWrongRequest, "writeGitRefPollerChangedFiles> invalid url of hook %s: %v", h.UUID, err)
+	}
+	if keyName != "" && proj.GetSSHKey(keyName) == nil {
+		return sdk.WrapError(sdk.ErrKeyNotFound, "writeGitRefPollerChangedFiles> ssh key %s not found in project %s", keyName, proj.Key)
+	}
+
+	files, err := workflow.RemoteChangedFiles(ctx, api.mustDB(), api.Cache, *proj, repo, keyName, branch, base, head)
+	if err != nil {
+		return sdk.WrapError(err, "writeGitRefPollerChangedFiles> cannot list changed files of %s", repo)
+	}
+	return service.WriteJSON(w, files, http.StatusOK)
+}
@@ -19,7 +19,7 @@ import (
 
 func Test_ToWorkflow(t *testing.T) {
 	db, cache := test.SetupPG(t)
-	event.Initialize(event.KafkaConfig{}, cache)
+	event.Initialize(event.Config{}, cache)
 
 	u, _ := assets.InsertAdminUser(db)
 	key := sdk.RandomString(10)
@@ -72,6 +72,7 @@ func FetchTempURL(o Object) (string, error) {
 
 // Driver allows artifact to be stored and retrieve the same way to any backend
 // - Openstack / Swift
+// - S3 compatible (AWS, MinIO, Ceph RGW...)
 // - Filesystem
 type Driver interface {
 	Status() sdk.MonitoringStatusLine
@@ -106,6 +107,7 @@ const (
 	Openstack Kind = iota
 	Filesystem
 	Swift
+	S3
 )
 
 // Config represents all the configuration for all objecstore drivers
@@ -117,6 +119,7 @@ type Config struct {
 // ConfigOptions is used by Config
 type ConfigOptions struct {
 	Openstack  ConfigOptionsOpenstack
+	S3         ConfigOptionsS3
 	Filesystem ConfigOptionsFilesystem
 }
 
@@ -132,6 +135,19 @@ type ConfigOptionsOpenstack struct {
 	DisableTempURL  bool
 }
 
+// ConfigOptionsS3 is used by ConfigOptions
+type ConfigOptionsS3 struct {
+	Endpoint        string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	BucketName      string
+	Prefix          string
+	ForcePathStyle  bool
+	DisableTempURL  bool
+}
+
 // ConfigOptionsFilesystem is used by ConfigOptions
 type ConfigOptionsFilesystem struct {
 	Basedir string
@@ -153,6 +169,13 @@ func New(c context.Context, cfg Config) (Driver, error) {
 			cfg.Options.Openstack.Tenant,
 			cfg.Options.Openstack.Domain,
 			cfg.Options.Openstack.ContainerPrefix)
+	case S3:
+		instance = sdk.ArtifactsStore{
+			Name:                  "S3",
+			Private:               false,
+			TemporaryURLSupported: !cfg.Options.S3.DisableTempURL,
+		}
+		return NewS3Store(cfg.Options.S3)
 	case Filesystem:
 		instance = sdk.ArtifactsStore{
 			Name:                  "Local FS",
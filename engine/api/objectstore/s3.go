@@ -0,0 +1,370 @@
+package objectstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3Service         = "s3"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+	s3DateFormat      = "20060102"
+	s3TempURLTTL      = time.Hour
+)
+
+// S3Store implements ObjectStore interface with an S3 compatible backend (AWS, MinIO, Ceph RGW...)
+type S3Store struct {
+	endpoint        *url.URL
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	bucketName      string
+	prefix          string
+	pathStyle       bool
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3Store create a new ObjectStore with S3 driver and check configuration
+func NewS3Store(cfg ConfigOptionsS3) (*S3Store, error) {
+	log.Info("Objectstore> Initialize S3 driver on bucket: %s", cfg.BucketName)
+	if cfg.BucketName == "" {
+		return nil, fmt.Errorf("artifact storage is s3, but bucket name is not provided")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("artifact storage is s3, but credentials are not provided")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		if cfg.Region == "" {
+			return nil, fmt.Errorf("artifact storage is s3, but neither endpoint nor region are provided")
+		}
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, sdk.WrapError(err, "S3Store> Invalid endpoint %s", endpoint)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("S3Store> Invalid endpoint %s: scheme and host are mandatory", endpoint)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	s := &S3Store{
+		endpoint:        u,
+		region:          region,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		sessionToken:    cfg.SessionToken,
+		bucketName:      cfg.BucketName,
+		prefix:          strings.Trim(cfg.Prefix, "/"),
+		pathStyle:       cfg.ForcePathStyle,
+		client:          &http.Client{Timeout: 30 * time.Minute},
+		now:             time.Now,
+	}
+
+	if err := s.createBucketIfNotExists(); err != nil {
+		return nil, sdk.WrapError(err, "S3Store> Unable to initialize bucket %s", cfg.BucketName)
+	}
+	return s, nil
+}
+
+// Status returns the status of the s3 bucket
+func (s *S3Store) Status() sdk.MonitoringStatusLine {
+	resp, err := s.do(http.MethodHead, "", nil, -1)
+	if err != nil {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: "S3 KO (" + err.Error() + ")", Status: sdk.MonitoringStatusAlert}
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("S3 KO (bucket %s: HTTP %d)", s.bucketName, resp.StatusCode), Status: sdk.MonitoringStatusAlert}
+	}
+	return sdk.MonitoringStatusLine{Component: "Object-Store", Value: fmt.Sprintf("S3 OK (bucket %s)", s.bucketName), Status: sdk.MonitoringStatusOK}
+}
+
+// Store stores an object in the s3 bucket
+func (s *S3Store) Store(o Object, data io.ReadCloser) (string, error) {
+	defer data.Close()
+	key := s.objectKey(o)
+	log.Debug("S3Store> Storing %s/%s", s.bucketName, key)
+
+	// S3 needs the content length of the object, so the stream is buffered in a temporary file
+	tmp, err := ioutil.TempFile("", "cds-s3-")
+	if err != nil {
+		return "", sdk.WrapError(err, "S3Store> Unable to create temporary file")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, data)
+	if err != nil {
+		return "", sdk.WrapError(err, "S3Store> Unable to copy object buffer %s", key)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", sdk.WrapError(err, "S3Store> Unable to rewind object buffer %s", key)
+	}
+
+	resp, err := s.do(http.MethodPut, key, tmp, size)
+	if err != nil {
+		return "", sdk.WrapError(err, "S3Store> Unable to put object %s", key)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return "", s3Error(resp, "put object "+key)
+	}
+
+	return s.bucketName + "/" + key, nil
+}
+
+// Fetch an object from the s3 bucket
+func (s *S3Store) Fetch(o Object) (io.ReadCloser, error) {
+	key := s.objectKey(o)
+	log.Debug("S3Store> Fetching %s/%s", s.bucketName, key)
+
+	resp, err := s.do(http.MethodGet, key, nil, -1)
+	if err != nil {
+		return nil, sdk.WrapError(err, "S3Store> Unable to get object %s", key)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, s3Error(resp, "get object "+key)
+	}
+	return resp.Body, nil
+}
+
+// Delete an object from the s3 bucket
+func (s *S3Store) Delete(o Object) error {
+	key := s.objectKey(o)
+	resp, err := s.do(http.MethodDelete, key, nil, -1)
+	if err != nil {
+		return sdk.WrapError(err, "S3Store> Unable to delete object %s", key)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		log.Info("Delete.S3Store: %s/%s: not found", s.bucketName, key)
+		return nil
+	}
+	if resp.StatusCode >= 300 {
+		return s3Error(resp, "delete object "+key)
+	}
+	return nil
+}
+
+// StoreURL returns a presigned url to store an object. There is no secret key with S3, the url is self-sufficient
+func (s *S3Store) StoreURL(o Object) (string, string, error) {
+	return s.presign(http.MethodPut, s.objectKey(o), s3TempURLTTL), "", nil
+}
+
+// FetchURL returns a presigned url to fetch an object. There is no secret key with S3, the url is self-sufficient
+func (s *S3Store) FetchURL(o Object) (string, string, error) {
+	u := s.presign(http.MethodGet, s.objectKey(o), s3TempURLTTL)
+	log.Debug("S3Store> Fetch URL of %s/%s", s.bucketName, s.objectKey(o))
+	return u, "", nil
+}
+
+func (s *S3Store) createBucketIfNotExists() error {
+	resp, err := s.do(http.MethodHead, "", nil, -1)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode != http.StatusNotFound:
+		return fmt.Errorf("unable to check bucket: HTTP %d", resp.StatusCode)
+	}
+
+	log.Info("S3Store> creating bucket %s", s.bucketName)
+	resp, err = s.do(http.MethodPut, "", nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return s3Error(resp, "create bucket "+s.bucketName)
+	}
+	return nil
+}
+
+func (s *S3Store) objectKey(o Object) string {
+	return path.Join(s.prefix, o.GetPath(), o.GetName())
+}
+
+// objectURL returns the url of the given object key, handling virtual-hosted and path style addressing
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	p := "/"
+	if s.pathStyle {
+		p += s.bucketName
+		if key != "" {
+			p += "/"
+		}
+	} else {
+		u.Host = s.bucketName + "." + u.Host
+	}
+	p += key
+	u.Path = strings.TrimSuffix(s.endpoint.Path, "/") + p
+	u.RawPath = s3URIEncode(u.Path, false)
+	return &u
+}
+
+func (s *S3Store) do(method, key string, body io.Reader, size int64) (*http.Response, error) {
+	u := s.objectURL(key)
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	t := s.now().UTC()
+	req.Header.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	headers := map[string]string{
+		"host":                 u.Host,
+		"x-amz-content-sha256": s3UnsignedPayload,
+		"x-amz-date":           t.Format(s3TimeFormat),
+	}
+	if s.sessionToken != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+		headers["x-amz-security-token"] = s.sessionToken
+	}
+	sort.Strings(signedHeaders)
+
+	scope := s.scope(t)
+	canonical := s3CanonicalRequest(method, u, u.Query(), signedHeaders, headers, s3UnsignedPayload)
+	signature := s.sign(t, canonical)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+
+	return s.client.Do(req)
+}
+
+// presign computes a presigned url (AWS Signature V4, query string authentication)
+func (s *S3Store) presign(method, key string, ttl time.Duration) string {
+	u := s.objectURL(key)
+	t := s.now().UTC()
+
+	q := u.Query()
+	q.Set("X-Amz-Algorithm", s3Algorithm)
+	q.Set("X-Amz-Credential", s.accessKeyID+"/"+s.scope(t))
+	q.Set("X-Amz-Date", t.Format(s3TimeFormat))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	if s.sessionToken != "" {
+		q.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	canonical := s3CanonicalRequest(method, u, q, []string{"host"}, map[string]string{"host": u.Host}, s3UnsignedPayload)
+	q.Set("X-Amz-Signature", s.sign(t, canonical))
+	u.RawQuery = s3CanonicalQuery(q)
+	return u.String()
+}
+
+func (s *S3Store) scope(t time.Time) string {
+	return strings.Join([]string{t.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+}
+
+func (s *S3Store) sign(t time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3Algorithm, t.Format(s3TimeFormat), s.scope(t), hex.EncodeToString(hash[:])}, "\n")
+
+	k := s3HMAC([]byte("AWS4"+s.secretAccessKey), t.Format(s3DateFormat))
+	k = s3HMAC(k, s.region)
+	k = s3HMAC(k, s3Service)
+	k = s3HMAC(k, "aws4_request")
+	return hex.EncodeToString(s3HMAC(k, stringToSign))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data)) // nolint
+	return h.Sum(nil)
+}
+
+func s3CanonicalRequest(method string, u *url.URL, q url.Values, signedHeaders []string, headers map[string]string, payloadHash string) string {
+	var canonicalHeaders string
+	for _, h := range signedHeaders {
+		canonicalHeaders += h + ":" + strings.TrimSpace(headers[h]) + "\n"
+	}
+	return strings.Join([]string{
+		method,
+		s3URIEncode(u.Path, false),
+		s3CanonicalQuery(q),
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+func s3CanonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals := append([]string{}, q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, s3URIEncode(k, true)+"="+s3URIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3URIEncode encodes a string as specified by AWS Signature V4: every byte except unreserved characters is percent-encoded
+func s3URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func s3Error(resp *http.Response, action string) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3Store> Unable to %s: HTTP %d %s", action, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -1,6 +1,8 @@
 package pipeline
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -8,6 +10,7 @@ import (
 	"github.com/go-gorp/gorp"
 
 	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
 )
@@ -102,9 +105,24 @@ func InsertJob(db gorp.SqlExecutor, job *sdk.Job, stageID int64, pip *sdk.Pipeli
 	}
 	job.PipelineStageID = stage.ID
 
+	matrix, err := jobMatrixToDB(job.Matrix)
+	if err != nil {
+		return err
+	}
+	retryPolicy, err := retryPolicyToDB(job.RetryPolicy)
+	if err != nil {
+		return err
+	}
+	if err := sdk.IsValidJobPriority(job.Priority); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	if err := sdk.IsValidTimeout(job.Timeout); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+
 	// Create pipeline action
-	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled) VALUES ($1, $2, $3) RETURNING id`
-	return db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled).Scan(&job.PipelineActionID)
+	query := `INSERT INTO pipeline_action (pipeline_stage_id, action_id, enabled, matrix, retry_policy, priority, timeout) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	return db.QueryRow(query, job.PipelineStageID, job.Action.ID, job.Enabled, matrix, retryPolicy, job.Priority, job.Timeout).Scan(&job.PipelineActionID)
 }
 
 // UpdateJob  updates the job by actionData.PipelineActionID and actionData.ID
@@ -132,14 +150,59 @@ func DeleteJob(db gorp.SqlExecutor, job sdk.Job, userID int64) error {
 
 // UpdatePipelineAction Update an action in a pipeline
 func UpdatePipelineAction(db gorp.SqlExecutor, job sdk.Job) error {
-	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3 WHERE id=$4`
-	if _, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, job.PipelineActionID); err != nil {
+	matrix, err := jobMatrixToDB(job.Matrix)
+	if err != nil {
+		return err
+	}
+	retryPolicy, err := retryPolicyToDB(job.RetryPolicy)
+	if err != nil {
+		return err
+	}
+	if err := sdk.IsValidJobPriority(job.Priority); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	if err := sdk.IsValidTimeout(job.Timeout); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+
+	query := `UPDATE pipeline_action set action_id=$1, pipeline_stage_id=$2, enabled=$3, matrix=$4, retry_policy=$5, priority=$6, timeout=$7 WHERE id=$8`
+	if _, err := db.Exec(query, job.Action.ID, job.PipelineStageID, job.Enabled, matrix, retryPolicy, job.Priority, job.Timeout, job.PipelineActionID); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// jobMatrixToDB returns the matrix of a job as a nullable json string
+func jobMatrixToDB(m sdk.JobMatrix) (sql.NullString, error) {
+	if len(m) == 0 {
+		return sql.NullString{}, nil
+	}
+	if err := m.IsValid(); err != nil {
+		return sql.NullString{}, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return sql.NullString{}, sdk.WrapError(err, "jobMatrixToDB> cannot marshal matrix")
+	}
+	return sql.NullString{Valid: true, String: string(b)}, nil
+}
+
+// retryPolicyToDB returns the retry policy of a job as a nullable json string
+func retryPolicyToDB(p *sdk.RetryPolicy) (sql.NullString, error) {
+	if p == nil {
+		return sql.NullString{}, nil
+	}
+	if err := p.IsValid(); err != nil {
+		return sql.NullString{}, sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	s, err := gorpmapping.JSONToNullString(p)
+	if err != nil {
+		return sql.NullString{}, sdk.WrapError(err, "retryPolicyToDB> cannot marshal retry policy")
+	}
+	return s, nil
+}
+
 // DeletePipelineAction Delete an action in a pipeline
 func DeletePipelineAction(db gorp.SqlExecutor, pipelineActionID int64) error {
 
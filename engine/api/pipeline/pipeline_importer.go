@@ -18,6 +18,10 @@ func ImportUpdate(db gorp.SqlExecutor, proj *sdk.Project, pip *sdk.Pipeline, msg
 	log.Debug("ImportUpdate> Begin")
 	defer log.Debug("ImportUpdate> End (%d ns)", time.Since(t).Nanoseconds())
 
+	if err := sdk.CheckStageGraph(pip.Stages); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+
 	oldPipeline, err := LoadPipeline(db,
 		proj.Key,
 		pip.Name, true)
@@ -167,6 +171,11 @@ func ImportUpdate(db gorp.SqlExecutor, proj *sdk.Project, pip *sdk.Pipeline, msg
 				}
 			}
 
+			s.ID = oldStage.ID
+			if err := updateStageGraph(db, s); err != nil {
+				return sdk.WrapError(err, "ImportUpdate> Unable to update stage %s", s.Name)
+			}
+
 			//Update stage
 			if msgChan != nil {
 				msgChan <- sdk.NewMessage(sdk.MsgPipelineStageUpdated, s.Name)
@@ -277,6 +286,9 @@ func Import(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, pip *sdk.
 
 func importNew(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, pip *sdk.Pipeline, u *sdk.User) error {
 	log.Debug("pipeline.importNew> Creating pipeline %s", pip.Name)
+	if err := sdk.CheckStageGraph(pip.Stages); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
 	//Insert pipeline
 	if err := InsertPipeline(db, store, proj, pip, u); err != nil {
 		return err
@@ -75,7 +75,7 @@ func testImportUpdate(t *testing.T, db gorp.SqlExecutor, store cache.Store, tt t
 
 func TestImportUpdate(t *testing.T) {
 	db, cache := test.SetupPG(t, bootstrap.InitiliazeDB)
-	event.Initialize(event.KafkaConfig{}, cache)
+	event.Initialize(event.Config{}, cache)
 
 	if db == nil {
 		t.FailNow()
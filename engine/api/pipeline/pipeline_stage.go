@@ -13,6 +13,7 @@ import (
 	"github.com/lib/pq"
 
 	"github.com/ovh/cds/engine/api/action"
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/observability"
 	"github.com/ovh/cds/engine/api/trigger"
 	"github.com/ovh/cds/sdk"
@@ -27,7 +28,7 @@ var (
 // LoadStage Get a stage from its ID and pipeline ID
 func LoadStage(db gorp.SqlExecutor, pipelineID int64, stageID int64) (*sdk.Stage, error) {
 	query := `
-		SELECT pipeline_stage.id, pipeline_stage.pipeline_id, pipeline_stage.name, pipeline_stage.build_order, pipeline_stage.enabled, pipeline_stage_prerequisite.parameter, pipeline_stage_prerequisite.expected_value
+		SELECT pipeline_stage.id, pipeline_stage.pipeline_id, pipeline_stage.name, pipeline_stage.build_order, pipeline_stage.enabled, pipeline_stage.needs, pipeline_stage.run_conditions, pipeline_stage_prerequisite.parameter, pipeline_stage_prerequisite.expected_value
 		FROM pipeline_stage
 		LEFT OUTER JOIN pipeline_stage_prerequisite ON pipeline_stage_prerequisite.pipeline_stage_id = pipeline_stage.id
 		WHERE pipeline_stage.pipeline_id = $1
@@ -47,8 +48,11 @@ func LoadStage(db gorp.SqlExecutor, pipelineID int64, stageID int64) (*sdk.Stage
 	defer rows.Close()
 
 	for rows.Next() {
-		var parameter, expectedValue sql.NullString
-		rows.Scan(&stage.ID, &stage.PipelineID, &stage.Name, &stage.BuildOrder, &stage.Enabled, &parameter, &expectedValue)
+		var parameter, expectedValue, needs, runConditions sql.NullString
+		rows.Scan(&stage.ID, &stage.PipelineID, &stage.Name, &stage.BuildOrder, &stage.Enabled, &needs, &runConditions, &parameter, &expectedValue)
+		if err := unmarshalStageGraph(&stage, needs, runConditions); err != nil {
+			return nil, err
+		}
 		if parameter.Valid && expectedValue.Valid {
 			p := sdk.Prerequisite{
 				Parameter:     parameter.String,
@@ -61,11 +65,55 @@ func LoadStage(db gorp.SqlExecutor, pipelineID int64, stageID int64) (*sdk.Stage
 	return &stage, nil
 }
 
+// unmarshalStageGraph sets the needs and the run conditions of a stage
+func unmarshalStageGraph(s *sdk.Stage, needs, runConditions sql.NullString) error {
+	s.Needs = nil
+	if err := gorpmapping.JSONNullString(needs, &s.Needs); err != nil {
+		return sdk.WrapError(err, "unmarshalStageGraph> cannot unmarshal needs of stage %d", s.ID)
+	}
+	s.RunConditions = nil
+	if runConditions.Valid {
+		s.RunConditions = new(sdk.WorkflowNodeConditions)
+		if err := gorpmapping.JSONNullString(runConditions, s.RunConditions); err != nil {
+			return sdk.WrapError(err, "unmarshalStageGraph> cannot unmarshal run conditions of stage %d", s.ID)
+		}
+	}
+	return nil
+}
+
+// marshalStageGraph returns the needs and the run conditions of a stage to store them in database
+func marshalStageGraph(s *sdk.Stage) (sql.NullString, sql.NullString, error) {
+	var needs, runConditions sql.NullString
+	if len(s.Needs) > 0 {
+		var err error
+		needs, err = gorpmapping.JSONToNullString(s.Needs)
+		if err != nil {
+			return needs, runConditions, sdk.WrapError(err, "marshalStageGraph> cannot marshal needs of stage %s", s.Name)
+		}
+	}
+	if s.RunConditions != nil {
+		if err := s.IsValidRunConditions(); err != nil {
+			return needs, runConditions, sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+		var err error
+		runConditions, err = gorpmapping.JSONToNullString(s.RunConditions)
+		if err != nil {
+			return needs, runConditions, sdk.WrapError(err, "marshalStageGraph> cannot marshal run conditions of stage %s", s.Name)
+		}
+	}
+	return needs, runConditions, nil
+}
+
 // InsertStage insert given stage into given database
 func InsertStage(db gorp.SqlExecutor, s *sdk.Stage) error {
-	query := `INSERT INTO "pipeline_stage" (pipeline_id, name, build_order, enabled) VALUES($1,$2,$3,$4) RETURNING id`
+	needs, runConditions, err := marshalStageGraph(s)
+	if err != nil {
+		return err
+	}
 
-	if err := db.QueryRow(query, s.PipelineID, s.Name, s.BuildOrder, s.Enabled).Scan(&s.ID); err != nil {
+	query := `INSERT INTO "pipeline_stage" (pipeline_id, name, build_order, enabled, needs, run_conditions) VALUES($1,$2,$3,$4,$5,$6) RETURNING id`
+
+	if err := db.QueryRow(query, s.PipelineID, s.Name, s.BuildOrder, s.Enabled, needs, runConditions).Scan(&s.ID); err != nil {
 		return err
 	}
 	return InsertStagePrequisites(db, s)
@@ -105,13 +153,14 @@ func LoadPipelineStage(ctx context.Context, db gorp.SqlExecutor, p *sdk.Pipeline
 
 	query := `
 	SELECT pipeline_stage_R.id as stage_id, pipeline_stage_R.pipeline_id, pipeline_stage_R.name, pipeline_stage_R.last_modified,
-			pipeline_stage_R.build_order, pipeline_stage_R.enabled, pipeline_stage_R.parameter,
+			pipeline_stage_R.build_order, pipeline_stage_R.enabled, pipeline_stage_R.needs, pipeline_stage_R.run_conditions, pipeline_stage_R.parameter,
 			pipeline_stage_R.expected_value, pipeline_action_R.id as pipeline_action_id, pipeline_action_R.action_id, pipeline_action_R.action_last_modified,
-			pipeline_action_R.action_args, pipeline_action_R.action_enabled
+			pipeline_action_R.action_args, pipeline_action_R.action_enabled, pipeline_action_R.action_matrix, pipeline_action_R.action_retry_policy,
+			pipeline_action_R.action_priority, pipeline_action_R.action_timeout
 	FROM (
 		SELECT pipeline_stage.id, pipeline_stage.pipeline_id,
 				pipeline_stage.name, pipeline_stage.last_modified, pipeline_stage.build_order,
-				pipeline_stage.enabled,
+				pipeline_stage.enabled, pipeline_stage.needs, pipeline_stage.run_conditions,
 				pipeline_stage_prerequisite.parameter, pipeline_stage_prerequisite.expected_value
 		FROM pipeline_stage
 		LEFT OUTER JOIN pipeline_stage_prerequisite ON pipeline_stage.id = pipeline_stage_prerequisite.pipeline_stage_id
@@ -119,7 +168,9 @@ func LoadPipelineStage(ctx context.Context, db gorp.SqlExecutor, p *sdk.Pipeline
 	) as pipeline_stage_R
 	LEFT OUTER JOIN (
 		SELECT pipeline_action.id, action.id as action_id, action.name as action_name, action.last_modified as action_last_modified,
-				pipeline_action.args as action_args, pipeline_action.enabled as action_enabled,
+				pipeline_action.args as action_args, pipeline_action.enabled as action_enabled, pipeline_action.matrix as action_matrix,
+				pipeline_action.retry_policy as action_retry_policy, pipeline_action.priority as action_priority,
+				pipeline_action.timeout as action_timeout,
 				pipeline_action.pipeline_stage_id
 		FROM action
 		JOIN pipeline_action ON pipeline_action.action_id = action.id
@@ -143,15 +194,16 @@ func LoadPipelineStage(ctx context.Context, db gorp.SqlExecutor, p *sdk.Pipeline
 		var stageBuildOrder int
 		var pipelineActionID, actionID sql.NullInt64
 		var stageName string
-		var stagePrerequisiteParameter, stagePrerequisiteExpectedValue, actionArgs sql.NullString
+		var stageNeeds, stageRunConditions, stagePrerequisiteParameter, stagePrerequisiteExpectedValue, actionArgs, actionMatrix, actionRetryPolicy, actionTimeout sql.NullString
 		var stageEnabled, actionEnabled sql.NullBool
+		var actionPriority sql.NullInt64
 		var stageLastModified, actionLastModified pq.NullTime
 
 		err = rows.Scan(
 			&stageID, &pipelineID, &stageName, &stageLastModified,
-			&stageBuildOrder, &stageEnabled, &stagePrerequisiteParameter,
+			&stageBuildOrder, &stageEnabled, &stageNeeds, &stageRunConditions, &stagePrerequisiteParameter,
 			&stagePrerequisiteExpectedValue, &pipelineActionID, &actionID, &actionLastModified,
-			&actionArgs, &actionEnabled)
+			&actionArgs, &actionEnabled, &actionMatrix, &actionRetryPolicy, &actionPriority, &actionTimeout)
 		if err != nil {
 			return err
 		}
@@ -167,6 +219,9 @@ func LoadPipelineStage(ctx context.Context, db gorp.SqlExecutor, p *sdk.Pipeline
 				BuildOrder:   stageBuildOrder,
 				LastModified: stageLastModified.Time.Unix(),
 			}
+			if err := unmarshalStageGraph(stageData, stageNeeds, stageRunConditions); err != nil {
+				return err
+			}
 			mapStages[stageID] = stageData
 			stagesPtr = append(stagesPtr, stageData)
 		}
@@ -199,10 +254,20 @@ func LoadPipelineStage(ctx context.Context, db gorp.SqlExecutor, p *sdk.Pipeline
 					PipelineActionID: pipelineActionID.Int64,
 					LastModified:     actionLastModified.Time.Unix(),
 					Enabled:          actionEnabled.Bool,
+					Priority:         int(actionPriority.Int64),
+					Timeout:          actionTimeout.String,
 					Action: sdk.Action{
 						ID: actionID.Int64,
 					},
 				}
+				if actionMatrix.Valid {
+					if err := json.Unmarshal([]byte(actionMatrix.String), &j.Matrix); err != nil {
+						return sdk.WrapError(err, "LoadPipelineStage> cannot unmarshal matrix of job %d", pipelineActionID.Int64)
+					}
+				}
+				if err := gorpmapping.JSONNullString(actionRetryPolicy, &j.RetryPolicy); err != nil {
+					return sdk.WrapError(err, "LoadPipelineStage> cannot unmarshal retry policy of job %d", pipelineActionID.Int64)
+				}
 				mapAllActions[pipelineActionID.Int64] = j
 				mapActionsStages[stageID] = append(mapActionsStages[stageID], *j)
 
@@ -260,10 +325,26 @@ func updateStageOrder(db gorp.SqlExecutor, id int64, order int) error {
 	return sdk.WrapError(err, "UpdateStageOrder>")
 }
 
+// updateStageGraph update only Stage needs and run conditions
+func updateStageGraph(db gorp.SqlExecutor, s *sdk.Stage) error {
+	needs, runConditions, err := marshalStageGraph(s)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE pipeline_stage SET needs=$1, run_conditions=$2 WHERE id=$3`
+	_, err = db.Exec(query, needs, runConditions, s.ID)
+	return sdk.WrapError(err, "updateStageGraph>")
+}
+
 // UpdateStage update Stage and all its prequisites
 func UpdateStage(db gorp.SqlExecutor, s *sdk.Stage) error {
-	query := `UPDATE pipeline_stage SET name=$1, build_order=$2, enabled=$3 WHERE id=$4`
-	_, err := db.Exec(query, s.Name, s.BuildOrder, s.Enabled, s.ID)
+	needs, runConditions, err := marshalStageGraph(s)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE pipeline_stage SET name=$1, build_order=$2, enabled=$3, needs=$4, run_conditions=$5 WHERE id=$6`
+	_, err = db.Exec(query, s.Name, s.BuildOrder, s.Enabled, needs, runConditions, s.ID)
 	if err != nil {
 		return err
 	}
@@ -57,7 +57,7 @@ func TestExist(t *testing.T) {
 
 func TestLoadAllByRepo(t *testing.T) {
 	db, cache := test.SetupPG(t, bootstrap.InitiliazeDB)
-	event.Initialize(event.KafkaConfig{}, cache)
+	event.Initialize(event.Config{}, cache)
 
 	app, _ := application.LoadByName(db, cache, "TestLoadAllByRepo", "TestLoadAllByRepo", nil)
 	if app != nil {
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/webhook"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+// loadProjectWebhook loads the project and the webhook of a project webhook route
+func (api *API) loadProjectWebhook(ctx context.Context, r *http.Request, clearSecret bool) (*sdk.Project, *sdk.ProjectWebhook, error) {
+	key := mux.Vars(r)["permProjectKey"]
+	id, err := requestVarInt(r, "webhookID")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proj, err := project.Load(api.mustDB(), api.Cache, key, getUser(ctx))
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "loadProjectWebhook> Cannot load project %s", key)
+	}
+	wh, err := webhook.LoadByID(api.mustDB(), proj.ID, id, clearSecret)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "loadProjectWebhook> Cannot load webhook %d", id)
+	}
+	return proj, wh, nil
+}
+
+func (api *API) getProjectWebhooksHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)["permProjectKey"]
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, getUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "getProjectWebhooksHandler> Cannot load project %s", key)
+		}
+
+		webhooks, err := webhook.LoadByProjectID(api.mustDB(), proj.ID, false)
+		if err != nil {
+			return sdk.WrapError(err, "getProjectWebhooksHandler> Cannot load webhooks")
+		}
+		return service.WriteJSON(w, webhooks, http.StatusOK)
+	}
+}
+
+// postProjectWebhookHandler creates a webhook. If no secret is given, a secret is generated and returned only once.
+func (api *API) postProjectWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)["permProjectKey"]
+
+		var wh sdk.ProjectWebhook
+		if err := UnmarshalBody(r, &wh); err != nil {
+			return sdk.WrapError(err, "postProjectWebhookHandler> Cannot read body")
+		}
+		if err := wh.IsValid(); err != nil {
+			return sdk.WrapError(err, "postProjectWebhookHandler> Invalid webhook")
+		}
+
+		proj, err := project.Load(api.mustDB(), api.Cache, key, getUser(ctx))
+		if err != nil {
+			return sdk.WrapError(err, "postProjectWebhookHandler> Cannot load project %s", key)
+		}
+
+		if wh.Secret == "" || wh.Secret == sdk.PasswordPlaceholder {
+			s, err := generateHash()
+			if err != nil {
+				return sdk.WrapError(err, "postProjectWebhookHandler> Cannot generate secret")
+			}
+			wh.Secret = s[:64]
+		}
+		wh.ProjectID = proj.ID
+
+		if err := webhook.Insert(api.mustDB(), &wh); err != nil {
+			return sdk.WrapError(err, "postProjectWebhookHandler> Cannot insert webhook")
+		}
+		return service.WriteJSON(w, wh, http.StatusCreated)
+	}
+}
+
+func (api *API) getProjectWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, wh, err := api.loadProjectWebhook(ctx, r, false)
+		if err != nil {
+			return err
+		}
+		return service.WriteJSON(w, wh, http.StatusOK)
+	}
+}
+
+func (api *API) putProjectWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, old, err := api.loadProjectWebhook(ctx, r, false)
+		if err != nil {
+			return err
+		}
+
+		var wh sdk.ProjectWebhook
+		if err := UnmarshalBody(r, &wh); err != nil {
+			return sdk.WrapError(err, "putProjectWebhookHandler> Cannot read body")
+		}
+		if err := wh.IsValid(); err != nil {
+			return sdk.WrapError(err, "putProjectWebhookHandler> Invalid webhook")
+		}
+		wh.ID = old.ID
+		wh.ProjectID = proj.ID
+		wh.Created = old.Created
+
+		if err := webhook.Update(api.mustDB(), &wh); err != nil {
+			return sdk.WrapError(err, "putProjectWebhookHandler> Cannot update webhook")
+		}
+		wh.Secret = sdk.PasswordPlaceholder
+		return service.WriteJSON(w, wh, http.StatusOK)
+	}
+}
+
+func (api *API) deleteProjectWebhookHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		proj, wh, err := api.loadProjectWebhook(ctx, r, false)
+		if err != nil {
+			return err
+		}
+
+		if err := webhook.Delete(api.mustDB(), proj.ID, wh.ID); err != nil {
+			return sdk.WrapError(err, "deleteProjectWebhookHandler> Cannot delete webhook")
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getProjectWebhookDeliveriesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, wh, err := api.loadProjectWebhook(ctx, r, false)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err := webhook.LoadDeliveries(api.mustDB(), wh.ID)
+		if err != nil {
+			return sdk.WrapError(err, "getProjectWebhookDeliveriesHandler> Cannot load deliveries")
+		}
+		return service.WriteJSON(w, deliveries, http.StatusOK)
+	}
+}
+
+// postProjectWebhookRedeliverHandler sends again a delivery and returns the new delivery
+func (api *API) postProjectWebhookRedeliverHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		_, wh, err := api.loadProjectWebhook(ctx, r, true)
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "deliveryID")
+		if err != nil {
+			return err
+		}
+
+		d, err := webhook.LoadDelivery(api.mustDB(), wh.ID, id)
+		if err != nil {
+			return sdk.WrapError(err, "postProjectWebhookRedeliverHandler> Cannot load delivery %d", id)
+		}
+
+		newDelivery, err := webhook.Redeliver(api.mustDB(), *wh, *d)
+		if err != nil {
+			return sdk.WrapError(err, "postProjectWebhookRedeliverHandler> Cannot redeliver %d", id)
+		}
+		return service.WriteJSON(w, newDelivery, http.StatusOK)
+	}
+}
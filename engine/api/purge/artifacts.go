@@ -0,0 +1,306 @@
+package purge
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/artifact"
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/objectstore"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// retentionRun is the part of a workflow run needed to apply a retention policy
+type retentionRun struct {
+	ID     int64
+	Number int64
+	Status string
+	Tags   []string
+}
+
+// expiredArtifact is an artifact to purge with the reason
+type expiredArtifact struct {
+	artifact sdk.WorkflowNodeRunArtifact
+	reason   string
+}
+
+// expiredCache is a worker cache to purge with the reason
+type expiredCache struct {
+	cache  sdk.WorkerCache
+	reason string
+}
+
+// Artifacts applies all the retention policies on artifacts and worker caches
+func Artifacts(db gorp.SqlExecutor, store cache.Store, dryRun bool) (*sdk.ArtifactPurgeReport, error) {
+	policies, err := artifact.LoadRetentionPolicies(db)
+	if err != nil {
+		return nil, sdk.WrapError(err, "purge.Artifacts> Unable to load retention policies")
+	}
+
+	report := &sdk.ArtifactPurgeReport{DryRun: dryRun}
+	projectIDs := map[int64]struct{}{}
+	for _, p := range policies {
+		if _, has := projectIDs[p.ProjectID]; has {
+			continue
+		}
+		projectIDs[p.ProjectID] = struct{}{}
+
+		proj, err := project.LoadByID(db, store, p.ProjectID, nil)
+		if err != nil {
+			log.Error("purge.Artifacts> unable to load project %d: %v", p.ProjectID, err)
+			continue
+		}
+		if err := projectArtifacts(db, proj, dryRun, report); err != nil {
+			log.Error("purge.Artifacts> unable to purge artifacts of project %s: %v", proj.Key, err)
+		}
+	}
+	return report, nil
+}
+
+// ProjectArtifacts applies the retention policies of a project on its artifacts and worker caches
+func ProjectArtifacts(db gorp.SqlExecutor, proj *sdk.Project, dryRun bool) (*sdk.ArtifactPurgeReport, error) {
+	report := &sdk.ArtifactPurgeReport{DryRun: dryRun}
+	if err := projectArtifacts(db, proj, dryRun, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func projectArtifacts(db gorp.SqlExecutor, proj *sdk.Project, dryRun bool, report *sdk.ArtifactPurgeReport) error {
+	projectPolicy, err := artifact.LoadRetentionPolicy(db, proj.ID, 0)
+	if err != nil && err != sdk.ErrNotFound {
+		return err
+	}
+
+	workflows := []struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+	}{}
+	if _, err := db.Select(&workflows, "SELECT id, name FROM workflow WHERE project_id = $1 ORDER BY name", proj.ID); err != nil {
+		return sdk.WrapError(err, "projectArtifacts> Unable to load workflows")
+	}
+
+	now := time.Now()
+	for _, w := range workflows {
+		policy, err := artifact.LoadRetentionPolicy(db, proj.ID, w.ID)
+		if err != nil {
+			if err != sdk.ErrNotFound {
+				return err
+			}
+			policy = projectPolicy
+		}
+		if policy == nil || policy.IsEmpty() {
+			continue
+		}
+
+		runs, err := loadRetentionRuns(db, w.ID)
+		if err != nil {
+			return err
+		}
+		arts, err := workflow.LoadArtifactsByWorkflowID(db, w.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range expiredArtifacts(*policy, now, runs, arts) {
+			item := sdk.ArtifactPurgeReportItem{
+				ProjectKey:   proj.Key,
+				WorkflowName: w.Name,
+				RunNumber:    runs[e.artifact.WorkflowID].Number,
+				Name:         e.artifact.Name,
+				Size:         e.artifact.Size,
+				Created:      e.artifact.Created,
+				Reason:       e.reason,
+			}
+			if !dryRun {
+				if err := deleteArtifact(db, e.artifact); err != nil {
+					log.Error("projectArtifacts> unable to delete artifact %d: %v", e.artifact.ID, err)
+					continue
+				}
+			}
+			report.Add(false, item)
+		}
+	}
+
+	if projectPolicy == nil || projectPolicy.IsEmpty() {
+		return nil
+	}
+
+	caches, err := artifact.LoadWorkerCaches(db, proj.ID)
+	if err != nil {
+		return err
+	}
+	for _, e := range expiredCaches(*projectPolicy, now, caches) {
+		item := sdk.ArtifactPurgeReportItem{
+			ProjectKey: proj.Key,
+			Name:       e.cache.Tag,
+			Size:       e.cache.Size,
+			Created:    e.cache.LastModified,
+			Reason:     e.reason,
+		}
+		if !dryRun {
+			if err := objectstore.Delete(&sdk.Cache{Project: proj.Key, Name: "cache.tar", Tag: e.cache.Tag}); err != nil {
+				log.Error("projectArtifacts> unable to delete worker cache %s: %v", e.cache.Tag, err)
+				continue
+			}
+			if err := artifact.DeleteWorkerCache(db, proj.ID, e.cache.Tag); err != nil {
+				log.Error("projectArtifacts> %v", err)
+				continue
+			}
+		}
+		report.Add(true, item)
+	}
+
+	return nil
+}
+
+func deleteArtifact(db gorp.SqlExecutor, art sdk.WorkflowNodeRunArtifact) error {
+	if err := artifact.DeleteWorkflowArtifactObject(&art); err != nil {
+		return sdk.WrapError(err, "deleteArtifact> Unable to delete object %s", art.Name)
+	}
+	return workflow.DeleteArtifact(db, art.ID)
+}
+
+// blobGracePeriod protects the blobs recently used from the purge, an upload may be linking them to a new artifact
+const blobGracePeriod = time.Hour
+
+// Blobs deletes from the objectstore the artifact blobs which are not referenced by any artifact anymore
+func Blobs(db gorp.SqlExecutor) (int, error) {
+	blobs, err := artifact.LoadUnreferencedBlobs(db, time.Now().Add(-blobGracePeriod))
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for i := range blobs {
+		if err := objectstore.Delete(&blobs[i]); err != nil {
+			log.Error("purge.Blobs> unable to delete blob %s: %v", blobs[i].Key, err)
+			continue
+		}
+		if err := artifact.DeleteBlob(db, blobs[i].Key); err != nil {
+			log.Error("purge.Blobs> %v", err)
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+func loadRetentionRuns(db gorp.SqlExecutor, workflowID int64) (map[int64]retentionRun, error) {
+	rows := []struct {
+		ID     int64  `db:"id"`
+		Number int64  `db:"num"`
+		Status string `db:"status"`
+	}{}
+	if _, err := db.Select(&rows, "SELECT id, num, status FROM workflow_run WHERE workflow_id = $1", workflowID); err != nil {
+		return nil, sdk.WrapError(err, "loadRetentionRuns> Unable to load runs of workflow %d", workflowID)
+	}
+
+	runs := make(map[int64]retentionRun, len(rows))
+	for _, r := range rows {
+		runs[r.ID] = retentionRun{ID: r.ID, Number: r.Number, Status: r.Status}
+	}
+
+	tags := []struct {
+		RunID int64  `db:"workflow_run_id"`
+		Tag   string `db:"tag"`
+	}{}
+	query := `SELECT workflow_run_tag.workflow_run_id, workflow_run_tag.tag
+		FROM workflow_run_tag
+		JOIN workflow_run ON workflow_run.id = workflow_run_tag.workflow_run_id
+		WHERE workflow_run.workflow_id = $1 AND workflow_run_tag.value <> ''`
+	if _, err := db.Select(&tags, query, workflowID); err != nil {
+		return nil, sdk.WrapError(err, "loadRetentionRuns> Unable to load run tags of workflow %d", workflowID)
+	}
+	for _, t := range tags {
+		r, has := runs[t.RunID]
+		if !has {
+			continue
+		}
+		r.Tags = append(r.Tags, t.Tag)
+		runs[t.RunID] = r
+	}
+	return runs, nil
+}
+
+// protectedRuns returns the runs which artifacts must be kept whatever the policy:
+// runs still in progress, the last N successful runs and the runs with a kept tag
+func protectedRuns(p sdk.ArtifactRetentionPolicy, runs map[int64]retentionRun) map[int64]bool {
+	protected := map[int64]bool{}
+	successes := []retentionRun{}
+	for id, r := range runs {
+		if !sdk.StatusIsTerminated(r.Status) || r.Status == sdk.StatusChecking.String() {
+			protected[id] = true
+		}
+		if r.Status == sdk.StatusSuccess.String() {
+			successes = append(successes, r)
+		}
+		for _, t := range r.Tags {
+			if sdk.IsInArray(t, p.KeepTaggedRuns) {
+				protected[id] = true
+			}
+		}
+	}
+
+	sort.Slice(successes, func(i, j int) bool { return successes[i].Number > successes[j].Number })
+	for i := 0; i < len(successes) && int64(i) < p.KeepLastSuccessfulRuns; i++ {
+		protected[successes[i].ID] = true
+	}
+	return protected
+}
+
+// expiredArtifacts returns the artifacts to delete according to the policy.
+// Artifacts are browsed from the newest to the oldest, protected artifacts are never deleted but count in the total size.
+func expiredArtifacts(p sdk.ArtifactRetentionPolicy, now time.Time, runs map[int64]retentionRun, arts []sdk.WorkflowNodeRunArtifact) []expiredArtifact {
+	protected := protectedRuns(p, runs)
+
+	sorted := make([]sdk.WorkflowNodeRunArtifact, len(arts))
+	copy(sorted, arts)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Created.After(sorted[j].Created) })
+
+	res := []expiredArtifact{}
+	var totalSize int64
+	for _, a := range sorted {
+		if protected[a.WorkflowID] {
+			totalSize += a.Size
+			continue
+		}
+		if p.MaxAge > 0 && now.Sub(a.Created) > p.MaxAgeDuration() {
+			res = append(res, expiredArtifact{artifact: a, reason: sdk.ArtifactPurgeReasonMaxAge})
+			continue
+		}
+		if p.MaxTotalSize > 0 && totalSize+a.Size > p.MaxTotalSize {
+			res = append(res, expiredArtifact{artifact: a, reason: sdk.ArtifactPurgeReasonMaxTotalSize})
+			continue
+		}
+		totalSize += a.Size
+	}
+	return res
+}
+
+// expiredCaches returns the worker caches to delete according to the policy of the project
+func expiredCaches(p sdk.ArtifactRetentionPolicy, now time.Time, caches []sdk.WorkerCache) []expiredCache {
+	sorted := make([]sdk.WorkerCache, len(caches))
+	copy(sorted, caches)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].LastModified.After(sorted[j].LastModified) })
+
+	res := []expiredCache{}
+	var totalSize int64
+	for _, c := range sorted {
+		if p.MaxAge > 0 && now.Sub(c.LastModified) > p.MaxAgeDuration() {
+			res = append(res, expiredCache{cache: c, reason: sdk.ArtifactPurgeReasonMaxAge})
+			continue
+		}
+		if p.MaxTotalSize > 0 && totalSize+c.Size > p.MaxTotalSize {
+			res = append(res, expiredCache{cache: c, reason: sdk.ArtifactPurgeReasonMaxTotalSize})
+			continue
+		}
+		totalSize += c.Size
+	}
+	return res
+}
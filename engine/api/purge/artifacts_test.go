@@ -0,0 +1,71 @@
+package purge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_expiredArtifacts(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+
+	runs := map[int64]retentionRun{
+		1: {ID: 1, Number: 1, Status: sdk.StatusSuccess.String(), Tags: []string{"git.tag"}},
+		2: {ID: 2, Number: 2, Status: sdk.StatusFail.String()},
+		3: {ID: 3, Number: 3, Status: sdk.StatusSuccess.String()},
+		4: {ID: 4, Number: 4, Status: sdk.StatusSuccess.String()},
+		5: {ID: 5, Number: 5, Status: sdk.StatusBuilding.String()},
+	}
+	arts := []sdk.WorkflowNodeRunArtifact{
+		{ID: 10, WorkflowID: 1, Name: "release", Size: 100, Created: now.Add(-30 * day)},
+		{ID: 20, WorkflowID: 2, Name: "failed", Size: 100, Created: now.Add(-20 * day)},
+		{ID: 30, WorkflowID: 3, Name: "old-success", Size: 100, Created: now.Add(-10 * day)},
+		{ID: 40, WorkflowID: 4, Name: "last-success", Size: 100, Created: now.Add(-9 * day)},
+		{ID: 50, WorkflowID: 5, Name: "building", Size: 100, Created: now.Add(-8 * day)},
+	}
+
+	ids := func(res []expiredArtifact) map[int64]string {
+		m := map[int64]string{}
+		for _, e := range res {
+			m[e.artifact.ID] = e.reason
+		}
+		return m
+	}
+
+	// Only max age: tagged run, last success and run in progress are kept
+	p := sdk.ArtifactRetentionPolicy{MaxAge: 7, KeepLastSuccessfulRuns: 1, KeepTaggedRuns: []string{"git.tag"}}
+	assert.Equal(t, map[int64]string{
+		20: sdk.ArtifactPurgeReasonMaxAge,
+		30: sdk.ArtifactPurgeReasonMaxAge,
+	}, ids(expiredArtifacts(p, now, runs, arts)))
+
+	// Only max total size: protected artifacts count in the total size
+	p = sdk.ArtifactRetentionPolicy{MaxTotalSize: 300, KeepLastSuccessfulRuns: 1, KeepTaggedRuns: []string{"git.tag"}}
+	assert.Equal(t, map[int64]string{
+		20: sdk.ArtifactPurgeReasonMaxTotalSize,
+	}, ids(expiredArtifacts(p, now, runs, arts)))
+
+	// Empty policy does not expire anything
+	assert.Len(t, expiredArtifacts(sdk.ArtifactRetentionPolicy{}, now, runs, arts), 0)
+}
+
+func Test_expiredCaches(t *testing.T) {
+	now := time.Now()
+	caches := []sdk.WorkerCache{
+		{Tag: "old", Size: 10, LastModified: now.Add(-48 * time.Hour)},
+		{Tag: "recent", Size: 10, LastModified: now.Add(-1 * time.Hour)},
+		{Tag: "middle", Size: 10, LastModified: now.Add(-2 * time.Hour)},
+	}
+
+	res := expiredCaches(sdk.ArtifactRetentionPolicy{MaxAge: 1, MaxTotalSize: 10}, now, caches)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "middle", res[0].cache.Tag)
+		assert.Equal(t, sdk.ArtifactPurgeReasonMaxTotalSize, res[0].reason)
+		assert.Equal(t, "old", res[1].cache.Tag)
+		assert.Equal(t, sdk.ArtifactPurgeReasonMaxAge, res[1].reason)
+	}
+}
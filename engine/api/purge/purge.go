@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-gorp/gorp"
 
+	"github.com/ovh/cds/engine/api/artifact"
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/project"
 	"github.com/ovh/cds/engine/api/workflow"
@@ -36,6 +37,21 @@ func Initialize(c context.Context, store cache.Store, DBFunc func() *gorp.DbMap)
 			if err := Workflows(c, DBFunc(), store); err != nil {
 				log.Warning("purge> Error on workflows : %v", err)
 			}
+
+			log.Debug("purge> Applying artifacts retention policies...")
+			report, err := Artifacts(DBFunc(), store, false)
+			if err != nil {
+				log.Warning("purge> Error on artifacts : %v", err)
+			} else if report.TotalCount > 0 {
+				log.Info("purge> %d artifacts and caches deleted (%d bytes)", report.TotalCount, report.TotalSize)
+			}
+
+			log.Debug("purge> Deleting unreferenced artifact blobs...")
+			if n, err := Blobs(DBFunc()); err != nil {
+				log.Warning("purge> Error on artifact blobs : %v", err)
+			} else if n > 0 {
+				log.Info("purge> %d artifact blobs deleted", n)
+			}
 		}
 	}
 }
@@ -104,13 +120,36 @@ func Workflows(ctx context.Context, db *gorp.DbMap, store cache.Store) error {
 	return nil
 }
 
-// deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db
+// deleteWorkflowRunsHistory is useful to delete all the workflow run marked with to delete flag in db.
+// Artifacts of the deleted runs are removed from the objectstore.
 func deleteWorkflowRunsHistory(db gorp.SqlExecutor) error {
-	query := `DELETE FROM workflow_run WHERE workflow_run.id IN (SELECT id FROM workflow_run WHERE to_delete = true LIMIT 30)`
-
-	if _, err := db.Exec(query); err != nil {
-		log.Warning("deleteWorkflowRunsHistory> Unable to delete workflow history %s", err)
+	ids := []struct {
+		ID int64 `db:"id"`
+	}{}
+	if _, err := db.Select(&ids, "SELECT id FROM workflow_run WHERE to_delete = true LIMIT 30"); err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		log.Warning("deleteWorkflowRunsHistory> Unable to load workflow history %s", err)
 		return err
 	}
+
+	for _, r := range ids {
+		arts, err := workflow.LoadArtifactsByRunID(db, r.ID)
+		if err != nil {
+			log.Warning("deleteWorkflowRunsHistory> %v", err)
+			continue
+		}
+		for i := range arts {
+			if err := artifact.DeleteWorkflowArtifactObject(&arts[i]); err != nil {
+				log.Warning("deleteWorkflowRunsHistory> Unable to delete artifact %s of workflow run %d: %v", arts[i].Name, r.ID, err)
+			}
+		}
+
+		if _, err := db.Exec("DELETE FROM workflow_run WHERE id = $1", r.ID); err != nil {
+			log.Warning("deleteWorkflowRunsHistory> Unable to delete workflow history %s", err)
+			return err
+		}
+	}
 	return nil
 }
@@ -353,6 +353,23 @@ func (c *vcsClient) PullRequestComment(ctx context.Context, fullname string, id
 	return nil
 }
 
+func (c *vcsClient) PullRequestComments(ctx context.Context, fullname string, id int) ([]sdk.VCSPullRequestComment, error) {
+	comments := []sdk.VCSPullRequestComment{}
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/comments", c.name, fullname, id)
+	if _, err := c.doJSONRequest(ctx, "GET", path, nil, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (c *vcsClient) PullRequestCommentUpdate(ctx context.Context, fullname string, id int, comment sdk.VCSPullRequestComment) error {
+	path := fmt.Sprintf("/vcs/%s/repos/%s/pullrequests/%d/comments/%d", c.name, fullname, id, comment.ID)
+	if _, err := c.doJSONRequest(ctx, "PUT", path, comment, nil); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *vcsClient) CreateHook(ctx context.Context, fullname string, hook *sdk.VCSHook) error {
 	path := fmt.Sprintf("/vcs/%s/repos/%s/hooks", c.name, fullname)
 	_, err := c.doJSONRequest(ctx, "POST", path, hook, hook)
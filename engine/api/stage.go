@@ -35,6 +35,11 @@ func (api *API) addStageHandler() service.Handler {
 		stageData.PipelineID = pipelineData.ID
 		stageData.Enabled = true
 
+		stages := append([]sdk.Stage{*stageData}, pipelineData.Stages...)
+		if err := sdk.CheckStageGraph(stages); err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "addStageHandler> Cannot start transaction")
@@ -190,6 +195,16 @@ func (api *API) updateStageHandler() service.Handler {
 		}
 		stageData.ID = s.ID
 
+		stages := []sdk.Stage{*stageData}
+		for _, ps := range pipelineData.Stages {
+			if ps.ID != stageData.ID {
+				stages = append(stages, ps)
+			}
+		}
+		if err := sdk.CheckStageGraph(stages); err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "updateStageHandler> Cannot start transaction")
@@ -243,6 +258,16 @@ func (api *API) deleteStageHandler() service.Handler {
 			return sdk.WrapError(err, "deleteStageHandler> Cannot Load stage")
 		}
 
+		stages := make([]sdk.Stage, 0, len(pipelineData.Stages))
+		for _, ps := range pipelineData.Stages {
+			if ps.ID != s.ID {
+				stages = append(stages, ps)
+			}
+		}
+		if err := sdk.CheckStageGraph(stages); err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+
 		tx, err := api.mustDB().Begin()
 		if err != nil {
 			return sdk.WrapError(err, "deleteStageHandler> Cannot start transaction")
@@ -16,6 +16,7 @@ import (
 	"github.com/ovh/cds/engine/api/scheduler"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/api/sessionstore"
+	"github.com/ovh/cds/engine/api/webhook"
 	"github.com/ovh/cds/engine/api/worker"
 	"github.com/ovh/cds/engine/service"
 	"github.com/ovh/cds/sdk"
@@ -43,6 +44,7 @@ func (api *API) Status() sdk.MonitoringStatus {
 	m.Lines = append(m.Lines, getStatusLine(scheduler.Status()))
 	m.Lines = append(m.Lines, getStatusLine(event.Status()))
 	m.Lines = append(m.Lines, getStatusLine(repositoriesmanager.EventsStatus(api.Cache)))
+	m.Lines = append(m.Lines, getStatusLine(webhook.EventsStatus(api.Cache)))
 	m.Lines = append(m.Lines, getStatusLine(api.Cache.Status()))
 	m.Lines = append(m.Lines, getStatusLine(sessionstore.Status))
 	m.Lines = append(m.Lines, getStatusLine(objectstore.Status()))
@@ -19,7 +19,7 @@ import (
 func TestUnusedProjectKeyWarningEventProjectKeyAdd(t *testing.T) {
 	// INIT
 	db, cache := test.SetupPG(t, bootstrap.InitiliazeDB)
-	event.Initialize(event.KafkaConfig{}, cache)
+	event.Initialize(event.Config{}, cache)
 
 	u, _ := assets.InsertAdminUser(db)
 	key := sdk.RandomString(10)
@@ -0,0 +1,235 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/engine/api/secret"
+	"github.com/ovh/cds/sdk"
+)
+
+// maxDeliveries is the number of deliveries kept in the log of a webhook
+const maxDeliveries = 100
+
+const webhookFields = `project_webhook.id, project_webhook.project_id, project_webhook.name, project_webhook.url, project_webhook.secret,
+	project_webhook.event_types, project_webhook.workflow_names, project_webhook.enabled, project_webhook.created, project_webhook.last_modified`
+
+func scanWebhook(s interface {
+	Scan(dest ...interface{}) error
+}, clearSecret bool) (*sdk.ProjectWebhook, error) {
+	var w sdk.ProjectWebhook
+	var eventTypes, workflowNames sql.NullString
+	if err := s.Scan(&w.ID, &w.ProjectID, &w.Name, &w.URL, &w.Secret, &eventTypes, &workflowNames, &w.Enabled, &w.Created, &w.LastModified); err != nil {
+		return nil, err
+	}
+	if err := gorpmapping.JSONNullString(eventTypes, &w.EventTypes); err != nil {
+		return nil, sdk.WrapError(err, "scanWebhook> Unable to unmarshal event_types")
+	}
+	if err := gorpmapping.JSONNullString(workflowNames, &w.WorkflowNames); err != nil {
+		return nil, sdk.WrapError(err, "scanWebhook> Unable to unmarshal workflow_names")
+	}
+
+	if !clearSecret {
+		w.Secret = sdk.PasswordPlaceholder
+		return &w, nil
+	}
+	s64, err := base64.StdEncoding.DecodeString(w.Secret)
+	if err != nil {
+		return nil, sdk.WrapError(err, "scanWebhook> Unable to decode secret")
+	}
+	clear, err := secret.Decrypt(s64)
+	if err != nil {
+		return nil, sdk.WrapError(err, "scanWebhook> Unable to decrypt secret")
+	}
+	w.Secret = string(clear)
+	return &w, nil
+}
+
+func loadWebhooks(db gorp.SqlExecutor, clearSecret bool, query string, args ...interface{}) ([]sdk.ProjectWebhook, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []sdk.ProjectWebhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows, clearSecret)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *w)
+	}
+	return webhooks, nil
+}
+
+// LoadByProjectID loads all the webhooks of a project
+func LoadByProjectID(db gorp.SqlExecutor, projectID int64, clearSecret bool) ([]sdk.ProjectWebhook, error) {
+	query := `SELECT ` + webhookFields + ` FROM project_webhook WHERE project_id = $1 ORDER BY name`
+	webhooks, err := loadWebhooks(db, clearSecret, query, projectID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadByProjectID> Unable to load webhooks of project %d", projectID)
+	}
+	return webhooks, nil
+}
+
+// LoadEnabledByProjectKey loads the enabled webhooks of a project with their secret
+func LoadEnabledByProjectKey(db gorp.SqlExecutor, projectKey string) ([]sdk.ProjectWebhook, error) {
+	query := `SELECT ` + webhookFields + ` FROM project_webhook
+		JOIN project ON project.id = project_webhook.project_id
+		WHERE project.projectkey = $1 AND project_webhook.enabled = true`
+	webhooks, err := loadWebhooks(db, true, query, projectKey)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadEnabledByProjectKey> Unable to load webhooks of project %s", projectKey)
+	}
+	return webhooks, nil
+}
+
+// LoadByID loads a webhook of a project
+func LoadByID(db gorp.SqlExecutor, projectID, id int64, clearSecret bool) (*sdk.ProjectWebhook, error) {
+	query := `SELECT ` + webhookFields + ` FROM project_webhook WHERE project_id = $1 AND id = $2`
+	w, err := scanWebhook(db.QueryRow(query, projectID, id), clearSecret)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrNotFound
+		}
+		return nil, sdk.WrapError(err, "LoadByID> Unable to load webhook %d", id)
+	}
+	return w, nil
+}
+
+func encryptSecret(s string) (string, error) {
+	encrypted, err := secret.Encrypt([]byte(s))
+	if err != nil {
+		return "", sdk.WrapError(err, "encryptSecret> Unable to encrypt secret")
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+// Insert inserts a webhook, the secret is stored encrypted
+func Insert(db gorp.SqlExecutor, w *sdk.ProjectWebhook) error {
+	s, err := encryptSecret(w.Secret)
+	if err != nil {
+		return err
+	}
+	eventTypes, err := gorpmapping.JSONToNullString(w.EventTypes)
+	if err != nil {
+		return sdk.WrapError(err, "Insert> Unable to marshal event_types")
+	}
+	workflowNames, err := gorpmapping.JSONToNullString(w.WorkflowNames)
+	if err != nil {
+		return sdk.WrapError(err, "Insert> Unable to marshal workflow_names")
+	}
+
+	w.Created = time.Now()
+	w.LastModified = w.Created
+	query := `INSERT INTO project_webhook (project_id, name, url, secret, event_types, workflow_names, enabled, created, last_modified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	if err := db.QueryRow(query, w.ProjectID, w.Name, w.URL, s, eventTypes, workflowNames, w.Enabled, w.Created, w.LastModified).Scan(&w.ID); err != nil {
+		return sdk.WrapError(err, "Insert> Unable to insert webhook %s", w.Name)
+	}
+	return nil
+}
+
+// Update updates a webhook, the secret is kept if it is the placeholder
+func Update(db gorp.SqlExecutor, w *sdk.ProjectWebhook) error {
+	eventTypes, err := gorpmapping.JSONToNullString(w.EventTypes)
+	if err != nil {
+		return sdk.WrapError(err, "Update> Unable to marshal event_types")
+	}
+	workflowNames, err := gorpmapping.JSONToNullString(w.WorkflowNames)
+	if err != nil {
+		return sdk.WrapError(err, "Update> Unable to marshal workflow_names")
+	}
+
+	w.LastModified = time.Now()
+	query := `UPDATE project_webhook SET name = $3, url = $4, event_types = $5, workflow_names = $6, enabled = $7, last_modified = $8
+		WHERE project_id = $1 AND id = $2`
+	if _, err := db.Exec(query, w.ProjectID, w.ID, w.Name, w.URL, eventTypes, workflowNames, w.Enabled, w.LastModified); err != nil {
+		return sdk.WrapError(err, "Update> Unable to update webhook %d", w.ID)
+	}
+
+	if w.Secret == "" || w.Secret == sdk.PasswordPlaceholder {
+		return nil
+	}
+	s, err := encryptSecret(w.Secret)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(`UPDATE project_webhook SET secret = $2 WHERE id = $1`, w.ID, s); err != nil {
+		return sdk.WrapError(err, "Update> Unable to update secret of webhook %d", w.ID)
+	}
+	return nil
+}
+
+// Delete deletes a webhook and its delivery log
+func Delete(db gorp.SqlExecutor, projectID, id int64) error {
+	if _, err := db.Exec(`DELETE FROM project_webhook WHERE project_id = $1 AND id = $2`, projectID, id); err != nil {
+		return sdk.WrapError(err, "Delete> Unable to delete webhook %d", id)
+	}
+	return nil
+}
+
+const deliveryFields = `id, webhook_id, event_type, coalesce(workflow_name, ''), coalesce(payload, ''), status, attempts, response_code, coalesce(error, ''), redelivery, created`
+
+func scanDelivery(s interface {
+	Scan(dest ...interface{}) error
+}) (*sdk.ProjectWebhookDelivery, error) {
+	var d sdk.ProjectWebhookDelivery
+	if err := s.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.WorkflowName, &d.Payload, &d.Status, &d.Attempts, &d.ResponseCode, &d.Error, &d.Redelivery, &d.Created); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// InsertDelivery inserts an entry in the delivery log of a webhook, only the last deliveries are kept
+func InsertDelivery(db gorp.SqlExecutor, d *sdk.ProjectWebhookDelivery) error {
+	query := `INSERT INTO project_webhook_delivery (webhook_id, event_type, workflow_name, payload, status, attempts, response_code, error, redelivery, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+	if err := db.QueryRow(query, d.WebhookID, d.EventType, d.WorkflowName, d.Payload, d.Status, d.Attempts, d.ResponseCode, d.Error, d.Redelivery, d.Created).Scan(&d.ID); err != nil {
+		return sdk.WrapError(err, "InsertDelivery> Unable to insert delivery of webhook %d", d.WebhookID)
+	}
+
+	query = `DELETE FROM project_webhook_delivery WHERE webhook_id = $1 AND id NOT IN (
+		SELECT id FROM project_webhook_delivery WHERE webhook_id = $1 ORDER BY id DESC LIMIT $2
+	)`
+	if _, err := db.Exec(query, d.WebhookID, maxDeliveries); err != nil {
+		return sdk.WrapError(err, "InsertDelivery> Unable to delete old deliveries of webhook %d", d.WebhookID)
+	}
+	return nil
+}
+
+// LoadDeliveries loads the delivery log of a webhook, the newest first
+func LoadDeliveries(db gorp.SqlExecutor, webhookID int64) ([]sdk.ProjectWebhookDelivery, error) {
+	rows, err := db.Query(`SELECT `+deliveryFields+` FROM project_webhook_delivery WHERE webhook_id = $1 ORDER BY id DESC`, webhookID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadDeliveries> Unable to load deliveries of webhook %d", webhookID)
+	}
+	defer rows.Close()
+
+	deliveries := []sdk.ProjectWebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, sdk.WrapError(err, "LoadDeliveries> Unable to scan delivery")
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, nil
+}
+
+// LoadDelivery loads a delivery of a webhook
+func LoadDelivery(db gorp.SqlExecutor, webhookID, id int64) (*sdk.ProjectWebhookDelivery, error) {
+	d, err := scanDelivery(db.QueryRow(`SELECT `+deliveryFields+` FROM project_webhook_delivery WHERE webhook_id = $1 AND id = $2`, webhookID, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrNotFound
+		}
+		return nil, sdk.WrapError(err, "LoadDelivery> Unable to load delivery %d", id)
+	}
+	return d, nil
+}
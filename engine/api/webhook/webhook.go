@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/event"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	deliveryMaxRetries = 3
+	deliveryRetryDelay = 2 * time.Second
+	// maxConcurrentDeliveries is the number of deliveries sent in parallel for each project
+	maxConcurrentDeliveries = 2
+	// maxPendingDeliveries is the number of deliveries a project can have in progress or waiting, the next events are dropped
+	maxPendingDeliveries = 100
+)
+
+// httpClient refuses to connect to the loopback, link-local and private addresses, after the resolution of the hostname.
+// It does not use the proxy of the environment: the address checked when dialing must be the one of the webhook
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: checkDialAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !sdk.IsPublicIP(ip) {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+// projectDeliveries bounds the deliveries of a project, so a slow webhook does not delay the other projects
+type projectDeliveries struct {
+	sem     chan struct{}
+	pending int
+}
+
+var deliveries = struct {
+	sync.Mutex
+	projects map[string]*projectDeliveries
+}{projects: map[string]*projectDeliveries{}}
+
+// acquireDelivery reserves a pending delivery for the project. It returns nil if the project has too many pending deliveries
+func acquireDelivery(projectKey string) *projectDeliveries {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	p, ok := deliveries.projects[projectKey]
+	if !ok {
+		p = &projectDeliveries{sem: make(chan struct{}, maxConcurrentDeliveries)}
+		deliveries.projects[projectKey] = p
+	}
+	if p.pending >= maxPendingDeliveries {
+		return nil
+	}
+	p.pending++
+	return p
+}
+
+func releaseDelivery(projectKey string) {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	p := deliveries.projects[projectKey]
+	p.pending--
+	if p.pending == 0 {
+		delete(deliveries.projects, projectKey)
+	}
+}
+
+//EventsStatus returns info about length of project webhooks queue
+func EventsStatus(store cache.Store) sdk.MonitoringStatusLine {
+	status := sdk.MonitoringStatusOK
+	n := store.QueueLen(event.WebhooksQueue)
+	if n > 100 {
+		status = sdk.MonitoringStatusWarn
+	}
+	return sdk.MonitoringStatusLine{Component: "Project Webhooks Queue", Value: fmt.Sprintf("%d", n), Status: status}
+}
+
+//ReceiveEvents has to be launched as a goroutine, it sends the events to the matching project webhooks
+func ReceiveEvents(c context.Context, DBFunc func() *gorp.DbMap, store cache.Store) {
+	for {
+		e := sdk.Event{}
+		store.DequeueWithContext(c, event.WebhooksQueue, &e)
+		if err := c.Err(); err != nil {
+			log.Error("Exiting webhook.ReceiveEvents: %v", err)
+			return
+		}
+
+		db := DBFunc()
+		if db == nil {
+			continue
+		}
+
+		webhooks, err := LoadEnabledByProjectKey(db, e.ProjectKey)
+		if err != nil {
+			log.Error("webhook.ReceiveEvents> %v", err)
+			continue
+		}
+
+		if len(webhooks) == 0 {
+			continue
+		}
+		payload, err := json.Marshal(e)
+		if err != nil {
+			log.Error("webhook.ReceiveEvents> Unable to marshal event: %v", err)
+			continue
+		}
+
+		for _, w := range webhooks {
+			if !w.Match(e) {
+				continue
+			}
+			p := acquireDelivery(e.ProjectKey)
+			if p == nil {
+				log.Warning("webhook.ReceiveEvents> Too many pending deliveries for project %s, dropping %s for webhook %s", e.ProjectKey, e.EventType, w.Name)
+				continue
+			}
+			go func(w sdk.ProjectWebhook, projectKey string, p *projectDeliveries) {
+				defer releaseDelivery(projectKey)
+				p.sem <- struct{}{}
+				defer func() { <-p.sem }()
+				if _, err := deliver(db, w, e.EventType, e.WorkflowName, payload, deliveryMaxRetries, false); err != nil {
+					log.Warning("webhook.ReceiveEvents> %v", err)
+				}
+			}(w, e.ProjectKey, p)
+		}
+	}
+}
+
+// Redeliver sends again the payload of a delivery, without retry, and adds a new entry in the delivery log
+func Redeliver(db gorp.SqlExecutor, w sdk.ProjectWebhook, d sdk.ProjectWebhookDelivery) (*sdk.ProjectWebhookDelivery, error) {
+	return deliver(db, w, d.EventType, d.WorkflowName, []byte(d.Payload), 0, true)
+}
+
+func deliver(db gorp.SqlExecutor, w sdk.ProjectWebhook, eventType, workflowName string, payload []byte, maxRetries int, redelivery bool) (*sdk.ProjectWebhookDelivery, error) {
+	d := sdk.ProjectWebhookDelivery{
+		WebhookID:    w.ID,
+		EventType:    eventType,
+		WorkflowName: workflowName,
+		Payload:      string(payload),
+		Status:       sdk.ProjectWebhookDeliverySuccess,
+		Redelivery:   redelivery,
+		Created:      time.Now(),
+	}
+
+	attempts, code, err := event.DeliverWebhook(httpClient, w.URL, w.Secret, eventType, payload, maxRetries, deliveryRetryDelay)
+	d.Attempts = attempts
+	d.ResponseCode = code
+	if err != nil {
+		d.Status = sdk.ProjectWebhookDeliveryFail
+		d.Error = err.Error()
+	}
+
+	if err := InsertDelivery(db, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
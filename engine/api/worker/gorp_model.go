@@ -42,8 +42,16 @@ func (m *WorkerModel) PostInsert(s gorp.SqlExecutor) error {
 		}
 	}
 
-	query := "update worker_model set created_by = $2, model = $3 where id = $1"
-	if _, err := s.Exec(query, m.ID, btes, modelBtes); err != nil {
+	var reuse sql.NullString
+	if m.Reuse != nil {
+		var err error
+		if reuse, err = gorpmapping.JSONToNullString(m.Reuse); err != nil {
+			return sdk.WrapError(err, "PostInsert> cannot marshal reuse")
+		}
+	}
+
+	query := "update worker_model set created_by = $2, model = $3, reuse = $4 where id = $1"
+	if _, err := s.Exec(query, m.ID, btes, modelBtes, reuse); err != nil {
 		return err
 	}
 
@@ -100,8 +108,8 @@ func (m *WorkerModel) PostSelect(s gorp.SqlExecutor) error {
 
 	//Load created_by
 	m.CreatedBy = sdk.User{}
-	var createdBy, model, registeredOS, registeredArch sql.NullString
-	err := s.QueryRow("select created_by, model, registered_os, registered_arch from worker_model where id = $1", m.ID).Scan(&createdBy, &model, &registeredOS, &registeredArch)
+	var createdBy, model, registeredOS, registeredArch, reuse sql.NullString
+	err := s.QueryRow("select created_by, model, registered_os, registered_arch, reuse from worker_model where id = $1", m.ID).Scan(&createdBy, &model, &registeredOS, &registeredArch, &reuse)
 	if err != nil {
 		return err
 	}
@@ -129,6 +137,14 @@ func (m *WorkerModel) PostSelect(s gorp.SqlExecutor) error {
 		return err
 	}
 
+	m.Reuse = nil
+	if reuse.Valid {
+		m.Reuse = &sdk.ModelReuse{}
+		if err := gorpmapping.JSONNullString(reuse, m.Reuse); err != nil {
+			return sdk.WrapError(err, "PostSelect> cannot unmarshall reuse")
+		}
+	}
+
 	m.CreatedBy.Groups = nil
 	m.CreatedBy.Permissions = sdk.UserPermissions{}
 	m.CreatedBy.Auth = sdk.Auth{}
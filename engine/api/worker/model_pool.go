@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+const (
+	// modelBusySlot is the duration of the slots in which the busy time of the workers of a model is counted
+	modelBusySlot = 10 * time.Minute
+	// modelBusyHistory is the queue history used to size the pools of reusable workers
+	modelBusyHistory = time.Hour
+)
+
+func modelBusyKey(modelID, slot int64) string {
+	return cache.Key("worker", "model", "busy", strconv.FormatInt(modelID, 10), strconv.FormatInt(slot, 10))
+}
+
+func currentModelBusySlot() int64 {
+	return time.Now().Unix() / int64(modelBusySlot.Seconds())
+}
+
+// AddModelBusyTime counts the time spent by a worker of a model running a job.
+// The counters are not locked: the history is an approximation.
+func AddModelBusyTime(store cache.Store, modelID int64, d time.Duration) {
+	if modelID == 0 || d <= 0 {
+		return
+	}
+	key := modelBusyKey(modelID, currentModelBusySlot())
+	var busy int64
+	store.Get(key, &busy)
+	store.SetWithTTL(key, busy+int64(d.Seconds()), int((modelBusyHistory + modelBusySlot).Seconds()))
+}
+
+// ModelPoolSize returns the number of reusable workers needed to run the jobs of a model, computed from the
+// busy time of its workers during the last hour. It returns 0 if the workers of the model are not reusable.
+func ModelPoolSize(store cache.Store, m sdk.Model) int64 {
+	if !m.Reuse.Enabled() {
+		return 0
+	}
+	slot := currentModelBusySlot()
+	var busy int64
+	for i := int64(0); i < int64(modelBusyHistory/modelBusySlot); i++ {
+		var b int64
+		if store.Get(modelBusyKey(m.ID, slot-i), &b) {
+			busy += b
+		}
+	}
+	return poolSize(busy, m.Reuse.MaxPool)
+}
+
+// poolSize applies Little's law: the mean number of busy workers is their busy time divided by the
+// observation period. It is rounded up and capped to max if max is set.
+func poolSize(busySeconds, max int64) int64 {
+	period := int64(modelBusyHistory.Seconds())
+	size := (busySeconds + period - 1) / period
+	if max > 0 && size > max {
+		size = max
+	}
+	return size
+}
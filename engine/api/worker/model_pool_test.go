@@ -0,0 +1,15 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_poolSize(t *testing.T) {
+	assert.Equal(t, int64(0), poolSize(0, 0))
+	assert.Equal(t, int64(1), poolSize(1, 0))
+	assert.Equal(t, int64(1), poolSize(3600, 0))
+	assert.Equal(t, int64(3), poolSize(3*3600-10, 0))
+	assert.Equal(t, int64(2), poolSize(10*3600, 2))
+}
@@ -70,7 +70,13 @@ func DisableWorker(db *gorp.DbMap, id string) error {
 			}
 		case sdk.JobTypeWorkflowNode:
 			wNodeJob, errL := workflow.LoadNodeJobRun(tx, nil, jobID.Int64)
-			if errL == nil && wNodeJob.Retry < 3 {
+			if errL == nil && wNodeJob.Job.RetryPolicy.ShouldRetryWorkerLost(wNodeJob.Job.CurrentAttempt()) {
+				if err := workflow.RetryNodeJobRunOnWorkerLost(nil, db, *wNodeJob); err != nil {
+					log.Warning("DisableWorker[%s]> Cannot retry workflow node run : %s", name, err)
+				} else {
+					log.Info("DisableWorker[%s]> WorkflowNodeRun %d retried after crash", name, jobID.Int64)
+				}
+			} else if errL == nil && wNodeJob.Retry < 3 {
 				if err := workflow.RestartWorkflowNodeJob(nil, db, *wNodeJob); err != nil {
 					log.Warning("DisableWorker[%s]> Cannot restart workflow node run : %s", name, err)
 				} else {
@@ -421,6 +427,47 @@ func SetToBuilding(db gorp.SqlExecutor, workerID string, actionBuildID int64, jo
 	return err
 }
 
+// CheckWorkerCanTakeJob checks that a worker can take a workflow job of a project. A worker which has run the job of
+// a pull request opened from a fork can't take any other job, and a worker of a reusable model only takes the jobs
+// of the project of its first job. It is checked by the API, the worker itself can't be trusted to enforce it.
+func CheckWorkerCanTakeJob(db gorp.SqlExecutor, w *sdk.Worker, projectID int64) error {
+	var boundProjectID sql.NullInt64
+	var untrusted sql.NullBool
+	query := `SELECT project_id, untrusted FROM worker WHERE id = $1 FOR UPDATE`
+	if err := db.QueryRow(query, w.ID).Scan(&boundProjectID, &untrusted); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrNoWorker
+		}
+		return sdk.WrapError(err, "CheckWorkerCanTakeJob> Unable to load worker %s", w.ID)
+	}
+
+	if untrusted.Bool {
+		return sdk.WrapError(sdk.ErrForbidden, "CheckWorkerCanTakeJob> worker %s has run an untrusted job", w.Name)
+	}
+	if !boundProjectID.Valid || boundProjectID.Int64 == projectID || w.ModelID == 0 {
+		return nil
+	}
+
+	m, err := LoadWorkerModelByID(db, w.ModelID)
+	if err != nil {
+		return sdk.WrapError(err, "CheckWorkerCanTakeJob> Unable to load model %d of worker %s", w.ModelID, w.Name)
+	}
+	if m.Reuse.Enabled() {
+		return sdk.WrapError(sdk.ErrForbidden, "CheckWorkerCanTakeJob> worker %s only takes the jobs of project %d", w.Name, boundProjectID.Int64)
+	}
+	return nil
+}
+
+// BindToJob records the project of the workflow job taken by a worker. If the job runs untrusted code,
+// the worker is flagged so that it can't take any other job.
+func BindToJob(db gorp.SqlExecutor, workerID string, projectID int64, untrusted bool) error {
+	query := `UPDATE worker SET project_id = $2, untrusted = (COALESCE(untrusted, false) OR $3) WHERE id = $1`
+	if _, err := db.Exec(query, workerID, projectID, untrusted); err != nil {
+		return sdk.WrapError(err, "BindToJob> Unable to update worker %s", workerID)
+	}
+	return nil
+}
+
 // UpdateWorkerStatus changes worker status to Disabled
 func UpdateWorkerStatus(db gorp.SqlExecutor, workerID string, status sdk.Status) error {
 	query := `UPDATE worker SET status = $1, action_build_id = NULL WHERE id = $2`
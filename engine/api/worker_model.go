@@ -69,10 +69,16 @@ func (api *API) addWorkerModelHandler() service.Handler {
 				}
 				model.ModelDocker.Cmd = modelPattern.Model.Cmd
 				model.ModelDocker.Shell = modelPattern.Model.Shell
+				model.ModelDocker.PodTemplate = nil
 			}
 			if model.ModelDocker.Cmd == "" || model.ModelDocker.Shell == "" {
 				return sdk.WrapError(sdk.ErrWrongRequest, "updateWorkerModel> Invalid worker command or invalid shell command")
 			}
+			if model.ModelDocker.PodTemplate != nil {
+				if err := model.ModelDocker.PodTemplate.IsValid(); err != nil {
+					return sdk.WrapError(sdk.ErrWrongRequest, "addWorkerModel> Invalid pod template: %v", err)
+				}
+			}
 		default:
 			if model.ModelVirtualMachine.Image == "" {
 				return sdk.WrapError(sdk.ErrWrongRequest, "addWorkerModel> Invalid worker command or invalid image")
@@ -95,6 +101,12 @@ func (api *API) addWorkerModelHandler() service.Handler {
 			return sdk.WrapError(sdk.ErrWrongRequest, "addWorkerModel> groupID should be set")
 		}
 
+		if model.Reuse != nil {
+			if err := model.Reuse.IsValid(); err != nil {
+				return sdk.WrapError(sdk.ErrWrongRequest, "addWorkerModel> Invalid reuse: %v", err)
+			}
+		}
+
 		if group.IsDefaultGroupID(model.GroupID) {
 			return sdk.WrapError(sdk.ErrWrongRequest, "addWorkerModel> this group can't be owner of a worker model")
 		}
@@ -292,10 +304,16 @@ func (api *API) updateWorkerModelHandler() service.Handler {
 					model.ModelDocker.Shell = modelPattern.Model.Shell
 					model.ModelDocker.Envs = modelPattern.Model.Envs
 				}
+				model.ModelDocker.PodTemplate = old.ModelDocker.PodTemplate
 			}
 			if model.ModelDocker.Cmd == "" || model.ModelDocker.Shell == "" {
 				return sdk.WrapError(sdk.ErrWrongRequest, "updateWorkerModel> Invalid worker command or invalid shell command")
 			}
+			if model.ModelDocker.PodTemplate != nil {
+				if err := model.ModelDocker.PodTemplate.IsValid(); err != nil {
+					return sdk.WrapError(sdk.ErrWrongRequest, "updateWorkerModel> Invalid pod template: %v", err)
+				}
+			}
 		default:
 			if model.ModelVirtualMachine.Image == "" {
 				return sdk.WrapError(sdk.ErrWrongRequest, "updateWorkerModel> Invalid worker command or invalid image")
@@ -316,6 +334,12 @@ func (api *API) updateWorkerModelHandler() service.Handler {
 			}
 		}
 
+		if model.Reuse != nil {
+			if err := model.Reuse.IsValid(); err != nil {
+				return sdk.WrapError(sdk.ErrWrongRequest, "updateWorkerModel> Invalid reuse: %v", err)
+			}
+		}
+
 		//If the model modelID has not been set, keep the old modelID
 		if model.ID == 0 {
 			model.ID = old.ID
@@ -425,6 +449,9 @@ func (api *API) getWorkerModelsEnabledHandler() service.Handler {
 		if errgroup != nil {
 			return sdk.WrapError(errgroup, "getWorkerModelsEnabled> cannot load worker models for hatchery %d with group %d", getHatchery(ctx).ID, getHatchery(ctx).GroupID)
 		}
+		for i := range models {
+			models[i].PoolSize = worker.ModelPoolSize(api.Cache, models[i])
+		}
 		return service.WriteJSON(w, models, http.StatusOK)
 	}
 }
@@ -53,7 +53,8 @@ func loadAwolJobRuns(db gorp.SqlExecutor) ([]sdk.WorkflowNodeJobRun, error) {
 // it loads all workflow_node_run_job which are linked to a worker that doesn't exist anymore
 // and the all workflow_node_run_job at status 'Building' but without any logs since more than 15 minutes
 // and queued since less than 48h
-// each of those workflow_node_run_job is restart by RestartWorkflowNodeJob
+// each of those workflow_node_run_job is restart by RestartWorkflowNodeJob, or retried by
+// RetryNodeJobRunOnWorkerLost if its retry policy allows it
 func RestartAwolJobs(ctx context.Context, store cache.Store, dbFunc func() *gorp.DbMap) {
 	ticker := time.NewTicker(30 * time.Second)
 	for {
@@ -73,7 +74,13 @@ func RestartAwolJobs(ctx context.Context, store cache.Store, dbFunc func() *gorp
 					log.Error("RestartAwolJobs> unable to start tx:%v", err)
 					continue
 				}
-				if err := RestartWorkflowNodeJob(ctx, tx, j); err != nil {
+				if j.Job.RetryPolicy.ShouldRetryWorkerLost(j.Job.CurrentAttempt()) {
+					if err := RetryNodeJobRunOnWorkerLost(ctx, tx, j); err != nil {
+						log.Error("RestartAwolJobs> unable to retry job %d:%v", j.ID, err)
+						_ = tx.Rollback()
+						continue
+					}
+				} else if err := RestartWorkflowNodeJob(ctx, tx, j); err != nil {
 					log.Error("RestartAwolJobs> unable to restart job %d:%v", j.ID, err)
 					_ = tx.Rollback()
 					continue
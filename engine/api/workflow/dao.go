@@ -109,11 +109,12 @@ func (w *Workflow) PostInsert(db gorp.SqlExecutor) error {
 // PostGet is a db hook
 func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	var res = struct {
-		Metadata  sql.NullString `db:"metadata"`
-		PurgeTags sql.NullString `db:"purge_tags"`
+		Metadata    sql.NullString `db:"metadata"`
+		PurgeTags   sql.NullString `db:"purge_tags"`
+		Concurrency sql.NullString `db:"concurrency"`
 	}{}
 
-	if err := db.SelectOne(&res, "SELECT metadata, purge_tags FROM workflow WHERE id = $1", w.ID); err != nil {
+	if err := db.SelectOne(&res, "SELECT metadata, purge_tags, concurrency FROM workflow WHERE id = $1", w.ID); err != nil {
 		return sdk.WrapError(err, "PostGet> Unable to load marshalled workflow")
 	}
 
@@ -129,6 +130,13 @@ func (w *Workflow) PostGet(db gorp.SqlExecutor) error {
 	}
 	w.PurgeTags = purgeTags
 
+	if res.Concurrency.Valid {
+		w.Concurrency = new(sdk.WorkflowConcurrency)
+		if err := gorpmapping.JSONNullString(res.Concurrency, w.Concurrency); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -160,6 +168,18 @@ func (w *Workflow) PostUpdate(db gorp.SqlExecutor) error {
 		return err
 	}
 
+	var concurrency sql.NullString
+	if w.Concurrency != nil {
+		var errC error
+		concurrency, errC = gorpmapping.JSONToNullString(w.Concurrency)
+		if errC != nil {
+			return errC
+		}
+	}
+	if _, err := db.Exec("update workflow set concurrency = $1 where id = $2", concurrency, w.ID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -245,7 +265,8 @@ func Load(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk
 		workflow.derived_from_workflow_id,
 		workflow.derived_from_workflow_name,
 		workflow.derivation_branch,
-		workflow.to_delete
+		workflow.to_delete,
+		workflow.cancel_superseded_runs
 		from workflow
 		join project on project.id = workflow.project_id
 		where project.projectkey = $1
@@ -476,6 +497,9 @@ func Insert(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow, p *sdk.Proj
 	if err := IsValid(w, p); err != nil {
 		return err
 	}
+	if err := checkSharedConcurrencyGroups(db, w, nil, p, u); err != nil {
+		return err
+	}
 
 	if w.HistoryLength == 0 {
 		w.HistoryLength = sdk.DefaultHistoryLength
@@ -627,6 +651,9 @@ func Update(db gorp.SqlExecutor, store cache.Store, w *sdk.Workflow, oldWorkflow
 	if err := IsValid(w, p); err != nil {
 		return err
 	}
+	if err := checkSharedConcurrencyGroups(db, w, oldWorkflow, p, u); err != nil {
+		return err
+	}
 
 	if err := renameNode(db, w); err != nil {
 		return sdk.WrapError(err, "Update> cannot check pipeline name")
@@ -766,6 +793,13 @@ func IsValid(w *sdk.Workflow, proj *sdk.Project) error {
 		return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Invalid workflow name. It should match %s", sdk.NamePattern))
 	}
 
+	//Check concurrency group
+	if w.Concurrency != nil {
+		if err := w.Concurrency.IsValid(); err != nil {
+			return sdk.NewError(sdk.ErrWorkflowInvalid, err)
+		}
+	}
+
 	//Check duplicate refs
 	refs := w.References()
 	for i, ref1 := range refs {
@@ -859,6 +893,35 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 	ctx, end := observability.Span(ctx, "workflow.Push")
 	defer end()
 
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "Push> Unable to start tx")
+	}
+	defer tx.Rollback()
+
+	allMsg, wf, err := PushInTx(ctx, tx, store, proj, tr, opts, u, decryptFunc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dryRun, isDefaultBranch bool
+	if opts != nil {
+		dryRun = opts.DryRun
+		isDefaultBranch = opts.IsDefaultBranch
+	}
+	if dryRun && !isDefaultBranch {
+		_ = tx.Rollback()
+	} else {
+		if err := tx.Commit(); err != nil {
+			return nil, nil, sdk.WrapError(err, "Push> Cannot commit transaction")
+		}
+	}
+
+	return allMsg, wf, nil
+}
+
+// PushInTx push a workflow from cds files in a transaction. The caller commits or rollbacks the transaction
+func PushInTx(ctx context.Context, tx gorp.SqlExecutor, store cache.Store, proj *sdk.Project, tr *tar.Reader, opts *PushOption, u *sdk.User, decryptFunc keys.DecryptFunc) ([]sdk.Message, *sdk.Workflow, error) {
 	apps := make(map[string]exportentities.Application)
 	pips := make(map[string]exportentities.PipelineV1)
 	envs := make(map[string]exportentities.Environment)
@@ -925,12 +988,6 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 		return nil, nil, sdk.NewError(sdk.ErrWorkflowInvalid, mError)
 	}
 
-	tx, err := db.Begin()
-	if err != nil {
-		return nil, nil, sdk.WrapError(err, "Push> Unable to start tx")
-	}
-	defer tx.Rollback()
-
 	allMsg := []sdk.Message{}
 	for filename, app := range apps {
 		log.Debug("Push> Parsing %s", filename)
@@ -1064,18 +1121,6 @@ func Push(ctx context.Context, db *gorp.DbMap, store cache.Store, proj *sdk.Proj
 
 	allMsg = append(allMsg, msgList...)
 
-	isDefaultBranch := false
-	if opts != nil {
-		isDefaultBranch = opts.IsDefaultBranch
-	}
-	if dryRun && !isDefaultBranch {
-		_ = tx.Rollback()
-	} else {
-		if err := tx.Commit(); err != nil {
-			return nil, nil, sdk.WrapError(err, "Push> Cannot commit transaction")
-		}
-	}
-
 	return allMsg, wf, nil
 }
 
@@ -22,7 +22,8 @@ func LoadWorkfowArtifactByHash(db gorp.SqlExecutor, hash string) (*sdk.WorkflowN
 				object_path,
 				created,
 				workflow_run_id,
-				coalesce(sha512sum, '') AS sha512sum
+				coalesce(sha512sum, '') AS sha512sum,
+				coalesce(blob_key, '') AS blob_key
 		  FROM workflow_node_run_artifacts
 		  WHERE workflow_node_run_artifacts.download_hash = $1`
 	if err := db.SelectOne(&artGorp, query, hash); err != nil {
@@ -50,7 +51,8 @@ func LoadArtifactByIDs(db gorp.SqlExecutor, workflowID, artifactID int64) (*sdk.
 			workflow_node_run_artifacts.object_path,
 			workflow_node_run_artifacts.created,
 			workflow_node_run_artifacts.workflow_run_id,
-			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum
+			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum,
+			coalesce(workflow_node_run_artifacts.blob_key, '') AS blob_key
 		FROM workflow_node_run_artifacts
 		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
 		WHERE workflow_run.workflow_id = $1 AND workflow_node_run_artifacts.id = $2
@@ -78,7 +80,8 @@ func loadArtifactByNodeRunID(db gorp.SqlExecutor, nodeRunID int64) ([]sdk.Workfl
 			object_path,
 			created,
 			workflow_run_id,
-			coalesce(sha512sum, '') AS sha512sum
+			coalesce(sha512sum, '') AS sha512sum,
+			coalesce(blob_key, '') AS blob_key
 		FROM workflow_node_run_artifacts WHERE workflow_node_run_id = $1`, nodeRunID); err != nil {
 		return nil, err
 	}
@@ -99,3 +102,72 @@ func InsertArtifact(db gorp.SqlExecutor, a *sdk.WorkflowNodeRunArtifact) error {
 	a.ID = wArtifactDB.ID
 	return nil
 }
+
+// LoadArtifactsByWorkflowID loads all the artifacts of all the runs of a workflow, the newest first
+func LoadArtifactsByWorkflowID(db gorp.SqlExecutor, workflowID int64) ([]sdk.WorkflowNodeRunArtifact, error) {
+	var artifactsGorp []NodeRunArtifact
+	if _, err := db.Select(&artifactsGorp, `SELECT
+			workflow_node_run_artifacts.id,
+			workflow_node_run_artifacts.name,
+			workflow_node_run_artifacts.tag,
+			workflow_node_run_artifacts.ref,
+			workflow_node_run_artifacts.workflow_node_run_id,
+			workflow_node_run_artifacts.download_hash,
+			workflow_node_run_artifacts.size,
+			workflow_node_run_artifacts.perm,
+			workflow_node_run_artifacts.md5sum,
+			workflow_node_run_artifacts.object_path,
+			workflow_node_run_artifacts.created,
+			workflow_node_run_artifacts.workflow_run_id,
+			coalesce(workflow_node_run_artifacts.sha512sum, '') AS sha512sum,
+			coalesce(workflow_node_run_artifacts.blob_key, '') AS blob_key
+		FROM workflow_node_run_artifacts
+		JOIN workflow_run ON workflow_run.id = workflow_node_run_artifacts.workflow_run_id
+		WHERE workflow_run.workflow_id = $1
+		ORDER BY workflow_node_run_artifacts.created DESC`, workflowID); err != nil {
+		return nil, sdk.WrapError(err, "LoadArtifactsByWorkflowID> Unable to load artifacts of workflow %d", workflowID)
+	}
+
+	artifacts := make([]sdk.WorkflowNodeRunArtifact, len(artifactsGorp))
+	for i := range artifactsGorp {
+		artifacts[i] = sdk.WorkflowNodeRunArtifact(artifactsGorp[i])
+	}
+	return artifacts, nil
+}
+
+// DeleteArtifact deletes an artifact from the database, the object has to be deleted from the objectstore by the caller
+func DeleteArtifact(db gorp.SqlExecutor, id int64) error {
+	if _, err := db.Exec("DELETE FROM workflow_node_run_artifacts WHERE id = $1", id); err != nil {
+		return sdk.WrapError(err, "DeleteArtifact> Unable to delete artifact %d", id)
+	}
+	return nil
+}
+
+// LoadArtifactsByRunID loads all the artifacts of a workflow run
+func LoadArtifactsByRunID(db gorp.SqlExecutor, workflowRunID int64) ([]sdk.WorkflowNodeRunArtifact, error) {
+	var artifactsGorp []NodeRunArtifact
+	if _, err := db.Select(&artifactsGorp, `SELECT
+			id,
+			name,
+			tag,
+			ref,
+			workflow_node_run_id,
+			download_hash,
+			size,
+			perm,
+			md5sum,
+			object_path,
+			created,
+			workflow_run_id,
+			coalesce(sha512sum, '') AS sha512sum,
+			coalesce(blob_key, '') AS blob_key
+		FROM workflow_node_run_artifacts WHERE workflow_run_id = $1`, workflowRunID); err != nil {
+		return nil, sdk.WrapError(err, "LoadArtifactsByRunID> Unable to load artifacts of workflow run %d", workflowRunID)
+	}
+
+	artifacts := make([]sdk.WorkflowNodeRunArtifact, len(artifactsGorp))
+	for i := range artifactsGorp {
+		artifacts[i] = sdk.WorkflowNodeRunArtifact(artifactsGorp[i])
+	}
+	return artifacts, nil
+}
@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/sdk"
+)
+
+// LoadSharedConcurrencyGroups returns all the shared concurrency groups
+func LoadSharedConcurrencyGroups(db gorp.SqlExecutor) ([]sdk.SharedConcurrencyGroup, error) {
+	query := `SELECT workflow_concurrency_group.id, workflow_concurrency_group.name, workflow_concurrency_group.group_id, "group".name AS group_name
+	FROM workflow_concurrency_group
+	JOIN "group" ON "group".id = workflow_concurrency_group.group_id
+	ORDER BY workflow_concurrency_group.name`
+	var res []sdk.SharedConcurrencyGroup
+	if _, err := db.Select(&res, query); err != nil {
+		return nil, sdk.WrapError(err, "LoadSharedConcurrencyGroups> Unable to load shared concurrency groups")
+	}
+	return res, nil
+}
+
+// LoadSharedConcurrencyGroupByName returns a shared concurrency group by its name, if not found, it returns an error
+func LoadSharedConcurrencyGroupByName(db gorp.SqlExecutor, name string) (*sdk.SharedConcurrencyGroup, error) {
+	query := `SELECT workflow_concurrency_group.id, workflow_concurrency_group.name, workflow_concurrency_group.group_id, "group".name AS group_name
+	FROM workflow_concurrency_group
+	JOIN "group" ON "group".id = workflow_concurrency_group.group_id
+	WHERE workflow_concurrency_group.name = $1`
+	var g sdk.SharedConcurrencyGroup
+	if err := db.SelectOne(&g, query, name); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.WrapError(sdk.ErrNotFound, "LoadSharedConcurrencyGroupByName> Unable to load shared concurrency group %s", name)
+		}
+		return nil, sdk.WrapError(err, "LoadSharedConcurrencyGroupByName> Unable to load shared concurrency group %s", name)
+	}
+	return &g, nil
+}
+
+// InsertSharedConcurrencyGroup inserts a shared concurrency group in database
+func InsertSharedConcurrencyGroup(db gorp.SqlExecutor, g *sdk.SharedConcurrencyGroup) error {
+	if err := g.IsValid(); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, err)
+	}
+	if err := db.QueryRow("INSERT INTO workflow_concurrency_group (name, group_id) VALUES ($1, $2) RETURNING id", g.Name, g.GroupID).Scan(&g.ID); err != nil {
+		return sdk.WrapError(err, "InsertSharedConcurrencyGroup> Unable to insert shared concurrency group %s", g.Name)
+	}
+	return nil
+}
+
+// DeleteSharedConcurrencyGroup deletes a shared concurrency group in database. The workflows using it
+// are not updated: their runs are scoped to their project again
+func DeleteSharedConcurrencyGroup(db gorp.SqlExecutor, name string) error {
+	res, err := db.Exec("DELETE FROM workflow_concurrency_group WHERE name = $1", name)
+	if err != nil {
+		return sdk.WrapError(err, "DeleteSharedConcurrencyGroup> Unable to delete shared concurrency group %s", name)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sdk.WrapError(sdk.ErrNotFound, "DeleteSharedConcurrencyGroup> Unable to delete shared concurrency group %s", name)
+	}
+	return nil
+}
+
+// isSharedConcurrencyGroupAllowed checks if the concurrency group is a shared group whose owner group
+// has the read-write-execute permission on the project
+func isSharedConcurrencyGroupAllowed(db gorp.SqlExecutor, projectID int64, name string) (bool, error) {
+	query := `SELECT count(1)
+	FROM workflow_concurrency_group
+	JOIN project_group ON project_group.group_id = workflow_concurrency_group.group_id
+	WHERE workflow_concurrency_group.name = $1
+	AND project_group.project_id = $2
+	AND project_group.role >= $3`
+	nb, err := db.SelectInt(query, name, projectID, permission.PermissionReadWriteExecute)
+	if err != nil {
+		return false, sdk.WrapError(err, "isSharedConcurrencyGroupAllowed> Unable to check shared concurrency group %s", name)
+	}
+	return nb > 0, nil
+}
+
+// checkSharedConcurrencyGroups checks the shared concurrency groups added to the workflow: the owner group of each
+// shared group must have the read-write-execute permission on the project, and the user must be a member of it
+func checkSharedConcurrencyGroups(db gorp.SqlExecutor, w *sdk.Workflow, oldWorkflow *sdk.Workflow, proj *sdk.Project, u *sdk.User) error {
+	var oldGroups []string
+	if oldWorkflow != nil {
+		oldGroups = oldWorkflow.ConcurrencyGroups()
+	}
+
+groups:
+	for _, name := range w.ConcurrencyGroups() {
+		for _, old := range oldGroups {
+			if old == name {
+				continue groups
+			}
+		}
+
+		g, err := LoadSharedConcurrencyGroupByName(db, name)
+		if err != nil {
+			if sdk.ErrorIs(err, sdk.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+
+		allowed, err := isSharedConcurrencyGroupAllowed(db, proj.ID, name)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return sdk.NewError(sdk.ErrWorkflowInvalid, fmt.Errorf("Concurrency group %s is shared: group %s must have the read-write-execute permission on the project", name, g.GroupName))
+		}
+
+		if u == nil || u.Admin {
+			continue
+		}
+		nb, err := db.SelectInt("SELECT count(1) FROM group_user WHERE group_id = $1 AND user_id = $2", g.GroupID, u.ID)
+		if err != nil {
+			return sdk.WrapError(err, "checkSharedConcurrencyGroups> Unable to check the members of group %s", g.GroupName)
+		}
+		if nb == 0 {
+			return sdk.NewError(sdk.ErrForbidden, fmt.Errorf("Concurrency group %s is shared: only the members of group %s can use it", name, g.GroupName))
+		}
+	}
+	return nil
+}
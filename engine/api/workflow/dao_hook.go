@@ -10,6 +10,7 @@ import (
 	"github.com/ovh/cds/engine/api/database/gorpmapping"
 	"github.com/ovh/cds/engine/api/sessionstore"
 	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
 // UpdateHook Update a workflow node hook
@@ -61,6 +62,18 @@ func insertHook(db gorp.SqlExecutor, node *sdk.WorkflowNode, hook *sdk.WorkflowN
 	if _, ok := hook.Config[sdk.SchedulerModelPayload]; hook.WorkflowHookModel.Name == sdk.SchedulerModelName && !ok {
 		hook.Config[sdk.SchedulerModelPayload] = sdk.SchedulerModel.DefaultConfig[sdk.SchedulerModelPayload]
 	}
+	// Repository webhooks created before the events configuration are triggered by push events only
+	if _, ok := hook.Config[sdk.RepositoryWebHookModelEvents]; hook.WorkflowHookModel.Name == sdk.RepositoryWebHookModelName && !ok {
+		hook.Config[sdk.RepositoryWebHookModelEvents] = sdk.RepositoryWebHookModel.DefaultConfig[sdk.RepositoryWebHookModelEvents]
+	}
+	// Repository webhooks and git pollers created before the path filters are triggered by all the changes
+	if hook.WorkflowHookModel.Name == sdk.RepositoryWebHookModelName || hook.WorkflowHookModel.Name == sdk.GitPollerModelName || hook.WorkflowHookModel.Name == sdk.GitRefPollerModelName {
+		for _, k := range []string{sdk.HookConfigIncludePaths, sdk.HookConfigExcludePaths} {
+			if _, ok := hook.Config[k]; !ok {
+				hook.Config[k] = hook.WorkflowHookModel.DefaultConfig[k]
+			}
+		}
+	}
 
 	errmu := sdk.MultiError{}
 	// Check configuration of the hook vs the model
@@ -69,6 +82,14 @@ func insertHook(db gorp.SqlExecutor, node *sdk.WorkflowNode, hook *sdk.WorkflowN
 			errmu = append(errmu, fmt.Errorf("Missing configuration key: %s", k))
 		}
 	}
+	if hook.WorkflowHookModel.Name == sdk.GitRefPollerModelName {
+		if err := git.CheckRemoteURL(hook.Config[sdk.GitRefPollerModelURL].Value, hook.Config[sdk.GitRefPollerModelKey].Value != ""); err != nil {
+			errmu = append(errmu, err)
+		}
+		if _, err := time.ParseDuration(hook.Config[sdk.GitRefPollerModelInterval].Value); err != nil {
+			errmu = append(errmu, fmt.Errorf("Invalid interval: %s", hook.Config[sdk.GitRefPollerModelInterval].Value))
+		}
+	}
 	if len(errmu) > 0 {
 		return sdk.WrapError(&errmu, "insertHook> Invalid hook configuration")
 	}
@@ -209,6 +209,8 @@ type sqlContext struct {
 	DefaultPipelineParameters sql.NullString `db:"default_pipeline_parameters"`
 	Conditions                sql.NullString `db:"conditions"`
 	Mutex                     sql.NullBool   `db:"mutex"`
+	Approval                  sql.NullString `db:"approval"`
+	Concurrency               sql.NullString `db:"concurrency"`
 }
 
 // UpdateNodeContext updates the node context in database
@@ -255,6 +257,9 @@ func UpdateNodeContext(db gorp.SqlExecutor, c *sdk.WorkflowNodeContext) error {
 			return sdk.ErrWorkflowConditionBadOperator
 		}
 	}
+	if err := sdk.WorkflowNodeConditionsIsValid(c.Conditions); err != nil {
+		return sdk.WrapError(err, "updateNodeContext> Invalid conditions expression on workflow node context(%d)", c.ID)
+	}
 
 	var errC error
 	sqlContext.Conditions, errC = gorpmapping.JSONToNullString(c.Conditions)
@@ -262,6 +267,28 @@ func UpdateNodeContext(db gorp.SqlExecutor, c *sdk.WorkflowNodeContext) error {
 		return sdk.WrapError(errC, "updateNodeContext> Unable to marshall workflow node context(%d) conditions", c.ID)
 	}
 
+	if c.Approval != nil {
+		if err := c.Approval.IsValid(); err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+		var errA error
+		sqlContext.Approval, errA = gorpmapping.JSONToNullString(c.Approval)
+		if errA != nil {
+			return sdk.WrapError(errA, "updateNodeContext> Unable to marshall workflow node context(%d) approval", c.ID)
+		}
+	}
+
+	if c.Concurrency != nil {
+		if err := c.Concurrency.IsValid(); err != nil {
+			return sdk.NewError(sdk.ErrWrongRequest, err)
+		}
+		var errCo error
+		sqlContext.Concurrency, errCo = gorpmapping.JSONToNullString(c.Concurrency)
+		if errCo != nil {
+			return sdk.WrapError(errCo, "updateNodeContext> Unable to marshall workflow node context(%d) concurrency", c.ID)
+		}
+	}
+
 	if _, err := db.Update(&sqlContext); err != nil {
 		return sdk.WrapError(err, "updateNodeContext> Unable to update workflow node context(%d)", c.ID)
 	}
@@ -398,7 +425,7 @@ func LoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project,
 func postLoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, u *sdk.User, ctx *sdk.WorkflowNodeContext, opts LoadOptions) error {
 	var sqlContext = sqlContext{}
 	if err := db.SelectOne(&sqlContext,
-		"select application_id, environment_id, default_payload, default_pipeline_parameters, conditions, mutex, project_platform_id from workflow_node_context where id = $1", ctx.ID); err != nil {
+		"select application_id, environment_id, default_payload, default_pipeline_parameters, conditions, mutex, project_platform_id, approval, concurrency from workflow_node_context where id = $1", ctx.ID); err != nil {
 		return err
 	}
 	if sqlContext.AppID.Valid {
@@ -424,6 +451,22 @@ func postLoadNodeContext(db gorp.SqlExecutor, store cache.Store, proj *sdk.Proje
 		return sdk.WrapError(err, "postLoadNodeContext> Unable to unmarshall context %d default pipeline parameters", ctx.ID)
 	}
 
+	//Unmarshal approval gate
+	if sqlContext.Approval.Valid {
+		ctx.Approval = new(sdk.WorkflowNodeApproval)
+		if err := gorpmapping.JSONNullString(sqlContext.Approval, ctx.Approval); err != nil {
+			return sdk.WrapError(err, "postLoadNodeContext> Unable to unmarshall context %d approval", ctx.ID)
+		}
+	}
+
+	//Unmarshal concurrency group
+	if sqlContext.Concurrency.Valid {
+		ctx.Concurrency = new(sdk.WorkflowConcurrency)
+		if err := gorpmapping.JSONNullString(sqlContext.Concurrency, ctx.Concurrency); err != nil {
+			return sdk.WrapError(err, "postLoadNodeContext> Unable to unmarshall context %d concurrency", ctx.ID)
+		}
+	}
+
 	//Load the application in the context
 	if ctx.ApplicationID != 0 {
 		app, err := application.LoadByID(db, store, ctx.ApplicationID, nil, application.LoadOptions.WithVariables, application.LoadOptions.WithDeploymentStrategies)
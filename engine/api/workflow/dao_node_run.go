@@ -45,7 +45,9 @@ workflow_node_run.vcs_branch,
 workflow_node_run.vcs_tag,
 workflow_node_run.vcs_server,
 workflow_node_run.workflow_node_name,
-workflow_node_run.header
+workflow_node_run.header,
+workflow_node_run.approval,
+workflow_node_run.concurrency
 `
 
 const nodeRunTestsField string = ", workflow_node_run.tests"
@@ -310,6 +312,20 @@ func fromDBNodeRun(rr NodeRun, opts LoadRunOptions) (*sdk.WorkflowNodeRun, error
 		}
 	}
 
+	if rr.Approval.Valid {
+		r.Approval = new(sdk.WorkflowNodeRunApproval)
+		if err := gorpmapping.JSONNullString(rr.Approval, r.Approval); err != nil {
+			return nil, sdk.WrapError(err, "fromDBNodeRun>Error loading node run %d: Approval", r.ID)
+		}
+	}
+
+	if rr.Concurrency.Valid {
+		r.Concurrency = new(sdk.WorkflowNodeRunConcurrency)
+		if err := gorpmapping.JSONNullString(rr.Concurrency, r.Concurrency); err != nil {
+			return nil, sdk.WrapError(err, "fromDBNodeRun>Error loading node run %d: Concurrency", r.ID)
+		}
+	}
+
 	if rr.Tests.Valid {
 		r.Tests = new(venom.Tests)
 		if err := gorpmapping.JSONNullString(rr.Tests, r.Tests); err != nil {
@@ -423,6 +439,20 @@ func makeDBNodeRun(n sdk.WorkflowNodeRun) (*NodeRun, error) {
 		return nil, sdk.WrapError(err, "makeDBNodeRun> unable to get json from header")
 	}
 	nodeRunDB.Header = sh
+	if n.Approval != nil {
+		s, err := gorpmapping.JSONToNullString(n.Approval)
+		if err != nil {
+			return nil, sdk.WrapError(err, "makeDBNodeRun> unable to get json from approval")
+		}
+		nodeRunDB.Approval = s
+	}
+	if n.Concurrency != nil {
+		s, err := gorpmapping.JSONToNullString(n.Concurrency)
+		if err != nil {
+			return nil, sdk.WrapError(err, "makeDBNodeRun> unable to get json from concurrency")
+		}
+		nodeRunDB.Concurrency = s
+	}
 
 	return nodeRunDB, nil
 }
@@ -722,6 +752,31 @@ func updateNodeRunStatusAndStage(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeR
 	return nil
 }
 
+// updateNodeRunApproval update just noderun approval gate
+func updateNodeRunApproval(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun) error {
+	approval, err := gorpmapping.JSONToNullString(nodeRun.Approval)
+	if err != nil {
+		return sdk.WrapError(err, "updateNodeRunApproval> Unable to marshal approval")
+	}
+
+	if _, err := db.Exec("UPDATE workflow_node_run SET approval = $1 where id = $2", approval, nodeRun.ID); err != nil {
+		return sdk.WrapError(err, "updateNodeRunApproval> Unable to update workflow_node_run %s", nodeRun.WorkflowNodeName)
+	}
+	return nil
+}
+
+func updateNodeRunConcurrency(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun) error {
+	concurrency, err := gorpmapping.JSONToNullString(nodeRun.Concurrency)
+	if err != nil {
+		return sdk.WrapError(err, "updateNodeRunConcurrency> Unable to marshal concurrency")
+	}
+
+	if _, err := db.Exec("UPDATE workflow_node_run SET concurrency = $1 where id = $2", concurrency, nodeRun.ID); err != nil {
+		return sdk.WrapError(err, "updateNodeRunConcurrency> Unable to update workflow_node_run %s", nodeRun.WorkflowNodeName)
+	}
+	return nil
+}
+
 func updateNodeRunStatusAndTriggersRun(db gorp.SqlExecutor, nodeRun *sdk.WorkflowNodeRun) error {
 	triggersRunbts, errMarshal := json.Marshal(nodeRun.TriggersRun)
 	if errMarshal != nil {
@@ -749,3 +804,25 @@ func RunExist(db gorp.SqlExecutor, projectKey string, workflowID int64, hash str
 	count, err := db.SelectInt(query, projectKey, workflowID, hash)
 	return count != 0, err
 }
+
+// LoadLastRunVCSHash returns the last commit of the application built by the workflow on the branch, or an empty string if the branch has never been built.
+// The commits built by the nodes of the other applications are ignored, they may come from another repository
+func LoadLastRunVCSHash(db gorp.SqlExecutor, workflowID, appID int64, branch string) (string, error) {
+	query := `
+	SELECT workflow_node_run.vcs_hash
+		FROM workflow_node_run
+			JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+	WHERE workflow_run.workflow_id = $1
+	AND workflow_node_run.application_id = $2
+	AND workflow_node_run.vcs_branch = $3
+	AND workflow_node_run.vcs_hash IS NOT NULL
+	ORDER BY workflow_node_run.id DESC
+	LIMIT 1
+	`
+
+	hash, err := db.SelectNullStr(query, workflowID, appID, branch)
+	if err != nil {
+		return "", sdk.WrapError(err, "LoadLastRunVCSHash> Unable to load last hash of application %d in workflow %d on branch %s", appID, workflowID, branch)
+	}
+	return hash.String, nil
+}
@@ -184,6 +184,15 @@ func loadPreviousRunVulnerabilityReport(db gorp.SqlExecutor, nr *sdk.WorkflowNod
 }
 
 func loadLatestRunVulnerabilityReport(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun, branch string) (map[string]int64, error) {
+	report, err := loadLatestVulnerabilityReport(db, nr, branch)
+	if err != nil {
+		return nil, err
+	}
+	return report.Report.Summary, nil
+}
+
+// loadLatestVulnerabilityReport loads the latest vulnerability report of the application of the node run on the given branch
+func loadLatestVulnerabilityReport(db gorp.SqlExecutor, nr *sdk.WorkflowNodeRun, branch string) (sdk.WorkflowNodeRunVulnerabilityReport, error) {
 	var dbReport dbNodeRunVulenrabilitiesReport
 	query := `
     SELECT * FROM workflow_node_run_vulnerability
@@ -193,11 +202,11 @@ func loadLatestRunVulnerabilityReport(db gorp.SqlExecutor, nr *sdk.WorkflowNodeR
   `
 	if err := db.SelectOne(&dbReport, query, nr.ApplicationID, nr.WorkflowID, branch); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, sdk.ErrNotFound
+			return sdk.WorkflowNodeRunVulnerabilityReport{}, sdk.ErrNotFound
 		}
-		return nil, sdk.WrapError(err, "loadLatestRunVulnerabilityReport> Unable to load previous report")
+		return sdk.WorkflowNodeRunVulnerabilityReport{}, sdk.WrapError(err, "loadLatestVulnerabilityReport> Unable to load latest report")
 	}
-	return dbReport.Report.Summary, nil
+	return sdk.WorkflowNodeRunVulnerabilityReport(dbReport), nil
 }
 
 func InsertVulnerabilityReport(db gorp.SqlExecutor, report sdk.WorkflowNodeRunVulnerabilityReport) error {
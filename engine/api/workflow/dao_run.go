@@ -286,6 +286,25 @@ func LoadAndLockRunByJobID(db gorp.SqlExecutor, id int64, loadOpts LoadRunOption
 	return loadRun(db, loadOpts, query, id)
 }
 
+// LoadSupersededRunIDs returns the ids of the runs of a workflow started before the given run on the same branch which are not over
+func LoadSupersededRunIDs(db gorp.SqlExecutor, workflowID, runID int64, branch string) ([]int64, error) {
+	query := `select workflow_run.id
+	from workflow_run
+	join workflow_run_tag on workflow_run_tag.workflow_run_id = workflow_run.id
+	where workflow_run.workflow_id = $1
+	and workflow_run.id < $2
+	and workflow_run_tag.tag = $3
+	and $4 = ANY(string_to_array(workflow_run_tag.value, ','))
+	and workflow_run.status = ANY(string_to_array($5, ',')::text[])
+	order by workflow_run.id`
+	statuses := strings.Join([]string{sdk.StatusWaiting.String(), sdk.StatusBuilding.String(), sdk.StatusChecking.String()}, ",")
+	var ids []int64
+	if _, err := db.Select(&ids, query, workflowID, runID, tagGitBranch, branch, statuses); err != nil {
+		return nil, sdk.WrapError(err, "LoadSupersededRunIDs> Unable to load runs of workflow %d on branch %s", workflowID, branch)
+	}
+	return ids, nil
+}
+
 //LoadRuns loads all runs
 //It retuns runs, offset, limit count and an error
 func LoadRuns(db gorp.SqlExecutor, projectkey, workflowname string, offset, limit int, tagFilter map[string]string) ([]sdk.WorkflowRun, int, int, int, error) {
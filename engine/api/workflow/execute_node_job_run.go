@@ -165,6 +165,23 @@ func UpdateNodeJobRunStatus(ctx context.Context, dbFunc func() *gorp.DbMap, db g
 		}
 	}
 
+	//If the job has failed, retry it according to its retry policy
+	if status == sdk.StatusFail {
+		exitCode := job.Job.FailedExitCode()
+		if job.Job.RetryPolicy.ShouldRetry(job.Job.CurrentAttempt(), exitCode) {
+			reason := "job failed"
+			if exitCode != 0 {
+				reason = fmt.Sprintf("exit code %d", exitCode)
+			}
+			next, err := retryNodeJobRun(db, job, reason)
+			if err != nil {
+				return nil, sdk.WrapError(err, "workflow.UpdateNodeJobRunStatus> Cannot retry WorkflowNodeJobRun %d", job.ID)
+			}
+			addNodeJobRunAttempt(node, *job, *next)
+			report.Add(*next)
+		}
+	}
+
 	if err := UpdateNodeJobRun(ctx, db, job); err != nil {
 		return nil, sdk.WrapError(err, "workflow.UpdateNodeJobRunStatus> Cannot update WorkflowNodeJobRun %d", job.ID)
 	}
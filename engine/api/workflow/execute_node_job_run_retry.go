@@ -0,0 +1,117 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// retryNodeJobRun inserts in the queue a new attempt of a failed job run according to its retry policy.
+// The new attempt is queued after the backoff of the policy, it gets its own logs and spawn infos.
+// The failed job run is flagged as retried by the new attempt, it is up to the caller to update it.
+func retryNodeJobRun(db gorp.SqlExecutor, job *sdk.WorkflowNodeJobRun, reason string) (*sdk.WorkflowNodeJobRun, error) {
+	attempt := job.Job.CurrentAttempt()
+	policy := job.Job.RetryPolicy
+	now := time.Now()
+
+	next := sdk.WorkflowNodeJobRun{
+		ProjectID:              job.ProjectID,
+		WorkflowNodeRunID:      job.WorkflowNodeRunID,
+		Queued:                 now.Add(policy.BackoffDuration(attempt)),
+		Status:                 sdk.StatusWaiting.String(),
+		Parameters:             job.Parameters,
+		ExecGroups:             job.ExecGroups,
+		PlatformPluginBinaries: job.PlatformPluginBinaries,
+		Header:                 job.Header,
+		Job: sdk.ExecutedJob{
+			Job:        job.Job.Job,
+			MatrixCell: job.Job.MatrixCell,
+			Attempt:    attempt + 1,
+		},
+	}
+	if err := insertWorkflowNodeJobRun(db, &next); err != nil {
+		return nil, sdk.WrapError(err, "retryNodeJobRun> Unable to insert attempt %d of job %d", attempt+1, job.ID)
+	}
+
+	next.SpawnInfos = []sdk.SpawnInfo{{
+		APITime:    now,
+		RemoteTime: now,
+		Message: sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeJobRetry.ID,
+			Args: []interface{}{attempt + 1, policy.MaxAttempts, reason},
+		},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, next.ID, next.SpawnInfos); err != nil {
+		return nil, sdk.WrapError(err, "retryNodeJobRun> Unable to add spawn infos on job %d", next.ID)
+	}
+
+	job.Job.RetriedBy = next.ID
+	log.Debug("retryNodeJobRun> job %d retried by job %d (attempt %d/%d): %s", job.ID, next.ID, attempt+1, policy.MaxAttempts, reason)
+	return &next, nil
+}
+
+// addNodeJobRunAttempt puts a new attempt of a job run in the stage of the node run containing the job run
+func addNodeJobRunAttempt(nodeRun *sdk.WorkflowNodeRun, job sdk.WorkflowNodeJobRun, next sdk.WorkflowNodeJobRun) bool {
+	for i := range nodeRun.Stages {
+		s := &nodeRun.Stages[i]
+		for j := range s.RunJobs {
+			if s.RunJobs[j].ID == job.ID {
+				s.RunJobs[j] = job
+				s.RunJobs = append(s.RunJobs, next)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RetryNodeJobRunOnWorkerLost fails a job run whose worker disappeared and inserts a new attempt in the queue
+func RetryNodeJobRunOnWorkerLost(ctx context.Context, db gorp.SqlExecutor, job sdk.WorkflowNodeJobRun) error {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.RetryNodeJobRunOnWorkerLost")
+	defer end()
+
+	job.Status = sdk.StatusFail.String()
+	job.Done = time.Now()
+	job.Job.Reason = "Worker lost\n"
+	next, err := retryNodeJobRun(db, &job, "worker lost")
+	if err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRunOnWorkerLost> Cannot retry job %d", job.ID)
+	}
+	if err := UpdateNodeJobRun(ctx, db, &job); err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRunOnWorkerLost> Cannot update job %d", job.ID)
+	}
+
+	nodeRun, err := LoadAndLockNodeRunByID(ctx, db, job.WorkflowNodeRunID, true)
+	if err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRunOnWorkerLost> Cannot load node run")
+	}
+	if !addNodeJobRunAttempt(nodeRun, job, *next) {
+		return fmt.Errorf("RetryNodeJobRunOnWorkerLost> job %d not found in node run %d", job.ID, nodeRun.ID)
+	}
+	if err := UpdateNodeRun(db, nodeRun); err != nil {
+		return sdk.WrapError(err, "RetryNodeJobRunOnWorkerLost> Cannot update node run")
+	}
+
+	return releaseNodeJobRunWorker(db, job.ID)
+}
+
+// releaseNodeJobRunWorker detaches a job run from its worker and disables the worker
+func releaseNodeJobRunWorker(db gorp.SqlExecutor, id int64) error {
+	query := "UPDATE workflow_node_run_job SET worker_id = NULL WHERE id = $1"
+	if _, err := db.Exec(query, id); err != nil {
+		return sdk.WrapError(err, "releaseNodeJobRunWorker> Unable to release workflow_node_run_job id %d", id)
+	}
+
+	query = "UPDATE worker SET status = $2, action_build_id = NULL where action_build_id = $1"
+	if _, err := db.Exec(query, id, sdk.StatusDisabled); err != nil {
+		return sdk.WrapError(err, "releaseNodeJobRunWorker> Unable to set workers")
+	}
+	return nil
+}
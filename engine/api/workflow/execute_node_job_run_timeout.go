@@ -0,0 +1,154 @@
+package workflow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/lib/pq"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/observability"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// staleNodeJobRunGracePeriod is the delay given to a worker to send the result of a job after its timeout
+const staleNodeJobRunGracePeriod = 5 * time.Minute
+
+// isNodeJobRunStale returns true if the worker of a building job run didn't send any log nor heartbeat
+// for longer than the timeout of the job, or if the job is still building after its timeout and a grace period
+func isNodeJobRunStale(timeout time.Duration, start, lastLog, lastBeat, now time.Time) bool {
+	if timeout <= 0 || start.IsZero() {
+		return false
+	}
+	if now.Sub(start) > timeout+staleNodeJobRunGracePeriod {
+		return true
+	}
+	lastActivity := start
+	if lastLog.After(lastActivity) {
+		lastActivity = lastLog
+	}
+	if lastBeat.After(lastActivity) {
+		lastActivity = lastBeat
+	}
+	return now.Sub(lastActivity) > timeout
+}
+
+// staleNodeJobRunMinAge is the minimum age of the building job runs checked by LoadStaleNodeJobRuns, it is the period of the check
+const staleNodeJobRunMinAge = time.Minute
+
+// LoadStaleNodeJobRuns returns the ids of the building job runs whose worker didn't send any log nor heartbeat
+// for longer than the timeout of the job, or which are still building after their timeout, with the key of their project.
+// The logs are only loaded for the job runs whose worker didn't send any heartbeat within the timeout
+func LoadStaleNodeJobRuns(db gorp.SqlExecutor) (map[int64]string, error) {
+	type candidate struct {
+		key      string
+		timeout  time.Duration
+		start    time.Time
+		lastBeat time.Time
+	}
+
+	now := time.Now()
+	query := `SELECT workflow_node_run_job.id, project.projectkey, workflow_node_run_job.job->>'timeout', workflow_node_run_job.start, worker.last_beat
+	FROM workflow_node_run_job
+	JOIN project ON project.id = workflow_node_run_job.project_id
+	LEFT JOIN worker ON worker.id = workflow_node_run_job.worker_id
+	WHERE workflow_node_run_job.status = $1 AND workflow_node_run_job.start < $2`
+	rows, err := db.Query(query, sdk.StatusBuilding.String(), now.Add(-staleNodeJobRunMinAge))
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadStaleNodeJobRuns> Unable to load job runs")
+	}
+	defer rows.Close()
+
+	candidates := map[int64]candidate{}
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		var key string
+		var timeout sql.NullString
+		var start, lastBeat pq.NullTime
+		if err := rows.Scan(&id, &key, &timeout, &start, &lastBeat); err != nil {
+			return nil, sdk.WrapError(err, "LoadStaleNodeJobRuns> Unable to scan job run")
+		}
+		c := candidate{key: key, timeout: sdk.Job{Timeout: timeout.String}.TimeoutDuration(), start: start.Time, lastBeat: lastBeat.Time}
+		// The logs can only make a job run more recent
+		if isNodeJobRunStale(c.timeout, c.start, time.Time{}, c.lastBeat, now) {
+			candidates[id] = c
+			ids = append(ids, id)
+		}
+	}
+	rows.Close()
+
+	res := map[int64]string{}
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	lastLogs := map[int64]time.Time{}
+	query = `SELECT workflow_node_run_job_id, MAX(last_modified) FROM workflow_node_run_job_logs
+	WHERE workflow_node_run_job_id = ANY($1)
+	GROUP BY workflow_node_run_job_id`
+	logRows, err := db.Query(query, pq.Int64Array(ids))
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadStaleNodeJobRuns> Unable to load logs of job runs")
+	}
+	defer logRows.Close()
+	for logRows.Next() {
+		var id int64
+		var lastLog pq.NullTime
+		if err := logRows.Scan(&id, &lastLog); err != nil {
+			return nil, sdk.WrapError(err, "LoadStaleNodeJobRuns> Unable to scan logs of job run")
+		}
+		lastLogs[id] = lastLog.Time
+	}
+
+	for id, c := range candidates {
+		if isNodeJobRunStale(c.timeout, c.start, lastLogs[id], c.lastBeat, now) {
+			res[id] = c.key
+		}
+	}
+	return res, nil
+}
+
+// FailStaleNodeJobRun fails a stale building job run, it may be retried according to its retry policy.
+// Its worker is released and disabled.
+func FailStaleNodeJobRun(ctx context.Context, dbFunc func() *gorp.DbMap, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, id int64) (*ProcessorReport, error) {
+	var end func()
+	ctx, end = observability.Span(ctx, "workflow.FailStaleNodeJobRun")
+	defer end()
+
+	job, err := LoadAndLockNodeJobRunNoWait(ctx, db, store, id)
+	if err != nil {
+		return nil, sdk.WrapError(err, "FailStaleNodeJobRun> Unable to load job %d", id)
+	}
+	if job.Status != sdk.StatusBuilding.String() {
+		return nil, nil
+	}
+
+	timeout := job.Job.TimeoutDuration()
+	now := time.Now()
+	job.Job.Reason = fmt.Sprintf("No news from the worker within the timeout of %s\n", timeout)
+	infos := []sdk.SpawnInfo{{
+		APITime:    now,
+		RemoteTime: now,
+		Message:    sdk.SpawnMsg{ID: sdk.MsgSpawnInfoJobStale.ID, Args: []interface{}{timeout.String()}},
+	}}
+	if err := AddSpawnInfosNodeJobRun(db, job.ID, infos); err != nil {
+		return nil, sdk.WrapError(err, "FailStaleNodeJobRun> Unable to add spawn infos on job %d", job.ID)
+	}
+
+	report, err := UpdateNodeJobRunStatus(ctx, dbFunc, db, store, proj, job, sdk.StatusFail)
+	if err != nil {
+		return nil, sdk.WrapError(err, "FailStaleNodeJobRun> Unable to fail job %d", job.ID)
+	}
+
+	if err := releaseNodeJobRunWorker(db, job.ID); err != nil {
+		return nil, sdk.WrapError(err, "FailStaleNodeJobRun> Unable to release worker of job %d", job.ID)
+	}
+
+	log.Info("FailStaleNodeJobRun> job %d failed after its timeout of %s", job.ID, timeout)
+	return report, nil
+}
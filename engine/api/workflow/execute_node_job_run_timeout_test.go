@@ -0,0 +1,28 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isNodeJobRunStale(t *testing.T) {
+	now := time.Now()
+	timeout := 10 * time.Minute
+
+	// Without timeout or not started, a job is never stale
+	assert.False(t, isNodeJobRunStale(0, now.Add(-time.Hour), time.Time{}, time.Time{}, now))
+	assert.False(t, isNodeJobRunStale(timeout, time.Time{}, time.Time{}, time.Time{}, now))
+
+	// Recent logs or heartbeats
+	assert.False(t, isNodeJobRunStale(timeout, now.Add(-12*time.Minute), now.Add(-time.Minute), time.Time{}, now))
+	assert.False(t, isNodeJobRunStale(timeout, now.Add(-12*time.Minute), time.Time{}, now.Add(-time.Minute), now))
+
+	// No logs nor heartbeats since the timeout
+	assert.True(t, isNodeJobRunStale(timeout, now.Add(-12*time.Minute), now.Add(-11*time.Minute), now.Add(-11*time.Minute), now))
+	assert.True(t, isNodeJobRunStale(timeout, now.Add(-11*time.Minute), time.Time{}, time.Time{}, now))
+
+	// Still building after the timeout and the grace period, even with a live worker
+	assert.True(t, isNodeJobRunStale(timeout, now.Add(-16*time.Minute), now, now, now))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/ovh/cds/engine/api/repositoriesmanager"
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/luascript"
 )
 
 func syncTakeJobInNodeRun(ctx context.Context, db gorp.SqlExecutor, n *sdk.WorkflowNodeRun, j *sdk.WorkflowNodeJobRun, stageIndex int) (*ProcessorReport, error) {
@@ -32,6 +33,11 @@ func syncTakeJobInNodeRun(ctx context.Context, db gorp.SqlExecutor, n *sdk.Workf
 		return nil, nil
 	}
 
+	//If the node run waits for approvals: nothing to do
+	if n.Approval.IsWaiting() {
+		return report, nil
+	}
+
 	nodeUpdated := false
 	//Browse stages
 	stage := &n.Stages[stageIndex]
@@ -100,6 +106,11 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 		return nil, nil
 	}
 
+	//If the node run waits for approvals: nothing to do
+	if n.Approval.IsWaiting() {
+		return report, nil
+	}
+
 	var newStatus = n.Status
 
 	//If no stages ==> success
@@ -109,8 +120,18 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 	}
 
 	stagesTerminated := 0
-	//Browse stages
-	for stageIndex := range n.Stages {
+	//Stages with needs are run as a graph
+	isStageGraph := sdk.IsStageGraph(n.Stages)
+	if isStageGraph {
+		var err error
+		newStatus, stagesTerminated, err = executeStageGraph(ctx, db, store, wr, n, report)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	//Browse stages, one after the other
+	for stageIndex := 0; stageIndex < len(n.Stages) && !isStageGraph; stageIndex++ {
 		stage := &n.Stages[stageIndex]
 		log.Debug("workflow.execute> checking stage %s (status=%s)", stage.Name, stage.Status)
 		//Initialize stage status at waiting
@@ -206,6 +227,11 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 		}
 	}
 
+	//If the approval gate has expired, the node run is failed or skipped
+	if n.Approval != nil && n.Approval.Status == sdk.WorkflowNodeRunApprovalExpired {
+		newStatus = n.Approval.Gate.ExpiredStatus()
+	}
+
 	n.Status = newStatus
 
 	if sdk.StatusIsTerminated(n.Status) && n.Status != sdk.StatusNeverBuilt.String() {
@@ -253,9 +279,10 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 			where workflow.id = $1
 			and workflow_node_run.workflow_node_name = $2
 			and workflow_node_run.status = $3
+			and (workflow_node_run.approval is null or workflow_node_run.approval->>'status' <> $4)
 			order by workflow_node_run.start asc
 			limit 1`
-			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, node.Name, string(sdk.StatusWaiting))
+			waitingRunID, errID := db.SelectInt(mutexQuery, updatedWorkflowRun.WorkflowID, node.Name, string(sdk.StatusWaiting), sdk.WorkflowNodeRunApprovalWaiting)
 			if errID != nil && errID != sql.ErrNoRows {
 				log.Error("workflow.execute> Unable to load mutex-locked workflow node run ID: %v", errID)
 				return report, nil
@@ -273,6 +300,19 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 			if waitingRun == nil {
 				return report, nil
 			}
+			//The node run will be started when its concurrency group is free
+			if waitingRun.Concurrency != nil {
+				locked, err := isNodeRunConcurrencyLocked(db, waitingRun)
+				if err != nil {
+					return nil, sdk.WrapError(err, "workflow.execute> Unable to check concurrency group")
+				}
+				if locked {
+					if err := blockNodeRunForConcurrency(db, waitingRun); err != nil {
+						return nil, sdk.WrapError(err, "workflow.execute> Unable to hold node run")
+					}
+					return report, nil
+				}
+			}
 
 			//Here we are loading another workflow run
 			workflowRun, errWRun := LoadRunByID(db, waitingRun.WorkflowRunID, LoadRunOptions{})
@@ -302,6 +342,87 @@ func execute(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *
 	return report, nil
 }
 
+// executeStageGraph syncs the building stages of the node run and starts each stage whose needs are over.
+// It returns the status of the node run while it is not over, and the number of terminated stages
+func executeStageGraph(ctx context.Context, db gorp.SqlExecutor, store cache.Store, wr *sdk.WorkflowRun, n *sdk.WorkflowNodeRun, report *ProcessorReport) (string, int, error) {
+	_, end := observability.Span(ctx, "workflow.executeStageGraph")
+	defer end()
+
+	for i := range n.Stages {
+		stage := &n.Stages[i]
+		if stage.Status != sdk.StatusBuilding {
+			continue
+		}
+		if _, err := syncStage(db, store, stage); err != nil {
+			return n.Status, 0, err
+		}
+	}
+
+	for updated := true; updated; {
+		updated = false
+		for i := range n.Stages {
+			stage := &n.Stages[i]
+			if stage.Status.String() != "" {
+				continue
+			}
+			over, skip := sdk.NeedsStatus(n.Stages, stage)
+			if !over {
+				continue
+			}
+			updated = true
+			log.Debug("workflow.executeStageGraph> starting stage %s (skip=%t)", stage.Name, skip)
+			if skip {
+				stage.Status = sdk.StatusSkipped
+				continue
+			}
+			stage.Status = sdk.StatusWaiting
+			if len(stage.Jobs) == 0 {
+				stage.Status = sdk.StatusSuccess
+				continue
+			}
+			if _, err := report.Merge(addJobsToQueue(ctx, db, stage, wr, n)); err != nil {
+				return n.Status, 0, err
+			}
+		}
+	}
+
+	newStatus := n.Status
+	stagesTerminated := 0
+	for _, stage := range n.Stages {
+		switch {
+		case sdk.StatusIsTerminated(stage.Status.String()):
+			stagesTerminated++
+		case stage.Status == sdk.StatusBuilding:
+			newStatus = sdk.StatusBuilding.String()
+		}
+	}
+	return newStatus, stagesTerminated, nil
+}
+
+// checkStageRunConditions checks the run conditions of a stage, with the same operators as the workflow node conditions
+func checkStageRunConditions(stage *sdk.Stage, params []sdk.Parameter) (bool, error) {
+	c := stage.RunConditions
+	if c == nil {
+		return true, nil
+	}
+	if c.LuaScript != "" {
+		luacheck, err := luascript.NewCheck()
+		if err != nil {
+			return false, err
+		}
+		luacheck.SetVariables(sdk.ParametersToMap(params))
+		if err := luacheck.Perform(c.LuaScript); err != nil {
+			return false, err
+		}
+		return luacheck.Result, nil
+	}
+	conditionsOK, err := sdk.WorkflowCheckConditions(c.PlainConditions, params)
+	if err != nil || !conditionsOK || c.Expression == "" {
+		return conditionsOK, err
+	}
+	return sdk.WorkflowCheckConditionsExpression(c.Expression, params)
+}
+
 func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage, wr *sdk.WorkflowRun, run *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
 	var end func()
 	ctx, end = observability.Span(ctx, "workflow.addJobsToQueue")
@@ -316,6 +437,15 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 		return report, sdk.WrapError(err, "addJobsToQueue> Cannot compute prerequisites on stage %s(%d)", stage.Name, stage.ID)
 	}
 
+	if conditionsOK {
+		_, next = observability.Span(ctx, "workflow.checkStageRunConditions")
+		conditionsOK, err = checkStageRunConditions(stage, run.BuildParameters)
+		next()
+		if err != nil {
+			return report, sdk.WrapError(err, "addJobsToQueue> Cannot compute run conditions on stage %s(%d)", stage.Name, stage.ID)
+		}
+	}
+
 	if !conditionsOK {
 		stage.Status = sdk.StatusSkipped
 	}
@@ -338,95 +468,111 @@ func addJobsToQueue(ctx context.Context, db gorp.SqlExecutor, stage *sdk.Stage,
 	next()
 
 	skippedOrDisabledJobs := 0
+	nbRunJobs := 0
 	//Browse the jobs
 	for j := range stage.Jobs {
 		job := &stage.Jobs[j]
-		errs := sdk.MultiError{}
-		//Process variables for the jobs
-		_, next = observability.Span(ctx, "workflow..getNodeJobRunParameters")
-		jobParams, errParam := getNodeJobRunParameters(db, *job, run, stage)
-		next()
 
-		if errParam != nil {
-			errs.Join(*errParam)
+		//A job with a matrix is run once for each combination of the values of the matrix
+		cells := job.Matrix.Cells()
+		if len(cells) == 0 {
+			cells = []sdk.JobMatrixCell{nil}
 		}
 
-		_, next = observability.Span(ctx, "workflow.getNodeJobRunRequirements")
-		jobRequirements, errReq := getNodeJobRunRequirements(db, *job, run)
-		next()
+		for _, cell := range cells {
+			errs := sdk.MultiError{}
+			//Process variables for the jobs
+			_, next = observability.Span(ctx, "workflow..getNodeJobRunParameters")
+			jobParams, errParam := getNodeJobRunParameters(db, *job, run, stage, cell)
+			next()
 
-		if errReq != nil {
-			errs.Join(*errReq)
-		}
+			if errParam != nil {
+				errs.Join(*errParam)
+			}
 
-		// add requirements in job parameters, to use them as {{.job.requirement...}} in job
-		_, next = observability.Span(ctx, "workflow.prepareRequirementsToNodeJobRunParameters")
-		jobParams = append(jobParams, prepareRequirementsToNodeJobRunParameters(jobRequirements)...)
-		next()
+			_, next = observability.Span(ctx, "workflow.getNodeJobRunRequirements")
+			jobRequirements, errReq := getNodeJobRunRequirements(db, *job, run, cell)
+			next()
 
-		if errGroups != nil {
-			return report, sdk.WrapError(errGroups, "addJobsToQueue> error on getJobExecutablesGroups")
-		}
+			if errReq != nil {
+				errs.Join(*errReq)
+			}
 
-		//Create the job run
-		wjob := sdk.WorkflowNodeJobRun{
-			ProjectID:              wr.ProjectID,
-			WorkflowNodeRunID:      run.ID,
-			Start:                  time.Time{},
-			Queued:                 time.Now(),
-			Status:                 sdk.StatusWaiting.String(),
-			Parameters:             jobParams,
-			ExecGroups:             groups,
-			PlatformPluginBinaries: platformPluginBinaries,
-			Job: sdk.ExecutedJob{
-				Job: *job,
-			},
-			Header: run.Header,
-		}
-		wjob.Job.Job.Action.Requirements = jobRequirements // Set the interpolated requirements on the job run only
+			// add requirements in job parameters, to use them as {{.job.requirement...}} in job
+			_, next = observability.Span(ctx, "workflow.prepareRequirementsToNodeJobRunParameters")
+			jobParams = append(jobParams, prepareRequirementsToNodeJobRunParameters(jobRequirements)...)
+			next()
 
-		if !stage.Enabled || !wjob.Job.Enabled {
-			wjob.Status = sdk.StatusDisabled.String()
-			skippedOrDisabledJobs++
-		} else if !conditionsOK {
-			wjob.Status = sdk.StatusSkipped.String()
-			skippedOrDisabledJobs++
-		}
+			if errGroups != nil {
+				return report, sdk.WrapError(errGroups, "addJobsToQueue> error on getJobExecutablesGroups")
+			}
 
-		if errParam != nil {
-			wjob.Status = sdk.StatusFail.String()
-			spawnInfos := sdk.SpawnMsg{
-				ID: sdk.MsgSpawnInfoJobError.ID,
+			//Create the job run
+			wjob := sdk.WorkflowNodeJobRun{
+				ProjectID:              wr.ProjectID,
+				WorkflowNodeRunID:      run.ID,
+				Start:                  time.Time{},
+				Queued:                 time.Now(),
+				Status:                 sdk.StatusWaiting.String(),
+				Parameters:             jobParams,
+				ExecGroups:             groups,
+				PlatformPluginBinaries: platformPluginBinaries,
+				Job: sdk.ExecutedJob{
+					Job:        *job,
+					MatrixCell: cell,
+				},
+				Header: run.Header,
+			}
+			wjob.Job.Job.Action.Requirements = jobRequirements // Set the interpolated requirements on the job run only
+			if len(cell) > 0 {
+				wjob.Job.Job.Action.Name = fmt.Sprintf("%s (%s)", job.Action.Name, cell)
 			}
 
-			for _, e := range *errParam {
-				spawnInfos.Args = append(spawnInfos.Args, e.Error())
+			if !stage.Enabled || !wjob.Job.Enabled {
+				wjob.Status = sdk.StatusDisabled.String()
+				skippedOrDisabledJobs++
+			} else if !conditionsOK {
+				wjob.Status = sdk.StatusSkipped.String()
+				skippedOrDisabledJobs++
 			}
 
-			wjob.SpawnInfos = []sdk.SpawnInfo{sdk.SpawnInfo{
-				APITime:    time.Now(),
-				Message:    spawnInfos,
-				RemoteTime: time.Now(),
-			}}
-		}
+			if errParam != nil {
+				wjob.Status = sdk.StatusFail.String()
+				spawnInfos := sdk.SpawnMsg{
+					ID: sdk.MsgSpawnInfoJobError.ID,
+				}
 
-		//Insert in database
-		_, next = observability.Span(ctx, "workflow.insertWorkflowNodeJobRun")
-		if err := insertWorkflowNodeJobRun(db, &wjob); err != nil {
+				for _, e := range *errParam {
+					spawnInfos.Args = append(spawnInfos.Args, e.Error())
+				}
+
+				wjob.SpawnInfos = []sdk.SpawnInfo{sdk.SpawnInfo{
+					APITime:    time.Now(),
+					Message:    spawnInfos,
+					RemoteTime: time.Now(),
+				}}
+			}
+
+			//Insert in database
+			_, next = observability.Span(ctx, "workflow.insertWorkflowNodeJobRun")
+			if err := insertWorkflowNodeJobRun(db, &wjob); err != nil {
+				next()
+				return report, sdk.WrapError(err, "addJobsToQueue> Unable to insert in table workflow_node_run_job")
+			}
 			next()
-			return report, sdk.WrapError(err, "addJobsToQueue> Unable to insert in table workflow_node_run_job")
-		}
-		next()
 
-		//Put the job run in database
-		stage.RunJobs = append(stage.RunJobs, wjob)
+			//Put the job run in database
+			stage.RunJobs = append(stage.RunJobs, wjob)
+			nbRunJobs++
 
-		report.Add(wjob)
+			report.Add(wjob)
+		}
 	}
 
-	if skippedOrDisabledJobs == len(stage.Jobs) {
+	if skippedOrDisabledJobs == nbRunJobs {
 		stage.Status = sdk.StatusSkipped
 	}
+	stage.ComputeMatrix()
 
 	return report, nil
 }
@@ -523,6 +669,10 @@ func syncStage(db gorp.SqlExecutor, store cache.Store, stage *sdk.Stage) (bool,
 		// Determine final stage status
 	finalStageLoop:
 		for _, runJob := range stage.RunJobs {
+			// Attempts replaced by a retry don't change the stage status
+			if runJob.Job.RetriedBy != 0 {
+				continue
+			}
 			switch runJob.Status {
 			case sdk.StatusDisabled.String():
 				if finalStatus == sdk.StatusBuilding {
@@ -548,6 +698,7 @@ func syncStage(db gorp.SqlExecutor, store cache.Store, stage *sdk.Stage) (bool,
 	}
 	log.Debug("syncStage> set stage %s from %s to %s", stage.Name, stage.Status, finalStatus)
 	stage.Status = finalStatus
+	stage.ComputeMatrix()
 	return stageEnd, nil
 }
 
@@ -0,0 +1,138 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ApproveNodeRun adds the approval of a user on a node run waiting for approvals.
+// The node run is executed when it has enough approvals.
+func ApproveNodeRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nodeRun *sdk.WorkflowNodeRun, u *sdk.User, comment string) (*ProcessorReport, error) {
+	var triggeredBy string
+	if nodeRun.Manual != nil {
+		triggeredBy = nodeRun.Manual.User.Username
+	}
+	if err := nodeRun.Approval.Approve(u, triggeredBy, comment, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := updateNodeRunApproval(db, nodeRun); err != nil {
+		return nil, err
+	}
+
+	wr, err := LoadRunByID(db, nodeRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "ApproveNodeRun> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeApproved.ID,
+		Args: []interface{}{nodeRun.WorkflowNodeName, u.Username},
+	})
+
+	report := new(ProcessorReport)
+	report.Add(*nodeRun)
+
+	//Check the context.mutex to know if we are allowed to run it
+	var locked bool
+	node := wr.Workflow.GetNode(nodeRun.WorkflowNodeID)
+	if !nodeRun.Approval.IsWaiting() && node != nil && node.Context != nil && node.Context.Mutex {
+		locked, err = isNodeRunMutexLocked(db, wr.WorkflowID, nodeRun.ID, node.Name)
+		if err != nil {
+			return nil, sdk.WrapError(err, "ApproveNodeRun> unable to check mutexes")
+		}
+		if locked {
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeMutex.ID,
+				Args: []interface{}{node.Name},
+			})
+		}
+	}
+
+	//Check the concurrency group
+	if !nodeRun.Approval.IsWaiting() && !locked && nodeRun.Concurrency != nil {
+		locked, err = isNodeRunConcurrencyLocked(db, nodeRun)
+		if err != nil {
+			return nil, sdk.WrapError(err, "ApproveNodeRun> unable to check concurrency group")
+		}
+		if locked {
+			if err := blockNodeRunForConcurrency(db, nodeRun); err != nil {
+				return nil, sdk.WrapError(err, "ApproveNodeRun> unable to hold node run")
+			}
+			AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeConcurrency.ID,
+				Args: []interface{}{nodeRun.WorkflowNodeName, nodeRun.Concurrency.Group},
+			})
+		}
+	}
+
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "ApproveNodeRun> Unable to update workflow run %d", wr.ID)
+	}
+	if nodeRun.Approval.IsWaiting() || locked {
+		return report, nil
+	}
+
+	log.Debug("ApproveNodeRun> process the node run %d because it has been approved", nodeRun.ID)
+	return report.Merge(execute(ctx, db, store, proj, nodeRun))
+}
+
+// ExpireNodeRunApproval fails or skips a node run which is still waiting for approvals after the expiry of its approval gate
+func ExpireNodeRunApproval(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nodeRun *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	if !nodeRun.Approval.IsExpired(time.Now()) {
+		return nil, nil
+	}
+	nodeRun.Approval.Status = sdk.WorkflowNodeRunApprovalExpired
+	for i := range nodeRun.Stages {
+		nodeRun.Stages[i].Status = sdk.StatusSkipped
+	}
+	if err := updateNodeRunApproval(db, nodeRun); err != nil {
+		return nil, err
+	}
+
+	wr, err := LoadRunByID(db, nodeRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "ExpireNodeRunApproval> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeApprovalExpired.ID,
+		Args: []interface{}{nodeRun.WorkflowNodeName},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "ExpireNodeRunApproval> Unable to update workflow run %d", wr.ID)
+	}
+
+	return execute(ctx, db, store, proj, nodeRun)
+}
+
+// LoadNodeRunsWithExpiredApproval returns the ids of the node runs waiting for approvals after the expiry of their approval gate,
+// with the key of their project
+func LoadNodeRunsWithExpiredApproval(db gorp.SqlExecutor) (map[int64]string, error) {
+	query := `SELECT workflow_node_run.id, project.projectkey
+	FROM workflow_node_run
+	JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+	JOIN project ON project.id = workflow_run.project_id
+	WHERE workflow_node_run.status = $1
+	AND workflow_node_run.approval->>'status' = $2
+	AND (workflow_node_run.approval->>'expire_at')::timestamp with time zone < now()`
+	rows, err := db.Query(query, sdk.StatusWaiting.String(), sdk.WorkflowNodeRunApprovalWaiting)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadNodeRunsWithExpiredApproval> Unable to load node runs")
+	}
+	defer rows.Close()
+
+	res := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, sdk.WrapError(err, "LoadNodeRunsWithExpiredApproval> Unable to scan node run")
+		}
+		res[id] = key
+	}
+	return res, nil
+}
@@ -0,0 +1,158 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// ConcurrencyNodeRun is a node run of a concurrency group, with the key of its project
+type ConcurrencyNodeRun struct {
+	ID            int64  `db:"id"`
+	WorkflowRunID int64  `db:"workflow_run_id"`
+	ProjectKey    string `db:"projectkey"`
+	Group         string `db:"concurrency_group"`
+}
+
+// isNodeRunConcurrencyLocked checks if the concurrency group of the node run is held by another run:
+// a building node run of the group, a workflow run which has started with a workflow level group
+// or a node run of the group which is waiting for its turn since before this one.
+// The groups are scoped to the project of the workflow run, except the shared groups which are held across the projects
+// allowed to use them. The group is locked until the end of the transaction to serialize the runs of a group across the API instances.
+func isNodeRunConcurrencyLocked(db gorp.SqlExecutor, run *sdk.WorkflowNodeRun) (bool, error) {
+	var errL error
+	if run.Concurrency.Shared {
+		_, errL = db.Exec("select pg_advisory_xact_lock(hashtext('shared/' || $1))", run.Concurrency.Group)
+	} else {
+		_, errL = db.Exec("select pg_advisory_xact_lock(hashtext(project_id::text || '/' || $1)) from workflow_run where id = $2", run.Concurrency.Group, run.WorkflowRunID)
+	}
+	if errL != nil {
+		return false, sdk.WrapError(errL, "isNodeRunConcurrencyLocked> Unable to lock concurrency group %s", run.Concurrency.Group)
+	}
+
+	query := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	where workflow_node_run.concurrency->>'group' = $1
+	and (
+		($8 = true and workflow_node_run.concurrency->>'shared' = 'true')
+		or (
+			$8 = false
+			and coalesce(workflow_node_run.concurrency->>'shared', 'false') <> 'true'
+			and workflow_run.project_id = (select project_id from workflow_run where id = $3)
+		)
+	)
+	and workflow_node_run.id <> $2
+	and ($4 = false or workflow_node_run.workflow_run_id <> $3)
+	and (
+		workflow_node_run.status = $5
+		or (
+			workflow_node_run.concurrency->>'workflow_level' = 'true'
+			and workflow_node_run.status <> $6
+			and workflow_run.status in ($5, $6)
+		)
+		or (
+			workflow_node_run.status = $6
+			and (workflow_node_run.approval is null or workflow_node_run.approval->>'status' <> $7)
+			and (workflow_node_run.workflow_run_id < $3 or (workflow_node_run.workflow_run_id = $3 and workflow_node_run.id < $2))
+		)
+	)`
+	nb, err := db.SelectInt(query, run.Concurrency.Group, run.ID, run.WorkflowRunID, run.Concurrency.WorkflowLevel,
+		sdk.StatusBuilding.String(), sdk.StatusWaiting.String(), sdk.WorkflowNodeRunApprovalWaiting, run.Concurrency.Shared)
+	if err != nil {
+		return false, sdk.WrapError(err, "isNodeRunConcurrencyLocked> Unable to count runs of concurrency group %s", run.Concurrency.Group)
+	}
+	return nb > 0, nil
+}
+
+// blockNodeRunForConcurrency flags the node run as held back by its concurrency group,
+// so that it's started by StartNodeRunWaitingForConcurrency when the group is free
+func blockNodeRunForConcurrency(db gorp.SqlExecutor, run *sdk.WorkflowNodeRun) error {
+	run.Concurrency.Blocked = true
+	return updateNodeRunConcurrency(db, run)
+}
+
+// LoadNodeRunsWaitingForConcurrency returns the node runs held back by their concurrency group, in their arrival order
+func LoadNodeRunsWaitingForConcurrency(db gorp.SqlExecutor) ([]ConcurrencyNodeRun, error) {
+	query := `SELECT workflow_node_run.id, workflow_node_run.workflow_run_id, project.projectkey, workflow_node_run.concurrency->>'group' AS concurrency_group
+	FROM workflow_node_run
+	JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+	JOIN project ON project.id = workflow_run.project_id
+	WHERE workflow_node_run.concurrency IS NOT NULL
+	AND workflow_node_run.concurrency->>'blocked' = 'true'
+	AND workflow_node_run.status = $1
+	AND (workflow_node_run.approval IS NULL OR workflow_node_run.approval->>'status' <> $2)
+	ORDER BY workflow_node_run.workflow_run_id, workflow_node_run.id`
+	var res []ConcurrencyNodeRun
+	if _, err := db.Select(&res, query, sdk.StatusWaiting.String(), sdk.WorkflowNodeRunApprovalWaiting); err != nil {
+		return nil, sdk.WrapError(err, "LoadNodeRunsWaitingForConcurrency> Unable to load node runs")
+	}
+	return res, nil
+}
+
+// LoadSupersededNodeRuns returns the node runs with the cancel-previous policy which are not over while a newer workflow run
+// of the same project, or of any project for a shared group, has started a node run of the same concurrency group with the cancel-previous policy
+func LoadSupersededNodeRuns(db gorp.SqlExecutor) ([]ConcurrencyNodeRun, error) {
+	query := `SELECT workflow_node_run.id, workflow_node_run.workflow_run_id, project.projectkey, workflow_node_run.concurrency->>'group' AS concurrency_group
+	FROM workflow_node_run
+	JOIN workflow_run ON workflow_run.id = workflow_node_run.workflow_run_id
+	JOIN project ON project.id = workflow_run.project_id
+	WHERE workflow_node_run.concurrency IS NOT NULL
+	AND workflow_node_run.concurrency->>'policy' = $3
+	AND workflow_node_run.status IN ($1, $2)
+	AND EXISTS (
+		SELECT 1 FROM workflow_node_run newer
+		JOIN workflow_run newer_run ON newer_run.id = newer.workflow_run_id
+		WHERE newer.concurrency->>'group' = workflow_node_run.concurrency->>'group'
+		AND coalesce(newer.concurrency->>'shared', 'false') = coalesce(workflow_node_run.concurrency->>'shared', 'false')
+		AND (newer.concurrency->>'shared' = 'true' OR newer_run.project_id = workflow_run.project_id)
+		AND newer.concurrency->>'policy' = $3
+		AND newer.workflow_run_id > workflow_node_run.workflow_run_id
+		AND newer.status IN ($1, $2)
+	)
+	ORDER BY workflow_node_run.workflow_run_id, workflow_node_run.id`
+	var res []ConcurrencyNodeRun
+	if _, err := db.Select(&res, query, sdk.StatusWaiting.String(), sdk.StatusBuilding.String(), sdk.ConcurrencyPolicyCancelPrevious); err != nil {
+		return nil, sdk.WrapError(err, "LoadSupersededNodeRuns> Unable to load node runs")
+	}
+	return res, nil
+}
+
+// StartNodeRunWaitingForConcurrency executes a node run held back by its concurrency group if the group is free
+func StartNodeRunWaitingForConcurrency(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj *sdk.Project, nodeRun *sdk.WorkflowNodeRun) (*ProcessorReport, error) {
+	if nodeRun.Concurrency == nil || !nodeRun.Concurrency.Blocked || nodeRun.Status != sdk.StatusWaiting.String() || nodeRun.Approval.IsWaiting() {
+		return nil, nil
+	}
+
+	locked, err := isNodeRunConcurrencyLocked(db, nodeRun)
+	if err != nil {
+		return nil, err
+	}
+	if locked {
+		return nil, nil
+	}
+
+	nodeRun.Concurrency.Blocked = false
+	if err := updateNodeRunConcurrency(db, nodeRun); err != nil {
+		return nil, err
+	}
+
+	wr, err := LoadRunByID(db, nodeRun.WorkflowRunID, LoadRunOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "StartNodeRunWaitingForConcurrency> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	AddWorkflowRunInfo(wr, false, sdk.SpawnMsg{
+		ID:   sdk.MsgWorkflowNodeConcurrencyRelease.ID,
+		Args: []interface{}{nodeRun.WorkflowNodeName, nodeRun.Concurrency.Group},
+	})
+	if err := UpdateWorkflowRun(ctx, db, wr); err != nil {
+		return nil, sdk.WrapError(err, "StartNodeRunWaitingForConcurrency> Unable to update workflow run %d", wr.ID)
+	}
+
+	log.Debug("StartNodeRunWaitingForConcurrency> process the node run %d because concurrency group %s is free", nodeRun.ID, nodeRun.Concurrency.Group)
+	return execute(ctx, db, store, proj, nodeRun)
+}
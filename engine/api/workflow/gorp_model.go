@@ -70,6 +70,8 @@ type NodeRun struct {
 	VCSHash            sql.NullString `db:"vcs_hash"`
 	VCSServer          sql.NullString `db:"vcs_server"`
 	Header             sql.NullString `db:"header"`
+	Approval           sql.NullString `db:"approval"`
+	Concurrency        sql.NullString `db:"concurrency"`
 }
 
 // JobRun is a gorp wrapper around sdk.WorkflowNodeJobRun
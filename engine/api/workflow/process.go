@@ -650,6 +650,18 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		}
 	}
 
+	if n.Context.Approval != nil && run.Status == string(sdk.StatusWaiting) {
+		run.Approval = sdk.NewWorkflowNodeRunApproval(*n.Context.Approval, run.Start)
+	}
+	run.Concurrency = sdk.NewWorkflowNodeRunConcurrency(&w.Workflow, n)
+	if run.Concurrency != nil {
+		shared, err := isSharedConcurrencyGroupAllowed(db, w.ProjectID, run.Concurrency.Group)
+		if err != nil {
+			return report, false, sdk.WrapError(err, "processWorkflowNodeRun> unable to check concurrency group")
+		}
+		run.Concurrency.Shared = shared
+	}
+
 	if err := insertWorkflowNodeRun(db, run); err != nil {
 		return report, true, sdk.WrapError(err, "processWorkflowNodeRun> unable to insert run (node id : %d, node name : %s, subnumber : %d)", run.WorkflowNodeID, run.WorkflowNodeName, run.SubNumber)
 	}
@@ -685,22 +697,27 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		return report, true, sdk.WrapError(err, "processWorkflowNodeRun> unable to update workflow run")
 	}
 
+	//Wait for the approvals before executing the node run
+	if run.Approval.IsWaiting() {
+		log.Debug("processWorkflowNodeRun> Noderun %s processed but not executed because of approval gate", n.Name)
+		AddWorkflowRunInfo(w, false, sdk.SpawnMsg{
+			ID:   sdk.MsgWorkflowNodeApprovalWaiting.ID,
+			Args: []interface{}{n.Name, run.Approval.Gate.RequiredApprovals()},
+		})
+
+		if err := UpdateWorkflowRun(ctx, db, w); err != nil {
+			return report, true, sdk.WrapError(err, "processWorkflowNodeRun> unable to update workflow run")
+		}
+		return report, true, nil
+	}
+
 	//Check the context.mutex to know if we are allowed to run it
 	if n.Context.Mutex {
-		//Check if there are builing workflownoderun with the same workflow_node_name for the same workflow
-		mutexQuery := `select count(1)
-		from workflow_node_run
-		join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
-		join workflow on workflow.id = workflow_run.workflow_id
-		where workflow.id = $1
-		and workflow_node_run.id <> $2
-		and workflow_node_run.workflow_node_name = $3
-		and workflow_node_run.status = $4`
-		nbMutex, err := db.SelectInt(mutexQuery, n.WorkflowID, run.ID, n.Name, string(sdk.StatusBuilding))
+		locked, err := isNodeRunMutexLocked(db, n.WorkflowID, run.ID, n.Name)
 		if err != nil {
 			return report, false, sdk.WrapError(err, "processWorkflowNodeRun> unable to check mutexes")
 		}
-		if nbMutex > 0 {
+		if locked {
 			log.Debug("processWorkflowNodeRun> Noderun %s processed but not executed because of mutex", n.Name)
 			AddWorkflowRunInfo(w, false, sdk.SpawnMsg{
 				ID:   sdk.MsgWorkflowNodeMutex.ID,
@@ -717,6 +734,31 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 		//Mutex is free, continue
 	}
 
+	//Check the concurrency group to know if we are allowed to run it
+	if run.Concurrency != nil {
+		locked, err := isNodeRunConcurrencyLocked(db, run)
+		if err != nil {
+			return report, false, sdk.WrapError(err, "processWorkflowNodeRun> unable to check concurrency group")
+		}
+		if locked {
+			log.Debug("processWorkflowNodeRun> Noderun %s processed but not executed because of concurrency group %s", n.Name, run.Concurrency.Group)
+			if err := blockNodeRunForConcurrency(db, run); err != nil {
+				return report, true, sdk.WrapError(err, "processWorkflowNodeRun> unable to hold node run")
+			}
+			AddWorkflowRunInfo(w, false, sdk.SpawnMsg{
+				ID:   sdk.MsgWorkflowNodeConcurrency.ID,
+				Args: []interface{}{n.Name, run.Concurrency.Group},
+			})
+
+			if err := UpdateWorkflowRun(ctx, db, w); err != nil {
+				return report, true, sdk.WrapError(err, "processWorkflowNodeRun> unable to update workflow run")
+			}
+
+			//The group is held by another run, it will be started when the group is free
+			return report, true, nil
+		}
+	}
+
 	//Execute the node run !
 	r1, err := execute(ctx, db, store, p, run)
 	if err != nil {
@@ -726,6 +768,23 @@ func processWorkflowNodeRun(ctx context.Context, db gorp.SqlExecutor, store cach
 	return report, true, nil
 }
 
+// isNodeRunMutexLocked checks if there are builing workflownoderun with the same workflow_node_name for the same workflow
+func isNodeRunMutexLocked(db gorp.SqlExecutor, workflowID, nodeRunID int64, nodeName string) (bool, error) {
+	mutexQuery := `select count(1)
+	from workflow_node_run
+	join workflow_run on workflow_run.id = workflow_node_run.workflow_run_id
+	join workflow on workflow.id = workflow_run.workflow_id
+	where workflow.id = $1
+	and workflow_node_run.id <> $2
+	and workflow_node_run.workflow_node_name = $3
+	and workflow_node_run.status = $4`
+	nbMutex, err := db.SelectInt(mutexQuery, workflowID, nodeRunID, nodeName, string(sdk.StatusBuilding))
+	if err != nil {
+		return false, err
+	}
+	return nbMutex > 0, nil
+}
+
 func setValuesGitInBuildParameters(run *sdk.WorkflowNodeRun, vcsInfos vcsInfos) {
 	run.VCSRepository = vcsInfos.Repository
 	run.VCSBranch = vcsInfos.Branch
@@ -758,6 +817,9 @@ func checkNodeRunCondition(wr *sdk.WorkflowRun, node sdk.WorkflowNode, params []
 	var errc error
 	if node.Context.Conditions.LuaScript == "" {
 		conditionsOK, errc = sdk.WorkflowCheckConditions(node.Context.Conditions.PlainConditions, params)
+		if errc == nil && conditionsOK && node.Context.Conditions.Expression != "" {
+			conditionsOK, errc = sdk.WorkflowCheckConditionsExpression(node.Context.Conditions.Expression, params)
+		}
 	} else {
 		luacheck, err := luascript.NewCheck()
 		if err != nil {
@@ -15,8 +15,11 @@ import (
 	"github.com/ovh/cds/sdk/interpolate"
 )
 
-func getNodeJobRunParameters(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, stage *sdk.Stage) ([]sdk.Parameter, *sdk.MultiError) {
-	params := run.BuildParameters
+func getNodeJobRunParameters(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, stage *sdk.Stage, cell sdk.JobMatrixCell) ([]sdk.Parameter, *sdk.MultiError) {
+	// Copy the build parameters, they are shared by all the jobs of the node run
+	params := make([]sdk.Parameter, len(run.BuildParameters), len(run.BuildParameters)+len(cell)+2)
+	copy(params, run.BuildParameters)
+	params = append(params, cell.Parameters()...)
 	tmp := map[string]string{
 		"cds.stage": stage.Name,
 		"cds.job":   j.Action.Name,
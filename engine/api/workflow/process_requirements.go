@@ -10,7 +10,7 @@ import (
 	"github.com/ovh/cds/sdk/interpolate"
 )
 
-func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun) (sdk.RequirementList, *sdk.MultiError) {
+func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.WorkflowNodeRun, cell sdk.JobMatrixCell) (sdk.RequirementList, *sdk.MultiError) {
 	requirements := sdk.RequirementList{}
 	tmp := map[string]string{}
 	errm := &sdk.MultiError{}
@@ -18,6 +18,9 @@ func getNodeJobRunRequirements(db gorp.SqlExecutor, j sdk.Job, run *sdk.Workflow
 	for _, v := range run.BuildParameters {
 		tmp[v.Name] = v.Value
 	}
+	for _, v := range cell.Parameters() {
+		tmp[v.Name] = v.Value
+	}
 
 	for _, v := range j.Action.Requirements {
 		name, errName := interpolate.Do(v.Name, tmp)
@@ -244,3 +244,122 @@ func GetRepositoryOperation(ctx context.Context, db gorp.SqlExecutor, store cach
 	}
 	return nil
 }
+
+// ChangedFiles returns the files changed between the base and the head commits of a branch of the repository of the application.
+// The commits are listed by the repositories manager, then the repositories service computes the diff
+func ChangedFiles(ctx context.Context, db gorp.SqlExecutor, store cache.Store, client sdk.VCSAuthorizedClient, proj sdk.Project, app *sdk.Application, branch, base, head string) ([]string, error) {
+	ctx, end := observability.Span(ctx, "workflow.ChangedFiles")
+	defer end()
+
+	commits, err := client.CommitsBetweenRefs(ctx, app.RepositoryFullname, base, head)
+	if err != nil {
+		return nil, sdk.WrapError(err, "ChangedFiles> Cannot list commits between %s and %s", base, head)
+	}
+	if len(commits) == 0 {
+		return []string{}, nil
+	}
+
+	repo, err := client.RepoByFullname(ctx, app.RepositoryFullname)
+	if err != nil {
+		return nil, sdk.WrapError(err, "ChangedFiles> Cannot get repository %s", app.RepositoryFullname)
+	}
+	url := repo.HTTPCloneURL
+	if app.RepositoryStrategy.ConnectionType == "ssh" {
+		url = repo.SSHCloneURL
+	}
+
+	ope := sdk.Operation{
+		VCSServer:          app.VCSServer,
+		RepoFullName:       app.RepositoryFullname,
+		URL:                url,
+		RepositoryStrategy: app.RepositoryStrategy,
+		Setup: sdk.OperationSetup{
+			Checkout: sdk.OperationCheckout{
+				Branch: branch,
+				Commit: head,
+			},
+		},
+		Diff: sdk.OperationDiff{
+			Base: base,
+			Head: head,
+		},
+	}
+
+	if err := PostRepositoryOperation(ctx, db, store, proj, &ope); err != nil {
+		return nil, sdk.WrapError(err, "ChangedFiles> Unable to post repository operation")
+	}
+
+	if err := pollRepositoryOperation(ctx, db, store, &ope); err != nil {
+		return nil, sdk.WrapError(err, "ChangedFiles> Cannot diff repository")
+	}
+
+	if ope.Diff.Files == nil {
+		return []string{}, nil
+	}
+	return ope.Diff.Files, nil
+}
+
+// RemoteChangedFiles returns the files changed between the base and the head commits of a branch of a remote repository
+// which is not attached to a repositories manager, like the repository of a git ref poller. The repositories service clones
+// the repository with the ssh key of the project named keyName, or without key for https urls, then computes the diff
+func RemoteChangedFiles(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, url, keyName, branch, base, head string) ([]string, error) {
+	ctx, end := observability.Span(ctx, "workflow.RemoteChangedFiles")
+	defer end()
+
+	ope := sdk.Operation{
+		URL:                url,
+		RepositoryStrategy: sdk.RepositoryStrategy{ConnectionType: "https"},
+		Setup: sdk.OperationSetup{
+			Checkout: sdk.OperationCheckout{
+				Branch: branch,
+				Commit: head,
+			},
+		},
+		Diff: sdk.OperationDiff{
+			Base: base,
+			Head: head,
+		},
+	}
+	if keyName != "" {
+		ope.RepositoryStrategy = sdk.RepositoryStrategy{ConnectionType: "ssh", SSHKey: keyName}
+	}
+
+	if err := PostRepositoryOperation(ctx, db, store, proj, &ope); err != nil {
+		return nil, sdk.WrapError(err, "RemoteChangedFiles> Unable to post repository operation")
+	}
+
+	if err := pollRepositoryOperation(ctx, db, store, &ope); err != nil {
+		return nil, sdk.WrapError(err, "RemoteChangedFiles> Cannot diff repository %s", url)
+	}
+
+	if ope.Diff.Files == nil {
+		return []string{}, nil
+	}
+	return ope.Diff.Files, nil
+}
+
+// LsRemote returns the branches and the tags of a remote repository, listed by the repositories service.
+// The ssh key of the project named keyName is used with ssh urls, https urls are listed without key
+func LsRemote(ctx context.Context, db gorp.SqlExecutor, store cache.Store, proj sdk.Project, url, keyName string) (map[string]string, error) {
+	ope := sdk.Operation{
+		URL:                url,
+		RepositoryStrategy: sdk.RepositoryStrategy{ConnectionType: "https"},
+		LsRemote:           &sdk.OperationLsRemote{},
+	}
+	if keyName != "" {
+		ope.RepositoryStrategy = sdk.RepositoryStrategy{ConnectionType: "ssh", SSHKey: keyName}
+	}
+
+	if err := PostRepositoryOperation(ctx, db, store, proj, &ope); err != nil {
+		return nil, sdk.WrapError(err, "LsRemote> Unable to post repository operation")
+	}
+
+	if err := pollRepositoryOperation(ctx, db, store, &ope); err != nil {
+		return nil, sdk.WrapError(err, "LsRemote> Cannot list references of %s", url)
+	}
+
+	if ope.LsRemote == nil || ope.LsRemote.Refs == nil {
+		return map[string]string{}, nil
+	}
+	return ope.LsRemote.Refs, nil
+}
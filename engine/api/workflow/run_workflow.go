@@ -199,3 +199,29 @@ func ManualRun(ctx context.Context, db gorp.SqlExecutor, store cache.Store, p *s
 	}
 	return wr, report, nil
 }
+
+// runSourceRepository returns the repository holding the branch of the run:
+// the source repository of a pull request, else the repository of the run
+func runSourceRepository(wr *sdk.WorkflowRun) string {
+	rootRuns := wr.WorkflowNodeRuns[wr.Workflow.RootID]
+	if len(rootRuns) == 0 {
+		return ""
+	}
+	if repo := sdk.ParameterValue(rootRuns[0].BuildParameters, "git.pr.source.repository"); repo != "" {
+		return repo
+	}
+	return rootRuns[0].VCSRepository
+}
+
+// IsSupersededBy checks that the run and the newer run build the same branch of the same repository,
+// so that a pull request opened from a fork never supersedes the runs of the branch with the same name in the repository
+func IsSupersededBy(wr, newer *sdk.WorkflowRun) bool {
+	if wr.WorkflowID != newer.WorkflowID || wr.ID >= newer.ID {
+		return false
+	}
+	wrRoots, newerRoots := wr.WorkflowNodeRuns[wr.Workflow.RootID], newer.WorkflowNodeRuns[newer.Workflow.RootID]
+	if len(wrRoots) == 0 || len(newerRoots) == 0 || wrRoots[0].VCSBranch != newerRoots[0].VCSBranch {
+		return false
+	}
+	return runSourceRepository(wr) == runSourceRepository(newer)
+}
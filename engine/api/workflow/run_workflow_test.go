@@ -595,3 +595,29 @@ func TestNoJob(t *testing.T) {
 
 	assert.Equal(t, sdk.StatusSuccess.String(), nodeRun.Status)
 }
+
+func TestIsSupersededBy(t *testing.T) {
+	newRun := func(id int64, branch string, params ...sdk.Parameter) *sdk.WorkflowRun {
+		return &sdk.WorkflowRun{
+			ID:         id,
+			WorkflowID: 1,
+			Workflow:   sdk.Workflow{RootID: 10},
+			WorkflowNodeRuns: map[int64][]sdk.WorkflowNodeRun{
+				10: {{VCSRepository: "ovh/cds", VCSBranch: branch, BuildParameters: params}},
+			},
+		}
+	}
+
+	master := newRun(1, "master")
+	assert.True(t, workflow.IsSupersededBy(master, newRun(2, "master")))
+	assert.False(t, workflow.IsSupersededBy(master, newRun(2, "feat")))
+	assert.False(t, workflow.IsSupersededBy(newRun(3, "master"), newRun(2, "master")))
+
+	// A pull request opened from the branch master of a fork does not stop the runs of master
+	fork := newRun(2, "master",
+		sdk.Parameter{Name: "git.pr.fork", Type: sdk.StringParameter, Value: "true"},
+		sdk.Parameter{Name: "git.pr.source.repository", Type: sdk.StringParameter, Value: "someone/cds"})
+	assert.False(t, workflow.IsSupersededBy(master, fork))
+	assert.True(t, workflow.IsSupersededBy(fork, newRun(3, "master",
+		sdk.Parameter{Name: "git.pr.source.repository", Type: sdk.StringParameter, Value: "someone/cds"})))
+}
@@ -252,6 +252,18 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 	//Send comment on pull request
 	for _, pr := range prs {
 		if pr.Head.Branch.DisplayID == nodeRun.VCSBranch && pr.Head.Branch.LatestCommit == nodeRun.VCSHash {
+			if node.Context.Application.RepositoryStrategy.PullRequestComment {
+				// The report is posted when the node run is over
+				switch nodeRun.Status {
+				case sdk.StatusSuccess.String(), sdk.StatusFail.String(), sdk.StatusStopped.String():
+				default:
+					continue
+				}
+				if err := sendPullRequestReport(ctx, db, client, proj, wr, node, nodeRun, pr); err != nil {
+					log.Error("sendVCSEventStatus> unable to send PR report: %v", err)
+				}
+				continue
+			}
 			if nodeRun.Status != sdk.StatusFail.String() {
 				continue
 			}
@@ -269,3 +281,71 @@ func sendVCSEventStatus(ctx context.Context, db gorp.SqlExecutor, store cache.St
 
 	return nil
 }
+
+// sendPullRequestReport posts the tests, the coverage and the new vulnerabilities of the node run as a comment on the pull request.
+// The comment posted by a previous run of the node is updated
+func sendPullRequestReport(ctx context.Context, db gorp.SqlExecutor, client sdk.VCSAuthorizedClient, proj *sdk.Project, wr *sdk.WorkflowRun, node *sdk.WorkflowNode, nodeRun *sdk.WorkflowNodeRun, pr sdk.VCSPullRequest) error {
+	app := node.Context.Application
+	report := sdk.WorkflowNodeRunPullRequestReport{
+		ProjectKey:   proj.Key,
+		WorkflowName: wr.Workflow.Name,
+		NodeRun:      *nodeRun,
+		URL:          fmt.Sprintf("%s/project/%s/workflow/%s/run/%d/node/%d?name=%s", baseUIURL, proj.Key, wr.Workflow.Name, nodeRun.Number, nodeRun.ID, wr.Workflow.Name),
+		TargetBranch: pr.Base.Branch.DisplayID,
+	}
+
+	cov, errC := LoadCoverageReport(db, nodeRun.ID)
+	if errC != nil && errC != sdk.ErrNotFound {
+		return sdk.WrapError(errC, "sendPullRequestReport> Unable to load coverage report")
+	}
+	if errC == nil {
+		report.NodeRun.Coverage = cov
+		targetCov, errT := loadLatestCoverageReport(db, wr.WorkflowID, cov.Repository, report.TargetBranch, app.ID)
+		if errT != nil && errT != sdk.ErrNotFound {
+			return sdk.WrapError(errT, "sendPullRequestReport> Unable to load coverage report of branch %s", report.TargetBranch)
+		}
+		if errT == nil {
+			report.TargetCoverage = &targetCov.Report
+		}
+	}
+
+	vulns, errV := loadVulnerabilityReport(db, nodeRun.ID)
+	if errV != nil && errV != sdk.ErrNotFound {
+		return sdk.WrapError(errV, "sendPullRequestReport> Unable to load vulnerability report")
+	}
+	if errV == nil && len(vulns.Report.Vulnerabilities) > 0 {
+		targetVulns, errT := loadLatestVulnerabilityReport(db, nodeRun, report.TargetBranch)
+		if errT != nil && errT != sdk.ErrNotFound {
+			return sdk.WrapError(errT, "sendPullRequestReport> Unable to load vulnerability report of branch %s", report.TargetBranch)
+		}
+		report.NewVulnerabilities = sdk.NewVulnerabilities(vulns.Report.Vulnerabilities, targetVulns.Report.Vulnerabilities)
+	}
+
+	body, err := report.String()
+	if err != nil {
+		return sdk.WrapError(err, "sendPullRequestReport> Unable to compute report")
+	}
+
+	comments, err := client.PullRequestComments(ctx, app.RepositoryFullname, pr.ID)
+	if err != nil {
+		return sdk.WrapError(err, "sendPullRequestReport> Unable to get comments of pull request %d", pr.ID)
+	}
+	for _, c := range comments {
+		if !report.IsComment(c) {
+			continue
+		}
+		if c.Body == body {
+			return nil
+		}
+		c.Body = body
+		if err := client.PullRequestCommentUpdate(ctx, app.RepositoryFullname, pr.ID, c); err != nil {
+			return sdk.WrapError(err, "sendPullRequestReport> Unable to update comment %d of pull request %d", c.ID, pr.ID)
+		}
+		return nil
+	}
+
+	if err := client.PullRequestComment(ctx, app.RepositoryFullname, pr.ID, body); err != nil {
+		return sdk.WrapError(err, "sendPullRequestReport> Unable to comment pull request %d", pr.ID)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/group"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+)
+
+func (api *API) getSharedConcurrencyGroupsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		groups, err := workflow.LoadSharedConcurrencyGroups(api.mustDB())
+		if err != nil {
+			return sdk.WrapError(err, "getSharedConcurrencyGroupsHandler")
+		}
+		return service.WriteJSON(w, groups, http.StatusOK)
+	}
+}
+
+func (api *API) postSharedConcurrencyGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var g sdk.SharedConcurrencyGroup
+		if err := UnmarshalBody(r, &g); err != nil {
+			return sdk.WrapError(err, "postSharedConcurrencyGroupHandler")
+		}
+
+		owner, err := group.LoadGroup(api.mustDB(), g.GroupName)
+		if err != nil {
+			return sdk.WrapError(err, "postSharedConcurrencyGroupHandler> Unable to load group %s", g.GroupName)
+		}
+		g.GroupID = owner.ID
+
+		if err := workflow.InsertSharedConcurrencyGroup(api.mustDB(), &g); err != nil {
+			return sdk.WrapError(err, "postSharedConcurrencyGroupHandler")
+		}
+		return service.WriteJSON(w, g, http.StatusCreated)
+	}
+}
+
+func (api *API) deleteSharedConcurrencyGroupHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := mux.Vars(r)["name"]
+		if err := workflow.DeleteSharedConcurrencyGroup(api.mustDB(), name); err != nil {
+			return sdk.WrapError(err, "deleteSharedConcurrencyGroupHandler")
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
@@ -133,6 +133,11 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 		})
 	}
 
+	//Check the worker can take a job of this project
+	if err := worker.CheckWorkerCanTakeJob(tx, getWorker(ctx), p.ID); err != nil {
+		return nil, sdk.WrapError(err, "takeJob> Worker %s cannot take job %d", getWorker(ctx).Name, id)
+	}
+
 	//Take node job run
 	job, report, errTake := workflow.TakeNodeJobRun(ctx, dbFunc, tx, store, p, id, workerModel, getWorker(ctx).Name, getWorker(ctx).ID, infos)
 	if errTake != nil {
@@ -164,29 +169,39 @@ func takeJob(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store,
 		return nil, sdk.WrapError(err, "takeJob> Unable to load workflow run")
 	}
 
-	//Load the secrets
-	pv, err := project.GetAllVariableInProject(tx, p.ID, project.WithClearPassword())
-	if err != nil {
-		return nil, sdk.WrapError(err, "takeJob> Cannot load project variable")
-	}
-
-	secrets, errSecret := workflow.LoadNodeJobRunSecrets(tx, store, job, noderun, workflowRun, pv)
-	if errSecret != nil {
-		return nil, sdk.WrapError(errSecret, "takeJob> Cannot load secrets")
-	}
-
 	//Feed the worker
 	wnjri.NodeJobRun = *job
 	wnjri.Number = noderun.Number
 	wnjri.SubNumber = noderun.SubNumber
-	wnjri.Secrets = secrets
 
-	params, secretsKeys, errK := workflow.LoadNodeJobRunKeys(tx, store, job, noderun, workflowRun, p)
-	if errK != nil {
-		return nil, sdk.WrapError(errK, "takeJob> Cannot load keys")
+	//The code of a pull request opened from a fork is not trusted: it never gets the secrets and the keys,
+	//and the worker running it can't take any other job
+	untrusted := sdk.ParameterValue(noderun.BuildParameters, "git.pr.fork") == "true"
+	if err := worker.BindToJob(tx, getWorker(ctx).ID, p.ID, untrusted); err != nil {
+		return nil, sdk.WrapError(err, "takeJob> Cannot bind worker %s to job %d", getWorker(ctx).Name, job.ID)
+	}
+	if untrusted {
+		log.Info("takeJob> Job %d runs a pull request from a fork, secrets and keys are not sent to the worker", job.ID)
+	} else {
+		//Load the secrets
+		pv, err := project.GetAllVariableInProject(tx, p.ID, project.WithClearPassword())
+		if err != nil {
+			return nil, sdk.WrapError(err, "takeJob> Cannot load project variable")
+		}
+
+		secrets, errSecret := workflow.LoadNodeJobRunSecrets(tx, store, job, noderun, workflowRun, pv)
+		if errSecret != nil {
+			return nil, sdk.WrapError(errSecret, "takeJob> Cannot load secrets")
+		}
+		wnjri.Secrets = secrets
+
+		params, secretsKeys, errK := workflow.LoadNodeJobRunKeys(tx, store, job, noderun, workflowRun, p)
+		if errK != nil {
+			return nil, sdk.WrapError(errK, "takeJob> Cannot load keys")
+		}
+		wnjri.Secrets = append(wnjri.Secrets, secretsKeys...)
+		wnjri.NodeJobRun.Parameters = append(wnjri.NodeJobRun.Parameters, params...)
 	}
-	wnjri.Secrets = append(wnjri.Secrets, secretsKeys...)
-	wnjri.NodeJobRun.Parameters = append(wnjri.NodeJobRun.Parameters, params...)
 
 	if err := tx.Commit(); err != nil {
 		return nil, sdk.WrapError(err, "takeJob> Cannot commit transaction")
@@ -493,6 +508,11 @@ func postJobResult(ctx context.Context, dbFunc func(context.Context) *gorp.DbMap
 		return nil, sdk.WrapError(err, "postJobResult> Cannot commit tx")
 	}
 
+	//Count the busy time of the worker model to size the pools of reusable workers
+	if !job.Start.IsZero() {
+		worker.AddModelBusyTime(store, wr.ModelID, remoteTime.Sub(job.Start))
+	}
+
 	return report, nil
 }
 
@@ -613,6 +633,7 @@ func (api *API) postWorkflowJobStepStatusHandler() service.Handler {
 				jobStep.Status = step.Status
 				if sdk.StatusIsTerminated(step.Status) {
 					jobStep.Done = step.Done
+					jobStep.ExitCode = step.ExitCode
 				}
 				found = true
 				break
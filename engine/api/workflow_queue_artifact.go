@@ -117,7 +117,7 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 
 			}
 
-			if err := artifact.SaveWorkflowFile(&art, file); err != nil {
+			if err := artifact.SaveWorkflowBlob(api.mustDB(), nodeJobRun.ProjectID, &art, file); err != nil {
 				file.Close()
 				return sdk.WrapError(err, "postWorkflowJobArtifactHandler> Cannot save artifact in store")
 			}
@@ -126,13 +126,79 @@ func (api *API) postWorkflowJobArtifactHandler() service.Handler {
 
 		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
 		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
-			_ = objectstore.Delete(&art)
+			_ = artifact.DeleteWorkflowArtifactObject(&art)
 			return sdk.WrapError(err, "postWorkflowJobArtifactHandler> Cannot update workflow node run")
 		}
 		return nil
 	}
 }
 
+// postWorkflowJobArtifactBlobHandler links an artifact to a blob already stored by the project, so the worker
+// does not have to upload the content again. It returns a not found error if there is no blob with this SHA512.
+func (api *API) postWorkflowJobArtifactBlobHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(sdk.ErrInvalidID, "postWorkflowJobArtifactBlobHandler> Invalid node job run ID")
+		}
+
+		vars := mux.Vars(r)
+		ref := vars["ref"]
+
+		art := sdk.WorkflowNodeRunArtifact{}
+		if err := UnmarshalBody(r, &art); err != nil {
+			return sdk.WrapError(err, "postWorkflowJobArtifactBlobHandler>")
+		}
+		if art.SHA512sum == "" || art.Name == "" {
+			return sdk.WrapError(sdk.ErrWrongRequest, "postWorkflowJobArtifactBlobHandler> Missing name or sha512sum")
+		}
+
+		nodeJobRun, errJ := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
+		if errJ != nil {
+			return sdk.WrapError(errJ, "postWorkflowJobArtifactBlobHandler> Cannot load node job run")
+		}
+
+		nodeRun, errR := workflow.LoadNodeRunByID(api.mustDB(), nodeJobRun.WorkflowNodeRunID, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		if errR != nil {
+			return sdk.WrapError(errR, "postWorkflowJobArtifactBlobHandler> Cannot load node run")
+		}
+
+		tag, errT := base64.RawURLEncoding.DecodeString(ref)
+		if errT != nil {
+			return sdk.WrapError(errT, "postWorkflowJobArtifactBlobHandler> Cannot decode ref")
+		}
+
+		key := sdk.ArtifactBlobKey(nodeJobRun.ProjectID, art.SHA512sum)
+		exists, err := artifact.UseBlob(api.mustDB(), key)
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowJobArtifactBlobHandler> Cannot check blob")
+		}
+		if !exists {
+			return sdk.ErrNotFound
+		}
+
+		hash, errG := generateHash()
+		if errG != nil {
+			return sdk.WrapError(errG, "postWorkflowJobArtifactBlobHandler> Could not generate hash")
+		}
+
+		art.ID = 0
+		art.WorkflowID = nodeRun.WorkflowRunID
+		art.WorkflowNodeRunID = nodeRun.ID
+		art.DownloadHash = hash
+		art.Tag = string(tag)
+		art.Ref = ref
+		art.BlobKey = key
+		art.ObjectPath = ""
+		art.Created = time.Now()
+
+		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
+			return sdk.WrapError(err, "postWorkflowJobArtifactBlobHandler> Cannot insert artifact")
+		}
+		return service.WriteJSON(w, art, http.StatusOK)
+	}
+}
+
 func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		if !objectstore.Instance().TemporaryURLSupported {
@@ -183,6 +249,8 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 		art.DownloadHash = hash
 		art.Tag = string(tag)
 		art.Ref = ref
+		// The content is uploaded to the object of the artifact, it is moved to its blob by the callback once checked
+		art.BlobKey = ""
 
 		url, key, err := store.StoreURL(&art)
 		if err != nil {
@@ -192,7 +260,7 @@ func (api *API) postWorkflowJobArtifacWithTempURLHandler() service.Handler {
 		art.TempURL = url
 		art.TempURLSecretKey = key
 
-		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName())
+		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName(), art.DownloadHash)
 		api.Cache.SetWithTTL(cacheKey, art, 60*60) //Put this in cache for 1 hour
 
 		return service.WriteJSON(w, art, http.StatusOK)
@@ -210,7 +278,7 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 			return err
 		}
 
-		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName())
+		cacheKey := cache.Key("workflows:artifacts", art.GetPath(), art.GetName(), art.DownloadHash)
 		cachedArt := sdk.WorkflowNodeRunArtifact{}
 		if !api.Cache.Get(cacheKey, &cachedArt) {
 			return sdk.WrapError(sdk.ErrNotFound, "postWorkflowJobArtifactWithTempURLCallbackHandler> Unable to find artifact, key:%s", cacheKey)
@@ -225,9 +293,21 @@ func (api *API) postWorkflowJobArtifactWithTempURLCallbackHandler() service.Hand
 			return sdk.WrapError(errR, "Cannot load node run")
 		}
 
+		id, errI := requestVarInt(r, "permID")
+		if errI != nil {
+			return sdk.WrapError(errI, "postWorkflowJobArtifactWithTempURLCallbackHandler> Invalid node job run ID")
+		}
+		nodeJobRun, errJ := workflow.LoadNodeJobRun(api.mustDB(), api.Cache, id)
+		if errJ != nil {
+			return sdk.WrapError(errJ, "postWorkflowJobArtifactWithTempURLCallbackHandler> Cannot load node job run")
+		}
+		if err := artifact.PromoteWorkflowBlob(api.mustDB(), nodeJobRun.ProjectID, &art); err != nil {
+			return sdk.WrapError(err, "postWorkflowJobArtifactWithTempURLCallbackHandler> Cannot save blob")
+		}
+
 		nodeRun.Artifacts = append(nodeRun.Artifacts, art)
 		if err := workflow.InsertArtifact(api.mustDB(), &art); err != nil {
-			_ = objectstore.Delete(&art)
+			_ = artifact.DeleteWorkflowArtifactObject(&art)
 			return sdk.WrapError(err, "postWorkflowJobArtifactWithTempURLCallbackHandler> Cannot update workflow node run")
 		}
 
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workflowNodeJobRunTimeout fails the building jobs whose worker stopped sending logs and heartbeats for longer than the timeout of the job
+func workflowNodeJobRunTimeout(c context.Context, DBFunc func() *gorp.DbMap, store cache.Store) {
+	tick := time.NewTicker(time.Minute).C
+	for {
+		select {
+		case <-c.Done():
+			if c.Err() != nil {
+				log.Error("Exiting workflowNodeJobRunTimeout: %v", c.Err())
+				return
+			}
+		case <-tick:
+			db := DBFunc()
+			if db == nil {
+				continue
+			}
+			ids, err := workflow.LoadStaleNodeJobRuns(db)
+			if err != nil {
+				log.Warning("workflowNodeJobRunTimeout> %v", err)
+				continue
+			}
+			for id, key := range ids {
+				if err := failStaleWorkflowNodeJobRun(c, DBFunc, store, key, id); err != nil {
+					log.Warning("workflowNodeJobRunTimeout> Unable to fail job %d: %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+func failStaleWorkflowNodeJobRun(ctx context.Context, DBFunc func() *gorp.DbMap, store cache.Store, key string, id int64) error {
+	db := DBFunc()
+	p, errP := project.Load(db, store, key, nil, project.LoadOptions.WithVariables)
+	if errP != nil {
+		return sdk.WrapError(errP, "failStaleWorkflowNodeJobRun> Cannot load project %s", key)
+	}
+
+	tx, errTx := db.Begin()
+	if errTx != nil {
+		return sdk.WrapError(errTx, "failStaleWorkflowNodeJobRun> Unable to create transaction")
+	}
+	defer tx.Rollback()
+
+	report, errF := workflow.FailStaleNodeJobRun(ctx, DBFunc, tx, store, p, id)
+	if errF != nil {
+		return sdk.WrapError(errF, "failStaleWorkflowNodeJobRun> Unable to fail job")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "failStaleWorkflowNodeJobRun> Unable to commit")
+	}
+	if report == nil {
+		return nil
+	}
+
+	workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+	go workflow.SendEvent(db, workflowRuns, workflowNodeRuns, p.Key)
+	return nil
+}
@@ -303,6 +303,31 @@ func (api *API) getWorkflowRunHandler() service.Handler {
 	}
 }
 
+func (api *API) getWorkflowRunDiffHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		to, err := requestVarInt(r, "to")
+		if err != nil {
+			return err
+		}
+		fromRun, err := workflow.LoadRun(api.mustDB(), key, name, number, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowRunDiffHandler> Unable to load workflow %s run number %d", name, number)
+		}
+		toRun, err := workflow.LoadRun(api.mustDB(), key, name, to, workflow.LoadRunOptions{})
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowRunDiffHandler> Unable to load workflow %s run number %d", name, to)
+		}
+		return service.WriteJSON(w, sdk.NewWorkflowRunDiff(fromRun, toRun), http.StatusOK)
+	}
+}
+
 func (api *API) stopWorkflowRunHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -323,7 +348,8 @@ func (api *API) stopWorkflowRunHandler() service.Handler {
 			return sdk.WrapError(errP, "stopWorkflowRunHandler> Unable to load project")
 		}
 
-		report, err := stopWorkflowRun(ctx, api.mustDB, api.Cache, proj, run, getUser(ctx))
+		stopMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowNodeStop.ID, Args: []interface{}{getUser(ctx).Username}}
+		report, err := stopWorkflowRun(ctx, api.mustDB, api.Cache, proj, run, stopMsg)
 		if err != nil {
 			return sdk.WrapError(err, "stopWorkflowRun> Unable to stop workflow")
 		}
@@ -335,7 +361,7 @@ func (api *API) stopWorkflowRunHandler() service.Handler {
 	}
 }
 
-func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, run *sdk.WorkflowRun, u *sdk.User) (*workflow.ProcessorReport, error) {
+func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, run *sdk.WorkflowRun, spwnMsg sdk.SpawnMsg) (*workflow.ProcessorReport, error) {
 	report := new(workflow.ProcessorReport)
 
 	tx, errTx := dbFunc().Begin()
@@ -344,8 +370,6 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 	}
 	defer tx.Rollback() //nolint
 
-	spwnMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowNodeStop.ID, Args: []interface{}{u.Username}}
-
 	stopInfos := sdk.SpawnInfo{
 		APITime:    time.Now(),
 		RemoteTime: time.Now(),
@@ -385,6 +409,44 @@ func stopWorkflowRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache
 	return report, nil
 }
 
+// cancelSupersededWorkflowRuns stops the runs of the workflow started before the given run on the same branch of the same repository
+func cancelSupersededWorkflowRuns(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, runID int64) error {
+	run, errR := workflow.LoadRunByID(dbFunc(), runID, workflow.LoadRunOptions{})
+	if errR != nil {
+		return sdk.WrapError(errR, "cancelSupersededWorkflowRuns> Unable to load workflow run %d", runID)
+	}
+	rootRuns := run.WorkflowNodeRuns[run.Workflow.RootID]
+	if len(rootRuns) == 0 || rootRuns[0].VCSBranch == "" {
+		return nil
+	}
+	branch := rootRuns[0].VCSBranch
+
+	ids, errL := workflow.LoadSupersededRunIDs(dbFunc(), run.WorkflowID, run.ID, branch)
+	if errL != nil {
+		return errL
+	}
+	for _, id := range ids {
+		supersededRun, errS := workflow.LoadRunByID(dbFunc(), id, workflow.LoadRunOptions{})
+		if errS != nil {
+			return sdk.WrapError(errS, "cancelSupersededWorkflowRuns> Unable to load workflow run %d", id)
+		}
+		if !workflow.IsSupersededBy(supersededRun, run) {
+			continue
+		}
+
+		log.Info("cancelSupersededWorkflowRuns> stopping run %d of workflow %s superseded by run %d on branch %s", supersededRun.Number, run.Workflow.Name, run.Number, branch)
+		stopMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowRunSuperseded.ID, Args: []interface{}{run.Number, branch}}
+		report, errStop := stopWorkflowRun(ctx, dbFunc, store, p, supersededRun, stopMsg)
+		if errStop != nil {
+			return sdk.WrapError(errStop, "cancelSupersededWorkflowRuns> Unable to stop workflow run %d", id)
+		}
+
+		workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+		go workflow.SendEvent(dbFunc(), workflowRuns, workflowNodeRuns, p.Key)
+	}
+	return nil
+}
+
 func (api *API) getWorkflowNodeRunHistoryHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		vars := mux.Vars(r)
@@ -515,7 +577,8 @@ func (api *API) stopWorkflowNodeRunHandler() service.Handler {
 			return sdk.WrapError(err, "stopWorkflowNodeRunHandler> Unable to load last workflow run")
 		}
 
-		report, err := stopWorkflowNodeRun(ctx, api.mustDB, api.Cache, p, nodeRun, name, getUser(ctx))
+		stopMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowNodeStop.ID, Args: []interface{}{getUser(ctx).Username}}
+		report, err := stopWorkflowNodeRun(ctx, api.mustDB, api.Cache, p, nodeRun, stopMsg)
 		if err != nil {
 			return sdk.WrapError(err, "stopWorkflowNodeRunHandler> Unable to stop workflow run")
 		}
@@ -527,7 +590,7 @@ func (api *API) stopWorkflowNodeRunHandler() service.Handler {
 	}
 }
 
-func stopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, nodeRun *sdk.WorkflowNodeRun, workflowName string, u *sdk.User) (*workflow.ProcessorReport, error) {
+func stopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, p *sdk.Project, nodeRun *sdk.WorkflowNodeRun, stopMsg sdk.SpawnMsg) (*workflow.ProcessorReport, error) {
 	tx, errTx := dbFunc().Begin()
 	if errTx != nil {
 		return nil, sdk.WrapError(errTx, "stopWorkflowNodeRunHandler> Unable to create transaction")
@@ -537,16 +600,16 @@ func stopWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store c
 	stopInfos := sdk.SpawnInfo{
 		APITime:    time.Now(),
 		RemoteTime: time.Now(),
-		Message:    sdk.SpawnMsg{ID: sdk.MsgWorkflowNodeStop.ID, Args: []interface{}{u.Username}},
+		Message:    stopMsg,
 	}
 	report, errS := workflow.StopWorkflowNodeRun(ctx, dbFunc, store, p, *nodeRun, stopInfos)
 	if errS != nil {
 		return nil, sdk.WrapError(errS, "stopWorkflowNodeRunHandler> Unable to stop workflow node run")
 	}
 
-	wr, errLw := workflow.LoadRun(tx, p.Key, workflowName, nodeRun.Number, workflow.LoadRunOptions{})
+	wr, errLw := workflow.LoadRunByID(tx, nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
 	if errLw != nil {
-		return nil, sdk.WrapError(errLw, "stopWorkflowNodeRunHandler> Unable to load workflow run %s", workflowName)
+		return nil, sdk.WrapError(errLw, "stopWorkflowNodeRunHandler> Unable to load workflow run %d", nodeRun.WorkflowRunID)
 	}
 
 	r1, errR := workflow.ResyncWorkflowRunStatus(tx, wr)
@@ -614,6 +677,27 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 			return err
 		}
 
+		// A replay starts a new run with the build parameters of the root node of a past run
+		if opts.Replay != nil {
+			if opts.Number != nil || opts.Manual != nil || opts.Hook != nil {
+				return sdk.WrapError(sdk.ErrWrongRequest, "postWorkflowRunHandler> Replay cannot be used with number, manual or hook")
+			}
+			replayed, errr := workflow.LoadRun(api.mustDB(), key, name, opts.Replay.Number, workflow.LoadRunOptions{})
+			if errr != nil {
+				return sdk.WrapError(errr, "postWorkflowRunHandler> Unable to load workflow run %d to replay", opts.Replay.Number)
+			}
+			root := replayed.RootNodeRun()
+			if root == nil {
+				return sdk.WrapError(sdk.ErrWorkflowNodeNotFound, "postWorkflowRunHandler> Unable to find root node run of workflow run %d", opts.Replay.Number)
+			}
+			manual, errm := sdk.NewWorkflowNodeRunManualFromReplay(*root, opts.Replay.Overrides)
+			if errm != nil {
+				return sdk.WrapError(errm, "postWorkflowRunHandler> Unable to replay workflow run %d", opts.Replay.Number)
+			}
+			opts.Manual = manual
+			opts.Replay = nil
+		}
+
 		var lastRun *sdk.WorkflowRun
 		var asCodeInfosMsg []sdk.Message
 		if opts.Number != nil {
@@ -691,6 +775,18 @@ func (api *API) postWorkflowRunHandler() service.Handler {
 		workflow.ResyncNodeRunsWithCommits(ctx, api.mustDB(), api.Cache, p, workflowNodeRuns)
 		go workflow.SendEvent(api.mustDB(), workflowRuns, workflowNodeRuns, p.Key)
 
+		// Stop the previous runs on the same branch
+		if wf.CancelSupersededRuns && lastRun == nil && len(workflowRuns) > 0 {
+			runID := workflowRuns[0].ID
+			sdk.GoRoutine(
+				"cancelSupersededWorkflowRuns",
+				func() {
+					if err := cancelSupersededWorkflowRuns(context.Background(), api.mustDB, api.Cache, p, runID); err != nil {
+						log.Error("cancelSupersededWorkflowRuns> error %v", err)
+					}
+				})
+		}
+
 		// Purge workflow run
 		sdk.GoRoutine(
 			"workflow.PurgeWorkflowRun",
@@ -1110,7 +1206,8 @@ func (api *API) postResyncVCSWorkflowRunHandler() service.Handler {
 			return sdk.WrapError(errP, "postResyncVCSWorkflowRunHandler> Cannot load project")
 		}
 
-		wfr, errW := workflow.LoadRun(db, key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true})
+		// The tests are loaded for the pull request report, which is posted again
+		wfr, errW := workflow.LoadRun(db, key, name, number, workflow.LoadRunOptions{DisableDetailledNodeRun: true, WithTests: true})
 		if errW != nil {
 			return sdk.WrapError(errW, "postResyncVCSWorkflowRunHandler> Cannot load workflow run")
 		}
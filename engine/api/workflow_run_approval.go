@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// postWorkflowNodeRunApproveHandler adds the approval of the current user on a node run waiting for approvals
+func (api *API) postWorkflowNodeRunApproveHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		vars := mux.Vars(r)
+		key := vars["key"]
+		name := vars["permWorkflowName"]
+		number, err := requestVarInt(r, "number")
+		if err != nil {
+			return err
+		}
+		id, err := requestVarInt(r, "nodeRunID")
+		if err != nil {
+			return err
+		}
+
+		var approve sdk.WorkflowNodeRunApprove
+		if err := UnmarshalBody(r, &approve); err != nil {
+			return sdk.WrapError(err, "postWorkflowNodeRunApproveHandler> Cannot read body")
+		}
+
+		p, errP := project.Load(api.mustDB(), api.Cache, key, getUser(ctx), project.LoadOptions.WithVariables)
+		if errP != nil {
+			return sdk.WrapError(errP, "postWorkflowNodeRunApproveHandler> Cannot load project")
+		}
+
+		// Check that the node run belongs to the workflow run
+		if _, err := workflow.LoadNodeRun(api.mustDB(), key, name, number, id, workflow.LoadRunOptions{}); err != nil {
+			return sdk.WrapError(err, "postWorkflowNodeRunApproveHandler> Unable to load node run %d", id)
+		}
+
+		tx, errTx := api.mustDB().Begin()
+		if errTx != nil {
+			return sdk.WrapError(errTx, "postWorkflowNodeRunApproveHandler> Unable to create transaction")
+		}
+		defer tx.Rollback()
+
+		nodeRun, errL := workflow.LoadAndLockNodeRunByID(ctx, tx, id, true)
+		if errL != nil {
+			return sdk.WrapError(errL, "postWorkflowNodeRunApproveHandler> Unable to lock node run %d", id)
+		}
+
+		report, errA := workflow.ApproveNodeRun(ctx, tx, api.Cache, p, nodeRun, getUser(ctx), approve.Comment)
+		if errA != nil {
+			return sdk.WrapError(errA, "postWorkflowNodeRunApproveHandler> Unable to approve node run %d", id)
+		}
+
+		wr, errLw := workflow.LoadRunByID(tx, nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
+		if errLw != nil {
+			return sdk.WrapError(errLw, "postWorkflowNodeRunApproveHandler> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+		}
+		r1, errR := workflow.ResyncWorkflowRunStatus(tx, wr)
+		if errR != nil {
+			return sdk.WrapError(errR, "postWorkflowNodeRunApproveHandler> Unable to resync workflow run status")
+		}
+		_, _ = report.Merge(r1, nil)
+
+		if err := tx.Commit(); err != nil {
+			return sdk.WrapError(err, "postWorkflowNodeRunApproveHandler> Unable to commit")
+		}
+
+		workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+		go workflow.SendEvent(api.mustDB(), workflowRuns, workflowNodeRuns, p.Key)
+
+		return service.WriteJSON(w, nodeRun, http.StatusOK)
+	}
+}
+
+// workflowNodeRunApprovalExpiry fails or skips the node runs still waiting for approvals after the expiry of their approval gate
+func workflowNodeRunApprovalExpiry(c context.Context, DBFunc func() *gorp.DbMap, store cache.Store) {
+	tick := time.NewTicker(time.Minute).C
+	for {
+		select {
+		case <-c.Done():
+			if c.Err() != nil {
+				log.Error("Exiting workflowNodeRunApprovalExpiry: %v", c.Err())
+				return
+			}
+		case <-tick:
+			db := DBFunc()
+			if db == nil {
+				continue
+			}
+			ids, err := workflow.LoadNodeRunsWithExpiredApproval(db)
+			if err != nil {
+				log.Warning("workflowNodeRunApprovalExpiry> %v", err)
+				continue
+			}
+			for id, key := range ids {
+				if err := expireWorkflowNodeRunApproval(c, db, store, key, id); err != nil {
+					log.Warning("workflowNodeRunApprovalExpiry> Unable to expire node run %d: %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+func expireWorkflowNodeRunApproval(ctx context.Context, db *gorp.DbMap, store cache.Store, key string, id int64) error {
+	p, errP := project.Load(db, store, key, nil, project.LoadOptions.WithVariables)
+	if errP != nil {
+		return sdk.WrapError(errP, "expireWorkflowNodeRunApproval> Cannot load project %s", key)
+	}
+
+	tx, errTx := db.Begin()
+	if errTx != nil {
+		return sdk.WrapError(errTx, "expireWorkflowNodeRunApproval> Unable to create transaction")
+	}
+	defer tx.Rollback()
+
+	nodeRun, errL := workflow.LoadAndLockNodeRunByID(ctx, tx, id, true)
+	if errL != nil {
+		return sdk.WrapError(errL, "expireWorkflowNodeRunApproval> Unable to lock node run")
+	}
+
+	report, errE := workflow.ExpireNodeRunApproval(ctx, tx, store, p, nodeRun)
+	if errE != nil {
+		return sdk.WrapError(errE, "expireWorkflowNodeRunApproval> Unable to expire approval")
+	}
+
+	wr, errLw := workflow.LoadRunByID(tx, nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
+	if errLw != nil {
+		return sdk.WrapError(errLw, "expireWorkflowNodeRunApproval> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	r1, errR := workflow.ResyncWorkflowRunStatus(tx, wr)
+	if errR != nil {
+		return sdk.WrapError(errR, "expireWorkflowNodeRunApproval> Unable to resync workflow run status")
+	}
+	report, _ = report.Merge(r1, nil)
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "expireWorkflowNodeRunApproval> Unable to commit")
+	}
+
+	workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+	go workflow.SendEvent(db, workflowRuns, workflowNodeRuns, p.Key)
+	return nil
+}
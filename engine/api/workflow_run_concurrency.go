@@ -0,0 +1,125 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// workflowNodeRunConcurrency stops the node runs superseded by a newer run of a concurrency group with the cancel-previous policy,
+// then starts the node runs waiting for a concurrency group which is free
+func workflowNodeRunConcurrency(c context.Context, DBFunc func() *gorp.DbMap, store cache.Store) {
+	tick := time.NewTicker(5 * time.Second).C
+	for {
+		select {
+		case <-c.Done():
+			if c.Err() != nil {
+				log.Error("Exiting workflowNodeRunConcurrency: %v", c.Err())
+				return
+			}
+		case <-tick:
+			db := DBFunc()
+			if db == nil {
+				continue
+			}
+
+			superseded, err := workflow.LoadSupersededNodeRuns(db)
+			if err != nil {
+				log.Warning("workflowNodeRunConcurrency> %v", err)
+				continue
+			}
+			for _, r := range superseded {
+				if err := cancelSupersededWorkflowNodeRun(c, DBFunc, store, r); err != nil {
+					log.Warning("workflowNodeRunConcurrency> Unable to cancel node run %d: %v", r.ID, err)
+				}
+			}
+
+			waiting, err := workflow.LoadNodeRunsWaitingForConcurrency(db)
+			if err != nil {
+				log.Warning("workflowNodeRunConcurrency> %v", err)
+				continue
+			}
+			for _, r := range waiting {
+				if err := startWorkflowNodeRunWaitingForConcurrency(c, db, store, r); err != nil {
+					log.Warning("workflowNodeRunConcurrency> Unable to start node run %d: %v", r.ID, err)
+				}
+			}
+		}
+	}
+}
+
+func cancelSupersededWorkflowNodeRun(ctx context.Context, dbFunc func() *gorp.DbMap, store cache.Store, r workflow.ConcurrencyNodeRun) error {
+	p, errP := project.Load(dbFunc(), store, r.ProjectKey, nil, project.LoadOptions.WithVariables)
+	if errP != nil {
+		return sdk.WrapError(errP, "cancelSupersededWorkflowNodeRun> Cannot load project %s", r.ProjectKey)
+	}
+
+	nodeRun, errL := workflow.LoadNodeRunByID(dbFunc(), r.ID, workflow.LoadRunOptions{})
+	if errL != nil {
+		return sdk.WrapError(errL, "cancelSupersededWorkflowNodeRun> Unable to load node run")
+	}
+	if sdk.StatusIsTerminated(nodeRun.Status) {
+		return nil
+	}
+
+	stopMsg := sdk.SpawnMsg{ID: sdk.MsgWorkflowNodeConcurrencyCanceled.ID, Args: []interface{}{r.Group}}
+	report, errS := stopWorkflowNodeRun(ctx, dbFunc, store, p, nodeRun, stopMsg)
+	if errS != nil {
+		return sdk.WrapError(errS, "cancelSupersededWorkflowNodeRun> Unable to stop node run")
+	}
+
+	workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+	go workflow.SendEvent(dbFunc(), workflowRuns, workflowNodeRuns, p.Key)
+	return nil
+}
+
+func startWorkflowNodeRunWaitingForConcurrency(ctx context.Context, db *gorp.DbMap, store cache.Store, r workflow.ConcurrencyNodeRun) error {
+	p, errP := project.Load(db, store, r.ProjectKey, nil, project.LoadOptions.WithVariables)
+	if errP != nil {
+		return sdk.WrapError(errP, "startWorkflowNodeRunWaitingForConcurrency> Cannot load project %s", r.ProjectKey)
+	}
+
+	tx, errTx := db.Begin()
+	if errTx != nil {
+		return sdk.WrapError(errTx, "startWorkflowNodeRunWaitingForConcurrency> Unable to create transaction")
+	}
+	defer tx.Rollback()
+
+	nodeRun, errL := workflow.LoadAndLockNodeRunByID(ctx, tx, r.ID, true)
+	if errL != nil {
+		return sdk.WrapError(errL, "startWorkflowNodeRunWaitingForConcurrency> Unable to lock node run")
+	}
+
+	report, errS := workflow.StartNodeRunWaitingForConcurrency(ctx, tx, store, p, nodeRun)
+	if errS != nil {
+		return sdk.WrapError(errS, "startWorkflowNodeRunWaitingForConcurrency> Unable to start node run")
+	}
+	if report == nil {
+		return nil
+	}
+
+	wr, errLw := workflow.LoadRunByID(tx, nodeRun.WorkflowRunID, workflow.LoadRunOptions{})
+	if errLw != nil {
+		return sdk.WrapError(errLw, "startWorkflowNodeRunWaitingForConcurrency> Unable to load workflow run %d", nodeRun.WorkflowRunID)
+	}
+	r1, errR := workflow.ResyncWorkflowRunStatus(tx, wr)
+	if errR != nil {
+		return sdk.WrapError(errR, "startWorkflowNodeRunWaitingForConcurrency> Unable to resync workflow run status")
+	}
+	report, _ = report.Merge(r1, nil)
+
+	if err := tx.Commit(); err != nil {
+		return sdk.WrapError(err, "startWorkflowNodeRunWaitingForConcurrency> Unable to commit")
+	}
+
+	workflowRuns, workflowNodeRuns := workflow.GetWorkflowRunEventData(report, p.Key)
+	go workflow.SendEvent(db, workflowRuns, workflowNodeRuns, p.Key)
+	return nil
+}
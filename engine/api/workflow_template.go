@@ -0,0 +1,315 @@
+package api
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ovh/cds/engine/api/permission"
+	"github.com/ovh/cds/engine/api/project"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/engine/service"
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// isWorkflowTemplateAdmin returns true if the user is a CDS administrator or an administrator of the group
+func isWorkflowTemplateAdmin(u *sdk.User, groupID int64) bool {
+	if u.Admin {
+		return true
+	}
+	for _, g := range u.Groups {
+		if g.ID != groupID {
+			continue
+		}
+		for _, a := range g.Admins {
+			if a.ID == u.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (api *API) getWorkflowTemplatesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		templates, err := workflowtemplate.LoadAll(api.mustDB())
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowTemplatesHandler> Cannot load workflow templates")
+		}
+		return service.WriteJSON(w, templates, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowTemplateHandler> Invalid id")
+		}
+		t, err := workflowtemplate.LoadByID(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowTemplateHandler> Cannot load workflow template %d", id)
+		}
+		return service.WriteJSON(w, t, http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		var t sdk.WorkflowTemplate
+		if err := UnmarshalBody(r, &t); err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateHandler> Cannot unmarshal body")
+		}
+		if err := t.IsValid(); err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateHandler> Invalid workflow template")
+		}
+		if !isWorkflowTemplateAdmin(getUser(ctx), t.GroupID) {
+			return sdk.ErrWorkflowTemplateNoAdmin
+		}
+
+		if err := workflowtemplate.Insert(api.mustDB(), &t); err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateHandler> Cannot insert workflow template")
+		}
+
+		newTemplate, err := workflowtemplate.LoadByID(api.mustDB(), t.ID)
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateHandler> Cannot load workflow template %d", t.ID)
+		}
+		return service.WriteJSON(w, newTemplate, http.StatusCreated)
+	}
+}
+
+func (api *API) putWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Invalid id")
+		}
+		old, err := workflowtemplate.LoadByID(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Cannot load workflow template %d", id)
+		}
+
+		var t sdk.WorkflowTemplate
+		if err := UnmarshalBody(r, &t); err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Cannot unmarshal body")
+		}
+		t.ID = old.ID
+		if err := t.IsValid(); err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Invalid workflow template")
+		}
+		u := getUser(ctx)
+		if !isWorkflowTemplateAdmin(u, old.GroupID) || !isWorkflowTemplateAdmin(u, t.GroupID) {
+			return sdk.ErrWorkflowTemplateNoAdmin
+		}
+
+		if err := workflowtemplate.Update(api.mustDB(), &t); err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Cannot update workflow template %d", id)
+		}
+
+		newTemplate, err := workflowtemplate.LoadByID(api.mustDB(), t.ID)
+		if err != nil {
+			return sdk.WrapError(err, "putWorkflowTemplateHandler> Cannot load workflow template %d", t.ID)
+		}
+		return service.WriteJSON(w, newTemplate, http.StatusOK)
+	}
+}
+
+func (api *API) deleteWorkflowTemplateHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "deleteWorkflowTemplateHandler> Invalid id")
+		}
+		t, err := workflowtemplate.LoadByID(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "deleteWorkflowTemplateHandler> Cannot load workflow template %d", id)
+		}
+		if !isWorkflowTemplateAdmin(getUser(ctx), t.GroupID) {
+			return sdk.ErrWorkflowTemplateNoAdmin
+		}
+
+		if err := workflowtemplate.Delete(api.mustDB(), t.ID); err != nil {
+			return sdk.WrapError(err, "deleteWorkflowTemplateHandler> Cannot delete workflow template %d", id)
+		}
+		return service.WriteJSON(w, nil, http.StatusOK)
+	}
+}
+
+func (api *API) getWorkflowTemplateInstancesHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowTemplateInstancesHandler> Invalid id")
+		}
+		instances, err := workflowtemplate.LoadInstances(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "getWorkflowTemplateInstancesHandler> Cannot load instances of workflow template %d", id)
+		}
+
+		u := getUser(ctx)
+		res := make([]sdk.WorkflowTemplateInstance, 0, len(instances))
+		for _, i := range instances {
+			if permission.AccessToProject(i.ProjectKey, u, permission.PermissionRead) {
+				res = append(res, i)
+			}
+		}
+		return service.WriteJSON(w, res, http.StatusOK)
+	}
+}
+
+// applyWorkflowTemplate generates the workflow of a request, pushes it in the project and saves the instance of the template.
+// Without force, it returns a conflict error if the workflow or one of the generated entities already exists in the project
+// and does not belong to the workflow of the instance
+func (api *API) applyWorkflowTemplate(ctx context.Context, u *sdk.User, key string, t *sdk.WorkflowTemplate, req sdk.WorkflowTemplateRequest, force bool) ([]sdk.Message, *sdk.Workflow, error) {
+	res, err := workflowtemplate.Execute(t, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := new(bytes.Buffer)
+	if err := res.Tar(buf); err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := api.mustDB().Begin()
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "applyWorkflowTemplate> Cannot start transaction")
+	}
+	defer tx.Rollback()
+
+	proj, err := project.Load(tx, api.Cache, key, u,
+		project.LoadOptions.WithGroups,
+		project.LoadOptions.WithApplications,
+		project.LoadOptions.WithEnvironments,
+		project.LoadOptions.WithPipelines,
+		project.LoadOptions.WithApplicationWithDeploymentStrategies,
+		project.LoadOptions.WithPlatforms,
+		project.LoadOptions.WithWorkflowNames)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "applyWorkflowTemplate> Cannot load project %s", key)
+	}
+
+	instance, err := workflowtemplate.LoadInstanceByWorkflowName(tx, t.ID, proj.ID, req.WorkflowName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var owned *sdk.Workflow
+	if instance != nil && instance.WorkflowID != nil {
+		owned, err = workflow.LoadByID(tx, api.Cache, proj, *instance.WorkflowID, u, workflow.LoadOptions{})
+		if err != nil && !sdk.ErrorIs(err, sdk.ErrWorkflowNotFound) {
+			return nil, nil, sdk.WrapError(err, "applyWorkflowTemplate> Cannot load workflow %d of instance %d", *instance.WorkflowID, instance.ID)
+		}
+	}
+
+	if conflicts := res.Conflicts(proj, owned); !force && len(conflicts) > 0 {
+		return nil, nil, sdk.NewError(sdk.ErrConflict, fmt.Errorf("%s already exist in project %s, use force to overwrite them", strings.Join(conflicts, ", "), key))
+	}
+
+	msgs, wf, err := workflow.PushInTx(ctx, tx, api.Cache, proj, tar.NewReader(buf), nil, u, project.DecryptWithBuiltinKey)
+	if err != nil {
+		return nil, nil, sdk.WrapError(err, "applyWorkflowTemplate> Cannot push workflow %s", req.WorkflowName)
+	}
+
+	if instance == nil {
+		instance = &sdk.WorkflowTemplateInstance{
+			WorkflowTemplateID: t.ID,
+			ProjectID:          proj.ID,
+		}
+	}
+	instance.WorkflowTemplateVersion = t.Version
+	instance.Request = req
+	if wf != nil {
+		instance.WorkflowID = &wf.ID
+	}
+	if instance.ID == 0 {
+		err = workflowtemplate.InsertInstance(tx, instance)
+	} else {
+		err = workflowtemplate.UpdateInstance(tx, instance)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, sdk.WrapError(err, "applyWorkflowTemplate> Cannot commit transaction")
+	}
+	return msgs, wf, nil
+}
+
+func (api *API) postWorkflowTemplateApplyHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		key := mux.Vars(r)["permProjectKey"]
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateApplyHandler> Invalid id")
+		}
+		t, err := workflowtemplate.LoadByID(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateApplyHandler> Cannot load workflow template %d", id)
+		}
+
+		var req sdk.WorkflowTemplateRequest
+		if err := UnmarshalBody(r, &req); err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateApplyHandler> Cannot unmarshal body")
+		}
+
+		msgs, wf, err := api.applyWorkflowTemplate(ctx, getUser(ctx), key, t, req, FormBool(r, "force"))
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateApplyHandler> Cannot apply workflow template %d", id)
+		}
+
+		if wf != nil {
+			w.Header().Add(sdk.ResponseWorkflowNameHeader, wf.Name)
+		}
+		return service.WriteJSON(w, translate(r, msgs), http.StatusOK)
+	}
+}
+
+func (api *API) postWorkflowTemplateBulkHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		id, err := requestVarInt(r, "id")
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateBulkHandler> Invalid id")
+		}
+		t, err := workflowtemplate.LoadByID(api.mustDB(), id)
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateBulkHandler> Cannot load workflow template %d", id)
+		}
+		u := getUser(ctx)
+		if !isWorkflowTemplateAdmin(u, t.GroupID) {
+			return sdk.ErrWorkflowTemplateNoAdmin
+		}
+
+		instances, err := workflowtemplate.LoadOutdatedInstances(api.mustDB(), t)
+		if err != nil {
+			return sdk.WrapError(err, "postWorkflowTemplateBulkHandler> Cannot load instances of workflow template %d", id)
+		}
+
+		force := FormBool(r, "force")
+		al := r.Header.Get("Accept-Language")
+		results := make([]sdk.WorkflowTemplateBulkResult, 0, len(instances))
+		for _, i := range instances {
+			res := sdk.WorkflowTemplateBulkResult{
+				InstanceID:   i.ID,
+				ProjectKey:   i.ProjectKey,
+				WorkflowName: i.Request.WorkflowName,
+			}
+			if !permission.AccessToProject(i.ProjectKey, u, permission.PermissionReadWriteExecute) {
+				res.Error, _ = sdk.ProcessError(sdk.ErrForbidden, al)
+			} else if _, _, err := api.applyWorkflowTemplate(ctx, u, i.ProjectKey, t, i.Request, force); err != nil {
+				log.Warning("postWorkflowTemplateBulkHandler> Cannot upgrade instance %d of workflow template %d: %v", i.ID, t.ID, err)
+				res.Error, _ = sdk.ProcessError(err, al)
+			}
+			results = append(results, res)
+		}
+		return service.WriteJSON(w, results, http.StatusOK)
+	}
+}
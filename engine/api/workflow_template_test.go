@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/engine/api/test/assets"
+	"github.com/ovh/cds/engine/api/workflow"
+	"github.com/ovh/cds/engine/api/workflowtemplate"
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_workflowTemplateLifecycle(t *testing.T) {
+	api, db, _ := newTestAPI(t)
+	u, pass := assets.InsertAdminUser(db)
+	proj := assets.InsertTestProject(t, db, api.Cache, sdk.RandomString(10), sdk.RandomString(10), u)
+
+	tmpl := sdk.WorkflowTemplate{
+		GroupID: proj.ProjectGroups[0].Group.ID,
+		Name:    sdk.RandomString(10),
+		Parameters: []sdk.WorkflowTemplateParameter{
+			{Key: "command", Type: sdk.WorkflowTemplateParameterTypeString, Required: true},
+		},
+		Workflow: `version: v1.0
+workflow:
+  build:
+    pipeline: [[.name]]-build
+`,
+		Pipelines: []string{`version: v1.0
+name: [[.name]]-build
+jobs:
+- job: build
+  steps:
+  - script: [[.params.command]]
+`},
+	}
+
+	// Create the template
+	uri := api.Router.GetRoute("POST", api.postWorkflowTemplateHandler, nil)
+	req := assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, tmpl)
+	rec := httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 201, rec.Code)
+	test.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tmpl))
+	assert.Equal(t, int64(1), tmpl.Version)
+
+	// Instantiate it in the project
+	vars := map[string]string{"id": fmt.Sprintf("%d", tmpl.ID), "permProjectKey": proj.Key}
+	uri = api.Router.GetRoute("POST", api.postWorkflowTemplateApplyHandler, vars)
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"command": "make"},
+	})
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 200, rec.Code)
+
+	wf, err := workflow.Load(context.TODO(), db, api.Cache, proj, "my-service", u, workflow.LoadOptions{})
+	test.NoError(t, err)
+	assert.Equal(t, "my-service-build", wf.Root.PipelineName)
+
+	instances, err := workflowtemplate.LoadInstances(db, tmpl.ID)
+	test.NoError(t, err)
+	assert.Len(t, instances, 1)
+	assert.Equal(t, int64(1), instances[0].WorkflowTemplateVersion)
+
+	// Update the template and upgrade the instance
+	uri = api.Router.GetRoute("PUT", api.putWorkflowTemplateHandler, map[string]string{"id": fmt.Sprintf("%d", tmpl.ID)})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "PUT", uri, tmpl)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 200, rec.Code)
+	test.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tmpl))
+	assert.Equal(t, int64(2), tmpl.Version)
+
+	uri = api.Router.GetRoute("POST", api.postWorkflowTemplateBulkHandler, map[string]string{"id": fmt.Sprintf("%d", tmpl.ID)})
+	req = assets.NewAuthentifiedRequest(t, u, pass, "POST", uri, nil)
+	rec = httptest.NewRecorder()
+	api.Router.Mux.ServeHTTP(rec, req)
+	assert.Equal(t, 200, rec.Code)
+	var results []sdk.WorkflowTemplateBulkResult
+	test.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+	if assert.Len(t, results, 1) {
+		assert.Empty(t, results[0].Error)
+	}
+
+	instances, err = workflowtemplate.LoadInstances(db, tmpl.ID)
+	test.NoError(t, err)
+	assert.Equal(t, int64(2), instances[0].WorkflowTemplateVersion)
+}
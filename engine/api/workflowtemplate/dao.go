@@ -0,0 +1,247 @@
+package workflowtemplate
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/go-gorp/gorp"
+
+	"github.com/ovh/cds/engine/api/database/gorpmapping"
+	"github.com/ovh/cds/sdk"
+)
+
+const templateFields = `workflow_template.id, workflow_template.group_id, workflow_template.name, workflow_template.description,
+	workflow_template.version, workflow_template.updated, workflow_template.parameters, workflow_template.workflow,
+	workflow_template.pipelines, workflow_template.applications, workflow_template.environments, "group".name`
+
+const instanceFields = `workflow_template_instance.id, workflow_template_instance.workflow_template_id,
+	workflow_template_instance.workflow_template_version, workflow_template_instance.project_id, project.projectkey,
+	workflow_template_instance.workflow_id, workflow_template_instance.request`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(s scanner) (*sdk.WorkflowTemplate, error) {
+	var t sdk.WorkflowTemplate
+	var description, workflow, parameters, pipelines, applications, environments sql.NullString
+	var groupName string
+	if err := s.Scan(&t.ID, &t.GroupID, &t.Name, &description, &t.Version, &t.Updated, &parameters, &workflow,
+		&pipelines, &applications, &environments, &groupName); err != nil {
+		return nil, err
+	}
+	t.Description = description.String
+	t.Workflow = workflow.String
+	t.Group = &sdk.Group{ID: t.GroupID, Name: groupName}
+	if err := gorpmapping.JSONNullString(parameters, &t.Parameters); err != nil {
+		return nil, sdk.WrapError(err, "scanTemplate> Unable to unmarshal parameters")
+	}
+	if err := gorpmapping.JSONNullString(pipelines, &t.Pipelines); err != nil {
+		return nil, sdk.WrapError(err, "scanTemplate> Unable to unmarshal pipelines")
+	}
+	if err := gorpmapping.JSONNullString(applications, &t.Applications); err != nil {
+		return nil, sdk.WrapError(err, "scanTemplate> Unable to unmarshal applications")
+	}
+	if err := gorpmapping.JSONNullString(environments, &t.Environments); err != nil {
+		return nil, sdk.WrapError(err, "scanTemplate> Unable to unmarshal environments")
+	}
+	return &t, nil
+}
+
+// LoadAll loads all the workflow templates
+func LoadAll(db gorp.SqlExecutor) ([]sdk.WorkflowTemplate, error) {
+	query := `SELECT ` + templateFields + ` FROM workflow_template
+		JOIN "group" ON "group".id = workflow_template.group_id
+		ORDER BY "group".name, workflow_template.name`
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadAll> Unable to load workflow templates")
+	}
+	defer rows.Close()
+
+	templates := []sdk.WorkflowTemplate{}
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, sdk.WrapError(err, "LoadAll> Unable to scan workflow template")
+		}
+		templates = append(templates, *t)
+	}
+	return templates, nil
+}
+
+// LoadByID loads a workflow template
+func LoadByID(db gorp.SqlExecutor, id int64) (*sdk.WorkflowTemplate, error) {
+	query := `SELECT ` + templateFields + ` FROM workflow_template
+		JOIN "group" ON "group".id = workflow_template.group_id
+		WHERE workflow_template.id = $1`
+	t, err := scanTemplate(db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sdk.ErrWorkflowTemplateNotFound
+		}
+		return nil, sdk.WrapError(err, "LoadByID> Unable to load workflow template %d", id)
+	}
+	return t, nil
+}
+
+func marshalSources(t *sdk.WorkflowTemplate) ([]interface{}, error) {
+	values := make([]interface{}, 0, 4)
+	for _, v := range []interface{}{t.Parameters, t.Pipelines, t.Applications, t.Environments} {
+		s, err := gorpmapping.JSONToNullString(v)
+		if err != nil {
+			return nil, sdk.WrapError(err, "marshalSources> Unable to marshal workflow template %s", t.Name)
+		}
+		values = append(values, s)
+	}
+	return values, nil
+}
+
+// Insert inserts a workflow template at its first version
+func Insert(db gorp.SqlExecutor, t *sdk.WorkflowTemplate) error {
+	values, err := marshalSources(t)
+	if err != nil {
+		return err
+	}
+
+	t.Version = 1
+	t.Updated = time.Now()
+	query := `INSERT INTO workflow_template (group_id, name, description, version, updated, workflow, parameters, pipelines, applications, environments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+	args := append([]interface{}{t.GroupID, t.Name, t.Description, t.Version, t.Updated, t.Workflow}, values...)
+	if err := db.QueryRow(query, args...).Scan(&t.ID); err != nil {
+		return sdk.WrapError(err, "Insert> Unable to insert workflow template %s", t.Name)
+	}
+	return nil
+}
+
+// Update updates a workflow template and increments its version
+func Update(db gorp.SqlExecutor, t *sdk.WorkflowTemplate) error {
+	values, err := marshalSources(t)
+	if err != nil {
+		return err
+	}
+
+	t.Updated = time.Now()
+	query := `UPDATE workflow_template SET group_id = $2, name = $3, description = $4, version = version + 1, updated = $5,
+		workflow = $6, parameters = $7, pipelines = $8, applications = $9, environments = $10
+		WHERE id = $1 RETURNING version`
+	args := append([]interface{}{t.ID, t.GroupID, t.Name, t.Description, t.Updated, t.Workflow}, values...)
+	if err := db.QueryRow(query, args...).Scan(&t.Version); err != nil {
+		return sdk.WrapError(err, "Update> Unable to update workflow template %d", t.ID)
+	}
+	return nil
+}
+
+// Delete deletes a workflow template with its instances, the generated workflows are kept
+func Delete(db gorp.SqlExecutor, id int64) error {
+	if _, err := db.Exec("DELETE FROM workflow_template WHERE id = $1", id); err != nil {
+		return sdk.WrapError(err, "Delete> Unable to delete workflow template %d", id)
+	}
+	return nil
+}
+
+func scanInstance(s scanner) (*sdk.WorkflowTemplateInstance, error) {
+	var i sdk.WorkflowTemplateInstance
+	var workflowID sql.NullInt64
+	var request sql.NullString
+	if err := s.Scan(&i.ID, &i.WorkflowTemplateID, &i.WorkflowTemplateVersion, &i.ProjectID, &i.ProjectKey, &workflowID, &request); err != nil {
+		return nil, err
+	}
+	if workflowID.Valid {
+		i.WorkflowID = &workflowID.Int64
+	}
+	if err := gorpmapping.JSONNullString(request, &i.Request); err != nil {
+		return nil, sdk.WrapError(err, "scanInstance> Unable to unmarshal request")
+	}
+	return &i, nil
+}
+
+func loadInstances(db gorp.SqlExecutor, query string, args ...interface{}) ([]sdk.WorkflowTemplateInstance, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	instances := []sdk.WorkflowTemplateInstance{}
+	for rows.Next() {
+		i, err := scanInstance(rows)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, *i)
+	}
+	return instances, nil
+}
+
+// LoadInstances loads the instances of a workflow template
+func LoadInstances(db gorp.SqlExecutor, templateID int64) ([]sdk.WorkflowTemplateInstance, error) {
+	query := `SELECT ` + instanceFields + ` FROM workflow_template_instance
+		JOIN project ON project.id = workflow_template_instance.project_id
+		WHERE workflow_template_instance.workflow_template_id = $1
+		ORDER BY project.projectkey, workflow_template_instance.id`
+	instances, err := loadInstances(db, query, templateID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadInstances> Unable to load instances of workflow template %d", templateID)
+	}
+	return instances, nil
+}
+
+// LoadOutdatedInstances loads the instances of a workflow template generated by a previous version
+func LoadOutdatedInstances(db gorp.SqlExecutor, t *sdk.WorkflowTemplate) ([]sdk.WorkflowTemplateInstance, error) {
+	query := `SELECT ` + instanceFields + ` FROM workflow_template_instance
+		JOIN project ON project.id = workflow_template_instance.project_id
+		WHERE workflow_template_instance.workflow_template_id = $1
+		AND workflow_template_instance.workflow_template_version < $2
+		ORDER BY project.projectkey, workflow_template_instance.id`
+	instances, err := loadInstances(db, query, t.ID, t.Version)
+	if err != nil {
+		return nil, sdk.WrapError(err, "LoadOutdatedInstances> Unable to load instances of workflow template %d", t.ID)
+	}
+	return instances, nil
+}
+
+// LoadInstanceByWorkflowName loads the instance of a workflow template which generated a workflow of a project
+func LoadInstanceByWorkflowName(db gorp.SqlExecutor, templateID, projectID int64, workflowName string) (*sdk.WorkflowTemplateInstance, error) {
+	query := `SELECT ` + instanceFields + ` FROM workflow_template_instance
+		JOIN project ON project.id = workflow_template_instance.project_id
+		WHERE workflow_template_instance.workflow_template_id = $1
+		AND workflow_template_instance.project_id = $2
+		AND workflow_template_instance.request->>'workflow_name' = $3`
+	i, err := scanInstance(db.QueryRow(query, templateID, projectID, workflowName))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, sdk.WrapError(err, "LoadInstanceByWorkflowName> Unable to load instance of workflow template %d", templateID)
+	}
+	return i, nil
+}
+
+// InsertInstance inserts an instance of a workflow template
+func InsertInstance(db gorp.SqlExecutor, i *sdk.WorkflowTemplateInstance) error {
+	request, err := gorpmapping.JSONToNullString(i.Request)
+	if err != nil {
+		return sdk.WrapError(err, "InsertInstance> Unable to marshal request")
+	}
+	query := `INSERT INTO workflow_template_instance (workflow_template_id, workflow_template_version, project_id, workflow_id, request)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	if err := db.QueryRow(query, i.WorkflowTemplateID, i.WorkflowTemplateVersion, i.ProjectID, i.WorkflowID, request).Scan(&i.ID); err != nil {
+		return sdk.WrapError(err, "InsertInstance> Unable to insert instance of workflow template %d", i.WorkflowTemplateID)
+	}
+	return nil
+}
+
+// UpdateInstance updates the version, the workflow and the request of an instance of a workflow template
+func UpdateInstance(db gorp.SqlExecutor, i *sdk.WorkflowTemplateInstance) error {
+	request, err := gorpmapping.JSONToNullString(i.Request)
+	if err != nil {
+		return sdk.WrapError(err, "UpdateInstance> Unable to marshal request")
+	}
+	query := `UPDATE workflow_template_instance SET workflow_template_version = $2, workflow_id = $3, request = $4 WHERE id = $1`
+	if _, err := db.Exec(query, i.ID, i.WorkflowTemplateVersion, i.WorkflowID, request); err != nil {
+		return sdk.WrapError(err, "UpdateInstance> Unable to update instance %d", i.ID)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package workflowtemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/exportentities"
+)
+
+// Result contains the entities generated by a workflow template
+type Result struct {
+	Workflow     exportentities.Workflow
+	Pipelines    []exportentities.PipelineV1
+	Applications []exportentities.Application
+	Environments []exportentities.Environment
+}
+
+// funcs are the functions available in the templates. quote writes a value as a yaml scalar,
+// so that a parameter is inserted as a single value whatever its content: JSON scalars are valid yaml scalars
+var funcs = template.FuncMap{
+	"quote": func(v interface{}) (string, error) {
+		btes, err := json.Marshal(v)
+		return string(btes), err
+	},
+}
+
+func execute(name, src string, data map[string]interface{}, out interface{}) error {
+	tmpl, err := template.New(name).Delims("[[", "]]").Option("missingkey=error").Funcs(funcs).Parse(src)
+	if err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("invalid template %s: %v", name, err))
+	}
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("unable to execute template %s: %v", name, err))
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), out); err != nil {
+		return sdk.NewError(sdk.ErrWrongRequest, fmt.Errorf("invalid yaml generated by template %s: %v", name, err))
+	}
+	return nil
+}
+
+// Execute checks the request against the parameters of the template, and generates the entities
+func Execute(t *sdk.WorkflowTemplate, r sdk.WorkflowTemplateRequest) (*Result, error) {
+	params, err := t.CheckParameters(r)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{
+		"name":   r.WorkflowName,
+		"params": params,
+	}
+
+	res := new(Result)
+	if err := execute("workflow", t.Workflow, data, &res.Workflow); err != nil {
+		return nil, err
+	}
+	res.Workflow.Name = r.WorkflowName
+
+	for i, src := range t.Pipelines {
+		var p exportentities.PipelineV1
+		if err := execute(fmt.Sprintf("pipeline %d", i+1), src, data, &p); err != nil {
+			return nil, err
+		}
+		res.Pipelines = append(res.Pipelines, p)
+	}
+	for i, src := range t.Applications {
+		var a exportentities.Application
+		if err := execute(fmt.Sprintf("application %d", i+1), src, data, &a); err != nil {
+			return nil, err
+		}
+		res.Applications = append(res.Applications, a)
+	}
+	for i, src := range t.Environments {
+		var e exportentities.Environment
+		if err := execute(fmt.Sprintf("environment %d", i+1), src, data, &e); err != nil {
+			return nil, err
+		}
+		res.Environments = append(res.Environments, e)
+	}
+	return res, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, v interface{}) error {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return sdk.WrapError(err, "writeTarEntry> Unable to marshal %s", name)
+	}
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(b)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return sdk.WrapError(err, "writeTarEntry> Unable to write header %+v", hdr)
+	}
+	if _, err := tw.Write(b); err != nil {
+		return sdk.WrapError(err, "writeTarEntry> Unable to write %s", name)
+	}
+	return nil
+}
+
+// Tar writes the generated entities as the files pushed by workflow as code
+func (r *Result) Tar(w io.Writer) error {
+	tw := tar.NewWriter(w)
+	if err := writeTarEntry(tw, fmt.Sprintf("%s.yml", r.Workflow.Name), r.Workflow); err != nil {
+		tw.Close()
+		return err
+	}
+	for _, p := range r.Pipelines {
+		if err := writeTarEntry(tw, fmt.Sprintf("%s.pip.yml", p.Name), p); err != nil {
+			tw.Close()
+			return err
+		}
+	}
+	for _, a := range r.Applications {
+		if err := writeTarEntry(tw, fmt.Sprintf("%s.app.yml", a.Name), a); err != nil {
+			tw.Close()
+			return err
+		}
+	}
+	for _, e := range r.Environments {
+		if err := writeTarEntry(tw, fmt.Sprintf("%s.env.yml", e.Name), e); err != nil {
+			tw.Close()
+			return err
+		}
+	}
+	return sdk.WrapError(tw.Close(), "Tar> Unable to close tar writer")
+}
+
+// Conflicts returns the workflow, pipelines, applications and environments of the project which would be overwritten
+// by the generated entities. The entities used by the owned workflow, generated by a previous apply, are not conflicts
+func (r *Result) Conflicts(proj *sdk.Project, owned *sdk.Workflow) []string {
+	existing := map[string]bool{}
+	for _, w := range proj.WorkflowNames {
+		existing["workflow "+w.Name] = true
+	}
+	for _, p := range proj.Pipelines {
+		existing["pipeline "+p.Name] = true
+	}
+	for _, a := range proj.Applications {
+		existing["application "+a.Name] = true
+	}
+	for _, e := range proj.Environments {
+		existing["environment "+e.Name] = true
+	}
+
+	// The entities of the workflow of an instance of the template are overwritten when the instance is applied again
+	if owned != nil {
+		delete(existing, "workflow "+owned.Name)
+		for _, p := range owned.GetPipelines() {
+			delete(existing, "pipeline "+p.Name)
+		}
+		for _, a := range owned.GetApplications() {
+			delete(existing, "application "+a.Name)
+		}
+		for _, e := range owned.GetEnvironments() {
+			delete(existing, "environment "+e.Name)
+		}
+	}
+
+	generated := []string{"workflow " + r.Workflow.Name}
+	for _, p := range r.Pipelines {
+		generated = append(generated, "pipeline "+p.Name)
+	}
+	for _, a := range r.Applications {
+		generated = append(generated, "application "+a.Name)
+	}
+	for _, e := range r.Environments {
+		generated = append(generated, "environment "+e.Name)
+	}
+
+	var res []string
+	for _, g := range generated {
+		if existing[g] {
+			res = append(res, g)
+		}
+	}
+	return res
+}
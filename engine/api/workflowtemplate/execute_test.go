@@ -0,0 +1,118 @@
+package workflowtemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+func TestExecute(t *testing.T) {
+	tmpl := &sdk.WorkflowTemplate{
+		Name: "go-service",
+		Parameters: []sdk.WorkflowTemplateParameter{
+			{Key: "repo", Type: sdk.WorkflowTemplateParameterTypeString, Required: true},
+			{Key: "with-deploy", Type: sdk.WorkflowTemplateParameterTypeBoolean},
+		},
+		Workflow: `name: will-be-overridden
+version: v1.0
+workflow:
+  build:
+    pipeline: [[.name]]-build
+    application: [[.name]]
+[[- if index .params "with-deploy"]]
+  deploy:
+    depends_on:
+    - build
+    pipeline: [[.name]]-build
+    application: [[.name]]
+[[- end]]
+`,
+		Pipelines: []string{`version: v1.0
+name: [[.name]]-build
+jobs:
+- job: build
+  steps:
+  - script: make VERSION={{.cds.version}}
+`},
+		Applications: []string{`version: v1.0
+name: [[.name]]
+repo: [[quote .params.repo]]
+`},
+	}
+
+	res, err := Execute(tmpl, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds", "with-deploy": "true"},
+	})
+	test.NoError(t, err)
+	assert.Equal(t, "my-service", res.Workflow.Name)
+	assert.Len(t, res.Workflow.Workflow, 2)
+	assert.Equal(t, "my-service-build", res.Pipelines[0].Name)
+	assert.Equal(t, "make VERSION={{.cds.version}}", res.Pipelines[0].Jobs[0].Steps[0]["script"])
+	assert.Equal(t, "ovh/cds", res.Applications[0].RepositoryName)
+
+	buf := new(bytes.Buffer)
+	test.NoError(t, res.Tar(buf))
+	tr := tar.NewReader(buf)
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		test.NoError(t, err)
+		files = append(files, hdr.Name)
+	}
+	assert.Equal(t, []string{"my-service.yml", "my-service-build.pip.yml", "my-service.app.yml"}, files)
+
+	proj := &sdk.Project{
+		WorkflowNames: []sdk.IDName{{Name: "other-service"}},
+		Pipelines:     []sdk.Pipeline{{Name: "my-service-build"}},
+		Applications:  []sdk.Application{{Name: "other-service"}},
+	}
+	assert.Equal(t, []string{"pipeline my-service-build"}, res.Conflicts(proj, nil))
+	proj.WorkflowNames = append(proj.WorkflowNames, sdk.IDName{Name: "my-service"})
+	assert.Equal(t, []string{"workflow my-service", "pipeline my-service-build"}, res.Conflicts(proj, nil))
+
+	// The entities of the workflow of the instance are not conflicts
+	owned := &sdk.Workflow{
+		Name:      "my-service",
+		Root:      &sdk.WorkflowNode{},
+		Pipelines: map[int64]sdk.Pipeline{1: {Name: "my-service-build"}},
+	}
+	assert.Empty(t, res.Conflicts(proj, owned))
+	owned.Pipelines = map[int64]sdk.Pipeline{1: {Name: "other-build"}}
+	assert.Equal(t, []string{"pipeline my-service-build"}, res.Conflicts(proj, owned))
+
+	res, err = Execute(tmpl, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds"},
+	})
+	test.NoError(t, err)
+	assert.Len(t, res.Workflow.Workflow, 1)
+
+	// A quoted value stays a single yaml value
+	res, err = Execute(tmpl, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds, vcs_ssh_key: proj-admin # \"'"},
+	})
+	test.NoError(t, err)
+	assert.Equal(t, "ovh/cds, vcs_ssh_key: proj-admin # \"'", res.Applications[0].RepositoryName)
+	assert.Empty(t, res.Applications[0].VCSSSHKey)
+
+	_, err = Execute(tmpl, sdk.WorkflowTemplateRequest{WorkflowName: "my-service"})
+	assert.Error(t, err)
+
+	tmpl.Pipelines[0] = "name: [[.params.unknown]]"
+	_, err = Execute(tmpl, sdk.WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"strconv"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+// FreeResources returns the allocatable resources of each schedulable node minus the requests of its pods.
+// Without ClusterCapacity, the resources are not limited and only maxWorker is checked
+func (h *HatcheryKubernetes) FreeResources() ([]hatchery.Resources, error) {
+	if !h.Config.ClusterCapacity {
+		return []hatchery.Resources{{Memory: hatchery.Unlimited, CPU: hatchery.Unlimited, Instances: hatchery.Unlimited}}, nil
+	}
+	nodes, err := h.k8sClient.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, sdk.WrapError(err, "FreeResources> Cannot list nodes")
+	}
+	pods, err := h.k8sClient.CoreV1().Pods("").List(metav1.ListOptions{FieldSelector: "status.phase!=Succeeded,status.phase!=Failed"})
+	if err != nil {
+		return nil, sdk.WrapError(err, "FreeResources> Cannot list pods")
+	}
+	return nodesFreeResources(nodes.Items, pods.Items), nil
+}
+
+func nodesFreeResources(nodes []apiv1.Node, pods []apiv1.Pod) []hatchery.Resources {
+	requested := map[string]apiv1.ResourceList{}
+	nbPods := map[string]int{}
+	for _, p := range pods {
+		if p.Spec.NodeName == "" {
+			continue
+		}
+		nbPods[p.Spec.NodeName]++
+		r, ok := requested[p.Spec.NodeName]
+		if !ok {
+			r = apiv1.ResourceList{}
+			requested[p.Spec.NodeName] = r
+		}
+		for _, c := range p.Spec.Containers {
+			for name, q := range c.Resources.Requests {
+				sum := r[name]
+				sum.Add(q)
+				r[name] = sum
+			}
+		}
+	}
+
+	res := make([]hatchery.Resources, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Spec.Unschedulable || !isNodeReady(n) {
+			continue
+		}
+		alloc := n.Status.Allocatable
+		req := requested[n.Name]
+		free := hatchery.Resources{
+			Memory:    (alloc.Memory().Value() - req.Memory().Value()) / 1024 / 1024,
+			CPU:       float64(alloc.Cpu().MilliValue()-req.Cpu().MilliValue()) / 1000,
+			Instances: int(alloc.Pods().Value()) - nbPods[n.Name],
+		}
+		if free.Memory < 0 {
+			free.Memory = 0
+		}
+		if free.CPU < 0 {
+			free.CPU = 0
+		}
+		if free.Instances < 0 {
+			free.Instances = 0
+		}
+		res = append(res, free)
+	}
+	return res
+}
+
+func isNodeReady(n apiv1.Node) bool {
+	for _, c := range n.Status.Conditions {
+		if c.Type == apiv1.NodeReady {
+			return c.Status == apiv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// NeededResources returns the memory requested by the worker container and the service containers of the pod,
+// and the cpus requested by the worker container
+func (h *HatcheryKubernetes) NeededResources(model *sdk.Model, requirements []sdk.Requirement) hatchery.Resources {
+	need := hatchery.Resources{Memory: int64(h.Config.DefaultMemory), Instances: 1}
+	if res, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements); err == nil {
+		need.CPU = res.CPUs
+		if res.Memory != 0 {
+			need.Memory = res.Memory
+		}
+	}
+	var services int64
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.MemoryRequirement:
+			if m, err := strconv.ParseInt(r.Value, 10, 64); err == nil {
+				need.Memory = m
+			}
+		case sdk.ServiceRequirement:
+			for _, e := range strings.Split(r.Value, " ")[1:] {
+				if !strings.HasPrefix(e, "CDS_SERVICE_MEMORY=") {
+					continue
+				}
+				if q, err := resource.ParseQuantity(strings.TrimPrefix(e, "CDS_SERVICE_MEMORY=")); err == nil {
+					services += q.Value() / 1024 / 1024
+				}
+			}
+		}
+	}
+	need.Memory += services
+	return need
+}
@@ -0,0 +1,87 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func testNode(name string, ready, unschedulable bool) apiv1.Node {
+	status := apiv1.ConditionFalse
+	if ready {
+		status = apiv1.ConditionTrue
+	}
+	return apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apiv1.NodeSpec{Unschedulable: unschedulable},
+		Status: apiv1.NodeStatus{
+			Allocatable: apiv1.ResourceList{
+				apiv1.ResourceMemory: resource.MustParse("4Gi"),
+				apiv1.ResourceCPU:    resource.MustParse("2"),
+				apiv1.ResourcePods:   resource.MustParse("10"),
+			},
+			Conditions: []apiv1.NodeCondition{{Type: apiv1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func testPod(node, memory, cpu string) apiv1.Pod {
+	return apiv1.Pod{
+		Spec: apiv1.PodSpec{
+			NodeName: node,
+			Containers: []apiv1.Container{{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceMemory: resource.MustParse(memory),
+						apiv1.ResourceCPU:    resource.MustParse(cpu),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func Test_nodesFreeResources(t *testing.T) {
+	nodes := []apiv1.Node{
+		testNode("node-1", true, false),
+		testNode("node-2", true, true),
+		testNode("node-3", false, false),
+	}
+	pods := []apiv1.Pod{
+		testPod("node-1", "1Gi", "500m"),
+		testPod("node-1", "512Mi", "250m"),
+		testPod("node-2", "1Gi", "1"),
+		testPod("", "1Gi", "1"),
+	}
+	res := nodesFreeResources(nodes, pods)
+	assert.Equal(t, []hatchery.Resources{{Memory: 2560, CPU: 1.25, Instances: 8}}, res)
+}
+
+func TestHatcheryKubernetes_FreeResourcesWithoutClusterCapacity(t *testing.T) {
+	h := &HatcheryKubernetes{}
+	res, err := h.FreeResources()
+	assert.NoError(t, err)
+	assert.Equal(t, []hatchery.Resources{{Memory: hatchery.Unlimited, CPU: hatchery.Unlimited, Instances: hatchery.Unlimited}}, res)
+}
+
+func TestHatcheryKubernetes_NeededResources(t *testing.T) {
+	h := &HatcheryKubernetes{}
+	h.Config.DefaultMemory = 1024
+
+	reqs := []sdk.Requirement{
+		{Type: sdk.MemoryRequirement, Value: "2048"},
+		{Type: sdk.ServiceRequirement, Name: "pg", Value: "postgres:9.5 CDS_SERVICE_MEMORY=512Mi"},
+		{Type: sdk.ServiceRequirement, Name: "redis", Value: "redis"},
+	}
+	assert.Equal(t, hatchery.Resources{Memory: 2560, Instances: 1}, h.NeededResources(&sdk.Model{}, reqs))
+
+	h.Config.ResourceClasses = map[string]hatchery.ResourceClass{"large": {CPUs: 4, Memory: 8192}}
+	reqs = []sdk.Requirement{{Type: sdk.ResourceClassRequirement, Value: "large"}}
+	assert.Equal(t, hatchery.Resources{Memory: 8192, CPU: 4, Instances: 1}, h.NeededResources(&sdk.Model{}, reqs))
+}
@@ -12,7 +12,6 @@ import (
 
 	"github.com/gorilla/mux"
 	apiv1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -225,8 +224,16 @@ func (*HatcheryKubernetes) ModelType() string {
 }
 
 // CanSpawn return wether or not hatchery can spawn model.
-// requirements are not supported
+// service, memory, cpu, resource class and volume requirements are turned into sidecar containers, resource limits and volumes of the pod
 func (h *HatcheryKubernetes) CanSpawn(model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	if _, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements); err != nil {
+		log.Debug("CanSpawn> Job %d: %v", jobID, err)
+		return false
+	}
+	if _, _, err := volumesFromRequirements(h.hatch.IsSharedInfra, requirements); err != nil {
+		log.Debug("CanSpawn> Job %d has an invalid volume requirement: %v", jobID, err)
+		return false
+	}
 	return true
 }
 
@@ -250,7 +257,15 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		}
 	}
 
+	workerRes, errRes := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, spawnArgs.Requirements)
+	if errRes != nil {
+		return "", sdk.WrapError(errRes, "spawnKubernetesDockerWorker> %s unable to compute resource class", logJob)
+	}
+
 	memory := int64(h.Config.DefaultMemory)
+	if workerRes.Memory != 0 {
+		memory = workerRes.Memory
+	}
 	for _, r := range spawnArgs.Requirements {
 		if r.Type == sdk.MemoryRequirement {
 			var err error
@@ -278,6 +293,7 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		GrpcAPI:           h.Configuration().API.GRPC.URL,
 		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
 	}
+	udataParam.ApplyReuse(spawnArgs.Model)
 
 	if spawnArgs.IsWorkflowJob {
 		udataParam.WorkflowJobID = spawnArgs.JobID
@@ -313,6 +329,14 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	if udataParam.MaxJobs > 1 {
+		envsWm["CDS_MAX_JOBS"] = fmt.Sprintf("%d", udataParam.MaxJobs)
+		envsWm["CDS_TTL"] = fmt.Sprintf("%d", udataParam.TTL)
+	}
+
+	if workerRes.Class != "" {
+		envsWm["CDS_RESOURCE_CLASS"] = workerRes.Class
+	}
 
 	if spawnArgs.JobID > 0 {
 		if spawnArgs.IsWorkflowJob {
@@ -343,6 +367,16 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 		i++
 	}
 
+	resources, errR := workerResources(memory, workerRes.CPUs)
+	if errR != nil {
+		return "", sdk.WrapError(errR, "spawnKubernetesDockerWorker> %s unable to compute resources", logJob)
+	}
+
+	volumes, volumeMounts, errV := volumesFromRequirements(h.hatch.IsSharedInfra, spawnArgs.Requirements)
+	if errV != nil {
+		return "", sdk.WrapError(errV, "spawnKubernetesDockerWorker> %s unable to compute volumes", logJob)
+	}
+
 	var gracePeriodSecs int64
 	podSchema := apiv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -362,17 +396,16 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 					Name:    name,
 					Image:   spawnArgs.Model.ModelDocker.Image,
 					Env:     envs,
-					Command: strings.Fields(spawnArgs.Model.ModelDocker.Shell),
-					Args:    []string{cmd},
-					Resources: apiv1.ResourceRequirements{
-						Requests: apiv1.ResourceList{
-							apiv1.ResourceMemory: resource.MustParse(fmt.Sprintf("%d", memory)),
-						},
-					},
+					Command:      strings.Fields(spawnArgs.Model.ModelDocker.Shell),
+					Args:         []string{cmd},
+					Resources:    resources,
+					VolumeMounts: volumeMounts,
 				},
 			},
+			Volumes: volumes,
 		},
 	}
+	applyPodTemplate(&podSchema.Spec, spawnArgs.Model.ModelDocker.PodTemplate)
 
 	var services []sdk.Requirement
 	for _, req := range spawnArgs.Requirements {
@@ -388,33 +421,9 @@ func (h *HatcheryKubernetes) SpawnWorker(ctx context.Context, spawnArgs hatchery
 	}
 
 	for i, serv := range services {
-		//name= <alias> => the name of the host put in /etc/hosts of the worker
-		//value= "postgres:latest env_1=blabla env_2=blabla"" => we can add env variables in requirement name
-		tuple := strings.Split(serv.Value, " ")
-		img := tuple[0]
-
-		servContainer := apiv1.Container{
-			Name:  fmt.Sprintf("service-%d-%s", serv.ID, serv.Name),
-			Image: img,
-		}
-
-		if len(tuple) > 1 {
-			servContainer.Env = make([]apiv1.EnvVar, 0, len(tuple)-1)
-			for _, servEnv := range tuple[1:] {
-				envSplitted := strings.Split(servEnv, "=")
-				if len(envSplitted) < 2 {
-					continue
-				}
-				if envSplitted[0] == "CDS_SERVICE_MEMORY" {
-					servContainer.Resources = apiv1.ResourceRequirements{
-						Requests: apiv1.ResourceList{
-							apiv1.ResourceMemory: resource.MustParse(envSplitted[1]),
-						},
-					}
-					continue
-				}
-				servContainer.Env = append(servContainer.Env, apiv1.EnvVar{Name: envSplitted[0], Value: envSplitted[1]})
-			}
+		servContainer, errS := serviceContainer(serv)
+		if errS != nil {
+			return "", sdk.WrapError(errS, "spawnKubernetesDockerWorker> %s unable to compute service %s", logJob, serv.Name)
 		}
 		podSchema.ObjectMeta.Labels[LABEL_SERVICE_JOB_ID] = fmt.Sprintf("%d", spawnArgs.JobID)
 		podSchema.Spec.Containers = append(podSchema.Spec.Containers, servContainer)
@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// workerResources returns the requests and limits of a container, memory is in MB.
+// The cpu quota is set only if cpus is greater than 0.
+func workerResources(memory int64, cpus float64) (apiv1.ResourceRequirements, error) {
+	q, err := resource.ParseQuantity(fmt.Sprintf("%dMi", memory))
+	if err != nil {
+		return apiv1.ResourceRequirements{}, err
+	}
+	r := apiv1.ResourceRequirements{
+		Requests: apiv1.ResourceList{apiv1.ResourceMemory: q},
+		Limits:   apiv1.ResourceList{apiv1.ResourceMemory: q},
+	}
+	if cpus > 0 {
+		c := resource.NewMilliQuantity(int64(cpus*1000), resource.DecimalSI)
+		r.Requests[apiv1.ResourceCPU] = *c
+		r.Limits[apiv1.ResourceCPU] = *c
+	}
+	return r, nil
+}
+
+// volumesFromRequirements computes the volumes of the pod and the mounts of the worker container from the volume requirements.
+// Volume requirement value is a docker mount option, type bind is a host path and type volume is a persistent volume claim.
+// example: type=bind,source=/hostDir/sourceDir,destination=/dirInJob,readonly
+func volumesFromRequirements(isSharedInfra bool, requirements []sdk.Requirement) ([]apiv1.Volume, []apiv1.VolumeMount, error) {
+	var volumes []apiv1.Volume
+	var mounts []apiv1.VolumeMount
+	for _, r := range requirements {
+		if r.Type != sdk.VolumeRequirement {
+			continue
+		}
+		if isSharedInfra {
+			return nil, nil, fmt.Errorf("you could not use volume requirement '%s' with a 'shared.infra' hatchery. Please use you own hatchery or remove this requirement", r.Value)
+		}
+
+		opt := strings.Split(r.Value, " ")[0]
+		var mtype, source, destination string
+		var readonly bool
+		for _, o := range strings.Split(opt, ",") {
+			if strings.HasPrefix(o, "type=") {
+				mtype = strings.TrimPrefix(o, "type=")
+			} else if strings.HasPrefix(o, "source=") {
+				source = strings.TrimPrefix(o, "source=")
+			} else if strings.HasPrefix(o, "destination=") {
+				destination = strings.TrimPrefix(o, "destination=")
+			} else if o == "readonly" {
+				readonly = true
+			}
+		}
+		if mtype == "" || source == "" || destination == "" {
+			return nil, nil, fmt.Errorf("Invalid mount option - one arg is empty. Example:type=bind,source=/hostDir/sourceDir,destination=/dirInJob current:%s", opt)
+		}
+
+		name := fmt.Sprintf("volume-%d", len(volumes))
+		v := apiv1.Volume{Name: name}
+		switch mtype {
+		case "bind":
+			v.HostPath = &apiv1.HostPathVolumeSource{Path: source}
+		case "volume":
+			v.PersistentVolumeClaim = &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: source, ReadOnly: readonly}
+		default:
+			return nil, nil, fmt.Errorf("Invalid mount option - type %s is not supported, use bind or volume. current:%s", mtype, opt)
+		}
+		volumes = append(volumes, v)
+		mounts = append(mounts, apiv1.VolumeMount{Name: name, MountPath: destination, ReadOnly: readonly})
+	}
+	return volumes, mounts, nil
+}
+
+// serviceContainer computes the sidecar container of a service requirement
+// name= <alias> => the name of the host put in /etc/hosts of the worker
+// value= "postgres:latest env_1=blabla env_2=blabla" => we can add env variables in requirement name
+func serviceContainer(serv sdk.Requirement) (apiv1.Container, error) {
+	tuple := strings.Split(serv.Value, " ")
+	c := apiv1.Container{
+		Name:  fmt.Sprintf("service-%d-%s", serv.ID, serv.Name),
+		Image: tuple[0],
+	}
+
+	if len(tuple) > 1 {
+		c.Env = make([]apiv1.EnvVar, 0, len(tuple)-1)
+		for _, servEnv := range tuple[1:] {
+			envSplitted := strings.SplitN(servEnv, "=", 2)
+			if len(envSplitted) < 2 {
+				continue
+			}
+			if envSplitted[0] == "CDS_SERVICE_MEMORY" {
+				q, err := resource.ParseQuantity(envSplitted[1])
+				if err != nil {
+					return c, fmt.Errorf("Invalid CDS_SERVICE_MEMORY %s for service %s: %v", envSplitted[1], serv.Name, err)
+				}
+				c.Resources = apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{apiv1.ResourceMemory: q},
+					Limits:   apiv1.ResourceList{apiv1.ResourceMemory: q},
+				}
+				continue
+			}
+			c.Env = append(c.Env, apiv1.EnvVar{Name: envSplitted[0], Value: envSplitted[1]})
+		}
+	}
+	return c, nil
+}
+
+// applyPodTemplate sets the node selector, the tolerations and the service account of the pod template of the model
+func applyPodTemplate(spec *apiv1.PodSpec, tmpl *sdk.ModelPodTemplate) {
+	if tmpl == nil {
+		return
+	}
+	if len(tmpl.NodeSelector) > 0 {
+		spec.NodeSelector = make(map[string]string, len(tmpl.NodeSelector))
+		for k, v := range tmpl.NodeSelector {
+			spec.NodeSelector[k] = v
+		}
+	}
+	for _, t := range tmpl.Tolerations {
+		spec.Tolerations = append(spec.Tolerations, apiv1.Toleration{
+			Key:      t.Key,
+			Operator: apiv1.TolerationOperator(t.Operator),
+			Value:    t.Value,
+			Effect:   apiv1.TaintEffect(t.Effect),
+		})
+	}
+	if tmpl.ServiceAccountName != "" {
+		spec.ServiceAccountName = tmpl.ServiceAccountName
+	}
+}
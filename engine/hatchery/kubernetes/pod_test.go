@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_workerResources(t *testing.T) {
+	r, err := workerResources(1024, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1024*1024*1024), r.Limits.Memory().Value())
+	assert.Equal(t, int64(1024*1024*1024), r.Requests.Memory().Value())
+	assert.True(t, r.Requests.Cpu().IsZero())
+
+	r, err = workerResources(2048, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), r.Limits.Cpu().MilliValue())
+	assert.Equal(t, int64(1500), r.Requests.Cpu().MilliValue())
+}
+
+func Test_volumesFromRequirements(t *testing.T) {
+	reqs := []sdk.Requirement{
+		{Type: sdk.BinaryRequirement, Value: "git"},
+		{Type: sdk.VolumeRequirement, Value: "type=bind,source=/var/cache,destination=/cache,readonly"},
+		{Type: sdk.VolumeRequirement, Value: "type=volume,source=my-claim,destination=/data"},
+	}
+	volumes, mounts, err := volumesFromRequirements(false, reqs)
+	assert.NoError(t, err)
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "/var/cache", volumes[0].HostPath.Path)
+	assert.Equal(t, "my-claim", volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, apiv1.VolumeMount{Name: "volume-0", MountPath: "/cache", ReadOnly: true}, mounts[0])
+	assert.Equal(t, apiv1.VolumeMount{Name: "volume-1", MountPath: "/data"}, mounts[1])
+
+	_, _, err = volumesFromRequirements(true, reqs)
+	assert.Error(t, err)
+
+	_, _, err = volumesFromRequirements(false, []sdk.Requirement{{Type: sdk.VolumeRequirement, Value: "type=tmpfs,source=a,destination=/tmp"}})
+	assert.Error(t, err)
+}
+
+func Test_serviceContainer(t *testing.T) {
+	c, err := serviceContainer(sdk.Requirement{ID: 3, Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.6 POSTGRES_PASSWORD=a=b CDS_SERVICE_MEMORY=512Mi"})
+	assert.NoError(t, err)
+	assert.Equal(t, "service-3-pg", c.Name)
+	assert.Equal(t, "postgres:9.6", c.Image)
+	assert.Equal(t, []apiv1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "a=b"}}, c.Env)
+	assert.Equal(t, int64(512*1024*1024), c.Resources.Limits.Memory().Value())
+
+	_, err = serviceContainer(sdk.Requirement{Name: "pg", Type: sdk.ServiceRequirement, Value: "postgres:9.6 CDS_SERVICE_MEMORY=lots"})
+	assert.Error(t, err)
+}
+
+func Test_applyPodTemplate(t *testing.T) {
+	spec := apiv1.PodSpec{}
+	applyPodTemplate(&spec, nil)
+	assert.Equal(t, apiv1.PodSpec{}, spec)
+
+	applyPodTemplate(&spec, &sdk.ModelPodTemplate{
+		NodeSelector:       map[string]string{"pool": "ci"},
+		Tolerations:        []sdk.ModelPodToleration{{Key: "dedicated", Operator: "Equal", Value: "ci", Effect: "NoSchedule"}},
+		ServiceAccountName: "cds-worker",
+	})
+	assert.Equal(t, map[string]string{"pool": "ci"}, spec.NodeSelector)
+	assert.Equal(t, []apiv1.Toleration{{Key: "dedicated", Operator: apiv1.TolerationOpEqual, Value: "ci", Effect: apiv1.TaintEffectNoSchedule}}, spec.Tolerations)
+	assert.Equal(t, "cds-worker", spec.ServiceAccountName)
+}
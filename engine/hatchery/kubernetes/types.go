@@ -49,6 +49,10 @@ type HatcheryConfiguration struct {
 	KubernetesClientCertData string `mapstructure:"clientCertData" toml:"clientCertData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)"`
 	// KubernetesKeyData Client certificate data for tls kubernetes (optional if no tls needed)
 	KubernetesClientKeyData string `mapstructure:"clientKeyData" toml:"clientKeyData" default:"" commented:"true" comment:"Client certificate data (content, not path and not base64 encoded) for tls kubernetes (optional if no tls needed)"`
+	// ClusterCapacity checks the free resources of the nodes of the cluster before starting a worker
+	ClusterCapacity bool `mapstructure:"clusterCapacity" toml:"clusterCapacity" default:"false" commented:"true" comment:"Check the free resources of the nodes before starting a worker. The hatchery needs the permission to list the nodes and the pods of the whole cluster"`
+	// ResourceClasses are the cpus and the memory of the workers for each resource class requirement
+	ResourceClasses map[string]hatchery.ResourceClass `mapstructure:"resourceClasses" toml:"resourceClasses" commented:"true" comment:"CPU quota and memory (in Mo) of the workers for each resource class requirement. Example: [hatchery.kubernetes.resourceClasses.large] cpus=4.0 memory=8192"`
 }
 
 // HatcheryKubernetes implements HatcheryMode interface for local usage
@@ -7,6 +7,7 @@ import (
 	"html/template"
 	"os"
 	"os/exec"
+	"runtime"
 	"strings"
 	"time"
 
@@ -170,6 +171,12 @@ func (h *HatcheryLocal) CanSpawn(model *sdk.Model, jobID int64, requirements []s
 			return false
 		}
 	}
+	// resource classes are not supported, the cpu requirement is checked against the cpus of the host
+	res, err := hatchery.ComputeWorkerResources(nil, requirements)
+	if err != nil || res.CPUs > float64(runtime.NumCPU()) {
+		log.Debug("CanSpawn false for job %d: cpu or resource class requirement can't be honoured", jobID)
+		return false
+	}
 	log.Debug("CanSpawn true for job %d", jobID)
 	return true
 }
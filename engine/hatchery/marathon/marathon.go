@@ -186,6 +186,12 @@ func (h *HatcheryMarathon) CanSpawn(model *sdk.Model, jobID int64, requirements
 		}
 	}
 
+	//Resource class requirement are not supported
+	if _, err := hatchery.ComputeWorkerResources(nil, requirements); err != nil {
+		log.Debug("CanSpawn> Job %d: %v. Marathon can't spawn a worker for this job", jobID, err)
+		return false
+	}
+
 	deployments, errd := h.marathonClient.Deployments()
 	if errd != nil {
 		log.Info("CanSpawn> Error on h.marathonClient.Deployments() : %s", errd)
@@ -252,6 +258,7 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 		GrpcAPI:           h.Configuration().API.GRPC.URL,
 		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
 	}
+	udataParam.ApplyReuse(spawnArgs.Model)
 
 	if spawnArgs.JobID > 0 {
 		if spawnArgs.IsWorkflowJob {
@@ -299,6 +306,11 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 
 	mem := float64(memory * 110 / 100)
 
+	cpus := 0.5
+	if res, err := hatchery.ComputeWorkerResources(nil, spawnArgs.Requirements); err == nil && res.CPUs > 0 {
+		cpus = res.CPUs
+	}
+
 	if spawnArgs.Model.ModelDocker.Envs == nil {
 		spawnArgs.Model.ModelDocker.Envs = map[string]string{}
 	}
@@ -313,6 +325,10 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	if udataParam.MaxJobs > 1 {
+		envsWm["CDS_MAX_JOBS"] = fmt.Sprintf("%d", udataParam.MaxJobs)
+		envsWm["CDS_TTL"] = fmt.Sprintf("%d", udataParam.TTL)
+	}
 
 	if spawnArgs.JobID > 0 {
 		if spawnArgs.IsWorkflowJob {
@@ -348,7 +364,7 @@ func (h *HatcheryMarathon) SpawnWorker(ctx context.Context, spawnArgs hatchery.S
 			Type: "DOCKER",
 		},
 		Env:       &envsWm,
-		CPUs:      0.5,
+		CPUs:      cpus,
 		Instances: &instance,
 		Mem:       &mem,
 		Labels:    &h.marathonLabels,
@@ -0,0 +1,69 @@
+package openstack
+
+import (
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+// absoluteLimits are the compute quotas of the tenant and their usage, a negative max is unlimited
+type absoluteLimits struct {
+	MaxTotalRAMSize    int64 `json:"maxTotalRAMSize"`
+	TotalRAMUsed       int64 `json:"totalRAMUsed"`
+	MaxTotalCores      int   `json:"maxTotalCores"`
+	TotalCoresUsed     int   `json:"totalCoresUsed"`
+	MaxTotalInstances  int   `json:"maxTotalInstances"`
+	TotalInstancesUsed int   `json:"totalInstancesUsed"`
+}
+
+// FreeResources returns the free resources of the compute quotas of the tenant
+func (h *HatcheryOpenstack) FreeResources() ([]hatchery.Resources, error) {
+	var body struct {
+		Limits struct {
+			Absolute absoluteLimits `json:"absolute"`
+		} `json:"limits"`
+	}
+	if _, err := h.openstackClient.Get(h.openstackClient.ServiceURL("limits"), &body, nil); err != nil {
+		return nil, sdk.WrapError(err, "FreeResources> Cannot get compute limits")
+	}
+	return []hatchery.Resources{body.Limits.Absolute.free()}, nil
+}
+
+func (l absoluteLimits) free() hatchery.Resources {
+	r := hatchery.Resources{Memory: hatchery.Unlimited, CPU: hatchery.Unlimited, Instances: hatchery.Unlimited}
+	if l.MaxTotalRAMSize >= 0 {
+		r.Memory = l.MaxTotalRAMSize - l.TotalRAMUsed
+		if r.Memory < 0 {
+			r.Memory = 0
+		}
+	}
+	if l.MaxTotalCores >= 0 {
+		r.CPU = float64(l.MaxTotalCores - l.TotalCoresUsed)
+		if r.CPU < 0 {
+			r.CPU = 0
+		}
+	}
+	if l.MaxTotalInstances >= 0 {
+		r.Instances = l.MaxTotalInstances - l.TotalInstancesUsed
+		if r.Instances < 0 {
+			r.Instances = 0
+		}
+	}
+	return r
+}
+
+// NeededResources returns the memory and the vcpus of the flavor of the worker
+func (h *HatcheryOpenstack) NeededResources(model *sdk.Model, requirements []sdk.Requirement) hatchery.Resources {
+	need := hatchery.Resources{Instances: 1}
+	flavor, err := h.workerFlavor(model, requirements)
+	if err != nil {
+		flavor = model.ModelVirtualMachine.Flavor
+	}
+	for _, f := range h.flavors {
+		if f.Name == flavor {
+			need.Memory = int64(f.RAM)
+			need.CPU = float64(f.VCPUs)
+			break
+		}
+	}
+	return need
+}
@@ -170,16 +170,45 @@ func (*HatcheryOpenstack) ModelType() string {
 }
 
 // CanSpawn return wether or not hatchery can spawn model
-// requirements are not supported
+// service and memory requirements are not supported, cpu and resource class requirements select the flavor
 func (h *HatcheryOpenstack) CanSpawn(model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
 	for _, r := range requirements {
 		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
 			return false
 		}
 	}
+	if _, err := h.workerFlavor(model, requirements); err != nil {
+		log.Debug("CanSpawn> job %d: %v", jobID, err)
+		return false
+	}
 	return true
 }
 
+// workerFlavor returns the flavor of the resource class of a job, or the flavor of the model.
+// It returns an error if the flavor has less vcpus than the cpu requirement of the job.
+func (h *HatcheryOpenstack) workerFlavor(model *sdk.Model, requirements []sdk.Requirement) (string, error) {
+	res, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements)
+	if err != nil {
+		return "", err
+	}
+	flavor := model.ModelVirtualMachine.Flavor
+	if res.Flavor != "" {
+		flavor = res.Flavor
+	}
+	if res.CPUs > 0 {
+		for _, f := range h.flavors {
+			if f.Name == flavor {
+				if float64(f.VCPUs) < res.CPUs {
+					return "", fmt.Errorf("flavor %s has %d vcpus, %v required", flavor, f.VCPUs, res.CPUs)
+				}
+				return flavor, nil
+			}
+		}
+		return "", fmt.Errorf("flavor %s not found", flavor)
+	}
+	return flavor, nil
+}
+
 func (h *HatcheryOpenstack) main() {
 	serverListTick := time.NewTicker(10 * time.Second).C
 	killAwolServersTick := time.NewTicker(30 * time.Second).C
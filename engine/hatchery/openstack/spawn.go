@@ -49,7 +49,11 @@ func (h *HatcheryOpenstack) SpawnWorker(ctx context.Context, spawnArgs hatchery.
 	}
 
 	// Get flavor ID
-	flavorID, errf := h.flavorID(spawnArgs.Model.ModelVirtualMachine.Flavor)
+	flavor, errw := h.workerFlavor(&spawnArgs.Model, spawnArgs.Requirements)
+	if errw != nil {
+		return "", errw
+	}
+	flavorID, errf := h.flavorID(flavor)
 	if errf != nil {
 		return "", errf
 	}
@@ -100,6 +104,7 @@ func (h *HatcheryOpenstack) SpawnWorker(ctx context.Context, spawnArgs hatchery.
 		GrpcAPI:           h.Configuration().API.GRPC.URL,
 		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
 	}
+	udataParam.ApplyReuse(spawnArgs.Model)
 
 	if spawnArgs.IsWorkflowJob {
 		udataParam.WorkflowJobID = spawnArgs.JobID
@@ -120,7 +125,7 @@ func (h *HatcheryOpenstack) SpawnWorker(ctx context.Context, spawnArgs hatchery.
 		"worker":                     name,
 		"hatchery_name":              h.Hatchery().Name,
 		"register_only":              fmt.Sprintf("%t", spawnArgs.RegisterOnly),
-		"flavor":                     spawnArgs.Model.ModelVirtualMachine.Flavor,
+		"flavor":                     flavor,
 		"model":                      spawnArgs.Model.ModelVirtualMachine.Image,
 		"worker_model_id":            strconv.FormatInt(udataParam.Model, 10),
 		"worker_model_name":          spawnArgs.Model.Name,
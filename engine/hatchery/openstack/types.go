@@ -49,6 +49,9 @@ type HatcheryConfiguration struct {
 
 	// CreateImageTimeout max wait for create an openstack image (in seconds)
 	CreateImageTimeout int `mapstructure:"createImageTimeout" toml:"createImageTimeout" default:"180" commented:"false" comment:"max wait for create an openstack image (in seconds)"`
+
+	// ResourceClasses are the flavors of the workers for each resource class requirement
+	ResourceClasses map[string]hatchery.ResourceClass `mapstructure:"resourceClasses" toml:"resourceClasses" commented:"true" comment:"Flavor of the workers for each resource class requirement. Example: [hatchery.openstack.resourceClasses.large] flavor=\"b2-30\""`
 }
 
 // HatcheryOpenstack spawns instances of worker model with type 'ISO'
@@ -205,6 +205,15 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		memory = spawnArgs.Model.ModelDocker.Memory
 	}
 
+	//CPUs and memory of the resource class of the job
+	resources, errR := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, spawnArgs.Requirements)
+	if errR != nil {
+		return "", errR
+	}
+	if resources.Memory != 0 {
+		memory = resources.Memory
+	}
+
 	var network, networkAlias string
 	services := []string{}
 
@@ -233,22 +242,11 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 				tuple := strings.Split(r.Value, " ")
 				img := tuple[0]
 				env := []string{}
-				serviceMemory := int64(1024)
 				if len(tuple) > 1 {
 					env = append(env, tuple[1:]...)
 				}
 				//option for power user : set the service memory with CDS_SERVICE_MEMORY=1024
-				for _, e := range env {
-					if strings.HasPrefix(e, "CDS_SERVICE_MEMORY=") {
-						m := strings.Replace(e, "CDS_SERVICE_MEMORY=", "", -1)
-						i, err := strconv.Atoi(m)
-						if err != nil {
-							log.Warning("hatchery> swarm> SpawnWorker> Unable to parse service option %s : %s", e, err)
-							continue
-						}
-						serviceMemory = int64(i)
-					}
-				}
+				serviceMemory := parseServiceMemory(env)
 				serviceName := r.Name + "-" + name
 
 				//labels are used to make container cleanup easier. We "link" the service to its worker this way.
@@ -320,6 +318,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		GrpcAPI:           h.Configuration().API.GRPC.URL,
 		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
 	}
+	udataParam.ApplyReuse(spawnArgs.Model)
 
 	if spawnArgs.JobID > 0 {
 		if spawnArgs.IsWorkflowJob {
@@ -356,6 +355,14 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 	envsWm["CDS_HATCHERY_NAME"] = udataParam.HatcheryName
 	envsWm["CDS_FROM_WORKER_IMAGE"] = fmt.Sprintf("%v", udataParam.FromWorkerImage)
 	envsWm["CDS_INSECURE"] = fmt.Sprintf("%v", udataParam.HTTPInsecure)
+	if udataParam.MaxJobs > 1 {
+		envsWm["CDS_MAX_JOBS"] = fmt.Sprintf("%d", udataParam.MaxJobs)
+		envsWm["CDS_TTL"] = fmt.Sprintf("%d", udataParam.TTL)
+	}
+
+	if resources.Class != "" {
+		envsWm["CDS_RESOURCE_CLASS"] = resources.Class
+	}
 
 	if spawnArgs.JobID > 0 {
 		if spawnArgs.IsWorkflowJob {
@@ -394,6 +401,7 @@ func (h *HatcherySwarm) SpawnWorker(ctx context.Context, spawnArgs hatchery.Spaw
 		cmd:          cmds,
 		labels:       labels,
 		memory:       memory,
+		cpus:         resources.CPUs,
 		dockerOpts:   *dockerOpts,
 		entryPoint:   []string{},
 		env:          envs,
@@ -420,6 +428,11 @@ const (
 // CanSpawn checks if the model can be spawned by this hatchery
 // it checks on every docker engine is one of the docker has availability
 func (h *HatcherySwarm) CanSpawn(model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
+	if _, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements); err != nil {
+		log.Debug("hatchery> swarm> CanSpawn> job %d: %v", jobID, err)
+		return false
+	}
+
 	for dockerName, dockerClient := range h.dockerClients {
 		//List all containers to check if we can spawn a new one
 		cs, errList := h.getContainers(dockerClient, types.ContainerListOptions{All: true})
@@ -0,0 +1,130 @@
+package swarm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	types "github.com/docker/docker/api/types"
+	context "golang.org/x/net/context"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+	"github.com/ovh/cds/sdk/log"
+)
+
+const (
+	// labelMemory is the label of the containers containing their memory in MB
+	labelMemory = "memory"
+	// labelCPUs is the label of the containers containing their cpu quota
+	labelCPUs = "cpus"
+)
+
+// FreeResources returns the free memory, the free cpus and the free containers of each docker engine
+func (h *HatcherySwarm) FreeResources() ([]hatchery.Resources, error) {
+	res := make([]hatchery.Resources, 0, len(h.dockerClients))
+	for name, dockerClient := range h.dockerClients {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		info, errI := dockerClient.Info(ctx)
+		if errI != nil {
+			cancel()
+			log.Error("hatchery> swarm> FreeResources> Unable to get info of %s: %v", name, errI)
+			continue
+		}
+		cs, errL := dockerClient.ContainerList(ctx, types.ContainerListOptions{All: true})
+		cancel()
+		if errL != nil {
+			log.Error("hatchery> swarm> FreeResources> Unable to list containers on %s: %v", name, errL)
+			continue
+		}
+		res = append(res, freeResources(info.MemTotal, info.NCPU, cs, dockerClient.MaxContainers))
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("unable to get the free resources of the docker engines")
+	}
+	return res, nil
+}
+
+// freeResources computes the free resources of a docker engine from its memory in bytes, its cpus and its containers.
+// The memory and the cpu quota of the running containers are read from their labels.
+func freeResources(memTotal int64, ncpu int, cs []types.Container, maxContainers int) hatchery.Resources {
+	r := hatchery.Resources{
+		Memory:    memTotal / 1024 / 1024,
+		CPU:       float64(ncpu),
+		Instances: maxContainers - len(cs),
+	}
+	for _, c := range cs {
+		if c.State != "running" && c.State != "created" {
+			continue
+		}
+		if m, err := strconv.ParseInt(c.Labels[labelMemory], 10, 64); err == nil {
+			r.Memory -= m
+		}
+		if cpus, err := strconv.ParseFloat(c.Labels[labelCPUs], 64); err == nil {
+			r.CPU -= cpus
+		}
+	}
+	if r.Memory < 0 {
+		r.Memory = 0
+	}
+	if r.CPU < 0 {
+		r.CPU = 0
+	}
+	if r.Instances < 0 {
+		r.Instances = 0
+	}
+	return r
+}
+
+// NeededResources returns the memory, the cpus and the number of containers needed by a worker and its services
+func (h *HatcherySwarm) NeededResources(model *sdk.Model, requirements []sdk.Requirement) hatchery.Resources {
+	memory := int64(h.Config.DefaultMemory)
+	if model.ModelDocker.Memory != 0 {
+		memory = model.ModelDocker.Memory
+	}
+
+	need := hatchery.Resources{Instances: 1}
+	if res, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements); err == nil {
+		need.CPU = res.CPUs
+		if res.Memory != 0 {
+			memory = res.Memory
+		}
+	}
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.MemoryRequirement:
+			if m, err := strconv.ParseInt(r.Value, 10, 64); err == nil {
+				memory = m
+			}
+		case sdk.ServiceRequirement:
+			need.Memory += containerMemory(parseServiceMemory(strings.Split(r.Value, " ")[1:]))
+			need.Instances++
+		}
+	}
+	need.Memory += containerMemory(memory)
+	return need
+}
+
+// parseServiceMemory returns the memory of a service, set with CDS_SERVICE_MEMORY=1024 in its env
+func parseServiceMemory(env []string) int64 {
+	memory := int64(1024)
+	for _, e := range env {
+		if strings.HasPrefix(e, "CDS_SERVICE_MEMORY=") {
+			i, err := strconv.Atoi(strings.TrimPrefix(e, "CDS_SERVICE_MEMORY="))
+			if err != nil {
+				continue
+			}
+			memory = int64(i)
+		}
+	}
+	return memory
+}
+
+// containerMemory returns the memory of a container, 1GB by default
+func containerMemory(memory int64) int64 {
+	if memory <= 4 {
+		return 1024
+	}
+	return memory
+}
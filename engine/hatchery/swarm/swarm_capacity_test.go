@@ -0,0 +1,54 @@
+package swarm
+
+import (
+	"testing"
+
+	types "github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/hatchery"
+)
+
+func Test_freeResources(t *testing.T) {
+	cs := []types.Container{
+		{State: "running", Labels: map[string]string{labelMemory: "1024", labelCPUs: "2"}},
+		{State: "running", Labels: map[string]string{labelMemory: "512", labelCPUs: "0.5"}},
+		{State: "exited", Labels: map[string]string{labelMemory: "2048"}},
+		{State: "running"},
+	}
+	r := freeResources(4096*1024*1024, 4, cs, 10)
+	assert.Equal(t, int64(2560), r.Memory)
+	assert.Equal(t, 1.5, r.CPU)
+	assert.Equal(t, 6, r.Instances)
+
+	r = freeResources(1024*1024*1024, 2, cs, 2)
+	assert.Equal(t, int64(0), r.Memory)
+	assert.Equal(t, float64(0), r.CPU)
+	assert.Equal(t, 0, r.Instances)
+}
+
+func TestHatcherySwarm_NeededResources(t *testing.T) {
+	h := &HatcherySwarm{}
+	h.Config.DefaultMemory = 1024
+
+	m := &sdk.Model{}
+	assert.Equal(t, hatchery.Resources{Memory: 1024, Instances: 1}, h.NeededResources(m, nil))
+
+	m.ModelDocker.Memory = 2048
+	reqs := []sdk.Requirement{
+		{Type: sdk.ServiceRequirement, Name: "pg", Value: "postgres:9.5 CDS_SERVICE_MEMORY=512"},
+		{Type: sdk.ServiceRequirement, Name: "redis", Value: "redis"},
+	}
+	assert.Equal(t, hatchery.Resources{Memory: 2048 + 512 + 1024, Instances: 3}, h.NeededResources(m, reqs))
+
+	reqs = append(reqs, sdk.Requirement{Type: sdk.MemoryRequirement, Value: "4096"})
+	assert.Equal(t, hatchery.Resources{Memory: 4096 + 512 + 1024, Instances: 3}, h.NeededResources(m, reqs))
+
+	h.Config.ResourceClasses = map[string]hatchery.ResourceClass{"large": {CPUs: 4, Memory: 8192}}
+	reqs = []sdk.Requirement{{Type: sdk.ResourceClassRequirement, Value: "large"}}
+	assert.Equal(t, hatchery.Resources{Memory: 8192, CPU: 4, Instances: 1}, h.NeededResources(m, reqs))
+
+	reqs = append(reqs, sdk.Requirement{Type: sdk.CPURequirement, Value: "2"})
+	assert.Equal(t, hatchery.Resources{Memory: 8192, CPU: 2, Instances: 1}, h.NeededResources(m, reqs))
+}
@@ -3,6 +3,7 @@ package swarm
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	types "github.com/docker/docker/api/types"
@@ -44,6 +45,7 @@ type containerArgs struct {
 	cmd, env                           []string
 	labels                             map[string]string
 	memory                             int64
+	cpus                               float64
 	dockerOpts                         dockerOpts
 	entryPoint                         strslice.StrSlice
 }
@@ -54,8 +56,14 @@ func (h *HatcherySwarm) createAndStartContainer(ctx context.Context, dockerClien
 	defer end()
 
 	//Memory is set to 1GB by default
-	if cArgs.memory <= 4 {
-		cArgs.memory = 1024
+	cArgs.memory = containerMemory(cArgs.memory)
+	//The memory label is used to compute the free resources of the docker engine
+	if cArgs.labels == nil {
+		cArgs.labels = map[string]string{}
+	}
+	cArgs.labels[labelMemory] = strconv.FormatInt(cArgs.memory, 10)
+	if cArgs.cpus > 0 {
+		cArgs.labels[labelCPUs] = strconv.FormatFloat(cArgs.cpus, 'f', -1, 64)
 	}
 	log.Info("hatchery> swarm> createAndStartContainer> Create container %s on %s from %s (memory=%dMB)", cArgs.name, dockerClient.name, cArgs.image, cArgs.memory)
 
@@ -83,6 +91,7 @@ func (h *HatcherySwarm) createAndStartContainer(ctx context.Context, dockerClien
 	hostConfig.Resources = container.Resources{
 		Memory:     cArgs.memory * 1024 * 1024, //from MB to B
 		MemorySwap: -1,
+		NanoCPUs:   int64(cArgs.cpus * 1e9),
 	}
 
 	networkingConfig := &network.NetworkingConfig{
@@ -28,6 +28,9 @@ type HatcheryConfiguration struct {
 	DockerOpts string `mapstructure:"dockerOpts" toml:"dockerOpts" default:"" commented:"true" comment:"Docker Options. --add-host and --privileged supported. Example: dockerOpts=\"--add-host=myhost:x.x.x.x,myhost2:y.y.y.y --privileged\""`
 
 	DockerEngines map[string]DockerEngineConfiguration `mapstructure:"dockerEngines" toml:"dockerEngines" comment:"List of Docker Engines"`
+
+	// ResourceClasses are the cpus and the memory of the workers for each resource class requirement
+	ResourceClasses map[string]hatchery.ResourceClass `mapstructure:"resourceClasses" toml:"resourceClasses" commented:"true" comment:"CPU quota and memory (in Mo) of the workers for each resource class requirement. Example: [hatchery.swarm.resourceClasses.large] cpus=4.0 memory=8192"`
 }
 
 // HatcherySwarm is a hatchery which can be connected to a remote to a docker remote api
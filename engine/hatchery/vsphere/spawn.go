@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"math"
 	"strings"
 	"time"
 
@@ -39,6 +40,11 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 		name = "register-" + name
 	}
 
+	resources, errR := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, spawnArgs.Requirements)
+	if errR != nil {
+		return "", sdk.WrapError(errR, "SpawnWorker> cannot compute resource class")
+	}
+
 	_, errM := h.getModelByName(spawnArgs.Model.Name)
 
 	if errM != nil || spawnArgs.Model.NeedRegistration {
@@ -67,6 +73,7 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 	if errCfg != nil {
 		return "", sdk.WrapError(errCfg, "SpawnWorker> cannot create VM configuration")
 	}
+	applyWorkerResources(cloneSpec.Config, resources)
 
 	log.Info("Create vm to exec worker %s", name)
 	defer log.Info("Terminate to create vm for worker %s", name)
@@ -83,6 +90,16 @@ func (h *HatcheryVSphere) SpawnWorker(ctx context.Context, spawnArgs hatchery.Sp
 	return "", h.launchScriptWorker(name, spawnArgs.IsWorkflowJob, spawnArgs.JobID, spawnArgs.Model, spawnArgs.RegisterOnly, info.Result.(types.ManagedObjectReference))
 }
 
+// applyWorkerResources sets the vcpus and the memory of the vm from the cpu and resource class requirements of the job
+func applyWorkerResources(spec *types.VirtualMachineConfigSpec, resources hatchery.WorkerResources) {
+	if resources.CPUs > 0 {
+		spec.NumCPUs = int32(math.Ceil(resources.CPUs))
+	}
+	if resources.Memory > 0 {
+		spec.MemoryMB = resources.Memory
+	}
+}
+
 // createVMModel create a model for a specific worker model
 func (h *HatcheryVSphere) createVMModel(model sdk.Model) (*object.VirtualMachine, error) {
 	log.Info("Create vm model %s", model.Name)
@@ -208,6 +225,7 @@ func (h *HatcheryVSphere) launchScriptWorker(name string, isWorkflowJob bool, jo
 		GrpcAPI:           h.Configuration().API.GRPC.URL,
 		GrpcInsecure:      h.Configuration().API.GRPC.Insecure,
 	}
+	udataParam.ApplyReuse(model)
 
 	if isWorkflowJob {
 		udataParam.WorkflowJobID = jobID
@@ -43,6 +43,9 @@ type HatcheryConfiguration struct {
 
 	// CreateImageTimeout max wait for create a vsphere image (in seconds)
 	CreateImageTimeout int `mapstructure:"createImageTimeout" toml:"createImageTimeout" default:"180" commented:"false" comment:"max wait for create a vsphere image (in seconds)"`
+
+	// ResourceClasses are the vcpus and the memory of the workers for each resource class requirement
+	ResourceClasses map[string]hatchery.ResourceClass `mapstructure:"resourceClasses" toml:"resourceClasses" commented:"true" comment:"VCPUs and memory (in Mo) of the workers for each resource class requirement. Example: [hatchery.vsphere.resourceClasses.large] cpus=4.0 memory=8192"`
 }
 
 // HatcheryVSphere spawns vm
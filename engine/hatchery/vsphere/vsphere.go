@@ -112,13 +112,17 @@ func (h *HatcheryVSphere) CheckConfiguration(cfg interface{}) error {
 }
 
 // CanSpawn return wether or not hatchery can spawn model
-// requirements are not supported
+// service and memory requirements are not supported, cpu and resource class requirements set the size of the vm
 func (h *HatcheryVSphere) CanSpawn(model *sdk.Model, jobID int64, requirements []sdk.Requirement) bool {
 	for _, r := range requirements {
 		if r.Type == sdk.ServiceRequirement || r.Type == sdk.MemoryRequirement {
 			return false
 		}
 	}
+	if _, err := hatchery.ComputeWorkerResources(h.Config.ResourceClasses, requirements); err != nil {
+		log.Debug("CanSpawn> job %d: %v", jobID, err)
+		return false
+	}
 	return true
 }
 
@@ -44,12 +44,26 @@ func (d *dao) SaveTask(r *sdk.Task) {
 
 func (d *dao) DeleteTask(r *sdk.Task) {
 	d.store.SetRemove(rootKey, r.UUID, r)
+	d.store.Delete(cache.Key(gitRefsRootKey, r.UUID))
 	execs, _ := d.FindAllTaskExecutions(r)
 	for _, e := range execs {
 		d.DeleteTaskExecution(&e)
 	}
 }
 
+// FindGitRefs returns the references of the repository saved by the last poll of a git ref poller
+func (d *dao) FindGitRefs(uuid string) (map[string]string, bool) {
+	refs := map[string]string{}
+	if d.store.Get(cache.Key(gitRefsRootKey, uuid), &refs) {
+		return refs, true
+	}
+	return nil, false
+}
+
+func (d *dao) SaveGitRefs(uuid string, refs map[string]string) {
+	d.store.Set(cache.Key(gitRefsRootKey, uuid), refs)
+}
+
 func (d *dao) SaveTaskExecution(r *sdk.TaskExecution) {
 	setKey := cache.Key(executionRootKey, r.Type, r.UUID)
 	execKey := fmt.Sprintf("%d", r.Timestamp)
@@ -0,0 +1,129 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsamin/go-dump"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// gitRefPollerMinInterval is the minimum delay between two polls of a repository
+const gitRefPollerMinInterval = time.Minute
+
+// gitRefPollerInterval returns the delay between two polls of the repository of the task
+func gitRefPollerInterval(t *sdk.Task) time.Duration {
+	d, err := time.ParseDuration(t.Config[sdk.GitRefPollerModelInterval].Value)
+	if err != nil || d < gitRefPollerMinInterval {
+		return gitRefPollerMinInterval
+	}
+	return d
+}
+
+func (s *Service) doGitRefPollerTaskExecution(t *sdk.Task, e *sdk.TaskExecution) ([]sdk.WorkflowNodeRunHookEvent, error) {
+	log.Debug("Hooks> Processing git ref poller task %s:%d", e.UUID, e.Timestamp)
+
+	refs, err := s.Client.HookGitRefs(t.UUID)
+	if err != nil {
+		return nil, sdk.WrapError(err, "Hooks> doGitRefPollerTaskExecution> Cannot list references of %s", t.Config[sdk.GitRefPollerModelURL].Value)
+	}
+
+	previous, found := s.Dao.FindGitRefs(t.UUID)
+	s.Dao.SaveGitRefs(t.UUID, refs)
+	// The first poll only saves the references, it does not trigger the workflow for all the existing branches
+	if !found {
+		return nil, nil
+	}
+
+	payloadValues := map[string]string{}
+	if payload, ok := t.Config[sdk.GitRefPollerModelPayload]; ok && payload.Value != "{}" {
+		var payloadInt interface{}
+		if err := json.Unmarshal([]byte(payload.Value), &payloadInt); err == nil {
+			e := dump.NewDefaultEncoder(new(bytes.Buffer))
+			e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+			e.ExtraFields.DetailedMap = false
+			e.ExtraFields.DetailedStruct = false
+			e.ExtraFields.Len = false
+			e.ExtraFields.Type = false
+
+			m1, errm1 := e.ToStringMap(payloadInt)
+			if errm1 != nil {
+				log.Error("Hooks> doGitRefPollerTaskExecution> Cannot convert payload to map %s", errm1)
+			} else {
+				payloadValues = m1
+			}
+		} else {
+			log.Error("Hooks> doGitRefPollerTaskExecution> Cannot unmarshall payload %s", err)
+		}
+	}
+
+	patterns := strings.Split(t.Config[sdk.GitRefPollerModelRefs].Value, ",")
+	var hookEvents []sdk.WorkflowNodeRunHookEvent
+	for _, ref := range updatedGitRefs(patterns, previous, refs) {
+		hookEvents = append(hookEvents, sdk.WorkflowNodeRunHookEvent{
+			WorkflowNodeHookUUID: t.UUID,
+			Payload:              sdk.ParametersMapMerge(payloadValues, gitRefPayload(t.Config[sdk.GitRefPollerModelURL].Value, ref, previous[ref], refs[ref])),
+		})
+	}
+	return hookEvents, nil
+}
+
+// updatedGitRefs returns the sorted names of the new and the moved references which match one of the patterns
+func updatedGitRefs(patterns []string, previous, current map[string]string) []string {
+	res := []string{}
+	for ref, hash := range current {
+		if previous[ref] == hash || !matchGitRef(patterns, ref) {
+			continue
+		}
+		res = append(res, ref)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// matchGitRef checks if the reference matches a pattern, like refs/heads/* or refs/tags/v*. An empty list of patterns matches all the references
+func matchGitRef(patterns []string, ref string) bool {
+	var hasPattern bool
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		hasPattern = true
+		if p == ref {
+			return true
+		}
+		if ok, _ := path.Match(p, ref); ok {
+			return true
+		}
+		// refs/heads/* also matches the branches with a slash, like refs/heads/feat/my-feature
+		if strings.HasSuffix(p, "/*") && strings.HasPrefix(ref, strings.TrimSuffix(p, "*")) {
+			return true
+		}
+	}
+	return !hasPattern
+}
+
+// gitRefPayload returns the payload of the run triggered by the reference. The previous hash is empty for a new reference
+func gitRefPayload(url, ref, previousHash, hash string) map[string]string {
+	payload := make(map[string]string)
+	payload["git.url"] = url
+	payload["git.hash"] = hash
+	if previousHash != "" {
+		payload["git.hash.before"] = previousHash
+	}
+	if strings.HasPrefix(ref, "refs/tags/") {
+		payload["git.tag"] = strings.TrimPrefix(ref, "refs/tags/")
+	} else {
+		payload["git.branch"] = strings.TrimPrefix(ref, "refs/heads/")
+	}
+	payload["cds.triggered_by.username"] = "cds.gitpoller"
+	payload["cds.triggered_by.fullname"] = "CDS Git Poller"
+	return payload
+}
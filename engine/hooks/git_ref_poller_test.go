@@ -0,0 +1,57 @@
+package hooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_updatedGitRefs(t *testing.T) {
+	previous := map[string]string{
+		"refs/heads/master":   "aaa",
+		"refs/heads/feat/old": "bbb",
+		"refs/tags/v1.0.0":    "ccc",
+	}
+	current := map[string]string{
+		"refs/heads/master":   "ddd",
+		"refs/heads/feat/old": "bbb",
+		"refs/heads/feat/new": "eee",
+		"refs/tags/v1.0.0":    "ccc",
+		"refs/tags/v1.1.0":    "fff",
+		"refs/tags/latest":    "fff",
+	}
+
+	assert.Equal(t, []string{"refs/heads/feat/new", "refs/heads/master", "refs/tags/latest", "refs/tags/v1.1.0"}, updatedGitRefs([]string{"refs/heads/*", "refs/tags/*"}, previous, current))
+	assert.Equal(t, []string{"refs/tags/v1.1.0"}, updatedGitRefs([]string{"refs/tags/v*"}, previous, current))
+	assert.Equal(t, []string{"refs/heads/master"}, updatedGitRefs([]string{" refs/heads/master "}, previous, current))
+	assert.Len(t, updatedGitRefs([]string{""}, previous, current), 4)
+}
+
+func Test_gitRefPayload(t *testing.T) {
+	p := gitRefPayload("git@github.com:ovh/cds.git", "refs/heads/feat/new", "", "eee")
+	assert.Equal(t, "feat/new", p["git.branch"])
+	assert.Equal(t, "eee", p["git.hash"])
+	_, ok := p["git.hash.before"]
+	assert.False(t, ok)
+	assert.Equal(t, "git@github.com:ovh/cds.git", p["git.url"])
+	assert.Empty(t, p["git.tag"])
+
+	p = gitRefPayload("git@github.com:ovh/cds.git", "refs/heads/master", "ddd", "eee")
+	assert.Equal(t, "ddd", p["git.hash.before"])
+
+	p = gitRefPayload("git@github.com:ovh/cds.git", "refs/tags/v1.1.0", "", "fff")
+	assert.Equal(t, "v1.1.0", p["git.tag"])
+	assert.Empty(t, p["git.branch"])
+}
+
+func Test_gitRefPollerInterval(t *testing.T) {
+	task := &sdk.Task{Config: sdk.WorkflowNodeHookConfig{sdk.GitRefPollerModelInterval: {Value: "10m"}}}
+	assert.Equal(t, 10*time.Minute, gitRefPollerInterval(task))
+	task.Config[sdk.GitRefPollerModelInterval] = sdk.WorkflowNodeHookConfigValue{Value: "10s"}
+	assert.Equal(t, time.Minute, gitRefPollerInterval(task))
+	task.Config[sdk.GitRefPollerModelInterval] = sdk.WorkflowNodeHookConfigValue{Value: "often"}
+	assert.Equal(t, time.Minute, gitRefPollerInterval(task))
+}
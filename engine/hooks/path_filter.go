@@ -0,0 +1,81 @@
+package hooks
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// githubPushEventMaxCommits is the maximum number of commits described by the payload of a github push event
+const githubPushEventMaxCommits = 20
+
+func uniqueSortedFiles(files []string) []string {
+	res := []string{}
+	found := map[string]bool{}
+	for _, f := range files {
+		if f == "" || found[f] {
+			continue
+		}
+		found[f] = true
+		res = append(res, f)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// encodeChangedFiles returns the value of the variable git.changed_files. It is a JSON list: a file name may contain a comma
+func encodeChangedFiles(files []string) string {
+	btes, _ := json.Marshal(files)
+	return string(btes)
+}
+
+// decodeChangedFiles parses the value of the variable git.changed_files
+func decodeChangedFiles(files string) ([]string, error) {
+	res := []string{}
+	if err := json.Unmarshal([]byte(files), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// filterHookEventsByPaths removes the events which did not change any file matched by the path filter of the task.
+// The changed files are read from the payload of the event. If the repository manager did not send them, they are computed by CDS API.
+// When the changed files remain unknown, the event is kept. The changed files are only computed by CDS API when a path filter is configured
+func (s *Service) filterHookEventsByPaths(t *sdk.Task, hs []sdk.WorkflowNodeRunHookEvent) []sdk.WorkflowNodeRunHookEvent {
+	filter := sdk.NewHookPathFilter(t.Config)
+	if filter.IsEmpty() {
+		return hs
+	}
+
+	res := []sdk.WorkflowNodeRunHookEvent{}
+	for _, h := range hs {
+		if _, ok := h.Payload["git.changed_files"]; !ok && h.Payload["git.branch"] != "" {
+			files, err := s.Client.HookChangedFiles(t.UUID, h.Payload["git.branch"], h.Payload["git.hash.before"], h.Payload["git.hash"])
+			if err != nil {
+				log.Warning("Hooks> filterHookEventsByPaths> Cannot get the changed files of %s on %s: %v", h.Payload["git.hash"], h.Payload["git.branch"], err)
+			} else if files != nil {
+				h.Payload["git.changed_files"] = encodeChangedFiles(files)
+			}
+		}
+
+		files, ok := h.Payload["git.changed_files"]
+		if !ok {
+			res = append(res, h)
+			continue
+		}
+		changedFiles, err := decodeChangedFiles(files)
+		if err != nil {
+			log.Warning("Hooks> filterHookEventsByPaths> Invalid changed files of %s on %s: %v", h.Payload["git.hash"], h.Payload["git.branch"], err)
+			res = append(res, h)
+			continue
+		}
+		if !filter.Match(changedFiles) {
+			log.Debug("Hooks> filterHookEventsByPaths> %s on %s ignored by hook %s", h.Payload["git.hash"], h.Payload["git.branch"], t.UUID)
+			continue
+		}
+		res = append(res, h)
+	}
+	return res
+}
@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_filterHookEventsByPaths(t *testing.T) {
+	s := Service{}
+	task := &sdk.Task{
+		UUID: sdk.RandomString(10),
+		Config: sdk.WorkflowNodeHookConfig{
+			sdk.HookConfigIncludePaths: {Value: "services/api"},
+			sdk.HookConfigExcludePaths: {Value: "**/*.md"},
+		},
+	}
+	hs := []sdk.WorkflowNodeRunHookEvent{
+		{Payload: map[string]string{"git.hash": "aaa", "git.changed_files": `["services/api/main.go","services/front/main.go"]`}},
+		{Payload: map[string]string{"git.hash": "bbb", "git.changed_files": `["services/front/main.go"]`}},
+		{Payload: map[string]string{"git.hash": "ccc", "git.changed_files": `["services/api/README.md"]`}},
+		{Payload: map[string]string{"git.hash": "ddd", "git.changed_files": `[]`}},
+		// Without branch the changed files can not be computed, the event is kept
+		{Payload: map[string]string{"git.hash": "eee", "git.tag": "v1.0.0"}},
+	}
+
+	res := s.filterHookEventsByPaths(task, hs)
+	if assert.Len(t, res, 2) {
+		assert.Equal(t, "aaa", res[0].Payload["git.hash"])
+		assert.Equal(t, "eee", res[1].Payload["git.hash"])
+	}
+
+	// Without path filter, all the events are kept
+	task.Config = sdk.WorkflowNodeHookConfig{}
+	assert.Len(t, s.filterHookEventsByPaths(task, hs), 5)
+}
+
+func Test_uniqueSortedFiles(t *testing.T) {
+	assert.Equal(t, []string{"a.go", "b/c.go"}, uniqueSortedFiles([]string{"b/c.go", "a.go", "", "b/c.go"}))
+}
+
+func Test_encodeChangedFiles(t *testing.T) {
+	files := []string{"docs/a,b.md", "main.go"}
+	value := encodeChangedFiles(files)
+	assert.Equal(t, `["docs/a,b.md","main.go"]`, value)
+	res, err := decodeChangedFiles(value)
+	assert.NoError(t, err)
+	assert.Equal(t, files, res)
+
+	_, err = decodeChangedFiles("docs/a.md,main.go")
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+package hooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// These are the actions of a pull request which trigger a workflow, for all repository managers
+const (
+	PullRequestActionOpened      = "opened"
+	PullRequestActionSynchronize = "synchronize"
+	PullRequestActionReopened    = "reopened"
+	PullRequestActionClosed      = "closed"
+	PullRequestActionMerged      = "merged"
+)
+
+// getPullRequestPayload computes the payload of a pull request event. It returns a nil payload if the action of the event must not trigger the workflow.
+// The pull requests opened from a fork are flagged with git.pr.fork, and git.pr.ref is the ref of the repository of the workflow from which their commits can be fetched
+func getPullRequestPayload(header string, body []byte) (map[string]interface{}, error) {
+	payload := make(map[string]interface{})
+	var ref string
+	switch header {
+	case GithubHeader:
+		var prEvent GithubPullRequestEvent
+		if err := json.Unmarshal(body, &prEvent); err != nil {
+			return nil, sdk.WrapError(err, "getPullRequestPayload> unable to read github request: %s", string(body))
+		}
+		var action string
+		switch prEvent.Action {
+		case "opened":
+			action = PullRequestActionOpened
+		case "synchronize":
+			action = PullRequestActionSynchronize
+		case "reopened":
+			action = PullRequestActionReopened
+		case "closed":
+			action = PullRequestActionClosed
+			if prEvent.PullRequest.Merged {
+				action = PullRequestActionMerged
+			}
+		default:
+			return nil, nil
+		}
+		pr := prEvent.PullRequest
+		ref = fmt.Sprintf("refs/pull/%d/head", pr.Number)
+		payload["git.pr.id"] = pr.Number
+		payload["git.pr.action"] = action
+		payload["git.pr.title"] = pr.Title
+		payload["git.pr.author"] = pr.User.Login
+		payload["git.pr.source.branch"] = pr.Head.Ref
+		payload["git.pr.source.repository"] = pr.Head.Repo.FullName
+		payload["git.pr.target.branch"] = pr.Base.Ref
+		payload["git.pr.target.repository"] = pr.Base.Repo.FullName
+
+		payload["git.author"] = pr.User.Login
+		payload["git.branch"] = pr.Head.Ref
+		payload["git.hash"] = pr.Head.Sha
+		payload["git.repository"] = prEvent.Repository.FullName
+		payload["cds.triggered_by.username"] = prEvent.Sender.Login
+	case GitlabHeader:
+		var mrEvent GitlabMergeRequestEvent
+		if err := json.Unmarshal(body, &mrEvent); err != nil {
+			return nil, sdk.WrapError(err, "getPullRequestPayload> unable to read gitlab request: %s", string(body))
+		}
+		mr := mrEvent.ObjectAttributes
+		var action string
+		switch mr.Action {
+		case "open":
+			action = PullRequestActionOpened
+		case "update":
+			// An update without oldrev is a change of the title, the description or the labels
+			if mr.OldRev == "" {
+				return nil, nil
+			}
+			action = PullRequestActionSynchronize
+		case "reopen":
+			action = PullRequestActionReopened
+		case "close":
+			action = PullRequestActionClosed
+		case "merge":
+			action = PullRequestActionMerged
+		default:
+			return nil, nil
+		}
+		ref = fmt.Sprintf("refs/merge-requests/%d/head", mr.IID)
+		payload["git.pr.id"] = mr.IID
+		payload["git.pr.action"] = action
+		payload["git.pr.title"] = mr.Title
+		payload["git.pr.author"] = mrEvent.User.Username
+		payload["git.pr.source.branch"] = mr.SourceBranch
+		payload["git.pr.source.repository"] = mr.Source.PathWithNamespace
+		payload["git.pr.target.branch"] = mr.TargetBranch
+		payload["git.pr.target.repository"] = mr.Target.PathWithNamespace
+
+		payload["git.author"] = mrEvent.User.Username
+		payload["git.author.email"] = mrEvent.User.Email
+		payload["git.branch"] = mr.SourceBranch
+		payload["git.hash"] = mr.LastCommit.ID
+		payload["git.message"] = mr.LastCommit.Message
+		payload["git.repository"] = mrEvent.Project.PathWithNamespace
+		payload["cds.triggered_by.username"] = mrEvent.User.Username
+		payload["cds.triggered_by.fullname"] = mrEvent.User.Name
+		payload["cds.triggered_by.email"] = mrEvent.User.Email
+	case BitbucketHeader:
+		var prEvent BitbucketPullRequestEvent
+		if err := json.Unmarshal(body, &prEvent); err != nil {
+			return nil, sdk.WrapError(err, "getPullRequestPayload> unable to read bitbucket request: %s", string(body))
+		}
+		var action string
+		switch prEvent.EventKey {
+		case "pr:opened":
+			action = PullRequestActionOpened
+		case "pr:from_ref_updated":
+			action = PullRequestActionSynchronize
+		case "pr:declined":
+			action = PullRequestActionClosed
+		case "pr:merged":
+			action = PullRequestActionMerged
+		default:
+			return nil, nil
+		}
+		pr := prEvent.PullRequest
+		source := fmt.Sprintf("%s/%s", pr.FromRef.Repository.Project.Key, pr.FromRef.Repository.Slug)
+		target := fmt.Sprintf("%s/%s", pr.ToRef.Repository.Project.Key, pr.ToRef.Repository.Slug)
+		ref = fmt.Sprintf("refs/pull-requests/%d/from", pr.ID)
+		payload["git.pr.id"] = pr.ID
+		payload["git.pr.action"] = action
+		payload["git.pr.title"] = pr.Title
+		payload["git.pr.author"] = pr.Author.User.Name
+		payload["git.pr.source.branch"] = strings.TrimPrefix(pr.FromRef.ID, "refs/heads/")
+		payload["git.pr.source.repository"] = source
+		payload["git.pr.target.branch"] = strings.TrimPrefix(pr.ToRef.ID, "refs/heads/")
+		payload["git.pr.target.repository"] = target
+
+		payload["git.author"] = pr.Author.User.Name
+		payload["git.author.email"] = pr.Author.User.EmailAddress
+		payload["git.branch"] = strings.TrimPrefix(pr.FromRef.ID, "refs/heads/")
+		payload["git.hash"] = pr.FromRef.LatestCommit
+		payload["git.repository"] = target
+		payload["cds.triggered_by.username"] = prEvent.Actor.Name
+		payload["cds.triggered_by.fullname"] = prEvent.Actor.DisplayName
+		payload["cds.triggered_by.email"] = prEvent.Actor.EmailAddress
+	case GiteaHeader, GogsHeader:
+		var prEvent GiteaPullRequestEvent
+		if err := json.Unmarshal(body, &prEvent); err != nil {
+			return nil, sdk.WrapError(err, "getPullRequestPayload> unable to read gitea request: %s", string(body))
+		}
+		pr := prEvent.PullRequest
+		var action string
+		switch prEvent.Action {
+		case "opened":
+			action = PullRequestActionOpened
+		case "synchronized":
+			action = PullRequestActionSynchronize
+		case "reopened":
+			action = PullRequestActionReopened
+		case "closed":
+			action = PullRequestActionClosed
+			if pr.Merged {
+				action = PullRequestActionMerged
+			}
+		default:
+			return nil, nil
+		}
+
+		// Gogs only sends the names of the branches and the repositories
+		source, target := pr.HeadBranch, pr.BaseBranch
+		sourceRepo, targetRepo := prEvent.Repository.FullName, prEvent.Repository.FullName
+		if pr.HeadRepo != nil {
+			sourceRepo = pr.HeadRepo.FullName
+		}
+		if pr.BaseRepo != nil {
+			targetRepo = pr.BaseRepo.FullName
+		}
+		if pr.Head != nil {
+			source, sourceRepo = pr.Head.Ref, pr.Head.Repo.FullName
+			payload["git.hash"] = pr.Head.Sha
+		}
+		if pr.Base != nil {
+			target, targetRepo = pr.Base.Ref, pr.Base.Repo.FullName
+		}
+
+		ref = fmt.Sprintf("refs/pull/%d/head", pr.Number)
+		payload["git.pr.id"] = pr.Number
+		payload["git.pr.action"] = action
+		payload["git.pr.title"] = pr.Title
+		payload["git.pr.author"] = pr.User.Name()
+		payload["git.pr.source.branch"] = source
+		payload["git.pr.source.repository"] = sourceRepo
+		payload["git.pr.target.branch"] = target
+		payload["git.pr.target.repository"] = targetRepo
+
+		payload["git.author"] = pr.User.Name()
+		payload["git.author.email"] = pr.User.Email
+		payload["git.branch"] = source
+		payload["git.repository"] = prEvent.Repository.FullName
+		payload["cds.triggered_by.username"] = prEvent.Sender.Name()
+		payload["cds.triggered_by.fullname"] = prEvent.Sender.FullName
+		payload["cds.triggered_by.email"] = prEvent.Sender.Email
+	}
+
+	source, _ := payload["git.pr.source.repository"].(string)
+	target, _ := payload["git.pr.target.repository"].(string)
+	fork := !strings.EqualFold(source, target)
+	payload["git.pr.fork"] = fork
+	if fork {
+		payload["git.pr.ref"] = ref
+	}
+	return payload, nil
+}
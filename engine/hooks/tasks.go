@@ -26,18 +26,22 @@ const (
 	TypeWebHook            = "Webhook"
 	TypeScheduler          = "Scheduler"
 	TypeRepoPoller         = "RepoPoller"
+	TypeGitRefPoller       = "GitRefPoller"
 	TypeKafka              = "Kafka"
 	TypeRabbitMQ           = "RabbitMQ"
 
 	GithubHeader    = "X-Github-Event"
 	GitlabHeader    = "X-Gitlab-Event"
 	BitbucketHeader = "X-Event-Key"
+	GiteaHeader     = "X-Gitea-Event"
+	GogsHeader      = "X-Gogs-Event"
 )
 
 var (
 	rootKey           = cache.Key("hooks", "tasks")
 	executionRootKey  = cache.Key("hooks", "tasks", "executions")
 	schedulerQueueKey = cache.Key("hooks", "scheduler", "queue")
+	gitRefsRootKey    = cache.Key("hooks", "tasks", "gitrefs")
 )
 
 // runTasks should run as a long-running goroutine
@@ -157,6 +161,12 @@ func (s *Service) hookToTask(h *sdk.WorkflowNodeHook) (*sdk.Task, error) {
 			Type:   TypeRepoPoller,
 			Config: h.Config,
 		}, nil
+	case sdk.GitRefPollerModelName:
+		return &sdk.Task{
+			UUID:   h.UUID,
+			Type:   TypeGitRefPoller,
+			Config: h.Config,
+		}, nil
 	}
 
 	return nil, fmt.Errorf("Unsupported hook: %s", h.WorkflowHookModel.Name)
@@ -208,7 +218,7 @@ func (s *Service) startTask(ctx context.Context, t *sdk.Task) error {
 	switch t.Type {
 	case TypeWebHook, TypeRepoManagerWebHook:
 		return nil
-	case TypeScheduler, TypeRepoPoller:
+	case TypeScheduler, TypeRepoPoller, TypeGitRefPoller:
 		return s.prepareNextScheduledTaskExecution(t)
 	case TypeKafka:
 		return s.startKafkaHook(t)
@@ -267,6 +277,9 @@ func (s *Service) prepareNextScheduledTaskExecution(t *sdk.Task) error {
 				nextSchedule = time.Unix(nextExec, 0)
 			}
 		}
+
+	case TypeGitRefPoller:
+		nextSchedule = time.Now().Add(gitRefPollerInterval(t))
 	}
 
 	//Craft a new execution
@@ -295,7 +308,7 @@ func (s *Service) stopTask(t *sdk.Task) error {
 	s.Dao.SaveTask(t)
 
 	switch t.Type {
-	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeKafka:
+	case TypeWebHook, TypeScheduler, TypeRepoManagerWebHook, TypeRepoPoller, TypeGitRefPoller, TypeKafka:
 		log.Debug("Hooks> Tasks %s has been stopped", t.UUID)
 		return nil
 	default:
@@ -320,6 +333,8 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 	case e.ScheduledTask != nil && e.Type == TypeRepoPoller:
 		//Populate next execution
 		hs, err = s.doPollerTaskExecution(t, e)
+	case e.ScheduledTask != nil && e.Type == TypeGitRefPoller:
+		hs, err = s.doGitRefPollerTaskExecution(t, e)
 	case e.Kafka != nil:
 		h, err = s.doKafkaTaskExecution(e)
 	case e.RabbitMQ != nil:
@@ -334,6 +349,10 @@ func (s *Service) doTask(ctx context.Context, t *sdk.Task, e *sdk.TaskExecution)
 	if h != nil {
 		hs = append(hs, *h)
 	}
+	// Repository hooks only trigger the workflow when the commits changed a file matched by their path filter
+	if e.Type == TypeRepoManagerWebHook || e.Type == TypeRepoPoller || e.Type == TypeGitRefPoller {
+		hs = s.filterHookEventsByPaths(t, hs)
+	}
 	if hs == nil || len(hs) == 0 {
 		return nil
 	}
@@ -497,15 +516,58 @@ func (s *Service) doWebHookExecution(t *sdk.TaskExecution) (*sdk.WorkflowNodeRun
 	return executeWebHook(t)
 }
 
-func getRepositoryHeader(whe *sdk.WebHookExecution) string {
-	if v, ok := whe.RequestHeader[GithubHeader]; ok && v[0] == "push" {
-		return GithubHeader
-	} else if v, ok := whe.RequestHeader[GitlabHeader]; ok && v[0] == "Push Hook" {
-		return GitlabHeader
-	} else if v, ok := whe.RequestHeader[BitbucketHeader]; ok && v[0] == "repo:refs_changed" {
-		return BitbucketHeader
+// getRepositoryHeader returns the header of the repository manager which sent the webhook and the kind of the event
+func getRepositoryHeader(whe *sdk.WebHookExecution) (string, string) {
+	// Gitea also sends the github header, so it has to be checked first
+	for _, h := range []string{GiteaHeader, GogsHeader} {
+		if v, ok := whe.RequestHeader[h]; ok {
+			switch v[0] {
+			case "push":
+				return h, sdk.RepositoryWebHookEventPush
+			case "pull_request":
+				return h, sdk.RepositoryWebHookEventPullRequest
+			}
+			return "", ""
+		}
+	}
+
+	if v, ok := whe.RequestHeader[GithubHeader]; ok {
+		switch v[0] {
+		case "push":
+			return GithubHeader, sdk.RepositoryWebHookEventPush
+		case "pull_request":
+			return GithubHeader, sdk.RepositoryWebHookEventPullRequest
+		}
+	} else if v, ok := whe.RequestHeader[GitlabHeader]; ok {
+		switch v[0] {
+		case "Push Hook":
+			return GitlabHeader, sdk.RepositoryWebHookEventPush
+		case "Merge Request Hook":
+			return GitlabHeader, sdk.RepositoryWebHookEventPullRequest
+		}
+	} else if v, ok := whe.RequestHeader[BitbucketHeader]; ok {
+		switch {
+		case v[0] == "repo:refs_changed":
+			return BitbucketHeader, sdk.RepositoryWebHookEventPush
+		case strings.HasPrefix(v[0], "pr:"):
+			return BitbucketHeader, sdk.RepositoryWebHookEventPullRequest
+		}
+	}
+	return "", ""
+}
+
+// isRepositoryWebHookEventEnabled checks the event against the events configured on the hook, push events only if there is no configuration
+func isRepositoryWebHookEventEnabled(cfg sdk.WorkflowNodeHookConfig, event string) bool {
+	events := sdk.RepositoryWebHookEventPush
+	if c, ok := cfg[sdk.RepositoryWebHookModelEvents]; ok && strings.TrimSpace(c.Value) != "" {
+		events = c.Value
+	}
+	for _, e := range strings.Split(events, ",") {
+		if strings.TrimSpace(e) == event {
+			return true
+		}
 	}
-	return ""
+	return false
 }
 
 func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEvent, error) {
@@ -514,8 +576,27 @@ func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEve
 		WorkflowNodeHookUUID: t.UUID,
 	}
 
+	header, event := getRepositoryHeader(t.WebHook)
+	if header != "" && !isRepositoryWebHookEventEnabled(t.Config, event) {
+		log.Debug("executeRepositoryWebHook> %s event ignored by hook %s", event, t.UUID)
+		return nil, nil
+	}
+
+	if event == sdk.RepositoryWebHookEventPullRequest {
+		payload, err := getPullRequestPayload(header, t.WebHook.RequestBody)
+		if err != nil || payload == nil {
+			return nil, err
+		}
+		// The code of a fork runs in the context of the project only if the hook explicitly allows it
+		if fork, _ := payload["git.pr.fork"].(bool); fork && !isRepositoryWebHookEventEnabled(t.Config, sdk.RepositoryWebHookEventForkPullRequest) {
+			log.Debug("executeRepositoryWebHook> pull request from fork %s ignored by hook %s", payload["git.pr.source.repository"], t.UUID)
+			return nil, nil
+		}
+		return dumpRepositoryWebHookPayload(h, payload)
+	}
+
 	payload := make(map[string]interface{})
-	switch getRepositoryHeader(t.WebHook) {
+	switch header {
 	case GithubHeader:
 		var pushEvent GithubPushEvent
 		if err := json.Unmarshal(t.WebHook.RequestBody, &pushEvent); err != nil {
@@ -542,6 +623,9 @@ func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEve
 		if len(pushEvent.Commits) > 0 {
 			payload["git.message"] = pushEvent.Commits[0].Message
 		}
+		if files := pushEvent.ChangedFiles(); files != nil {
+			payload["git.changed_files"] = encodeChangedFiles(files)
+		}
 	case GitlabHeader:
 		var pushEvent GitlabPushEvent
 		if err := json.Unmarshal(t.WebHook.RequestBody, &pushEvent); err != nil {
@@ -569,6 +653,9 @@ func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEve
 		if len(pushEvent.Commits) > 0 {
 			payload["git.message"] = pushEvent.Commits[0].Message
 		}
+		if files := pushEvent.ChangedFiles(); files != nil {
+			payload["git.changed_files"] = encodeChangedFiles(files)
+		}
 	case BitbucketHeader:
 		var pushEvent BitbucketPushEvent
 		if err := json.Unmarshal(t.WebHook.RequestBody, &pushEvent); err != nil {
@@ -593,11 +680,44 @@ func executeRepositoryWebHook(t *sdk.TaskExecution) (*sdk.WorkflowNodeRunHookEve
 		payload["cds.triggered_by.username"] = pushEvent.Actor.Name
 		payload["cds.triggered_by.fullname"] = pushEvent.Actor.DisplayName
 		payload["cds.triggered_by.email"] = pushEvent.Actor.EmailAddress
+	case GiteaHeader, GogsHeader:
+		var pushEvent GiteaPushEvent
+		if err := json.Unmarshal(t.WebHook.RequestBody, &pushEvent); err != nil {
+			return nil, sdk.WrapError(err, "Hook> webhookHandler> unable ro read gitea request: %s", string(t.WebHook.RequestBody))
+		}
+		// Branch deletion
+		if pushEvent.After == "" || pushEvent.After == "0000000000000000000000000000000000000000" {
+			return nil, nil
+		}
+		payload["git.author"] = pushEvent.Pusher.Name()
+		payload["git.author.email"] = pushEvent.Pusher.Email
+		if !strings.HasPrefix(pushEvent.Ref, "refs/tags/") {
+			payload["git.branch"] = strings.TrimPrefix(pushEvent.Ref, "refs/heads/")
+		} else {
+			payload["git.tag"] = strings.TrimPrefix(pushEvent.Ref, "refs/tags/")
+		}
+		payload["git.hash.before"] = pushEvent.Before
+		payload["git.hash"] = pushEvent.After
+		payload["git.repository"] = pushEvent.Repository.FullName
+
+		payload["cds.triggered_by.username"] = pushEvent.Pusher.Name()
+		payload["cds.triggered_by.fullname"] = pushEvent.Pusher.FullName
+		payload["cds.triggered_by.email"] = pushEvent.Pusher.Email
+
+		if len(pushEvent.Commits) > 0 {
+			payload["git.message"] = pushEvent.Commits[0].Message
+		}
+		if files := pushEvent.ChangedFiles(); files != nil {
+			payload["git.changed_files"] = encodeChangedFiles(files)
+		}
 	default:
 		log.Warning("executeRepositoryWebHook> Repository manager not found. Cannot read %s", string(t.WebHook.RequestBody))
 		return nil, fmt.Errorf("Repository manager not found. Cannot read request body")
 	}
+	return dumpRepositoryWebHookPayload(h, payload)
+}
 
+func dumpRepositoryWebHookPayload(h sdk.WorkflowNodeRunHookEvent, payload map[string]interface{}) (*sdk.WorkflowNodeRunHookEvent, error) {
 	d := dump.NewDefaultEncoder(&bytes.Buffer{})
 	d.ExtraFields.Type = false
 	d.ExtraFields.Len = false
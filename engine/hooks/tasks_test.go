@@ -54,6 +54,7 @@ func Test_doWebHookExecutionGithub(t *testing.T) {
 	assert.Equal(t, "baxterthehacker", h.Payload["git.author"])
 	assert.Equal(t, "Update README.md", h.Payload["git.message"])
 	assert.Equal(t, "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", h.Payload["git.hash"])
+	assert.Equal(t, `["README.md"]`, h.Payload["git.changed_files"])
 }
 
 func Test_doWebHookExecutionTagGithub(t *testing.T) {
@@ -101,6 +102,9 @@ func Test_doWebHookExecutionGitlab(t *testing.T) {
 	assert.Equal(t, "jsmith", h.Payload["git.author"])
 	assert.Equal(t, "Update Catalan translation to e38cb41.", h.Payload["git.message"])
 	assert.Equal(t, "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", h.Payload["git.hash"])
+	// The payload describes 2 of the 4 pushed commits, so the changed files are unknown
+	_, ok := h.Payload["git.changed_files"]
+	assert.False(t, ok)
 }
 
 func Test_doWebHookExecutionBitbucket(t *testing.T) {
@@ -125,6 +129,207 @@ func Test_doWebHookExecutionBitbucket(t *testing.T) {
 	assert.Equal(t, "9f4fac7ec5642099982a86f584f2c4a362adb670", h.Payload["git.hash"])
 }
 
+func Test_doWebHookExecutionPullRequest(t *testing.T) {
+	log.SetLogger(t)
+	s := Service{}
+	prConfig := sdk.WorkflowNodeHookConfig{
+		sdk.RepositoryWebHookModelEvents: {Value: "push,pull_request"},
+	}
+
+	task := &sdk.TaskExecution{
+		UUID:   sdk.RandomString(10),
+		Type:   TypeRepoManagerWebHook,
+		Config: prConfig,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(githubPullRequestEvent),
+			RequestHeader: map[string][]string{
+				GithubHeader: {"pull_request"},
+			},
+		},
+	}
+	h, err := s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "1", h.Payload["git.pr.id"])
+	assert.Equal(t, "opened", h.Payload["git.pr.action"])
+	assert.Equal(t, "Update the README with new information", h.Payload["git.pr.title"])
+	assert.Equal(t, "baxterthehacker", h.Payload["git.pr.author"])
+	assert.Equal(t, "changes", h.Payload["git.pr.source.branch"])
+	assert.Equal(t, "master", h.Payload["git.pr.target.branch"])
+	assert.Equal(t, "changes", h.Payload["git.branch"])
+	assert.Equal(t, "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c", h.Payload["git.hash"])
+	assert.Equal(t, "false", h.Payload["git.pr.fork"])
+	assert.Equal(t, "", h.Payload["git.pr.ref"])
+
+	// Pull requests are ignored by the hooks configured for push events only
+	task.Config = nil
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Nil(t, h)
+
+	task = &sdk.TaskExecution{
+		UUID:   sdk.RandomString(10),
+		Type:   TypeRepoManagerWebHook,
+		Config: prConfig,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(gitlabMergeRequestEvent),
+			RequestHeader: map[string][]string{
+				GitlabHeader: {"Merge Request Hook"},
+			},
+		},
+	}
+	// Merge requests from a fork are ignored without the fork_pull_request event
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Nil(t, h)
+
+	task.Config = sdk.WorkflowNodeHookConfig{
+		sdk.RepositoryWebHookModelEvents: {Value: "push,pull_request,fork_pull_request"},
+	}
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "1", h.Payload["git.pr.id"])
+	assert.Equal(t, "synchronize", h.Payload["git.pr.action"])
+	assert.Equal(t, "true", h.Payload["git.pr.fork"])
+	assert.Equal(t, "refs/merge-requests/1/head", h.Payload["git.pr.ref"])
+	assert.Equal(t, "ms-viewport", h.Payload["git.pr.source.branch"])
+	assert.Equal(t, "master", h.Payload["git.pr.target.branch"])
+	assert.Equal(t, "root", h.Payload["git.pr.author"])
+	assert.Equal(t, "da1560886d4f094c3e6c9ef40349f7d38b5d27d7", h.Payload["git.hash"])
+
+	task = &sdk.TaskExecution{
+		UUID:   sdk.RandomString(10),
+		Type:   TypeRepoManagerWebHook,
+		Config: prConfig,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(bitbucketPullRequestEvent),
+			RequestHeader: map[string][]string{
+				BitbucketHeader: {"pr:merged"},
+			},
+		},
+	}
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "1", h.Payload["git.pr.id"])
+	assert.Equal(t, "merged", h.Payload["git.pr.action"])
+	assert.Equal(t, "feature/login", h.Payload["git.pr.source.branch"])
+	assert.Equal(t, "master", h.Payload["git.pr.target.branch"])
+	assert.Equal(t, "PRJ/repo", h.Payload["git.repository"])
+	assert.Equal(t, "steven.guiheux", h.Payload["git.pr.author"])
+}
+
+var githubPullRequestEvent = `{
+  "action": "opened",
+  "number": 1,
+  "pull_request": {
+    "number": 1,
+    "state": "open",
+    "title": "Update the README with new information",
+    "merged": false,
+    "user": {
+      "login": "baxterthehacker"
+    },
+    "head": {
+      "ref": "changes",
+      "sha": "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c",
+      "repo": {
+        "full_name": "baxterthehacker/public-repo"
+      }
+    },
+    "base": {
+      "ref": "master",
+      "sha": "9049f1265b7d61be4a8904a9a27120d2064dab3b",
+      "repo": {
+        "full_name": "baxterthehacker/public-repo"
+      }
+    }
+  },
+  "repository": {
+    "full_name": "baxterthehacker/public-repo"
+  },
+  "sender": {
+    "login": "baxterthehacker"
+  }
+}`
+
+var gitlabMergeRequestEvent = `{
+  "object_kind": "merge_request",
+  "user": {
+    "name": "Administrator",
+    "username": "root",
+    "email": "admin@example.com"
+  },
+  "project": {
+    "path_with_namespace": "gitlabhq/gitlab-test"
+  },
+  "object_attributes": {
+    "iid": 1,
+    "title": "MS-Viewport",
+    "state": "opened",
+    "action": "update",
+    "oldrev": "b83d6e391c22777fca1ed3012fce84f633d7fed0",
+    "source_branch": "ms-viewport",
+    "target_branch": "master",
+    "source": {
+      "path_with_namespace": "awesome_space/awesome_project"
+    },
+    "target": {
+      "path_with_namespace": "gitlabhq/gitlab-test"
+    },
+    "last_commit": {
+      "id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+      "message": "fixed readme",
+      "author": {
+        "name": "GitLab dev user",
+        "email": "gitlabdev@dv6700.(none)"
+      }
+    }
+  }
+}`
+
+var bitbucketPullRequestEvent = `{
+  "eventKey": "pr:merged",
+  "date": "2017-11-30T15:24:01+0100",
+  "actor": {
+    "name": "steven.guiheux",
+    "emailAddress": "steven.guiheux@corp.ovh.com",
+    "displayName": "Steven Guiheux"
+  },
+  "pullRequest": {
+    "id": 1,
+    "title": "Add login page",
+    "state": "MERGED",
+    "author": {
+      "user": {
+        "name": "steven.guiheux",
+        "emailAddress": "steven.guiheux@corp.ovh.com",
+        "displayName": "Steven Guiheux"
+      }
+    },
+    "fromRef": {
+      "id": "refs/heads/feature/login",
+      "displayId": "feature/login",
+      "latestCommit": "9f4fac7ec5642099982a86f584f2c4a362adb670",
+      "repository": {
+        "slug": "repo",
+        "project": {
+          "key": "PRJ"
+        }
+      }
+    },
+    "toRef": {
+      "id": "refs/heads/master",
+      "displayId": "master",
+      "latestCommit": "178864a7d521b6f5e720b386b2c2b0ef8563e0dc",
+      "repository": {
+        "slug": "repo",
+        "project": {
+          "key": "PRJ"
+        }
+      }
+    }
+  }
+}`
+
 var bitbucketPushEvent = `
 	{
     "eventKey": "repo:refs_changed",
@@ -581,3 +786,201 @@ var githubTagEvent = `
   }
 }
 `
+
+func Test_doWebHookExecutionGitea(t *testing.T) {
+	log.SetLogger(t)
+	s := Service{}
+	task := &sdk.TaskExecution{
+		UUID: sdk.RandomString(10),
+		Type: TypeRepoManagerWebHook,
+		WebHook: &sdk.WebHookExecution{
+			RequestBody: []byte(giteaPushEvent),
+			RequestHeader: map[string][]string{
+				GiteaHeader:  {"push"},
+				GithubHeader: {"push"},
+			},
+		},
+	}
+	h, err := s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "develop", h.Payload["git.branch"])
+	assert.Equal(t, "gitea", h.Payload["git.author"])
+	assert.Equal(t, "Add the changelog", h.Payload["git.message"])
+	assert.Equal(t, "bffeb74224043ba2feb48d137756c8a9331c449a", h.Payload["git.hash"])
+	assert.Equal(t, "gitea/webhooks", h.Payload["git.repository"])
+	assert.Equal(t, `["CHANGELOG.md","docs/index.md"]`, h.Payload["git.changed_files"])
+
+	task.Config = sdk.WorkflowNodeHookConfig{
+		sdk.RepositoryWebHookModelEvents: {Value: "push,pull_request,fork_pull_request"},
+	}
+	task.WebHook = &sdk.WebHookExecution{
+		RequestBody: []byte(giteaPullRequestEvent),
+		RequestHeader: map[string][]string{
+			GiteaHeader:  {"pull_request"},
+			GithubHeader: {"pull_request"},
+		},
+	}
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "2", h.Payload["git.pr.id"])
+	assert.Equal(t, "synchronize", h.Payload["git.pr.action"])
+	assert.Equal(t, "feat/changelog", h.Payload["git.pr.source.branch"])
+	assert.Equal(t, "john/webhooks", h.Payload["git.pr.source.repository"])
+	assert.Equal(t, "master", h.Payload["git.pr.target.branch"])
+	assert.Equal(t, "john", h.Payload["git.pr.author"])
+	assert.Equal(t, "4ad5e1c3fe1d6af0b3a4fcbf0f5e1b17ab5cc82e", h.Payload["git.hash"])
+
+	task.WebHook = &sdk.WebHookExecution{
+		RequestBody: []byte(gogsPullRequestEvent),
+		RequestHeader: map[string][]string{
+			GogsHeader: {"pull_request"},
+		},
+	}
+	h, err = s.doWebHookExecution(task)
+	test.NoError(t, err)
+	assert.Equal(t, "3", h.Payload["git.pr.id"])
+	assert.Equal(t, "merged", h.Payload["git.pr.action"])
+	assert.Equal(t, "fix/typo", h.Payload["git.pr.source.branch"])
+	assert.Equal(t, "unknwon/webhooks", h.Payload["git.pr.target.repository"])
+	assert.Equal(t, "unknwon", h.Payload["git.pr.author"])
+}
+
+var giteaPushEvent = `{
+  "secret": "",
+  "ref": "refs/heads/develop",
+  "before": "28e1879d029cb852e4844d9c718537df08844e03",
+  "after": "bffeb74224043ba2feb48d137756c8a9331c449a",
+  "compare_url": "http://localhost:3000/gitea/webhooks/compare/28e1879d029cb852e4844d9c718537df08844e03...bffeb74224043ba2feb48d137756c8a9331c449a",
+  "commits": [
+    {
+      "id": "bffeb74224043ba2feb48d137756c8a9331c449a",
+      "message": "Add the changelog",
+      "url": "http://localhost:3000/gitea/webhooks/commit/bffeb74224043ba2feb48d137756c8a9331c449a",
+      "author": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "committer": {
+        "name": "Gitea",
+        "email": "someone@gitea.io",
+        "username": "gitea"
+      },
+      "timestamp": "2017-03-13T13:52:11-04:00",
+      "added": ["CHANGELOG.md"],
+      "removed": [],
+      "modified": ["docs/index.md"]
+    }
+  ],
+  "total_commits": 1,
+  "repository": {
+    "id": 140,
+    "name": "webhooks",
+    "full_name": "gitea/webhooks",
+    "clone_url": "http://localhost:3000/gitea/webhooks.git"
+  },
+  "pusher": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "username": "gitea"
+  },
+  "sender": {
+    "id": 1,
+    "login": "gitea",
+    "full_name": "Gitea",
+    "email": "someone@gitea.io",
+    "username": "gitea"
+  }
+}`
+
+var giteaPullRequestEvent = `{
+  "action": "synchronized",
+  "number": 2,
+  "pull_request": {
+    "id": 12,
+    "number": 2,
+    "user": {
+      "id": 2,
+      "login": "john",
+      "full_name": "John Doe",
+      "email": "john@localhost"
+    },
+    "title": "Add the changelog",
+    "state": "open",
+    "merged": false,
+    "head": {
+      "label": "feat/changelog",
+      "ref": "feat/changelog",
+      "sha": "4ad5e1c3fe1d6af0b3a4fcbf0f5e1b17ab5cc82e",
+      "repo": {
+        "id": 141,
+        "name": "webhooks",
+        "full_name": "john/webhooks"
+      }
+    },
+    "base": {
+      "label": "master",
+      "ref": "master",
+      "sha": "28e1879d029cb852e4844d9c718537df08844e03",
+      "repo": {
+        "id": 140,
+        "name": "webhooks",
+        "full_name": "gitea/webhooks"
+      }
+    }
+  },
+  "repository": {
+    "id": 140,
+    "name": "webhooks",
+    "full_name": "gitea/webhooks"
+  },
+  "sender": {
+    "id": 2,
+    "login": "john",
+    "full_name": "John Doe",
+    "email": "john@localhost"
+  }
+}`
+
+var gogsPullRequestEvent = `{
+  "action": "closed",
+  "number": 3,
+  "pull_request": {
+    "id": 5,
+    "number": 3,
+    "title": "Fix a typo",
+    "user": {
+      "id": 1,
+      "username": "unknwon",
+      "full_name": "Unknwon",
+      "email": "u@gogs.io"
+    },
+    "state": "closed",
+    "head_branch": "fix/typo",
+    "head_repo": {
+      "id": 2,
+      "name": "webhooks",
+      "full_name": "unknwon/webhooks"
+    },
+    "base_branch": "master",
+    "base_repo": {
+      "id": 2,
+      "name": "webhooks",
+      "full_name": "unknwon/webhooks"
+    },
+    "merged": true
+  },
+  "repository": {
+    "id": 2,
+    "name": "webhooks",
+    "full_name": "unknwon/webhooks"
+  },
+  "sender": {
+    "id": 1,
+    "username": "unknwon",
+    "full_name": "Unknwon",
+    "email": "u@gogs.io"
+  }
+}`
@@ -42,3 +42,40 @@ type BitbucketPushEvent struct {
 		Type     string `json:"type"`
 	} `json:"changes"`
 }
+
+// BitbucketPullRequestEvent represents payload send by bitbucket on a pull request event
+type BitbucketPullRequestEvent struct {
+	EventKey    string        `json:"eventKey"`
+	Date        string        `json:"date"`
+	Actor       BitbucketUser `json:"actor"`
+	PullRequest struct {
+		ID     int    `json:"id"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Author struct {
+			User BitbucketUser `json:"user"`
+		} `json:"author"`
+		FromRef BitbucketPullRequestRef `json:"fromRef"`
+		ToRef   BitbucketPullRequestRef `json:"toRef"`
+	} `json:"pullRequest"`
+}
+
+// BitbucketUser represents a user in a bitbucket event
+type BitbucketUser struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+// BitbucketPullRequestRef represents the source or the target of a pull request
+type BitbucketPullRequestRef struct {
+	ID           string `json:"id"`
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+	Repository   struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+	} `json:"repository"`
+}
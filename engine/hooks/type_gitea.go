@@ -0,0 +1,91 @@
+package hooks
+
+// GiteaUser represents a user in the payloads sent by gitea and gogs
+type GiteaUser struct {
+	Login    string `json:"login"`
+	Username string `json:"username"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// Name returns the login of the user, gogs only sends the username in some payloads
+func (u GiteaUser) Name() string {
+	if u.Login != "" {
+		return u.Login
+	}
+	return u.Username
+}
+
+// GiteaRepository represents a repository in the payloads sent by gitea and gogs
+type GiteaRepository struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+}
+
+// GiteaPushEvent represents payload send by gitea or gogs on a push event
+type GiteaPushEvent struct {
+	Ref     string `json:"ref"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name     string `json:"name"`
+			Email    string `json:"email"`
+			Username string `json:"username"`
+		} `json:"author"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+	TotalCommits int             `json:"total_commits"`
+	Repository   GiteaRepository `json:"repository"`
+	Pusher       GiteaUser       `json:"pusher"`
+	Sender       GiteaUser       `json:"sender"`
+}
+
+// ChangedFiles returns the files added, removed or modified by the pushed commits.
+// It returns nil if the list of commits is truncated, or if the total number of commits is unknown like with Gogs
+func (g *GiteaPushEvent) ChangedFiles() []string {
+	if len(g.Commits) == 0 || len(g.Commits) != g.TotalCommits {
+		return nil
+	}
+	files := []string{}
+	for _, c := range g.Commits {
+		files = append(files, c.Added...)
+		files = append(files, c.Removed...)
+		files = append(files, c.Modified...)
+	}
+	return uniqueSortedFiles(files)
+}
+
+// GiteaPullRequestEvent represents payload send by gitea or gogs on a pull request event
+type GiteaPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Number     int                  `json:"number"`
+		Title      string               `json:"title"`
+		User       GiteaUser            `json:"user"`
+		Merged     bool                 `json:"merged"`
+		HeadBranch string               `json:"head_branch"`
+		HeadRepo   *GiteaRepository     `json:"head_repo"`
+		Head       *GiteaPullRequestRef `json:"head"`
+		BaseBranch string               `json:"base_branch"`
+		BaseRepo   *GiteaRepository     `json:"base_repo"`
+		Base       *GiteaPullRequestRef `json:"base"`
+	} `json:"pull_request"`
+	Repository GiteaRepository `json:"repository"`
+	Sender     GiteaUser       `json:"sender"`
+}
+
+// GiteaPullRequestRef represents the head or the base of a pull request sent by gitea, gogs does not send them
+type GiteaPullRequestRef struct {
+	Ref  string          `json:"ref"`
+	Sha  string          `json:"sha"`
+	Repo GiteaRepository `json:"repo"`
+}
@@ -32,9 +32,9 @@ type GithubPushEvent struct {
 			Email    string `json:"email"`
 			Username string `json:"username"`
 		} `json:"committer"`
-		Added    []interface{} `json:"added"`
-		Removed  []interface{} `json:"removed"`
-		Modified []string      `json:"modified"`
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
 	} `json:"commits"`
 	HeadCommit struct {
 		ID        string `json:"id"`
@@ -174,3 +174,50 @@ func (g *GithubPushEvent) GetCommits() []sdk.VCSCommit {
 	}
 	return commits
 }
+
+// ChangedFiles returns the files added, removed or modified by the pushed commits.
+// Github describes the 20 first commits of a push only, so it returns nil if the list of commits may be truncated
+func (g *GithubPushEvent) ChangedFiles() []string {
+	if len(g.Commits) == 0 || len(g.Commits) >= githubPushEventMaxCommits {
+		return nil
+	}
+	files := []string{}
+	for _, c := range g.Commits {
+		files = append(files, c.Added...)
+		files = append(files, c.Removed...)
+		files = append(files, c.Modified...)
+	}
+	return uniqueSortedFiles(files)
+}
+
+// GithubPullRequestEvent represents payload send by github on a pull_request event
+type GithubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Head GithubPullRequestRef `json:"head"`
+		Base GithubPullRequestRef `json:"base"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// GithubPullRequestRef represents the source or the target of a pull request
+type GithubPullRequestRef struct {
+	Ref  string `json:"ref"`
+	Sha  string `json:"sha"`
+	Repo struct {
+		FullName string `json:"full_name"`
+	} `json:"repo"`
+}
@@ -53,9 +53,9 @@ type GitlabPushEvent struct {
 			Name  string `json:"name"`
 			Email string `json:"email"`
 		} `json:"author"`
-		Added    []string      `json:"added"`
-		Modified []string      `json:"modified"`
-		Removed  []interface{} `json:"removed"`
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
 	} `json:"commits"`
 	TotalCommitsCount int `json:"total_commits_count"`
 }
@@ -78,3 +78,54 @@ func (g *GitlabPushEvent) GetCommits() []sdk.VCSCommit {
 	}
 	return commits
 }
+
+// ChangedFiles returns the files added, removed or modified by the pushed commits.
+// It returns nil if the list of commits is truncated
+func (g *GitlabPushEvent) ChangedFiles() []string {
+	if len(g.Commits) == 0 || len(g.Commits) != g.TotalCommitsCount {
+		return nil
+	}
+	files := []string{}
+	for _, c := range g.Commits {
+		files = append(files, c.Added...)
+		files = append(files, c.Removed...)
+		files = append(files, c.Modified...)
+	}
+	return uniqueSortedFiles(files)
+}
+
+// GitlabMergeRequestEvent represents payload send by gitlab on a merge request event
+type GitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Name     string `json:"name"`
+		Username string `json:"username"`
+		Email    string `json:"email"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		State        string `json:"state"`
+		Action       string `json:"action"`
+		OldRev       string `json:"oldrev"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		Source       struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"source"`
+		Target struct {
+			PathWithNamespace string `json:"path_with_namespace"`
+		} `json:"target"`
+		LastCommit struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			Author  struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+			} `json:"author"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+}
@@ -225,6 +225,13 @@ var configNewCmd = &cobra.Command{
 					Secret: "xxxx",
 				},
 			}
+			conf.VCS.Servers["Gitea"] = vcs.ServerConfiguration{
+				URL: "https://mygitea.com",
+				Gitea: &vcs.GiteaServerConfiguration{
+					ClientID:     "xxxx",
+					ClientSecret: "xxxx",
+				},
+			}
 		}
 
 		if !configNewAsEnvFlag {
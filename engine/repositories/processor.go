@@ -32,6 +32,18 @@ func (s *Service) processor(ctx context.Context) error {
 func (s *Service) do(op sdk.Operation) error {
 	log.Info("repositories > processing > %v", op.UUID)
 
+	// Listing the references of a repository does not need a clone, nor the lock of the repository
+	if op.LsRemote != nil {
+		if err := s.processLsRemote(&op); err != nil {
+			op.Error = err.Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
+		return s.dao.saveOperation(&op)
+	}
+
 	r := s.Repo(op)
 	if s.dao.lock(r.ID()) == errLockUnavailable {
 		return errLockUnavailable
@@ -65,6 +77,14 @@ func (s *Service) do(op sdk.Operation) error {
 			op.Error = ""
 			op.Status = sdk.OperationStatusDone
 		}
+	case op.Diff.Base != "":
+		if err := s.processDiff(&op); err != nil {
+			op.Error = err.Error()
+			op.Status = sdk.OperationStatusError
+		} else {
+			op.Error = ""
+			op.Status = sdk.OperationStatusDone
+		}
 	default:
 		op.Error = "unrecognized operation"
 		op.Status = sdk.OperationStatusError
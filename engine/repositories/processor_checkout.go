@@ -5,9 +5,17 @@ import (
 
 	"github.com/ovh/cds/sdk"
 	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs/git"
 )
 
 func (s *Service) processCheckout(op *sdk.Operation) error {
+	// The repositories which are not attached to a repositories manager, like the ones of the git ref pollers, must be public
+	if op.VCSServer == "" {
+		if err := git.CheckRemoteURL(op.URL, op.RepositoryStrategy.ConnectionType == "ssh"); err != nil {
+			return err
+		}
+	}
+
 	r := s.Repo(*op)
 	if err := s.checkOrCreateFS(r); err != nil {
 		log.Error("Repositories> processCheckout> checkOrCreateFS> [%s] Error %v", op.UUID, err)
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (s *Service) processDiff(op *sdk.Operation) error {
+	r := s.Repo(*op)
+
+	head := op.Diff.Head
+	if head == "" {
+		head = "HEAD"
+	}
+
+	if !sdk.IsGitHash(op.Diff.Base) || (head != "HEAD" && !sdk.IsGitHash(head)) {
+		return fmt.Errorf("unable to diff %s and %s: invalid commit hash", op.Diff.Base, head)
+	}
+
+	stderr := new(bytes.Buffer)
+	cmd := exec.Command("git", "diff", "--name-only", "--end-of-options", op.Diff.Base, head, "--")
+	cmd.Dir = r.Basedir
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		log.Error("Repositories> processDiff> [%s] Error: %v %s", op.UUID, err, stderr.String())
+		return fmt.Errorf("unable to diff %s and %s: %s", op.Diff.Base, head, stderr.String())
+	}
+
+	op.Diff.Files = []string{}
+	for _, f := range strings.Split(string(out), "\n") {
+		if f = strings.TrimSpace(f); f != "" {
+			op.Diff.Files = append(op.Diff.Files, f)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+	"github.com/ovh/cds/sdk/vcs"
+	"github.com/ovh/cds/sdk/vcs/git"
+)
+
+func (s *Service) processLsRemote(op *sdk.Operation) error {
+	withKey := op.RepositoryStrategy.ConnectionType == "ssh"
+	if err := git.CheckRemoteURL(op.URL, withKey); err != nil {
+		return err
+	}
+
+	var auth *git.AuthOpts
+	if withKey {
+		if op.RepositoryStrategy.SSHKeyContent == "" {
+			return fmt.Errorf("unable to list the references of %s: ssh key %s not found", op.URL, op.RepositoryStrategy.SSHKey)
+		}
+		dir, err := ioutil.TempDir("", "cds-lsremote-")
+		if err != nil {
+			log.Error("Repositories> processLsRemote> [%s] TempDir> Error: %v", op.UUID, err)
+			return err
+		}
+		defer os.RemoveAll(dir)
+		filename := filepath.Join(dir, "id_rsa")
+		if err := ioutil.WriteFile(filename, []byte(op.RepositoryStrategy.SSHKeyContent), os.FileMode(0600)); err != nil {
+			log.Error("Repositories> processLsRemote> [%s] WriteFile> Error: %v", op.UUID, err)
+			return err
+		}
+		auth = &git.AuthOpts{PrivateKey: vcs.SSHKey{Filename: filename, Content: []byte(op.RepositoryStrategy.SSHKeyContent)}}
+	}
+
+	stderr := new(bytes.Buffer)
+	refs, err := git.LsRemote(context.Background(), op.URL, auth, &git.OutputOpts{Stderr: stderr})
+	if err != nil {
+		log.Error("Repositories> processLsRemote> [%s] Error: %v %s", op.UUID, err, stderr.String())
+		return fmt.Errorf("unable to list the references of %s: %s", op.URL, stderr.String())
+	}
+
+	op.LsRemote.Refs = refs
+	return nil
+}
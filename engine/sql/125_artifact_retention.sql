@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "artifact_retention_policy" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    workflow_id BIGINT,
+    max_age BIGINT NOT NULL DEFAULT 0,
+    max_total_size BIGINT NOT NULL DEFAULT 0,
+    keep_last_success BIGINT NOT NULL DEFAULT 0,
+    keep_tags JSONB
+);
+
+SELECT create_foreign_key_idx_cascade('FK_ARTIFACT_RETENTION_POLICY_PROJECT', 'artifact_retention_policy', 'project', 'project_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_ARTIFACT_RETENTION_POLICY_WORKFLOW', 'artifact_retention_policy', 'workflow', 'workflow_id', 'id');
+CREATE UNIQUE INDEX IDX_ARTIFACT_RETENTION_POLICY_UNIQ ON artifact_retention_policy(project_id, coalesce(workflow_id, 0));
+
+CREATE TABLE IF NOT EXISTS "worker_cache" (
+    project_id BIGINT NOT NULL,
+    tag VARCHAR(256) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    PRIMARY KEY (project_id, tag)
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKER_CACHE_PROJECT', 'worker_cache', 'project', 'project_id', 'id');
+
+-- +migrate Down
+DROP TABLE artifact_retention_policy;
+DROP TABLE worker_cache;
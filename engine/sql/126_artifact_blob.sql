@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "artifact_blob" (
+    blob_key VARCHAR(256) PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    sha512sum VARCHAR(128) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_used TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_ARTIFACT_BLOB_PROJECT', 'artifact_blob', 'project', 'project_id', 'id');
+
+ALTER TABLE workflow_node_run_artifacts ADD COLUMN blob_key VARCHAR(256);
+SELECT create_index('workflow_node_run_artifacts', 'IDX_WORKFLOW_NODE_RUN_ARTIFACTS_BLOB_KEY', 'blob_key');
+
+-- +migrate Down
+ALTER TABLE workflow_node_run_artifacts DROP COLUMN blob_key;
+DROP TABLE artifact_blob;
@@ -0,0 +1,36 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "project_webhook" (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types JSONB,
+    workflow_names JSONB,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    last_modified TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_WEBHOOK_PROJECT', 'project_webhook', 'project', 'project_id', 'id');
+SELECT create_unique_index('project_webhook', 'IDX_PROJECT_WEBHOOK_NAME_UNIQ', 'project_id,name');
+
+CREATE TABLE IF NOT EXISTS "project_webhook_delivery" (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event_type VARCHAR(256) NOT NULL,
+    workflow_name VARCHAR(256),
+    payload TEXT,
+    status VARCHAR(32) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    response_code INT NOT NULL DEFAULT 0,
+    error TEXT,
+    redelivery BOOLEAN NOT NULL DEFAULT false,
+    created TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP
+);
+
+SELECT create_foreign_key_idx_cascade('FK_PROJECT_WEBHOOK_DELIVERY_WEBHOOK', 'project_webhook_delivery', 'project_webhook', 'webhook_id', 'id');
+
+-- +migrate Down
+DROP TABLE project_webhook_delivery;
+DROP TABLE project_webhook;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE workflow_node_context ADD COLUMN approval JSONB;
+ALTER TABLE workflow_node_run ADD COLUMN approval JSONB;
+
+-- +migrate Down
+ALTER TABLE workflow_node_context DROP COLUMN approval;
+ALTER TABLE workflow_node_run DROP COLUMN approval;
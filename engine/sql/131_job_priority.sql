@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE pipeline_action ADD COLUMN priority INT DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE pipeline_action DROP COLUMN priority;
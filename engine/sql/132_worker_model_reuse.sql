@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE worker_model ADD COLUMN reuse JSONB;
+
+-- +migrate Down
+ALTER TABLE worker_model DROP COLUMN reuse;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pipeline_action ADD COLUMN timeout VARCHAR(50) DEFAULT '';
+ALTER TABLE action_edge ADD COLUMN timeout VARCHAR(50) DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE pipeline_action DROP COLUMN timeout;
+ALTER TABLE action_edge DROP COLUMN timeout;
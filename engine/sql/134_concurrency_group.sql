@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE workflow ADD COLUMN concurrency JSONB;
+ALTER TABLE workflow_node_context ADD COLUMN concurrency JSONB;
+ALTER TABLE workflow_node_run ADD COLUMN concurrency JSONB;
+CREATE INDEX IDX_WORKFLOW_NODE_RUN_CONCURRENCY_GROUP ON workflow_node_run ((concurrency->>'group')) WHERE concurrency IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IDX_WORKFLOW_NODE_RUN_CONCURRENCY_GROUP;
+ALTER TABLE workflow DROP COLUMN concurrency;
+ALTER TABLE workflow_node_context DROP COLUMN concurrency;
+ALTER TABLE workflow_node_run DROP COLUMN concurrency;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE workflow ADD COLUMN cancel_superseded_runs BOOLEAN DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE workflow DROP COLUMN cancel_superseded_runs;
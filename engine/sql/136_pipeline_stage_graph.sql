@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pipeline_stage ADD COLUMN needs JSONB;
+ALTER TABLE pipeline_stage ADD COLUMN run_conditions JSONB;
+
+-- +migrate Down
+ALTER TABLE pipeline_stage DROP COLUMN needs;
+ALTER TABLE pipeline_stage DROP COLUMN run_conditions;
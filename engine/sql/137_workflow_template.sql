@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_template" (
+    id BIGSERIAL PRIMARY KEY,
+    group_id BIGINT NOT NULL,
+    name VARCHAR(256) NOT NULL,
+    description TEXT,
+    version BIGINT NOT NULL DEFAULT 1,
+    updated TIMESTAMP WITH TIME ZONE DEFAULT LOCALTIMESTAMP,
+    parameters JSONB,
+    workflow TEXT,
+    pipelines JSONB,
+    applications JSONB,
+    environments JSONB
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEMPLATE_GROUP', 'workflow_template', 'group', 'group_id', 'id');
+SELECT create_unique_index('workflow_template', 'IDX_WORKFLOW_TEMPLATE_GROUP_NAME', 'group_id,name');
+
+CREATE TABLE IF NOT EXISTS "workflow_template_instance" (
+    id BIGSERIAL PRIMARY KEY,
+    workflow_template_id BIGINT NOT NULL,
+    workflow_template_version BIGINT NOT NULL,
+    project_id BIGINT NOT NULL,
+    workflow_id BIGINT,
+    request JSONB
+);
+
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEMPLATE_INSTANCE_TEMPLATE', 'workflow_template_instance', 'workflow_template', 'workflow_template_id', 'id');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_TEMPLATE_INSTANCE_PROJECT', 'workflow_template_instance', 'project', 'project_id', 'id');
+ALTER TABLE workflow_template_instance ADD CONSTRAINT fk_workflow_template_instance_workflow FOREIGN KEY (workflow_id) REFERENCES workflow(id) ON DELETE SET NULL;
+
+-- +migrate Down
+DROP TABLE workflow_template_instance;
+DROP TABLE workflow_template;
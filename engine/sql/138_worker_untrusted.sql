@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE worker ADD COLUMN untrusted BOOLEAN DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE worker DROP COLUMN untrusted;
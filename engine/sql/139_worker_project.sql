@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE worker ADD COLUMN project_id BIGINT;
+
+-- +migrate Down
+ALTER TABLE worker DROP COLUMN project_id;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "workflow_concurrency_group" (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(256) NOT NULL,
+    group_id BIGINT NOT NULL
+);
+
+SELECT create_unique_index('workflow_concurrency_group', 'IDX_WORKFLOW_CONCURRENCY_GROUP_NAME', 'name');
+SELECT create_foreign_key_idx_cascade('FK_WORKFLOW_CONCURRENCY_GROUP_GROUP', 'workflow_concurrency_group', 'group', 'group_id', 'id');
+
+-- +migrate Down
+DROP TABLE IF EXISTS "workflow_concurrency_group";
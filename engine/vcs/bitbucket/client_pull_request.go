@@ -101,3 +101,73 @@ func (b *bitbucketClient) PullRequestComment(ctx context.Context, repo string, p
 
 	return b.do(ctx, "POST", "core", path, nil, values, nil, &options{asUser: true})
 }
+
+// PullRequestComments returns the comments of a pull request, from its activities
+func (b *bitbucketClient) PullRequestComments(ctx context.Context, repo string, prID int) ([]sdk.VCSPullRequestComment, error) {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return nil, sdk.WrapError(err, "vcs> bitbucket> PullRequestComments>")
+	}
+
+	author, err := b.commentAuthor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/activities", project, slug, prID)
+	params := url.Values{}
+	comments := []sdk.VCSPullRequestComment{}
+	for {
+		var response PullRequestActivityResponse
+		if err := b.do(ctx, "GET", "core", path, params, nil, &response, nil); err != nil {
+			return nil, sdk.WrapError(err, "vcs> bitbucket> PullRequestComments> Unable to get activities")
+		}
+		for _, a := range response.Values {
+			if a.Action != "COMMENTED" || a.CommentAction != "ADDED" || a.Comment == nil {
+				continue
+			}
+			comments = append(comments, sdk.VCSPullRequestComment{
+				ID:      a.Comment.ID,
+				Body:    a.Comment.Text,
+				Author:  a.Comment.Author.Username,
+				Bot:     author != "" && strings.EqualFold(a.Comment.Author.Username, author),
+				Version: a.Comment.Version,
+			})
+		}
+		if response.IsLastPage {
+			break
+		}
+		params.Set("start", fmt.Sprintf("%d", response.NextPageStart))
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the text of a comment on a pull request
+func (b *bitbucketClient) PullRequestCommentUpdate(ctx context.Context, repo string, prID int, comment sdk.VCSPullRequestComment) error {
+	project, slug, err := getRepo(repo)
+	if err != nil {
+		return sdk.WrapError(err, "vcs> bitbucket> PullRequestCommentUpdate>")
+	}
+	values, _ := json.Marshal(Comment{
+		Version: comment.Version,
+		Text:    comment.Body,
+	})
+	path := fmt.Sprintf("/projects/%s/repos/%s/pull-requests/%d/comments/%d", project, slug, prID, comment.ID)
+
+	return b.do(ctx, "PUT", "core", path, nil, values, nil, &options{asUser: true})
+}
+
+// commentAuthor returns the name of the author of the comments posted on the pull requests:
+// the configured user if it has a token, the user of the oauth token otherwise
+func (b *bitbucketClient) commentAuthor(ctx context.Context) (string, error) {
+	if b.username != "" && b.token != "" {
+		return b.username, nil
+	}
+	// The username path reads the name of the authenticated user from the headers of the response.
+	// The limit keeps the cached response apart from the one of the list of the repositories
+	var user User
+	if err := b.do(ctx, "GET", "core", "username", url.Values{"limit": {"1"}}, nil, &user, nil); err != nil {
+		return "", sdk.WrapError(err, "vcs> bitbucket> commentAuthor> Unable to get the authenticated user")
+	}
+	return user.Username, nil
+}
@@ -327,6 +327,30 @@ type PullRequest struct {
 	} `json:"links"`
 }
 
+// Comment represents a comment on a pull request
+type Comment struct {
+	ID      int64  `json:"id"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+	Author  User   `json:"author"`
+}
+
+// PullRequestActivity represents an activity of a pull request
+type PullRequestActivity struct {
+	ID            int64    `json:"id"`
+	Action        string   `json:"action"`
+	CommentAction string   `json:"commentAction"`
+	Comment       *Comment `json:"comment"`
+}
+
+// PullRequestActivityResponse is a page of the activities of a pull request
+type PullRequestActivityResponse struct {
+	Values        []PullRequestActivity `json:"values"`
+	Size          int                   `json:"size"`
+	NextPageStart int                   `json:"nextPageStart"`
+	IsLastPage    bool                  `json:"isLastPage"`
+}
+
 type PullRequestResponse struct {
 	Values        []PullRequest `json:"values"`
 	Size          int           `json:"size"`
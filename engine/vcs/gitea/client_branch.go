@@ -0,0 +1,66 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (b Branch) toVCSBranch(defaultBranch string) sdk.VCSBranch {
+	return sdk.VCSBranch{
+		ID:           b.Name,
+		DisplayID:    b.Name,
+		LatestCommit: b.Commit.ID,
+		Default:      b.Name == defaultBranch,
+	}
+}
+
+// Branches returns list of branches for a repo
+func (c *giteaClient) Branches(ctx context.Context, fullname string) ([]sdk.VCSBranch, error) {
+	repo, err := c.repoByFullname(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := []sdk.VCSBranch{}
+	err = c.getAll(func(p int) (int, error) {
+		var page []Branch
+		if err := c.get(ctx, pagePath("/repos/"+fullname+"/branches", p), &page); err != nil {
+			return 0, err
+		}
+		for _, b := range page {
+			branches = append(branches, b.toVCSBranch(repo.DefaultBranch))
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Branches> Unable to list branches of %s", fullname)
+	}
+	return branches, nil
+}
+
+// Branch returns only detail of a branch
+func (c *giteaClient) Branch(ctx context.Context, fullname, branchName string) (*sdk.VCSBranch, error) {
+	repo, err := c.repoByFullname(ctx, fullname)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Branch
+	status, err := c.do(ctx, http.MethodGet, "/repos/"+fullname+"/branches/"+url.PathEscape(branchName), nil, &b, nil)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, sdk.ErrNoBranch
+		}
+		return nil, sdk.WrapError(err, "giteaClient.Branch> Unable to get branch %s of %s", branchName, fullname)
+	}
+	if b.Name == "" {
+		return nil, fmt.Errorf("giteaClient.Branch> Cannot find branch %s", branchName)
+	}
+
+	branch := b.toVCSBranch(repo.DefaultBranch)
+	return &branch, nil
+}
@@ -0,0 +1,95 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (c Commit) toVCSCommit() sdk.VCSCommit {
+	commit := sdk.VCSCommit{
+		Hash:      c.Sha,
+		Message:   c.Commit.Message,
+		URL:       c.HTMLURL,
+		Timestamp: c.Commit.Author.Date.Unix() * 1000,
+		Author: sdk.VCSAuthor{
+			Name:        c.Commit.Author.Name,
+			DisplayName: c.Commit.Author.Name,
+			Email:       c.Commit.Author.Email,
+		},
+	}
+	if c.Author != nil {
+		commit.Author.Name = c.Author.Login
+		commit.Author.Avatar = c.Author.AvatarURL
+	}
+	return commit
+}
+
+// commitsMaxPages is the maximum number of pages of commits read to find the stop commit
+const commitsMaxPages = 20
+
+// commitsUntil lists the commits of a ref until the stop commit, which is excluded, and returns whether the stop commit was found.
+// Without stop commit, only the first page of commits is returned. The search stops after commitsMaxPages pages
+func (c *giteaClient) commitsUntil(ctx context.Context, repo, ref, stop string) ([]sdk.VCSCommit, bool, error) {
+	commits := []sdk.VCSCommit{}
+	var found bool
+	err := c.getAll(func(p int) (int, error) {
+		var page []Commit
+		if err := c.get(ctx, pagePath("/repos/"+repo+"/commits?sha="+url.QueryEscape(ref), p), &page); err != nil {
+			return 0, err
+		}
+		for _, commit := range page {
+			if commit.Sha == stop {
+				found = true
+				break
+			}
+			commits = append(commits, commit.toVCSCommit())
+		}
+		if found || stop == "" || p >= commitsMaxPages {
+			return 0, nil
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, false, sdk.WrapError(err, "giteaClient.commitsUntil> Unable to list commits of %s on %s", repo, ref)
+	}
+	return commits, found, nil
+}
+
+// Commits returns the commits of a branch, from the commit since (excluded) to the commit until
+func (c *giteaClient) Commits(ctx context.Context, repo, branch, since, until string) ([]sdk.VCSCommit, error) {
+	ref := until
+	if ref == "" {
+		ref = branch
+	}
+	commits, _, err := c.commitsUntil(ctx, repo, ref, since)
+	return commits, err
+}
+
+// Commit retrieves a specific according to a hash
+func (c *giteaClient) Commit(ctx context.Context, repo, hash string) (sdk.VCSCommit, error) {
+	var commit Commit
+	if err := c.get(ctx, "/repos/"+repo+"/git/commits/"+hash, &commit); err != nil {
+		return sdk.VCSCommit{}, sdk.WrapError(err, "giteaClient.Commit> Unable to get commit %s of %s", hash, repo)
+	}
+	return commit.toVCSCommit(), nil
+}
+
+// CommitsBetweenRefs returns the commits of head which are not in base. It fails if base is not
+// in the last commitsMaxPages pages of commits of head, for instance when base is not an ancestor of head
+func (c *giteaClient) CommitsBetweenRefs(ctx context.Context, repo, base, head string) ([]sdk.VCSCommit, error) {
+	baseCommit, err := c.Commit(ctx, repo, base)
+	if err != nil {
+		return nil, err
+	}
+	commits, found, err := c.commitsUntil(ctx, repo, head, baseCommit.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("giteaClient.CommitsBetweenRefs> commit %s not found in the last %d commits of %s on %s", base, commitsMaxPages*pageLimit, repo, head)
+	}
+	return commits, nil
+}
@@ -0,0 +1,34 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+//GetEvents is not implemented
+func (c *giteaClient) GetEvents(ctx context.Context, repo string, dateRef time.Time) ([]interface{}, time.Duration, error) {
+	return nil, 0.0, fmt.Errorf("Not implemented on Gitea")
+}
+
+//PushEvents is not implemented
+func (c *giteaClient) PushEvents(context.Context, string, []interface{}) ([]sdk.VCSPushEvent, error) {
+	return nil, fmt.Errorf("Not implemented on Gitea")
+}
+
+//CreateEvents is not implemented
+func (c *giteaClient) CreateEvents(context.Context, string, []interface{}) ([]sdk.VCSCreateEvent, error) {
+	return nil, fmt.Errorf("Not implemented on Gitea")
+}
+
+//DeleteEvents is not implemented
+func (c *giteaClient) DeleteEvents(context.Context, string, []interface{}) ([]sdk.VCSDeleteEvent, error) {
+	return nil, fmt.Errorf("Not implemented on Gitea")
+}
+
+//PullRequestEvents is not implemented
+func (c *giteaClient) PullRequestEvents(context.Context, string, []interface{}) ([]sdk.VCSPullRequestEvent, error) {
+	return nil, fmt.Errorf("Not implemented on Gitea")
+}
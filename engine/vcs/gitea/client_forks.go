@@ -0,0 +1,26 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ListForks returns the forks of a repository
+func (c *giteaClient) ListForks(ctx context.Context, repo string) ([]sdk.VCSRepo, error) {
+	repos := []sdk.VCSRepo{}
+	err := c.getAll(func(p int) (int, error) {
+		var page []Repository
+		if err := c.get(ctx, pagePath("/repos/"+repo+"/forks", p), &page); err != nil {
+			return 0, err
+		}
+		for _, r := range page {
+			repos = append(repos, r.toVCSRepo())
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.ListForks> Unable to list forks of %s", repo)
+	}
+	return repos, nil
+}
@@ -0,0 +1,111 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func (h Hook) toVCSHook() sdk.VCSHook {
+	return sdk.VCSHook{
+		ID:          strconv.FormatInt(h.ID, 10),
+		Events:      h.Events,
+		URL:         h.Config["url"],
+		ContentType: h.Config["content_type"],
+		Disable:     !h.Active,
+	}
+}
+
+func (c *giteaClient) CreateHook(ctx context.Context, repo string, hook *sdk.VCSHook) error {
+	if c.proxyURL != "" {
+		lastIndexSlash := strings.LastIndex(hook.URL, "/")
+		if c.proxyURL[len(c.proxyURL)-1] == '/' {
+			lastIndexSlash++
+		}
+		hook.URL = c.proxyURL + hook.URL[lastIndexSlash:]
+	}
+
+	events := []string{"push"}
+	// Workflow hooks can also be triggered by pull requests
+	if hook.Workflow {
+		events = append(events, "pull_request")
+	}
+	h := Hook{
+		Type:   "gitea",
+		Active: true,
+		Events: events,
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/repos/"+repo+"/hooks", h, &h, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.CreateHook> Unable to create webhook on %s", repo)
+	}
+	hook.ID = strconv.FormatInt(h.ID, 10)
+	return nil
+}
+
+// findHook returns the webhook of the repository with the url
+func (c *giteaClient) findHook(ctx context.Context, repo, url string) (Hook, error) {
+	var hooks []Hook
+	if err := c.get(ctx, "/repos/"+repo+"/hooks", &hooks); err != nil {
+		return Hook{}, sdk.WrapError(err, "giteaClient.findHook> Unable to list webhooks of %s", repo)
+	}
+	for _, h := range hooks {
+		if h.Config["url"] == url {
+			return h, nil
+		}
+	}
+	return Hook{}, sdk.ErrNotFound
+}
+
+func (c *giteaClient) GetHook(ctx context.Context, repo, url string) (sdk.VCSHook, error) {
+	h, err := c.findHook(ctx, repo, url)
+	if err != nil {
+		return sdk.VCSHook{}, err
+	}
+	return h.toVCSHook(), nil
+}
+
+func (c *giteaClient) UpdateHook(ctx context.Context, repo, url string, hook sdk.VCSHook) error {
+	old, err := c.findHook(ctx, repo, url)
+	if err != nil {
+		return err
+	}
+	h := Hook{
+		Active: !hook.Disable,
+		Events: hook.Events,
+		Config: map[string]string{
+			"url":          hook.URL,
+			"content_type": "json",
+		},
+	}
+	path := fmt.Sprintf("/repos/%s/hooks/%d", repo, old.ID)
+	if _, err := c.do(ctx, http.MethodPatch, path, h, nil, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.UpdateHook> Unable to update webhook %d of %s", old.ID, repo)
+	}
+	return nil
+}
+
+// DeleteHook deletes the webhook by its id, or by its url if the id is unknown
+func (c *giteaClient) DeleteHook(ctx context.Context, repo string, hook sdk.VCSHook) error {
+	id := hook.ID
+	if id == "" {
+		h, err := c.findHook(ctx, repo, hook.URL)
+		if err != nil {
+			return sdk.WrapError(err, "giteaClient.DeleteHook> Unable to find webhook %s on %s", hook.URL, repo)
+		}
+		id = strconv.FormatInt(h.ID, 10)
+	}
+
+	status, err := c.do(ctx, http.MethodDelete, "/repos/"+repo+"/hooks/"+id, nil, nil, nil)
+	if err != nil && status != http.StatusNotFound {
+		return sdk.WrapError(err, "giteaClient.DeleteHook> Unable to delete webhook %s of %s", id, repo)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (b PRBranchInfo) toVCSPushEvent(updatedAt int64) sdk.VCSPushEvent {
+	return sdk.VCSPushEvent{
+		Repo:     b.Repo.FullName,
+		CloneURL: b.Repo.CloneURL,
+		Branch: sdk.VCSBranch{
+			ID:           b.Ref,
+			DisplayID:    b.Ref,
+			LatestCommit: b.Sha,
+		},
+		Commit: sdk.VCSCommit{
+			Hash:      b.Sha,
+			Message:   b.Label,
+			Timestamp: updatedAt,
+		},
+	}
+}
+
+// PullRequests fetch all the opened pull request for a repository
+func (c *giteaClient) PullRequests(ctx context.Context, fullname string) ([]sdk.VCSPullRequest, error) {
+	prs := []sdk.VCSPullRequest{}
+	err := c.getAll(func(p int) (int, error) {
+		var page []PullRequest
+		if err := c.get(ctx, pagePath("/repos/"+fullname+"/pulls?state=open", p), &page); err != nil {
+			return 0, err
+		}
+		for _, pr := range page {
+			updatedAt := pr.UpdatedAt.Unix() * 1000
+			prs = append(prs, sdk.VCSPullRequest{
+				ID:  pr.Number,
+				URL: pr.HTMLURL,
+				User: sdk.VCSAuthor{
+					Name:        pr.User.Login,
+					DisplayName: pr.User.FullName,
+					Email:       pr.User.Email,
+					Avatar:      pr.User.AvatarURL,
+				},
+				Head: pr.Head.toVCSPushEvent(updatedAt),
+				Base: pr.Base.toVCSPushEvent(updatedAt),
+			})
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.PullRequests> Unable to list pull requests of %s", fullname)
+	}
+	return prs, nil
+}
+
+// PullRequestComment push a new comment on a pull request
+func (c *giteaClient) PullRequestComment(ctx context.Context, repo string, id int, text string) error {
+	if c.disableStatus {
+		log.Warning("gitea.PullRequestComment>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, id)
+	if _, err := c.do(ctx, http.MethodPost, path, Comment{Body: text}, nil, &requestOptions{asUser: true}); err != nil {
+		return sdk.WrapError(err, "giteaClient.PullRequestComment> Unable to comment pull request %d of %s", id, repo)
+	}
+	return nil
+}
+
+// PullRequestComments returns the comments of a pull request
+func (c *giteaClient) PullRequestComments(ctx context.Context, repo string, id int) ([]sdk.VCSPullRequestComment, error) {
+	author, err := c.commentAuthor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []sdk.VCSPullRequestComment{}
+	err = c.getAll(func(p int) (int, error) {
+		var page []Comment
+		if err := c.get(ctx, pagePath(fmt.Sprintf("/repos/%s/issues/%d/comments", repo, id), p), &page); err != nil {
+			return 0, err
+		}
+		for _, comment := range page {
+			co := sdk.VCSPullRequestComment{ID: comment.ID, Body: comment.Body}
+			if comment.User != nil {
+				co.Author = comment.User.Name()
+				co.Bot = author != "" && strings.EqualFold(co.Author, author)
+			}
+			res = append(res, co)
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.PullRequestComments> Unable to list comments of pull request %d of %s", id, repo)
+	}
+	return res, nil
+}
+
+// PullRequestCommentUpdate updates the body of a comment on a pull request
+func (c *giteaClient) PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment sdk.VCSPullRequestComment) error {
+	if c.disableStatus {
+		log.Warning("gitea.PullRequestCommentUpdate>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/comments/%d", repo, comment.ID)
+	if _, err := c.do(ctx, http.MethodPatch, path, Comment{Body: comment.Body}, nil, &requestOptions{asUser: true}); err != nil {
+		return sdk.WrapError(err, "giteaClient.PullRequestCommentUpdate> Unable to update comment %d of %s", comment.ID, repo)
+	}
+	return nil
+}
+
+// commentAuthor returns the login of the author of the comments posted on the pull requests:
+// the configured user if it has a token, the user of the oauth token otherwise
+func (c *giteaClient) commentAuthor(ctx context.Context) (string, error) {
+	if c.username != "" && c.token != "" {
+		return c.username, nil
+	}
+	var user User
+	if err := c.get(ctx, "/user", &user); err != nil {
+		return "", sdk.WrapError(err, "giteaClient.commentAuthor> Unable to get the authenticated user")
+	}
+	return user.Name(), nil
+}
@@ -0,0 +1,58 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Release creates a release on gitea
+func (c *giteaClient) Release(ctx context.Context, fullname string, tagName string, title string, releaseNote string) (*sdk.VCSRelease, error) {
+	r := Release{
+		TagName: tagName,
+		Name:    title,
+		Body:    releaseNote,
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/repos/"+fullname+"/releases", r, &r, nil); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Release> Unable to create release %s on %s", tagName, fullname)
+	}
+
+	uploadURL := r.UploadURL
+	if uploadURL == "" {
+		uploadURL = c.apiURL(fmt.Sprintf("/repos/%s/releases/%d/assets", fullname, r.ID))
+	}
+	return &sdk.VCSRelease{
+		ID:        r.ID,
+		UploadURL: uploadURL,
+	}, nil
+}
+
+// UploadReleaseFile attaches a file to the release
+func (c *giteaClient) UploadReleaseFile(ctx context.Context, repo string, releaseName string, uploadURL string, artifactName string, r io.ReadCloser) error {
+	defer r.Close()
+
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("attachment", artifactName)
+	if err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to create form file")
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to read file %s", artifactName)
+	}
+	if err := w.Close(); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to close form")
+	}
+
+	path := uploadURL + "?name=" + url.QueryEscape(artifactName)
+	if _, _, err := c.request(ctx, http.MethodPost, path, body, &requestOptions{contentType: w.FormDataContentType()}); err != nil {
+		return sdk.WrapError(err, "giteaClient.UploadReleaseFile> Unable to upload file %s on release %s", artifactName, releaseName)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+func (r Repository) toVCSRepo() sdk.VCSRepo {
+	return sdk.VCSRepo{
+		ID:           strconv.FormatInt(r.ID, 10),
+		Name:         r.Name,
+		Slug:         r.Name,
+		Fullname:     r.FullName,
+		URL:          r.HTMLURL,
+		HTTPCloneURL: r.CloneURL,
+		SSHCloneURL:  r.SSHURL,
+	}
+}
+
+// Repos list repositories that are accessible to the authenticated user
+func (c *giteaClient) Repos(ctx context.Context) ([]sdk.VCSRepo, error) {
+	repos := []sdk.VCSRepo{}
+	err := c.getAll(func(p int) (int, error) {
+		var page []Repository
+		if err := c.get(ctx, pagePath("/user/repos", p), &page); err != nil {
+			return 0, err
+		}
+		for _, r := range page {
+			repos = append(repos, r.toVCSRepo())
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Repos> Unable to list repositories")
+	}
+	return repos, nil
+}
+
+// RepoByFullname returns only one repo
+func (c *giteaClient) RepoByFullname(ctx context.Context, fullname string) (sdk.VCSRepo, error) {
+	repo, err := c.repoByFullname(ctx, fullname)
+	if err != nil {
+		return sdk.VCSRepo{}, err
+	}
+	return repo.toVCSRepo(), nil
+}
+
+func (c *giteaClient) repoByFullname(ctx context.Context, fullname string) (Repository, error) {
+	var repo Repository
+	status, err := c.do(ctx, http.MethodGet, "/repos/"+fullname, nil, &repo, nil)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return repo, sdk.ErrRepoNotFound
+		}
+		return repo, sdk.WrapError(err, "giteaClient.repoByFullname> Unable to get repository %s", fullname)
+	}
+	return repo, nil
+}
+
+// GrantReadPermission adds the user of the configuration as a collaborator of the repository
+func (c *giteaClient) GrantReadPermission(ctx context.Context, fullname string) error {
+	owner := strings.SplitN(fullname, "/", 2)[0]
+	if c.username == "" || owner == c.username {
+		log.Debug("giteaClient.GrantReadPermission> nothing to do")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/collaborators/%s", fullname, c.username)
+	status, err := c.do(ctx, http.MethodPut, path, Collaborator{Permission: "read"}, nil, nil)
+	if err != nil {
+		return sdk.WrapError(err, "giteaClient.GrantReadPermission> Unable to add %s as collaborator of %s", c.username, fullname)
+	}
+	if status != http.StatusNoContent {
+		return fmt.Errorf("unexpected status code: %d", status)
+	}
+	return nil
+}
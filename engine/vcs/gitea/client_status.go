@@ -0,0 +1,95 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// SetStatus creates a status on the commit of a workflow node run
+func (c *giteaClient) SetStatus(ctx context.Context, event sdk.Event) error {
+	if c.disableStatus {
+		log.Warning("gitea.SetStatus>  ⚠ Gitea statuses are disabled")
+		return nil
+	}
+
+	if event.EventType != fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}) {
+		log.Debug("gitea.SetStatus> Unsupported event %s", event.EventType)
+		return nil
+	}
+
+	var eventNR sdk.EventRunWorkflowNode
+	if err := mapstructure.Decode(event.Payload, &eventNR); err != nil {
+		return sdk.WrapError(err, "giteaClient.SetStatus> Error durring consumption")
+	}
+
+	var state string
+	switch eventNR.Status {
+	case sdk.StatusSuccess.String():
+		state = "success"
+	case sdk.StatusFail.String():
+		state = "failure"
+	case sdk.StatusStopped.String():
+		state = "error"
+	case sdk.StatusWaiting.String(), sdk.StatusBuilding.String():
+		state = "pending"
+	default:
+		log.Debug("gitea.SetStatus> Do not process event for current status: %s", eventNR.Status)
+		return nil
+	}
+
+	s := Status{
+		State:       state,
+		Description: eventNR.NodeName + ": " + eventNR.Status,
+		Context:     sdk.VCSCommitStatusDescription(event.ProjectKey, event.WorkflowName, eventNR),
+	}
+	//CDS can avoid sending gitea target url in status, if it's disable
+	if !c.disableStatusDetail {
+		s.TargetURL = fmt.Sprintf("%s/project/%s/workflow/%s/run/%d", c.uiURL, event.ProjectKey, event.WorkflowName, eventNR.Number)
+	}
+
+	path := fmt.Sprintf("/repos/%s/statuses/%s", eventNR.RepositoryFullName, eventNR.Hash)
+	if _, err := c.do(ctx, http.MethodPost, path, s, nil, nil); err != nil {
+		return sdk.WrapError(err, "giteaClient.SetStatus> Unable to create status on %s", eventNR.RepositoryFullName)
+	}
+	return nil
+}
+
+// ListStatuses returns the CDS statuses of a commit
+func (c *giteaClient) ListStatuses(ctx context.Context, repo string, ref string) ([]sdk.VCSCommitStatus, error) {
+	var ss []Status
+	if err := c.get(ctx, "/repos/"+repo+"/commits/"+ref+"/statuses", &ss); err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.ListStatuses> Unable to list statuses of %s on %s", ref, repo)
+	}
+
+	vcsStatuses := []sdk.VCSCommitStatus{}
+	for _, s := range ss {
+		if !strings.HasPrefix(s.Context, "CDS/") {
+			continue
+		}
+		vcsStatuses = append(vcsStatuses, sdk.VCSCommitStatus{
+			CreatedAt:  s.CreatedAt,
+			Decription: s.Context,
+			Ref:        ref,
+			State:      processGiteaState(s),
+		})
+	}
+	return vcsStatuses, nil
+}
+
+func processGiteaState(s Status) string {
+	switch s.State {
+	case "success":
+		return sdk.StatusSuccess.String()
+	case "error", "failure":
+		return sdk.StatusFail.String()
+	default:
+		return sdk.StatusBuilding.String()
+	}
+}
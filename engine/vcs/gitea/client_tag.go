@@ -0,0 +1,31 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// Tags returns list of tags for a repo
+func (c *giteaClient) Tags(ctx context.Context, fullname string) ([]sdk.VCSTag, error) {
+	tags := []sdk.VCSTag{}
+	err := c.getAll(func(p int) (int, error) {
+		var page []Tag
+		if err := c.get(ctx, pagePath("/repos/"+fullname+"/tags", p), &page); err != nil {
+			return 0, err
+		}
+		for _, t := range page {
+			tags = append(tags, sdk.VCSTag{
+				Tag:     t.Name,
+				Sha:     t.ID,
+				Message: t.Message,
+				Hash:    t.Commit.Sha,
+			})
+		}
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, sdk.WrapError(err, "giteaClient.Tags> Unable to list tags of %s", fullname)
+	}
+	return tags, nil
+}
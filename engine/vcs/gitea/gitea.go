@@ -0,0 +1,61 @@
+package gitea
+
+import (
+	"sync"
+
+	"github.com/ovh/cds/engine/api/cache"
+	"github.com/ovh/cds/sdk"
+)
+
+var (
+	_ sdk.VCSAuthorizedClient = &giteaClient{}
+	_ sdk.VCSServer           = &giteaConsumer{}
+)
+
+// giteaClient implements VCSAuthorizedClient interface
+type giteaClient struct {
+	URL                 string
+	OAuthToken          string
+	refreshToken        string
+	consumer            *giteaConsumer
+	mutex               sync.Mutex
+	Cache               cache.Store
+	uiURL               string
+	proxyURL            string
+	username            string
+	token               string
+	disableStatus       bool
+	disableStatusDetail bool
+}
+
+// giteaConsumer implements vcs.Server and it's used to instanciate a giteaClient
+type giteaConsumer struct {
+	URL                      string `json:"url"`
+	ClientID                 string `json:"client-id"`
+	ClientSecret             string `json:"-"`
+	Cache                    cache.Store
+	AuthorizationCallbackURL string
+	uiURL                    string
+	proxyURL                 string
+	username                 string
+	token                    string
+	disableStatus            bool
+	disableStatusDetail      bool
+}
+
+// New instanciates a new gitea consumer
+func New(clientID, clientSecret, URL, callbackURL, uiURL, proxyURL, username, token string, store cache.Store, disableStatus, disableStatusDetail bool) sdk.VCSServer {
+	return &giteaConsumer{
+		URL:                      URL,
+		ClientID:                 clientID,
+		ClientSecret:             clientSecret,
+		Cache:                    store,
+		AuthorizationCallbackURL: callbackURL,
+		uiURL:                    uiURL,
+		proxyURL:                 proxyURL,
+		username:                 username,
+		token:                    token,
+		disableStatus:            disableStatus,
+		disableStatusDetail:      disableStatusDetail,
+	}
+}
@@ -0,0 +1,336 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/engine/api/test"
+	"github.com/ovh/cds/sdk"
+)
+
+const fixtureRepo = `{"id": 1, "name": "cds", "full_name": "ovh/cds", "html_url": "http://gitea.local/ovh/cds", "clone_url": "http://gitea.local/ovh/cds.git", "ssh_url": "git@gitea.local:ovh/cds.git", "default_branch": "master"}`
+
+func TestAuthorizeToken(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodPost, "/login/oauth/access_token", http.StatusOK, `{"access_token": "my-token", "token_type": "bearer", "refresh_token": "my-refresh-token"}`)
+
+	consumer := New("client-id", "client-secret", s.URL, "http://cds.local/callback", "", "", "", "", nil, false, false)
+
+	state, redirect, err := consumer.AuthorizeRedirect(context.Background())
+	test.NoError(t, err)
+	assert.True(t, strings.HasPrefix(redirect, s.URL+"/login/oauth/authorize?"))
+	assert.Contains(t, redirect, "client_id=client-id")
+	assert.Contains(t, redirect, "state="+state)
+
+	token, secret, err := consumer.AuthorizeToken(context.Background(), state, "my-code")
+	test.NoError(t, err)
+	assert.Equal(t, "my-token", token)
+	assert.Equal(t, "my-refresh-token", secret)
+
+	req := s.lastRequest(http.MethodPost, "/login/oauth/access_token")
+	if assert.NotNil(t, req) {
+		assert.Contains(t, req.Body, "code=my-code")
+		assert.Contains(t, req.Body, "grant_type=authorization_code")
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/user/repos", http.StatusUnauthorized, `{"message": "token is expired"}`)
+	s.on(http.MethodPost, "/login/oauth/access_token", http.StatusOK, `{"access_token": "new-token", "token_type": "bearer", "refresh_token": "new-refresh-token"}`)
+
+	consumer := New("client-id", "client-secret", s.URL, "http://cds.local/callback", "", "", "", "", nil, false, false)
+	vcsClient, err := consumer.GetAuthorizedClient(context.Background(), "expired-token", "my-refresh-token")
+	test.NoError(t, err)
+	c := vcsClient.(*giteaClient)
+
+	// The request is sent again with the new access token, the fixture server still answers unauthorized
+	_, err = c.Repos(context.Background())
+	assert.Error(t, err)
+	req := s.lastRequest(http.MethodPost, "/login/oauth/access_token")
+	if assert.NotNil(t, req) {
+		assert.Contains(t, req.Body, "grant_type=refresh_token")
+		assert.Contains(t, req.Body, "refresh_token=my-refresh-token")
+	}
+	assert.Equal(t, "token new-token", s.lastRequest(http.MethodGet, "/api/v1/user/repos").Header.Get("Authorization"))
+	assert.Equal(t, "new-refresh-token", c.refreshToken)
+}
+
+func TestRepos(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/user/repos", http.StatusOK, "["+fixtureRepo+"]")
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds", http.StatusOK, fixtureRepo)
+	c := newFixtureClient(s)
+
+	repos, err := c.Repos(context.Background())
+	test.NoError(t, err)
+	if assert.Len(t, repos, 1) {
+		assert.Equal(t, "ovh/cds", repos[0].Fullname)
+		assert.Equal(t, "http://gitea.local/ovh/cds.git", repos[0].HTTPCloneURL)
+		assert.Equal(t, "git@gitea.local:ovh/cds.git", repos[0].SSHCloneURL)
+	}
+	req := s.lastRequest(http.MethodGet, "/api/v1/user/repos")
+	assert.Equal(t, "token my-token", req.Header.Get("Authorization"))
+	assert.Equal(t, fmt.Sprintf("page=1&limit=%d", pageLimit), req.Query)
+
+	repo, err := c.RepoByFullname(context.Background(), "ovh/cds")
+	test.NoError(t, err)
+	assert.Equal(t, "cds", repo.Name)
+
+	_, err = c.RepoByFullname(context.Background(), "ovh/unknown")
+	assert.Equal(t, sdk.ErrRepoNotFound, err)
+}
+
+func TestBranches(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds", http.StatusOK, fixtureRepo)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/branches", http.StatusOK, `[
+		{"name": "master", "commit": {"id": "aaa"}},
+		{"name": "feat/gitea", "commit": {"id": "bbb"}}
+	]`)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/branches/feat/gitea", http.StatusOK, `{"name": "feat/gitea", "commit": {"id": "bbb"}}`)
+	c := newFixtureClient(s)
+
+	branches, err := c.Branches(context.Background(), "ovh/cds")
+	test.NoError(t, err)
+	if assert.Len(t, branches, 2) {
+		assert.True(t, branches[0].Default)
+		assert.Equal(t, "aaa", branches[0].LatestCommit)
+		assert.False(t, branches[1].Default)
+	}
+
+	branch, err := c.Branch(context.Background(), "ovh/cds", "feat/gitea")
+	test.NoError(t, err)
+	assert.Equal(t, "bbb", branch.LatestCommit)
+
+	_, err = c.Branch(context.Background(), "ovh/cds", "unknown")
+	assert.Equal(t, sdk.ErrNoBranch, err)
+}
+
+func TestCommits(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/commits", http.StatusOK, `[
+		{"sha": "ccc", "commit": {"message": "third", "author": {"name": "John", "email": "john@localhost", "date": "2018-06-01T10:00:00Z"}}, "author": {"login": "john"}},
+		{"sha": "bbb", "commit": {"message": "second", "author": {"name": "John", "email": "john@localhost", "date": "2018-06-01T09:00:00Z"}}},
+		{"sha": "aaa", "commit": {"message": "first", "author": {"name": "John", "email": "john@localhost", "date": "2018-06-01T08:00:00Z"}}}
+	]`)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/git/commits/aaa", http.StatusOK, `{"sha": "aaa", "commit": {"message": "first"}}`)
+	c := newFixtureClient(s)
+
+	commits, err := c.Commits(context.Background(), "ovh/cds", "master", "aaa", "ccc")
+	test.NoError(t, err)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, "ccc", commits[0].Hash)
+		assert.Equal(t, "john", commits[0].Author.Name)
+		assert.Equal(t, int64(1527847200000), commits[0].Timestamp)
+		assert.Equal(t, "bbb", commits[1].Hash)
+	}
+	assert.Contains(t, s.lastRequest(http.MethodGet, "/api/v1/repos/ovh/cds/commits").Query, "sha=ccc")
+
+	commit, err := c.Commit(context.Background(), "ovh/cds", "aaa")
+	test.NoError(t, err)
+	assert.Equal(t, "first", commit.Message)
+
+	commits, err = c.CommitsBetweenRefs(context.Background(), "ovh/cds", "aaa", "master")
+	test.NoError(t, err)
+	assert.Len(t, commits, 2)
+
+	// The base is not an ancestor of the head
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/git/commits/zzz", http.StatusOK, `{"sha": "zzz", "commit": {"message": "other"}}`)
+	_, err = c.CommitsBetweenRefs(context.Background(), "ovh/cds", "zzz", "master")
+	assert.Error(t, err)
+
+	// The search stops after commitsMaxPages full pages
+	page := make([]string, pageLimit)
+	for i := range page {
+		page[i] = fmt.Sprintf(`{"sha": "%040d", "commit": {"message": "commit %d"}}`, i, i)
+	}
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/commits", http.StatusOK, "["+strings.Join(page, ",")+"]")
+	_, err = c.CommitsBetweenRefs(context.Background(), "ovh/cds", "zzz", "master")
+	assert.Error(t, err)
+	assert.Contains(t, s.lastRequest(http.MethodGet, "/api/v1/repos/ovh/cds/commits").Query, fmt.Sprintf("page=%d&", commitsMaxPages))
+}
+
+func TestPullRequests(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/pulls", http.StatusOK, `[{
+		"number": 42,
+		"html_url": "http://gitea.local/ovh/cds/pulls/42",
+		"user": {"login": "john", "full_name": "John Doe"},
+		"head": {"ref": "feat/gitea", "sha": "bbb", "repo": {"full_name": "john/cds"}},
+		"base": {"ref": "master", "sha": "aaa", "repo": {"full_name": "ovh/cds"}}
+	}]`)
+	s.on(http.MethodPost, "/api/v1/repos/ovh/cds/issues/42/comments", http.StatusCreated, `{"id": 1}`)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/issues/42/comments", http.StatusOK, `[{"id": 1, "body": "Tests are OK", "user": {"login": "cds"}}, {"id": 2, "body": "Tests are OK", "user": {"login": "john"}}]`)
+	s.on(http.MethodPatch, "/api/v1/repos/ovh/cds/issues/comments/1", http.StatusOK, `{"id": 1}`)
+	c := newFixtureClient(s)
+
+	prs, err := c.PullRequests(context.Background(), "ovh/cds")
+	test.NoError(t, err)
+	if assert.Len(t, prs, 1) {
+		assert.Equal(t, 42, prs[0].ID)
+		assert.Equal(t, "John Doe", prs[0].User.DisplayName)
+		assert.Equal(t, "feat/gitea", prs[0].Head.Branch.ID)
+		assert.Equal(t, "john/cds", prs[0].Head.Repo)
+		assert.Equal(t, "master", prs[0].Base.Branch.ID)
+	}
+	assert.Contains(t, s.lastRequest(http.MethodGet, "/api/v1/repos/ovh/cds/pulls").Query, "state=open")
+
+	test.NoError(t, c.PullRequestComment(context.Background(), "ovh/cds", 42, "Tests are OK"))
+	req := s.lastRequest(http.MethodPost, "/api/v1/repos/ovh/cds/issues/42/comments")
+	if assert.NotNil(t, req) {
+		assert.JSONEq(t, `{"body": "Tests are OK"}`, req.Body)
+		user, token, ok := (&http.Request{Header: req.Header}).BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "cds", user)
+		assert.Equal(t, "cds-token", token)
+	}
+
+	comments, err := c.PullRequestComments(context.Background(), "ovh/cds", 42)
+	test.NoError(t, err)
+	assert.Equal(t, []sdk.VCSPullRequestComment{
+		{ID: 1, Body: "Tests are OK", Author: "cds", Bot: true},
+		{ID: 2, Body: "Tests are OK", Author: "john"},
+	}, comments)
+	assert.Equal(t, fmt.Sprintf("page=1&limit=%d", pageLimit), s.lastRequest(http.MethodGet, "/api/v1/repos/ovh/cds/issues/42/comments").Query)
+
+	// Without token, the comments are posted by the user of the oauth token
+	s.on(http.MethodGet, "/api/v1/user", http.StatusOK, `{"login": "john"}`)
+	c.token = ""
+	comments, err = c.PullRequestComments(context.Background(), "ovh/cds", 42)
+	test.NoError(t, err)
+	assert.Equal(t, []sdk.VCSPullRequestComment{
+		{ID: 1, Body: "Tests are OK", Author: "cds"},
+		{ID: 2, Body: "Tests are OK", Author: "john", Bot: true},
+	}, comments)
+	c.token = "cds-token"
+
+	test.NoError(t, c.PullRequestCommentUpdate(context.Background(), "ovh/cds", 42, sdk.VCSPullRequestComment{ID: 1, Body: "Tests are KO"}))
+	req = s.lastRequest(http.MethodPatch, "/api/v1/repos/ovh/cds/issues/comments/1")
+	if assert.NotNil(t, req) {
+		assert.JSONEq(t, `{"body": "Tests are KO"}`, req.Body)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodPost, "/api/v1/repos/ovh/cds/hooks", http.StatusCreated, `{"id": 12, "type": "gitea", "active": true}`)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/hooks", http.StatusOK, `[{"id": 12, "events": ["push"], "active": true, "config": {"url": "http://proxy.local/my-uuid", "content_type": "json"}}]`)
+	s.on(http.MethodPatch, "/api/v1/repos/ovh/cds/hooks/12", http.StatusOK, `{"id": 12}`)
+	s.on(http.MethodDelete, "/api/v1/repos/ovh/cds/hooks/12", http.StatusNoContent, "")
+	c := newFixtureClient(s)
+	c.proxyURL = "http://proxy.local/"
+
+	hook := sdk.VCSHook{URL: "http://hooks.local/webhook/my-uuid", Workflow: true}
+	test.NoError(t, c.CreateHook(context.Background(), "ovh/cds", &hook))
+	assert.Equal(t, "12", hook.ID)
+	assert.Equal(t, "http://proxy.local/my-uuid", hook.URL)
+
+	var created Hook
+	test.NoError(t, json.Unmarshal([]byte(s.lastRequest(http.MethodPost, "/api/v1/repos/ovh/cds/hooks").Body), &created))
+	assert.Equal(t, "gitea", created.Type)
+	assert.Equal(t, []string{"push", "pull_request"}, created.Events)
+	assert.Equal(t, "http://proxy.local/my-uuid", created.Config["url"])
+	assert.Equal(t, "json", created.Config["content_type"])
+
+	got, err := c.GetHook(context.Background(), "ovh/cds", "http://proxy.local/my-uuid")
+	test.NoError(t, err)
+	assert.Equal(t, "http://proxy.local/my-uuid", got.URL)
+	assert.Equal(t, []string{"push"}, got.Events)
+
+	got.Disable = true
+	test.NoError(t, c.UpdateHook(context.Background(), "ovh/cds", "http://proxy.local/my-uuid", got))
+	assert.Contains(t, s.lastRequest(http.MethodPatch, "/api/v1/repos/ovh/cds/hooks/12").Body, `"active":false`)
+
+	test.NoError(t, c.DeleteHook(context.Background(), "ovh/cds", sdk.VCSHook{URL: "http://proxy.local/my-uuid"}))
+	assert.NotNil(t, s.lastRequest(http.MethodDelete, "/api/v1/repos/ovh/cds/hooks/12"))
+
+	_, err = c.GetHook(context.Background(), "ovh/cds", "http://proxy.local/unknown")
+	assert.Equal(t, sdk.ErrNotFound, err)
+}
+
+func TestStatuses(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodPost, "/api/v1/repos/ovh/cds/statuses/aaa", http.StatusCreated, `{"id": 1}`)
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/commits/aaa/statuses", http.StatusOK, `[
+		{"state": "failure", "context": "CDS/KEY-my-workflow-build"},
+		{"state": "success", "context": "ci/other"}
+	]`)
+	c := newFixtureClient(s)
+
+	evt := sdk.Event{
+		EventType:    fmt.Sprintf("%T", sdk.EventRunWorkflowNode{}),
+		ProjectKey:   "KEY",
+		WorkflowName: "my-workflow",
+		Payload: map[string]interface{}{
+			"Number":             3,
+			"NodeName":           "build",
+			"Status":             sdk.StatusFail.String(),
+			"Hash":               "aaa",
+			"RepositoryFullName": "ovh/cds",
+		},
+	}
+	test.NoError(t, c.SetStatus(context.Background(), evt))
+
+	var status Status
+	test.NoError(t, json.Unmarshal([]byte(s.lastRequest(http.MethodPost, "/api/v1/repos/ovh/cds/statuses/aaa").Body), &status))
+	assert.Equal(t, "failure", status.State)
+	assert.Equal(t, "CDS/KEY-my-workflow-build", status.Context)
+	assert.Equal(t, "http://cds.local/project/KEY/workflow/my-workflow/run/3", status.TargetURL)
+
+	statuses, err := c.ListStatuses(context.Background(), "ovh/cds", "aaa")
+	test.NoError(t, err)
+	if assert.Len(t, statuses, 1) {
+		assert.Equal(t, sdk.StatusFail.String(), statuses[0].State)
+	}
+}
+
+func TestRelease(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodPost, "/api/v1/repos/ovh/cds/releases", http.StatusCreated, `{"id": 7, "tag_name": "v1.0.0"}`)
+	s.on(http.MethodPost, "/api/v1/repos/ovh/cds/releases/7/assets", http.StatusCreated, `{"id": 1}`)
+	c := newFixtureClient(s)
+
+	release, err := c.Release(context.Background(), "ovh/cds", "v1.0.0", "Release v1.0.0", "First release")
+	test.NoError(t, err)
+	assert.Equal(t, int64(7), release.ID)
+	assert.Equal(t, s.URL+"/api/v1/repos/ovh/cds/releases/7/assets", release.UploadURL)
+
+	file := ioutil.NopCloser(strings.NewReader("my binary"))
+	test.NoError(t, c.UploadReleaseFile(context.Background(), "ovh/cds", "v1.0.0", release.UploadURL, "cds-linux-amd64", file))
+	req := s.lastRequest(http.MethodPost, "/api/v1/repos/ovh/cds/releases/7/assets")
+	if assert.NotNil(t, req) {
+		assert.Equal(t, "name=cds-linux-amd64", req.Query)
+		assert.Contains(t, req.Header.Get("Content-Type"), "multipart/form-data")
+		assert.Contains(t, req.Body, "my binary")
+	}
+}
+
+func TestListForks(t *testing.T) {
+	s := newFixtureServer(t)
+	defer s.Close()
+	s.on(http.MethodGet, "/api/v1/repos/ovh/cds/forks", http.StatusOK, `[{"id": 2, "name": "cds", "full_name": "john/cds", "fork": true}]`)
+	c := newFixtureClient(s)
+
+	forks, err := c.ListForks(context.Background(), "ovh/cds")
+	test.NoError(t, err)
+	if assert.Len(t, forks, 1) {
+		assert.Equal(t, "john/cds", forks[0].Fullname)
+	}
+}
@@ -0,0 +1,93 @@
+package gitea
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// fixtureRequest is a request received by the fixture server
+type fixtureRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+	Header http.Header
+}
+
+// fixtureServer is a fake gitea server. It answers the requests with the response registered for "METHOD path"
+// and records the requests it received
+type fixtureServer struct {
+	*httptest.Server
+	mutex     sync.Mutex
+	responses map[string]fixtureResponse
+	requests  []fixtureRequest
+}
+
+type fixtureResponse struct {
+	status int
+	body   string
+}
+
+func newFixtureServer(t *testing.T) *fixtureServer {
+	s := &fixtureServer{responses: map[string]fixtureResponse{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("fixture server: unable to read body: %v", err)
+		}
+
+		s.mutex.Lock()
+		s.requests = append(s.requests, fixtureRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Body:   string(body),
+			Header: r.Header,
+		})
+		res, ok := s.responses[r.Method+" "+r.URL.Path]
+		s.mutex.Unlock()
+
+		if !ok {
+			t.Logf("fixture server: no response for %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`)) // nolint
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(res.status)
+		w.Write([]byte(res.body)) // nolint
+	}))
+	return s
+}
+
+// on registers the response of a request
+func (s *fixtureServer) on(method, path string, status int, body string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.responses[method+" "+path] = fixtureResponse{status: status, body: body}
+}
+
+// lastRequest returns the last request received for "METHOD path"
+func (s *fixtureServer) lastRequest(method, path string) *fixtureRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		if s.requests[i].Method == method && s.requests[i].Path == path {
+			return &s.requests[i]
+		}
+	}
+	return nil
+}
+
+func newFixtureClient(s *fixtureServer) *giteaClient {
+	return &giteaClient{
+		URL:        s.URL,
+		OAuthToken: "my-token",
+		uiURL:      "http://cds.local",
+		username:   "cds",
+		token:      "cds-token",
+	}
+}
@@ -0,0 +1,199 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+// pageLimit is the number of elements asked for each page of a list
+const pageLimit = 50
+
+var httpClient = &http.Client{
+	Timeout: 60 * time.Second,
+}
+
+type requestOptions struct {
+	asUser      bool
+	contentType string
+}
+
+func (c *giteaClient) apiURL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return c.URL + "/api/v1" + path
+}
+
+// request calls the gitea API with the body, and returns the status code and the body of the response.
+// The oauth2 access tokens of gitea expire: on an unauthorized response, the client gets a new access token
+// with its refresh token and sends the request again
+func (c *giteaClient) request(ctx context.Context, method, path string, body io.Reader, opts *requestOptions) (int, []byte, error) {
+	if opts == nil {
+		opts = new(requestOptions)
+	}
+
+	var payload []byte
+	if body != nil {
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		payload = b
+	}
+
+	accessToken := c.accessToken()
+	status, resBody, err := c.send(ctx, method, path, payload, body != nil, accessToken, opts)
+	if status == http.StatusUnauthorized && !(opts.asUser && c.token != "") {
+		if errR := c.refreshAccessToken(accessToken); errR != nil {
+			log.Warning("giteaClient.request> Unable to refresh the access token: %v", errR)
+			return status, resBody, err
+		}
+		return c.send(ctx, method, path, payload, body != nil, c.accessToken(), opts)
+	}
+	return status, resBody, err
+}
+
+// send sends one request to the gitea API
+func (c *giteaClient) send(ctx context.Context, method, path string, payload []byte, hasBody bool, accessToken string, opts *requestOptions) (int, []byte, error) {
+	var body io.Reader
+	if hasBody {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, c.apiURL(path), body)
+	if err != nil {
+		return 0, nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if hasBody {
+		contentType := opts.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if opts.asUser && c.token != "" {
+		req.SetBasicAuth(c.username, c.token)
+	} else {
+		req.Header.Set("Authorization", "token "+accessToken)
+	}
+
+	log.Debug("Gitea API>> Request %s %s", method, req.URL.String())
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		giteaErr := Error{Message: string(resBody)}
+		_ = json.Unmarshal(resBody, &giteaErr)
+		switch res.StatusCode {
+		case http.StatusNotFound:
+			return res.StatusCode, resBody, sdk.NewError(sdk.ErrNotFound, giteaErr)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return res.StatusCode, resBody, sdk.NewError(sdk.ErrForbidden, giteaErr)
+		}
+		return res.StatusCode, resBody, sdk.NewError(sdk.ErrUnknownError, fmt.Errorf("gitea error (%d) %s", res.StatusCode, giteaErr.Message))
+	}
+
+	return res.StatusCode, resBody, nil
+}
+
+// do calls the gitea API with a json body and decodes the json response in out
+func (c *giteaClient) do(ctx context.Context, method, path string, in, out interface{}, opts *requestOptions) (int, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, sdk.WrapError(err, "giteaClient.do> Unable to marshal body")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	status, resBody, err := c.request(ctx, method, path, body, opts)
+	if err != nil {
+		return status, err
+	}
+
+	if out != nil && len(resBody) > 0 {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			return status, sdk.WrapError(err, "giteaClient.do> Unable to unmarshal response of %s %s", method, path)
+		}
+	}
+	return status, nil
+}
+
+func (c *giteaClient) get(ctx context.Context, path string, out interface{}) error {
+	_, err := c.do(ctx, http.MethodGet, path, nil, out, nil)
+	return err
+}
+
+// getAll gets all the pages of a list. The page func gets one page and returns its number of elements
+func (c *giteaClient) getAll(page func(p int) (int, error)) error {
+	for p := 1; ; p++ {
+		n, err := page(p)
+		if err != nil {
+			return err
+		}
+		if n < pageLimit {
+			return nil
+		}
+	}
+}
+
+// pagePath adds the pagination parameters to the path
+func pagePath(path string, p int) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%spage=%d&limit=%d", path, sep, p, pageLimit)
+}
+
+// accessToken returns the current oauth2 access token of the client
+func (c *giteaClient) accessToken() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.OAuthToken
+}
+
+// refreshAccessToken replaces the expired access token with a new one, obtained with the refresh token.
+// The access token is refreshed once when several requests get an unauthorized response. The new tokens
+// are kept in memory only: the API keeps the tokens obtained when the project was linked
+func (c *giteaClient) refreshAccessToken(expired string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.OAuthToken != expired {
+		return nil
+	}
+	if c.consumer == nil || c.refreshToken == "" {
+		return fmt.Errorf("no refresh token")
+	}
+	r, err := c.consumer.refreshAccessToken(c.refreshToken)
+	if err != nil {
+		return err
+	}
+	c.OAuthToken = r.AccessToken
+	if r.RefreshToken != "" {
+		c.refreshToken = r.RefreshToken
+	}
+	return nil
+}
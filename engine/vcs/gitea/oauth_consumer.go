@@ -0,0 +1,165 @@
+package gitea
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+	"github.com/ovh/cds/sdk/log"
+)
+
+type authorizeResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// oauthError matches gitea oauth2 error format
+type oauthError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func generateHash() (string, error) {
+	size := 128
+	bs := make([]byte, size)
+	if _, err := rand.Read(bs); err != nil {
+		log.Error("vcs> gitea> generateID: rand.Read failed: %s\n", err)
+		return "", err
+	}
+	str := hex.EncodeToString(bs)
+	token := []byte(str)[0:size]
+
+	log.Debug("vcs> gitea> generateID: new generated id: %s\n", token)
+	return string(token), nil
+}
+
+//AuthorizeRedirect returns the request token, the Authorize URL
+//doc: https://docs.gitea.io/en-us/oauth2-provider/
+func (g *giteaConsumer) AuthorizeRedirect(ctx context.Context) (string, string, error) {
+	requestToken, err := generateHash()
+	if err != nil {
+		return "", "", err
+	}
+
+	val := url.Values{}
+	val.Add("client_id", g.ClientID)
+	val.Add("redirect_uri", g.AuthorizationCallbackURL)
+	val.Add("response_type", "code")
+	val.Add("state", requestToken)
+
+	authorizeURL := fmt.Sprintf("%s/login/oauth/authorize?%s", g.URL, val.Encode())
+	return requestToken, authorizeURL, nil
+}
+
+func (g *giteaConsumer) postForm(path string, data url.Values) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, g.URL+path, strings.NewReader(data.Encode()))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		oErr := oauthError{}
+		if err := json.Unmarshal(resBody, &oErr); err == nil && oErr.Error != "" {
+			return res.StatusCode, resBody, fmt.Errorf("%s: %s", oErr.Error, oErr.Description)
+		}
+		return res.StatusCode, resBody, fmt.Errorf("Gitea error (%d) %s", res.StatusCode, string(resBody))
+	}
+
+	return res.StatusCode, resBody, nil
+}
+
+//AuthorizeToken returns the authorized token and its refresh token, used as secret,
+//from the request token and the verifier got on authorize url
+func (g *giteaConsumer) AuthorizeToken(ctx context.Context, state, code string) (string, string, error) {
+	log.Debug("AuthorizeToken> Gitea send code %s for state %s", code, state)
+
+	params := url.Values{}
+	params.Add("client_id", g.ClientID)
+	params.Add("client_secret", g.ClientSecret)
+	params.Add("code", code)
+	params.Add("grant_type", "authorization_code")
+	params.Add("redirect_uri", g.AuthorizationCallbackURL)
+
+	status, res, err := g.postForm("/login/oauth/access_token", params)
+	if err != nil {
+		return "", "", err
+	}
+
+	r := authorizeResponse{}
+	if err := json.Unmarshal(res, &r); err != nil {
+		return "", "", fmt.Errorf("Unable to parse gitea response (%d) %s ", status, string(res))
+	}
+	if r.AccessToken == "" {
+		return "", "", sdk.WrapError(sdk.ErrUnauthorized, "AuthorizeToken> No access token in gitea response (%d)", status)
+	}
+
+	return r.AccessToken, r.RefreshToken, nil
+}
+
+// refreshAccessToken gets a new access token with the refresh token
+func (g *giteaConsumer) refreshAccessToken(refreshToken string) (authorizeResponse, error) {
+	params := url.Values{}
+	params.Add("client_id", g.ClientID)
+	params.Add("client_secret", g.ClientSecret)
+	params.Add("refresh_token", refreshToken)
+	params.Add("grant_type", "refresh_token")
+
+	r := authorizeResponse{}
+	status, res, err := g.postForm("/login/oauth/access_token", params)
+	if err != nil {
+		return r, err
+	}
+	if err := json.Unmarshal(res, &r); err != nil {
+		return r, fmt.Errorf("Unable to parse gitea response (%d) %s ", status, string(res))
+	}
+	if r.AccessToken == "" {
+		return r, sdk.WrapError(sdk.ErrUnauthorized, "refreshAccessToken> No access token in gitea response (%d)", status)
+	}
+	return r, nil
+}
+
+//keep client in memory
+var instancesAuthorizedClient = map[string]*giteaClient{}
+
+//GetAuthorizedClient returns an authorized client
+func (g *giteaConsumer) GetAuthorizedClient(ctx context.Context, accessToken, accessTokenSecret string) (sdk.VCSAuthorizedClient, error) {
+	c, ok := instancesAuthorizedClient[accessToken]
+	if !ok {
+		c = &giteaClient{
+			URL:                 g.URL,
+			OAuthToken:          accessToken,
+			refreshToken:        accessTokenSecret,
+			consumer:            g,
+			Cache:               g.Cache,
+			uiURL:               g.uiURL,
+			proxyURL:            g.proxyURL,
+			username:            g.username,
+			token:               g.token,
+			disableStatus:       g.disableStatus,
+			disableStatusDetail: g.disableStatusDetail,
+		}
+		instancesAuthorizedClient[accessToken] = c
+	}
+	return c, nil
+}
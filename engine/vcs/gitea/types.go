@@ -0,0 +1,155 @@
+package gitea
+
+import "time"
+
+// User represents a gitea user
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Username  string `json:"username"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Name returns the login of the user, older versions of gitea only send the username
+func (u User) Name() string {
+	if u.Login != "" {
+		return u.Login
+	}
+	return u.Username
+}
+
+// Repository represents a gitea repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Owner         User   `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Fork          bool   `json:"fork"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// CommitUser represents the author or the committer of a commit
+type CommitUser struct {
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Username string    `json:"username"`
+	Date     time.Time `json:"date"`
+}
+
+// PayloadCommit represents the last commit of a branch
+type PayloadCommit struct {
+	ID        string     `json:"id"`
+	Message   string     `json:"message"`
+	URL       string     `json:"url"`
+	Author    CommitUser `json:"author"`
+	Committer CommitUser `json:"committer"`
+	Timestamp time.Time  `json:"timestamp"`
+}
+
+// Branch represents a gitea branch
+type Branch struct {
+	Name   string        `json:"name"`
+	Commit PayloadCommit `json:"commit"`
+}
+
+// Tag represents a gitea tag
+type Tag struct {
+	Name    string `json:"name"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Commit  struct {
+		Sha string `json:"sha"`
+		URL string `json:"url"`
+	} `json:"commit"`
+}
+
+// Commit represents a gitea commit
+type Commit struct {
+	Sha     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string     `json:"message"`
+		Author  CommitUser `json:"author"`
+	} `json:"commit"`
+	Author  *User `json:"author"`
+	Parents []struct {
+		Sha string `json:"sha"`
+	} `json:"parents"`
+}
+
+// PRBranchInfo represents the head or the base of a pull request
+type PRBranchInfo struct {
+	Label string     `json:"label"`
+	Ref   string     `json:"ref"`
+	Sha   string     `json:"sha"`
+	Repo  Repository `json:"repo"`
+}
+
+// PullRequest represents a gitea pull request
+type PullRequest struct {
+	ID        int64        `json:"id"`
+	Number    int          `json:"number"`
+	HTMLURL   string       `json:"html_url"`
+	Title     string       `json:"title"`
+	State     string       `json:"state"`
+	User      User         `json:"user"`
+	Head      PRBranchInfo `json:"head"`
+	Base      PRBranchInfo `json:"base"`
+	Merged    bool         `json:"merged"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// Comment represents a comment on a pull request
+type Comment struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+	User *User  `json:"user,omitempty"`
+}
+
+// Hook represents a gitea webhook
+type Hook struct {
+	ID     int64             `json:"id,omitempty"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// Status represents a commit status
+type Status struct {
+	ID          int64     `json:"id,omitempty"`
+	State       string    `json:"state"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// Release represents a gitea release
+type Release struct {
+	ID        int64  `json:"id,omitempty"`
+	TagName   string `json:"tag_name"`
+	Name      string `json:"name"`
+	Body      string `json:"body"`
+	UploadURL string `json:"upload_url,omitempty"`
+}
+
+// Collaborator represents the permission given to a collaborator of a repository
+type Collaborator struct {
+	Permission string `json:"permission"`
+}
+
+// Error represents an error returned by the gitea API
+type Error struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+func (e Error) Error() string {
+	return e.Message
+}
@@ -21,10 +21,15 @@ func (g *githubClient) CreateHook(ctx context.Context, repo string, hook *sdk.VC
 		hook.URL = g.proxyURL + hook.URL[lastIndexSlash:]
 	}
 
+	events := []string{"push"}
+	// Workflow hooks can also be triggered by pull requests
+	if hook.Workflow {
+		events = append(events, "pull_request")
+	}
 	r := WebhookCreate{
 		Name:   "web",
 		Active: true,
-		Events: []string{"push"},
+		Events: events,
 		Config: WebHookConfig{
 			URL:         hook.URL,
 			ContentType: "json",
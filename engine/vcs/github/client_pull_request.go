@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/sdk"
@@ -140,3 +141,59 @@ func (g *githubClient) PullRequestComment(ctx context.Context, repo string, id i
 
 	return nil
 }
+
+// PullRequestComments returns the comments of a pull request
+func (g *githubClient) PullRequestComments(ctx context.Context, repo string, id int) ([]sdk.VCSPullRequestComment, error) {
+	author, err := g.commentAuthor()
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []sdk.VCSPullRequestComment{}
+	nextPage := fmt.Sprintf("/repos/%s/issues/%d/comments", repo, id)
+	for nextPage != "" {
+		status, body, headers, err := g.get(nextPage, withoutETag)
+		if err != nil {
+			return nil, sdk.WrapError(err, "github.PullRequestComments> Unable to get comments")
+		}
+		if status >= 400 {
+			return nil, sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+		}
+		var page []IssueComment
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, sdk.WrapError(err, "github.PullRequestComments> Unable to parse comments")
+		}
+		for _, c := range page {
+			comments = append(comments, sdk.VCSPullRequestComment{
+				ID:     c.ID,
+				Body:   c.Body,
+				Author: c.User.Login,
+				Bot:    author != "" && strings.EqualFold(c.User.Login, author),
+			})
+		}
+		nextPage = getNextPage(headers)
+	}
+	return comments, nil
+}
+
+// PullRequestCommentUpdate updates the body of a comment on a pull request
+func (g *githubClient) PullRequestCommentUpdate(ctx context.Context, repo string, id int, comment sdk.VCSPullRequestComment) error {
+	if g.DisableStatus {
+		log.Warning("github.PullRequestCommentUpdate>  ⚠ Github statuses are disabled")
+		return nil
+	}
+
+	path := fmt.Sprintf("/repos/%s/issues/comments/%d", repo, comment.ID)
+	values, _ := json.Marshal(map[string]string{"body": comment.Body})
+	res, err := g.patch(path, "application/json", bytes.NewReader(values), &postOptions{asUser: true})
+	if err != nil {
+		return sdk.WrapError(err, "github.PullRequestCommentUpdate> Unable to update comment")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return sdk.WrapError(fmt.Errorf("status code %d - body: %s", res.StatusCode, body), "github.PullRequestCommentUpdate> Unable to update comment %d on github", comment.ID)
+	}
+	return nil
+}
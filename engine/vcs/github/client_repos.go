@@ -176,7 +176,7 @@ func (g *githubClient) GrantReadPermission(ctx context.Context, fullname string)
 
 		// Accept the invitation
 		url := fmt.Sprintf("/user/repository_invitations/%d", invit.ID)
-		resp, err := g.patch(url, &postOptions{asUser: true})
+		resp, err := g.patch(url, "", nil, &postOptions{asUser: true})
 		if err != nil {
 			log.Warning("githubClient.GrantReadPermission> Error (%s) %s", url, err)
 			return err
@@ -38,3 +38,23 @@ func (g *githubClient) User(ctx context.Context, username string) (User, error)
 
 	return user, nil
 }
+
+// commentAuthor returns the login of the author of the comments posted on the pull requests:
+// the configured user if it has a token, the user of the oauth token otherwise
+func (g *githubClient) commentAuthor() (string, error) {
+	if g.username != "" && g.token != "" {
+		return g.username, nil
+	}
+	status, body, _, err := g.get("/user", withoutETag)
+	if err != nil {
+		return "", sdk.WrapError(err, "githubClient.commentAuthor> Unable to get the authenticated user")
+	}
+	if status >= 400 {
+		return "", sdk.NewError(sdk.ErrUnknownError, errorAPI(body))
+	}
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return "", sdk.WrapError(err, "githubClient.commentAuthor> Unable to parse the authenticated user")
+	}
+	return user.Login, nil
+}
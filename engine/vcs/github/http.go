@@ -150,7 +150,7 @@ func (c *githubClient) post(path string, bodyType string, body io.Reader, opts *
 	return httpClient.Do(req)
 }
 
-func (c *githubClient) patch(path string, opts *postOptions) (*http.Response, error) {
+func (c *githubClient) patch(path string, bodyType string, body io.Reader, opts *postOptions) (*http.Response, error) {
 	if opts == nil {
 		opts = new(postOptions)
 	}
@@ -158,11 +158,14 @@ func (c *githubClient) patch(path string, opts *postOptions) (*http.Response, er
 		path = APIURL + path
 	}
 
-	req, err := http.NewRequest(http.MethodPatch, path, nil)
+	req, err := http.NewRequest(http.MethodPatch, path, body)
 	if err != nil {
 		return nil, err
 	}
 
+	if bodyType != "" {
+		req.Header.Set("Content-Type", bodyType)
+	}
 	req.Header.Set("User-Agent", "CDS-gh_client_id="+c.ClientID)
 	req.Header.Add("Accept", "application/json")
 	if opts.asUser && c.token != "" {
@@ -719,3 +719,10 @@ type Ref struct {
 		URL  string `json:"url"`
 	} `json:"object"`
 }
+
+// IssueComment represents a comment on an issue or a pull request
+type IssueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+	User User   `json:"user"`
+}
@@ -39,7 +39,7 @@ func (c *gitlabClient) CreateHook(ctx context.Context, repo string, hook *sdk.VC
 	opt := gitlab.AddProjectHookOptions{
 		URL:                   &url,
 		PushEvents:            &t,
-		MergeRequestsEvents:   &f,
+		MergeRequestsEvents:   &hook.Workflow,
 		TagPushEvents:         &f,
 		EnableSSLVerification: &f,
 	}
@@ -11,7 +11,17 @@ func (c *gitlabClient) PullRequests(context.Context, string) ([]sdk.VCSPullReque
 	return []sdk.VCSPullRequest{}, nil
 }
 
-// PullRequestComment push a new comment on a pull request
+// PullRequestComment push a new comment on a pull request. The comments are not supported on gitlab
 func (c *gitlabClient) PullRequestComment(context.Context, string, int, string) error {
 	return nil
 }
+
+// PullRequestComments returns the comments of a pull request. The comments are not supported on gitlab
+func (c *gitlabClient) PullRequestComments(context.Context, string, int) ([]sdk.VCSPullRequestComment, error) {
+	return []sdk.VCSPullRequestComment{}, nil
+}
+
+// PullRequestCommentUpdate updates a comment on a pull request. The comments are not supported on gitlab
+func (c *gitlabClient) PullRequestCommentUpdate(context.Context, string, int, sdk.VCSPullRequestComment) error {
+	return nil
+}
@@ -49,6 +49,7 @@ type ServerConfiguration struct {
 	Github    *GithubServerConfiguration    `toml:"github" json:"github,omitempty"`
 	Gitlab    *GitlabServerConfiguration    `toml:"gitlab" json:"gitlab,omitempty"`
 	Bitbucket *BitbucketServerConfiguration `toml:"bitbucket" json:"bitbucket,omitempty"`
+	Gitea     *GiteaServerConfiguration     `toml:"gitea" json:"gitea,omitempty"`
 }
 
 // GithubServerConfiguration represents the github configuration
@@ -122,6 +123,31 @@ func (s BitbucketServerConfiguration) check() error {
 	return nil
 }
 
+// GiteaServerConfiguration represents the gitea (or gogs) configuration
+type GiteaServerConfiguration struct {
+	ClientID     string `toml:"clientId" json:"-" comment:"#######\n CDS <-> Gitea. Documentation on https://ovh.github.io/cds/hosting/repositories-manager/gitea/ \n#######\n Gitea OAuth2 Application Client ID"`
+	ClientSecret string `toml:"clientSecret" json:"-" comment:"Gitea OAuth2 Application Client Secret"`
+	Status       struct {
+		Disable    bool `toml:"disable" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push statuses on the VCS server" json:"disable"`
+		ShowDetail bool `toml:"showDetail" default:"false" commented:"true" comment:"Set to true if you don't want CDS to push CDS URL in statuses on the VCS server" json:"show_detail"`
+	}
+	DisableWebHooks bool   `toml:"disableWebHooks" comment:"Does webhooks are supported by VCS Server" json:"disable_web_hook"`
+	DisablePolling  bool   `toml:"disablePolling" comment:"Does polling is supported by VCS Server" json:"disable_polling"`
+	ProxyWebhook    string `toml:"proxyWebhook" default:"https://myproxy.com" commented:"true" comment:"If you want to have a reverse proxy url for your repository webhook, for example if you put https://myproxy.com it will generate a webhook URL like this https://myproxy.com/UUID_OF_YOUR_WEBHOOK" json:"proxy_webhook"`
+	Username        string `toml:"username" comment:"optional. Gitea username, used to add comment on Pull Request on failed build." json:"username"`
+	Token           string `toml:"token" comment:"optional, Gitea Token associated to username, used to add comment on Pull Request" json:"-"`
+}
+
+func (s GiteaServerConfiguration) check() error {
+	if s.ClientID == "" || s.ClientSecret == "" {
+		return fmt.Errorf("Gitea configuration Error")
+	}
+	if s.ProxyWebhook != "" && !strings.Contains(s.ProxyWebhook, "://") {
+		return fmt.Errorf("Gitea proxy webhook must have the HTTP scheme")
+	}
+	return nil
+}
+
 func (s *Service) addServerConfiguration(name string, c ServerConfiguration) error {
 	if name == "" {
 		return fmt.Errorf("Invalid VCS server name")
@@ -165,5 +191,11 @@ func (s ServerConfiguration) check() error {
 		}
 	}
 
+	if s.Gitea != nil {
+		if err := s.Gitea.check(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/ovh/cds/engine/api/cache"
 	"github.com/ovh/cds/engine/api/services"
 	"github.com/ovh/cds/engine/vcs/bitbucket"
+	"github.com/ovh/cds/engine/vcs/gitea"
 	"github.com/ovh/cds/engine/vcs/github"
 	"github.com/ovh/cds/engine/vcs/gitlab"
 	"github.com/ovh/cds/sdk"
@@ -113,6 +114,20 @@ func (s *Service) getConsumer(name string) (sdk.VCSServer, error) {
 			serverCfg.Gitlab.Status.ShowDetail,
 		), nil
 	}
+	if serverCfg.Gitea != nil {
+		return gitea.New(serverCfg.Gitea.ClientID,
+			serverCfg.Gitea.ClientSecret,
+			serverCfg.URL,
+			s.Cfg.API.HTTP.URL+"/repositories_manager/oauth2/callback",
+			s.Cfg.UI.HTTP.URL,
+			serverCfg.Gitea.ProxyWebhook,
+			serverCfg.Gitea.Username,
+			serverCfg.Gitea.Token,
+			s.Cache,
+			serverCfg.Gitea.Status.Disable,
+			!serverCfg.Gitea.Status.ShowDetail,
+		), nil
+	}
 	return nil, sdk.ErrNotFound
 }
 
@@ -65,6 +65,10 @@ func (s *Service) getVCSServersHooksHandler() service.Handler {
 			res.WebhooksSupported = true
 			res.WebhooksDisabled = cfg.Gitlab.DisableWebHooks
 			res.WebhooksIcon = sdk.GitlabIcon
+		case cfg.Gitea != nil:
+			res.WebhooksSupported = true
+			res.WebhooksDisabled = cfg.Gitea.DisableWebHooks
+			res.WebhooksIcon = sdk.GiteaIcon
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -93,6 +97,9 @@ func (s *Service) getVCSServersPollingHandler() service.Handler {
 		case cfg.Gitlab != nil:
 			res.PollingSupported = false
 			res.PollingDisabled = cfg.Gitlab.DisablePolling
+		case cfg.Gitea != nil:
+			res.PollingSupported = false
+			res.PollingDisabled = cfg.Gitea.DisablePolling
 		}
 
 		return service.WriteJSON(w, res, http.StatusOK)
@@ -488,6 +495,82 @@ func (s *Service) postPullRequestCommentHandler() service.Handler {
 	}
 }
 
+func (s *Service) getPullRequestCommentsHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id, err := strconv.Atoi(muxVar(r, "id"))
+		if err != nil {
+			return sdk.ErrWrongRequest
+		}
+
+		accessToken, accessTokenSecret, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "VCS> getPullRequestCommentsHandler> Unable to get access token headers")
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS> getPullRequestCommentsHandler> VCS server unavailable")
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		if err != nil {
+			return sdk.WrapError(err, "VCS> getPullRequestCommentsHandler> Unable to get authorized client")
+		}
+
+		comments, err := client.PullRequestComments(ctx, fmt.Sprintf("%s/%s", owner, repo), id)
+		if err != nil {
+			return sdk.WrapError(err, "VCS> getPullRequestCommentsHandler> Unable to get comments of pull request %d on %s/%s", id, owner, repo)
+		}
+		return service.WriteJSON(w, comments, http.StatusOK)
+	}
+}
+
+func (s *Service) putPullRequestCommentHandler() service.Handler {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		name := muxVar(r, "name")
+		owner := muxVar(r, "owner")
+		repo := muxVar(r, "repo")
+		id, err := strconv.Atoi(muxVar(r, "id"))
+		if err != nil {
+			return sdk.ErrWrongRequest
+		}
+		commentID, err := strconv.ParseInt(muxVar(r, "commentID"), 10, 64)
+		if err != nil {
+			return sdk.ErrWrongRequest
+		}
+
+		var comment sdk.VCSPullRequestComment
+		if err := api.UnmarshalBody(r, &comment); err != nil {
+			return sdk.WrapError(err, "VCS> putPullRequestCommentHandler")
+		}
+		comment.ID = commentID
+
+		accessToken, accessTokenSecret, ok := getAccessTokens(ctx)
+		if !ok {
+			return sdk.WrapError(sdk.ErrUnauthorized, "VCS> putPullRequestCommentHandler> Unable to get access token headers")
+		}
+
+		consumer, err := s.getConsumer(name)
+		if err != nil {
+			return sdk.WrapError(err, "VCS> putPullRequestCommentHandler> VCS server unavailable")
+		}
+
+		client, err := consumer.GetAuthorizedClient(ctx, accessToken, accessTokenSecret)
+		if err != nil {
+			return sdk.WrapError(err, "VCS> putPullRequestCommentHandler> Unable to get authorized client")
+		}
+
+		if err := client.PullRequestCommentUpdate(ctx, fmt.Sprintf("%s/%s", owner, repo), id, comment); err != nil {
+			return sdk.WrapError(err, "VCS> putPullRequestCommentHandler> Unable to update PR comment %d", commentID)
+		}
+
+		return nil
+	}
+}
+
 func (s *Service) getEventsHandler() service.Handler {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 		name := muxVar(r, "name")
@@ -145,6 +145,14 @@ func runArtifactUpload(w *currentWorker) BuiltInAction {
 			go func(path string) {
 				log.Debug("Uploading %s", path)
 				defer wg.Done()
+				linked, err := w.client.QueueArtifactLink(buildID, tag.Value, path)
+				if err != nil {
+					log.Warning("Unable to check if artifact %s is already stored: %v", path, err)
+				}
+				if linked {
+					sendLog(fmt.Sprintf("File '%s' already stored, upload skipped", filename))
+					return
+				}
 				throughTempURL, duration, err := w.client.QueueArtifactUpload(buildID, tag.Value, path)
 				if err != nil {
 					chanError <- sdk.WrapError(err, "Error while uploading artifact %s", path)
@@ -189,6 +189,14 @@ func runGitClone(w *currentWorker) BuiltInAction {
 			opts.CheckoutCommit = commit.Value
 		}
 
+		// The branch of a pull request opened from a fork is not in the repository of the application, its commits are fetched from the ref of the pull request
+		if prRef := sdk.ParameterValue(*params, "git.pr.ref"); prRef != "" && tag == "" &&
+			(gitURL == sdk.ParameterValue(*params, "git.url") || gitURL == sdk.ParameterValue(*params, "git.http_url")) &&
+			(branch == nil || branch.Value == "" || branch.Value == "{{.git.branch}}" || branch.Value == sdk.ParameterValue(*params, "git.branch")) {
+			opts.FetchRef = prRef
+			sendLog(fmt.Sprintf("pull request from a fork, fetching %s", prRef))
+		}
+
 		var dir string
 		if directory != nil {
 			dir = directory.Value
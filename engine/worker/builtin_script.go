@@ -10,6 +10,7 @@ import (
 	"path"
 	"runtime"
 	"strings"
+	"syscall"
 
 	"github.com/kardianos/osext"
 
@@ -121,6 +122,7 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 
 			log.Info("runScriptAction> %s %s", shell, strings.Trim(fmt.Sprint(opts), "[]"))
 			cmd := exec.CommandContext(ctx, shell, opts...)
+			setProcessGroup(cmd)
 			res.Status = sdk.StatusUnknown.String()
 
 			env := os.Environ()
@@ -240,9 +242,28 @@ func runScriptAction(w *currentWorker) BuiltInAction {
 				chanRes <- res
 			}
 
+			// On timeout or cancel, kill the script and the processes it started,
+			// otherwise they may keep the outputs open and the step would never end
+			cmdDone := make(chan struct{})
+			defer close(cmdDone)
+			go func() {
+				select {
+				case <-ctx.Done():
+					if err := killProcessGroup(cmd); err != nil {
+						log.Warning("runScriptAction> cannot kill process group: %v", err)
+					}
+				case <-cmdDone:
+				}
+			}()
+
 			<-outchan
 			<-errchan
 			if err := cmd.Wait(); err != nil {
+				if exitErr, ok := err.(*exec.ExitError); ok {
+					if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+						w.currentJob.exitCode = status.ExitStatus()
+					}
+				}
 				res.Reason = fmt.Sprintf("%s\n", err)
 				sendLog(res.Reason)
 				res.Status = sdk.StatusFail.String()
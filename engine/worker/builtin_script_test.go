@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_runScriptActionTimeout(t *testing.T) {
+	basedir, err := ioutil.TempDir("", "cds-worker-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(basedir)
+	marker := filepath.Join(basedir, "marker")
+
+	w := &currentWorker{basedir: basedir}
+	a := &sdk.Action{
+		Parameters: []sdk.Parameter{{
+			Name:  "script",
+			Value: fmt.Sprintf("(sleep 2; touch %s) &\nsleep 30", marker),
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	t0 := time.Now()
+	res := runScriptAction(w)(ctx, a, 1, &[]sdk.Parameter{}, nil, func(string) {})
+	assert.Equal(t, sdk.StatusFail.String(), res.Status)
+	assert.True(t, time.Since(t0) < 5*time.Second)
+
+	// The background process of the script has been killed with it
+	time.Sleep(3 * time.Second)
+	_, err = os.Stat(marker)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -52,6 +52,7 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 		log.Info("hostname: %s", hostname)
 		log.Info("auto-update: %t", w.autoUpdate)
 		log.Info("single-use: %t", w.singleUse)
+		log.Info("max-jobs: %d", w.maxJobs)
 
 		httpServerCtx, stopHTTPServer := context.WithCancel(context.Background())
 		w.initServer(httpServerCtx)
@@ -103,6 +104,13 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 
 		updateTick := time.NewTicker(5 * time.Minute)
 
+		// A reusable worker stops at the end of its ttl. The main loop is blocked while a job is running,
+		// so the worker never stops in the middle of a job.
+		var reuseTTL <-chan time.Time
+		if w.reusable() {
+			reuseTTL = time.After(time.Duration(ttl) * time.Minute)
+		}
+
 		// start logger routine with a large buffer
 		w.logger.logChan = make(chan sdk.Log, 100000)
 		go w.logProcessor(ctx)
@@ -277,6 +285,11 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 					continue
 				}
 
+				if !w.canTakeProjectJob(j) {
+					log.Debug("Unable to run this job %d, the worker only runs the jobs of project %s", j.ID, w.projectKey)
+					continue
+				}
+
 				requirementsOK, _ := checkRequirements(w, &j.Job.Action, nil, j.ID)
 				t := ""
 				if j.ID == w.bookedWJobID {
@@ -299,6 +312,7 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 							continue
 						}
 					}
+					w.projectKey, _ = j.Header.Get(sdk.ProjectKeyHeader)
 				} else if ttl > 0 {
 					// If requirements are KO and the ttl > 0, keep alive
 					if err := w.client.WorkerSetStatus(sdk.StatusWaiting); err != nil {
@@ -308,19 +322,7 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 					continue
 				}
 
-				var continueTakeJob = true
-
-				// Is the worker is "single use": unregister and exit the worker
-				if w.singleUse {
-					continueTakeJob = false
-				}
-
-				// If the TTL is null: unregister and exit the worker
-				if ttl == 0 {
-					continueTakeJob = false
-				}
-
-				if continueTakeJob {
+				if w.continueTakeWorkflowJob(ttl) {
 					//Continue
 					if err := w.client.WorkerSetStatus(sdk.StatusWaiting); err != nil {
 						log.Error("WorkerSetStatus> error on WorkerSetStatus(sdk.StatusWaiting): %s", err)
@@ -333,11 +335,44 @@ func runCmd(w *currentWorker) func(cmd *cobra.Command, args []string) {
 				cancel()
 			case <-updateTick.C:
 				w.doUpdate()
+			case <-reuseTTL:
+				log.Info("TTL is over after %d jobs. Unregistering...", w.nbActionsDone)
+				cancel()
 			}
 		}
 	}
 }
 
+// reusable returns true if the worker takes other jobs after its first one
+func (w *currentWorker) reusable() bool {
+	return w.maxJobs > 1
+}
+
+// continueTakeWorkflowJob returns true if the worker can take another job after a workflow job.
+// A reusable worker takes jobs until it has run maxJobs jobs. Otherwise a single use worker
+// or a worker with a null ttl stops after its first job. A worker which has run the job of a pull
+// request opened from a fork always stops.
+func (w *currentWorker) continueTakeWorkflowJob(ttl int) bool {
+	if w.untrusted {
+		return false
+	}
+	if w.reusable() {
+		return w.nbActionsDone < w.maxJobs
+	}
+	return !w.singleUse && ttl != 0
+}
+
+// canTakeProjectJob returns true if the worker can run a job of the project of the workflow job. After its first job,
+// a reusable worker only runs the jobs of the same project, so that the files left outside of the workspace by a job
+// are never read by the job of another project. The API refuses the jobs of other projects as well.
+func (w *currentWorker) canTakeProjectJob(j sdk.WorkflowNodeJobRun) bool {
+	if !w.reusable() || w.projectKey == "" {
+		return true
+	}
+	key, _ := j.Header.Get(sdk.ProjectKeyHeader)
+	return key == w.projectKey
+}
+
 func (w *currentWorker) processBookedPBJob(pbjobs chan<- sdk.PipelineBuildJob) {
 	log.Debug("Try to take the pipeline build job %d", w.bookedPBJobID)
 	b, _, err := sdk.Request("GET", fmt.Sprintf("/queue/%d/infos", w.bookedPBJobID), nil)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func Test_continueTakeWorkflowJob(t *testing.T) {
+	w := &currentWorker{}
+	assert.True(t, w.continueTakeWorkflowJob(30))
+	assert.False(t, w.continueTakeWorkflowJob(0))
+
+	w.singleUse = true
+	assert.False(t, w.continueTakeWorkflowJob(30))
+
+	w.maxJobs = 3
+	w.nbActionsDone = 1
+	assert.True(t, w.continueTakeWorkflowJob(30))
+	assert.True(t, w.continueTakeWorkflowJob(0))
+	w.nbActionsDone = 3
+	assert.False(t, w.continueTakeWorkflowJob(30))
+
+	w.nbActionsDone = 1
+	w.untrusted = true
+	assert.False(t, w.continueTakeWorkflowJob(30))
+}
+
+func Test_canTakeProjectJob(t *testing.T) {
+	foo := sdk.WorkflowNodeJobRun{Header: sdk.WorkflowRunHeaders{sdk.ProjectKeyHeader: "FOO"}}
+	bar := sdk.WorkflowNodeJobRun{Header: sdk.WorkflowRunHeaders{sdk.ProjectKeyHeader: "BAR"}}
+
+	w := &currentWorker{projectKey: "FOO"}
+	assert.True(t, w.canTakeProjectJob(bar))
+
+	w.maxJobs = 3
+	assert.True(t, w.canTakeProjectJob(foo))
+	assert.False(t, w.canTakeProjectJob(bar))
+
+	w.projectKey = ""
+	assert.True(t, w.canTakeProjectJob(bar))
+}
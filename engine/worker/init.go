@@ -28,6 +28,8 @@ const (
 	flagForceExit           = "force-exit"
 	flagBaseDir             = "basedir"
 	flagTTL                 = "ttl"
+	flagMaxJobs             = "max-jobs"
+	flagResourceClass       = "resource-class"
 	flagBookedPBJobID       = "booked-pb-job-id"
 	flagBookedWorkflowJobID = "booked-workflow-job-id"
 	flagBookedJobID         = "booked-job-id"
@@ -57,6 +59,8 @@ func initFlagsRun(cmd *cobra.Command) {
 	flags.Bool(flagForceExit, false, "If single_use=true, force exit. This is useful if it's spawned by an Hatchery (default: worker wait 30min for being killed by hatchery)")
 	flags.String(flagBaseDir, "", "This directory (default TMPDIR os environment var) will contains worker working directory and temporary files")
 	flags.Int(flagTTL, 30, "Worker time to live (minutes)")
+	flags.String(flagResourceClass, "", "Resource class of the worker, set by the hatchery")
+	flags.Int(flagMaxJobs, 0, "If greater than 1, the worker cleans its workspace and takes other jobs of the same project until it has run max-jobs jobs or its ttl is over, even with single_use=true")
 	flags.Int64(flagBookedPBJobID, 0, "Booked Pipeline Build job id")
 	flags.Int64(flagBookedWorkflowJobID, 0, "Booked Workflow job id")
 	flags.Int64(flagBookedJobID, 0, "Booked job id")
@@ -213,6 +217,8 @@ func initFlags(cmd *cobra.Command, w *currentWorker) {
 
 	w.autoUpdate = FlagBool(cmd, flagAutoUpdate)
 	w.singleUse = FlagBool(cmd, flagSingleUse)
+	w.maxJobs = FlagInt(cmd, flagMaxJobs)
+	w.resourceClass = FlagString(cmd, flagResourceClass)
 	w.grpc.address = FlagString(cmd, flagGRPCAPI)
 	w.grpc.insecure = FlagBool(cmd, flagGRPCInsecure)
 	w.disableOldWorkflows = FlagBool(cmd, flagDisableOldWorkflows)
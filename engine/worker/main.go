@@ -20,6 +20,10 @@ type currentWorker struct {
 	bookedPBJobID int64
 	bookedWJobID  int64
 	nbActionsDone int
+	maxJobs       int
+	projectKey    string
+	untrusted     bool
+	resourceClass string
 	basedir       string
 	manualExit    bool
 	logger        struct {
@@ -40,6 +44,7 @@ type currentWorker struct {
 		pbJob          sdk.PipelineBuildJob
 		wJob           *sdk.WorkflowNodeJobRun
 		currentStep    int
+		exitCode       int
 		buildVariables []sdk.Variable
 		pkey           string
 		gitsshPath     string
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, there is no process group
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the command
+func killProcessGroup(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -31,6 +31,8 @@ var requirementCheckFuncs = map[string]func(w *currentWorker, r sdk.Requirement)
 	sdk.MemoryRequirement:        checkMemoryRequirement,
 	sdk.VolumeRequirement:        checkVolumeRequirement,
 	sdk.OSArchRequirement:        checkOSArchRequirement,
+	sdk.CPURequirement:           checkCPURequirement,
+	sdk.ResourceClassRequirement: checkResourceClassRequirement,
 }
 
 func checkRequirements(w *currentWorker, a *sdk.Action, execGroups []sdk.Group, bookedJobID int64) (bool, []sdk.Requirement) {
@@ -210,6 +212,23 @@ func checkVolumeRequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
 	return false, nil
 }
 
+func checkCPURequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
+	cpu, err := sdk.ParseCPURequirement(r.Value)
+	if err != nil {
+		return false, err
+	}
+	return float64(runtime.NumCPU()) >= cpu, nil
+}
+
+// checkResourceClassRequirement checks the resource class given by the hatchery to the worker.
+// A worker without resource class trusts the hatchery which booked the job for it.
+func checkResourceClassRequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
+	if w.resourceClass != "" {
+		return w.resourceClass == r.Value, nil
+	}
+	return w.bookedPBJobID != 0 || w.bookedWJobID != 0, nil
+}
+
 func checkOSArchRequirement(w *currentWorker, r sdk.Requirement) (bool, error) {
 	osarch := strings.Split(r.Value, "/")
 	if len(osarch) != 2 {
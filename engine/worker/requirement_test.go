@@ -89,3 +89,61 @@ func TestNetworkAccessRequirement(t *testing.T) {
 		t.Fatalf("Requirement should not be ok")
 	}
 }
+
+func TestCPURequirement(t *testing.T) {
+	r := sdk.Requirement{
+		Type:  sdk.CPURequirement,
+		Value: "1",
+	}
+
+	ok, err := checkRequirement(nil, r)
+	if err != nil {
+		t.Fatalf("checkRequirement should not fail: %s", err)
+	}
+
+	if !ok {
+		t.Fatalf("Requirement should be ok")
+	}
+
+	r.Value = "100000"
+	ok, err = checkRequirement(nil, r)
+	if err != nil {
+		t.Fatalf("checkRequirement should not fail: %s", err)
+	}
+
+	if ok {
+		t.Fatalf("Requirement should not be ok")
+	}
+
+	r.Value = "many"
+	if _, err := checkRequirement(nil, r); err == nil {
+		t.Fatalf("checkRequirement should fail")
+	}
+}
+
+func TestResourceClassRequirement(t *testing.T) {
+	r := sdk.Requirement{
+		Type:  sdk.ResourceClassRequirement,
+		Value: "large",
+	}
+
+	w := &currentWorker{}
+	if ok, _ := checkRequirement(w, r); ok {
+		t.Fatalf("Requirement should not be ok on a worker without booked job")
+	}
+
+	w.bookedWJobID = 42
+	if ok, _ := checkRequirement(w, r); !ok {
+		t.Fatalf("Requirement should be ok on a booked worker")
+	}
+
+	w.resourceClass = "small"
+	if ok, _ := checkRequirement(w, r); ok {
+		t.Fatalf("Requirement should not be ok on a small worker")
+	}
+
+	w.resourceClass = "large"
+	if ok, _ := checkRequirement(w, r); !ok {
+		t.Fatalf("Requirement should be ok on a large worker")
+	}
+}
@@ -231,7 +231,7 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 		childName := fmt.Sprintf("%s/%s-%d", stepName, child.Name, i+1)
 		if !child.Enabled || w.manualExit {
 			// Update step status and continue
-			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusDisabled.String()); err != nil {
+			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusDisabled.String(), 0); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
 			}
 
@@ -246,12 +246,14 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 
 		if !criticalStepFailed || child.AlwaysExecuted {
 			// Update step status
-			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusBuilding.String()); err != nil {
+			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusBuilding.String(), 0); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s\n", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
 			}
 			w.sendLog(buildID, fmt.Sprintf("Starting step %s\n", childName), w.currentJob.currentStep, false)
 
-			r = w.startAction(ctx, &child, buildID, params, secrets, w.currentJob.currentStep, childName)
+			w.currentJob.exitCode = 0
+			r = w.runStep(ctx, &child, buildID, params, secrets, childName)
+			r = w.retryStep(ctx, &child, r, buildID, params, secrets, childName)
 			if r.Status != sdk.StatusSuccess.String() && !child.Optional {
 				criticalStepFailed = true
 			}
@@ -263,12 +265,12 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 			}
 
 			// Update step status
-			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, r.Status); err != nil {
+			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, r.Status, w.currentJob.exitCode); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusDisabled.String(), buildID, err)
 			}
 		} else if criticalStepFailed && !child.AlwaysExecuted { // Update status of steps which are never built
 			// Update step status
-			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusNeverBuilt.String()); err != nil {
+			if err := w.updateStepStatus(ctx, buildID, w.currentJob.currentStep, sdk.StatusNeverBuilt.String(), 0); err != nil {
 				log.Warning("Cannot update step (%d) status (%s) for build %d: %s", w.currentJob.currentStep, sdk.StatusNeverBuilt.String(), buildID, err)
 			}
 		}
@@ -283,12 +285,61 @@ func (w *currentWorker) runSteps(ctx context.Context, steps []sdk.Action, a *sdk
 	return r, nbDisabledChildren
 }
 
-func (w *currentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string) error {
+// retryStep runs again a failed step while its retry policy allows it, waiting for the backoff between each attempt
+func (w *currentWorker) retryStep(ctx context.Context, child *sdk.Action, r sdk.Result, buildID int64, params *[]sdk.Parameter, secrets []sdk.Variable, childName string) sdk.Result {
+	for attempt := 1; r.Status == sdk.StatusFail.String() && child.RetryPolicy.ShouldRetry(attempt, w.currentJob.exitCode); attempt++ {
+		w.sendLog(buildID, fmt.Sprintf("Step %s failed (exit code %d), retrying in %s\n", childName, w.currentJob.exitCode, child.RetryPolicy.BackoffDuration(attempt)), w.currentJob.currentStep, false)
+		select {
+		case <-ctx.Done():
+			return r
+		case <-time.After(child.RetryPolicy.BackoffDuration(attempt)):
+		}
+		w.sendLog(buildID, fmt.Sprintf("\n\n\n-=-=-=-=-=- Attempt %d/%d of step %s -=-=-=-=-=-\n\n\n", attempt+1, child.RetryPolicy.MaxAttempts, childName), w.currentJob.currentStep, false)
+		w.currentJob.exitCode = 0
+		r = w.runStep(ctx, child, buildID, params, secrets, childName)
+	}
+	return r
+}
+
+// runStep runs a step within its timeout. When the timeout expires, the step is killed and failed.
+func (w *currentWorker) runStep(ctx context.Context, child *sdk.Action, buildID int64, params *[]sdk.Parameter, secrets []sdk.Variable, childName string) sdk.Result {
+	timeout := sdk.TimeoutDuration(child.Timeout)
+	if timeout == 0 {
+		return w.startAction(ctx, child, buildID, params, secrets, w.currentJob.currentStep, childName)
+	}
+
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	r := w.startAction(stepCtx, child, buildID, params, secrets, w.currentJob.currentStep, childName)
+	if stepCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		r.Status = sdk.StatusFail.String()
+		r.Reason = fmt.Sprintf("Step %s timed out after %s", childName, timeout)
+		w.sendTimeoutSpawnInfo(buildID, sdk.MsgSpawnInfoStepTimeout, childName, timeout.String(), w.status.Name)
+	}
+	return r
+}
+
+// sendTimeoutSpawnInfo adds a spawn info on the current workflow job when a step or the job itself timed out
+func (w *currentWorker) sendTimeoutSpawnInfo(buildID int64, msg *sdk.Message, args ...interface{}) {
+	if w.currentJob.wJob == nil {
+		return
+	}
+	infos := []sdk.SpawnInfo{{
+		RemoteTime: time.Now(),
+		Message:    sdk.SpawnMsg{ID: msg.ID, Args: args},
+	}}
+	if err := w.client.QueueJobSendSpawnInfo(true, buildID, infos); err != nil {
+		log.Warning("sendTimeoutSpawnInfo> Cannot record spawn info for job %d: %s", buildID, err)
+	}
+}
+
+func (w *currentWorker) updateStepStatus(ctx context.Context, buildID int64, stepOrder int, status string, exitCode int) error {
 	step := sdk.StepStatus{
 		StepOrder: stepOrder,
 		Status:    status,
 		Start:     time.Now(),
 		Done:      time.Now(),
+		ExitCode:  exitCode,
 	}
 
 	var path string
@@ -348,7 +399,8 @@ func workingDirectory(basedir, jobPath string) string {
 
 func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNodeJobRunData) sdk.Result {
 	t0 := time.Now()
-	ctx, cancel := context.WithTimeout(ctx, 6*time.Hour)
+	timeout := jobInfo.NodeJobRun.Job.TimeoutDuration()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 
 	defer func() { log.Info("processJob> Process Job Done (%s)", sdk.Round(time.Since(t0), time.Second).String()) }()
 	defer cancel()
@@ -361,6 +413,8 @@ func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNod
 		fmt.Sprintf("%d", jobInfo.NodeJobRun.Job.PipelineActionID))
 
 	wd := workingDirectory(w.basedir, pbJobPath)
+	home := os.Getenv("HOME")
+	keysDirectory = ""
 
 	if err := setupBuildDirectory(wd); err != nil {
 		log.Debug("processJob> setupBuildDirectory error:%s", err)
@@ -369,6 +423,7 @@ func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNod
 			Reason: fmt.Sprintf("Error: cannot setup working directory: %s", err),
 		}
 	}
+	defer func() { w.cleanWorkspace(home, wd, keysDirectory) }()
 
 	//Add working directory as job parameter
 	jobInfo.NodeJobRun.Parameters = append(jobInfo.NodeJobRun.Parameters, sdk.Parameter{
@@ -439,12 +494,34 @@ func (w *currentWorker) processJob(ctx context.Context, jobInfo *sdk.WorkflowNod
 	res := w.startAction(ctx, &jobInfo.NodeJobRun.Job.Action, jobInfo.NodeJobRun.ID, &jobInfo.NodeJobRun.Parameters, logsecrets, -1, "")
 	logsecrets = nil
 
-	if err := teardownBuildDirectory(wd); err != nil {
-		log.Error("Cannot remove build directory: %s", err)
+	if ctx.Err() == context.DeadlineExceeded {
+		res.Status = sdk.StatusFail.String()
+		res.Reason = fmt.Sprintf("Job timed out after %s", timeout)
+		w.sendTimeoutSpawnInfo(jobInfo.NodeJobRun.ID, sdk.MsgSpawnInfoJobTimeout, timeout.String(), w.status.Name)
 	}
+
 	return res
 }
 
+// cleanWorkspace removes the directories of a job and restores the current directory and the home of the worker,
+// so that a reusable worker starts its next job in a clean workspace
+func (w *currentWorker) cleanWorkspace(home string, dirs ...string) {
+	for _, d := range dirs {
+		if d == "" {
+			continue
+		}
+		if err := teardownBuildDirectory(d); err != nil {
+			log.Error("Cannot remove build directory: %s", err)
+		}
+	}
+	if err := os.Chdir(w.basedir); err != nil {
+		log.Error("Cannot go back to base directory: %s", err)
+	}
+	if err := os.Setenv("HOME", home); err != nil {
+		log.Error("Cannot restore HOME: %s", err)
+	}
+}
+
 func (w *currentWorker) run(ctx context.Context, pbji *sdk.PipelineBuildJobInfo) sdk.Result {
 	ctx, cancel := context.WithTimeout(ctx, 6*time.Hour)
 	defer cancel()
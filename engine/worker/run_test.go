@@ -1,6 +1,9 @@
 package main
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +53,26 @@ func Test_processJobParameter(t *testing.T) {
 		assert.EqualValues(t, tt.want, tt.args.pbJob.Parameters)
 	}
 }
+
+func Test_cleanWorkspace(t *testing.T) {
+	basedir, err := ioutil.TempDir("", "worker")
+	assert.NoError(t, err)
+	defer os.RemoveAll(basedir)
+	home := os.Getenv("HOME")
+	defer os.Setenv("HOME", home)
+
+	w := &currentWorker{basedir: basedir}
+	wd := workingDirectory(basedir, "1/0/42")
+	assert.NoError(t, setupBuildDirectory(wd))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(wd, "file"), []byte("foo"), 0644))
+
+	w.cleanWorkspace(home, wd, "")
+
+	_, err = os.Stat(wd)
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, home, os.Getenv("HOME"))
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	resolved, _ := filepath.EvalSymlinks(basedir)
+	assert.Equal(t, resolved, cwd)
+}
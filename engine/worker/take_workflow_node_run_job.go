@@ -30,6 +30,10 @@ func (w *currentWorker) takeWorkflowJob(ctx context.Context, job sdk.WorkflowNod
 	log.Info("takeWorkflowJob> Job %d taken%s", job.ID, t)
 
 	w.nbActionsDone++
+	// The code of a pull request opened from a fork may leave processes or files behind, the worker never takes another job after it
+	if sdk.ParameterValue(info.NodeJobRun.Parameters, "git.pr.fork") == "true" {
+		w.untrusted = true
+	}
 	// Set build variables
 	w.currentJob.wJob = &info.NodeJobRun
 	w.currentJob.secrets = info.Secrets
@@ -37,6 +41,9 @@ func (w *currentWorker) takeWorkflowJob(ctx context.Context, job sdk.WorkflowNod
 	w.currentJob.gitsshPath = ""
 	w.currentJob.pkey = ""
 	w.currentJob.buildVariables = nil
+	w.currentJob.params = nil
+	w.currentJob.currentStep = 0
+	w.currentJob.exitCode = 0
 
 	start := time.Now()
 
@@ -20,6 +20,8 @@ type Action struct {
 	Deprecated     bool          `json:"deprecated" yaml:"-"`
 	Optional       bool          `json:"optional" yaml:"-"`
 	AlwaysExecuted bool          `json:"always_executed" yaml:"-"`
+	RetryPolicy    *RetryPolicy  `json:"retry_policy,omitempty" yaml:"-"`
+	Timeout        string        `json:"timeout,omitempty" yaml:"-"`
 	LastModified   int64         `json:"last_modified" cli:"modified"`
 }
 
@@ -92,6 +92,8 @@ type RepositoryStrategy struct {
 	Branch         string `json:"branch,omitempty"`
 	DefaultBranch  string `json:"default_branch,omitempty"`
 	PGPKey         string `json:"pgp_key"`
+	// PullRequestComment enables the report of the node runs posted as a comment on the pull requests
+	PullRequestComment bool `json:"pull_request_comment,omitempty"`
 }
 
 // ApplicationVariableAudit represents an audit on an application variable
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// ArtifactBlobContainer is the objectstore container of all the content-addressed artifacts
+const ArtifactBlobContainer = "artifact-blobs"
+
+// ArtifactBlob is a content-addressed object shared by all the workflow artifacts of a project with the same SHA512
+type ArtifactBlob struct {
+	Key       string    `json:"key"`
+	ProjectID int64     `json:"project_id"`
+	SHA512sum string    `json:"sha512sum"`
+	Size      int64     `json:"size"`
+	Created   time.Time `json:"created"`
+	LastUsed  time.Time `json:"last_used"`
+}
+
+// ArtifactBlobKey returns the key of the blob storing a content for a project.
+// Blobs are not shared between projects, a project can't reference a content it has not uploaded itself.
+func ArtifactBlobKey(projectID int64, sha512sum string) string {
+	if sha512sum == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d-%s", projectID, sha512sum)
+}
+
+// GetName returns the name of the blob in the objectstore
+func (b *ArtifactBlob) GetName() string {
+	return b.Key
+}
+
+// GetPath returns the container of the blob in the objectstore
+func (b *ArtifactBlob) GetPath() string {
+	return ArtifactBlobContainer
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import "time"
+
+// ArtifactRetentionPolicy defines how long artifacts and worker caches are kept in the objectstore.
+// A policy without WorkflowID applies to the whole project, a workflow policy overrides it.
+type ArtifactRetentionPolicy struct {
+	ID                     int64    `json:"id" cli:"-"`
+	ProjectID              int64    `json:"project_id" cli:"-"`
+	WorkflowID             int64    `json:"workflow_id,omitempty" cli:"-"`
+	MaxAge                 int64    `json:"max_age,omitempty" cli:"max_age"`
+	MaxTotalSize           int64    `json:"max_total_size,omitempty" cli:"max_total_size"`
+	KeepLastSuccessfulRuns int64    `json:"keep_last_successful_runs,omitempty" cli:"keep_last_successful_runs"`
+	KeepTaggedRuns         []string `json:"keep_tagged_runs,omitempty" cli:"keep_tagged_runs"`
+}
+
+// MaxAgeDuration returns the max age of the policy, MaxAge is expressed in days
+func (p ArtifactRetentionPolicy) MaxAgeDuration() time.Duration {
+	return time.Duration(p.MaxAge) * 24 * time.Hour
+}
+
+// IsEmpty returns true if the policy does not expire anything
+func (p ArtifactRetentionPolicy) IsEmpty() bool {
+	return p.MaxAge <= 0 && p.MaxTotalSize <= 0
+}
+
+// WorkerCache is a worker cache entry stored by a project in the objectstore
+type WorkerCache struct {
+	ProjectID    int64     `json:"project_id" cli:"-"`
+	Tag          string    `json:"tag" cli:"tag"`
+	Size         int64     `json:"size" cli:"size"`
+	LastModified time.Time `json:"last_modified" cli:"last_modified"`
+}
+
+// Reasons why an object is purged
+const (
+	ArtifactPurgeReasonMaxAge       = "max_age"
+	ArtifactPurgeReasonMaxTotalSize = "max_total_size"
+)
+
+// ArtifactPurgeReport lists all the objects deleted (or to delete in dry-run mode) by the purge
+type ArtifactPurgeReport struct {
+	DryRun     bool                      `json:"dry_run"`
+	Artifacts  []ArtifactPurgeReportItem `json:"artifacts"`
+	Caches     []ArtifactPurgeReportItem `json:"caches"`
+	TotalCount int                       `json:"total_count"`
+	TotalSize  int64                     `json:"total_size"`
+}
+
+// ArtifactPurgeReportItem is an object deleted by the purge
+type ArtifactPurgeReportItem struct {
+	ProjectKey   string    `json:"project_key" cli:"project"`
+	WorkflowName string    `json:"workflow_name,omitempty" cli:"workflow"`
+	RunNumber    int64     `json:"run_number,omitempty" cli:"run"`
+	Name         string    `json:"name" cli:"name"`
+	Size         int64     `json:"size" cli:"size"`
+	Created      time.Time `json:"created" cli:"created"`
+	Reason       string    `json:"reason" cli:"reason"`
+}
+
+// Add adds an item in the report
+func (r *ArtifactPurgeReport) Add(isCache bool, item ArtifactPurgeReportItem) {
+	if isCache {
+		r.Caches = append(r.Caches, item)
+	} else {
+		r.Artifacts = append(r.Artifacts, item)
+	}
+	r.TotalCount++
+	r.TotalSize += item.Size
+}
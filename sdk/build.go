@@ -40,10 +40,31 @@ type SpawnMsg struct {
 // ExecutedJob represents a running job
 type ExecutedJob struct {
 	Job
-	StepStatus []StepStatus `json:"step_status" db:"-"`
-	Reason     string       `json:"reason" db:"-"`
-	WorkerName string       `json:"worker_name" db:"-"`
-	WorkerID   string       `json:"worker_id" db:"-"`
+	StepStatus []StepStatus  `json:"step_status" db:"-"`
+	Reason     string        `json:"reason" db:"-"`
+	WorkerName string        `json:"worker_name" db:"-"`
+	WorkerID   string        `json:"worker_id" db:"-"`
+	MatrixCell JobMatrixCell `json:"matrix_cell,omitempty" db:"-"`
+	Attempt    int           `json:"attempt,omitempty" db:"-"`
+	RetriedBy  int64         `json:"retried_by,omitempty" db:"-"`
+}
+
+// CurrentAttempt returns the attempt number of the job, starting at 1
+func (j ExecutedJob) CurrentAttempt() int {
+	if j.Attempt < 1 {
+		return 1
+	}
+	return j.Attempt
+}
+
+// FailedExitCode returns the exit code of the last failed step, 0 if it is unknown
+func (j ExecutedJob) FailedExitCode() int {
+	for i := len(j.StepStatus) - 1; i >= 0; i-- {
+		if j.StepStatus[i].Status == StatusFail.String() {
+			return j.StepStatus[i].ExitCode
+		}
+	}
+	return 0
 }
 
 // ExecutedJobSummary is a light representation of ExecutedJob for CDS event
@@ -86,6 +107,7 @@ type StepStatus struct {
 	Status    string    `json:"status" db:"-"`
 	Start     time.Time `json:"start" db:"-"`
 	Done      time.Time `json:"done" db:"-"`
+	ExitCode  int       `json:"exit_code,omitempty" db:"-"`
 }
 
 // StepStatusSummary Represent a step and his status for CDS event
@@ -19,6 +19,7 @@ type Cache struct {
 	Tag       string `json:"tag"`
 	TmpURL    string `json:"tmp_url"`
 	SecretKey string `json:"secret_key"`
+	Size      int64  `json:"size,omitempty"`
 
 	Files            []string `json:"files"`
 	WorkingDirectory string   `json:"working_directory"`
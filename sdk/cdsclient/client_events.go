@@ -0,0 +1,80 @@
+package cdsclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// EventsListen opens the events stream of the API and sends the received events on the given chan,
+// until the context is done or the stream is closed. Events can be filtered by project, workflow and run number,
+// empty values match all the events.
+func (c *client) EventsListen(ctx context.Context, projectKey, workflowName string, runNumber int64, events chan<- sdk.Event) error {
+	q := url.Values{}
+	if projectKey != "" {
+		q.Set("project", projectKey)
+	}
+	if workflowName != "" {
+		q.Set("workflow", workflowName)
+	}
+	if runNumber > 0 {
+		q.Set("run", strconv.FormatInt(runNumber, 10))
+	}
+	path := "/events"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	body, _, code, err := c.Stream("GET", path, nil, true)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if code >= 400 {
+		return fmt.Errorf("HTTP %d", code)
+	}
+
+	// Closing the body stops the scanner when the context is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			body.Close()
+		case <-done:
+		}
+	}()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+		if strings.HasPrefix(data, "ACK: ") {
+			continue
+		}
+
+		var e sdk.Event
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			return fmt.Errorf("unable to read event: %v", err)
+		}
+		select {
+		case events <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
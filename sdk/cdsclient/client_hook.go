@@ -2,6 +2,7 @@ package cdsclient
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -25,3 +26,23 @@ func (c *client) PollVCSEvents(uuid string, workflowID int64, vcsServer string,
 
 	return events, interval, nil
 }
+
+func (c *client) HookGitRefs(uuid string) (map[string]string, error) {
+	refs := map[string]string{}
+	if _, err := c.GetJSON(fmt.Sprintf("/hook/%s/gitrefs", uuid), &refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+func (c *client) HookChangedFiles(uuid, branch, base, head string) ([]string, error) {
+	params := url.Values{}
+	params.Set("branch", branch)
+	params.Set("base", base)
+	params.Set("head", head)
+	var files []string
+	if _, err := c.GetJSON(fmt.Sprintf("/hook/%s/changedfiles?%s", uuid, params.Encode()), &files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
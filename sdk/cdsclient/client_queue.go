@@ -267,6 +267,53 @@ func (c *client) QueueArtifactUpload(id int64, tag, filePath string) (bool, time
 	return false, time.Since(t0), err
 }
 
+// QueueArtifactLink declares an artifact without uploading it if its content is already stored by the project.
+// It returns false if the content has to be uploaded.
+func (c *client) QueueArtifactLink(id int64, tag, filePath string) (bool, error) {
+	f, errop := os.Open(filePath)
+	if errop != nil {
+		return false, errop
+	}
+	defer f.Close()
+
+	stat, errst := f.Stat()
+	if errst != nil {
+		return false, errst
+	}
+
+	sha512sum, err512 := sdk.FileSHA512sum(filePath)
+	if err512 != nil {
+		return false, err512
+	}
+
+	md5sum, errmd5 := sdk.FileMd5sum(filePath)
+	if errmd5 != nil {
+		return false, errmd5
+	}
+
+	_, name := filepath.Split(filePath)
+	ref := base64.RawURLEncoding.EncodeToString([]byte(tag))
+	art := sdk.WorkflowNodeRunArtifact{
+		Name:      name,
+		Tag:       tag,
+		Ref:       ref,
+		Size:      stat.Size(),
+		Perm:      uint32(stat.Mode().Perm()),
+		MD5sum:    md5sum,
+		SHA512sum: sha512sum,
+	}
+
+	uri := fmt.Sprintf("/queue/workflows/%d/artifact/%s/blob", id, ref)
+	code, err := c.PostJSON(uri, &art, nil)
+	if code == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (c *client) queueIndirectArtifactTempURL(id int64, art *sdk.WorkflowNodeRunArtifact) error {
 	var retryURL = 10
 	var globalURLErr error
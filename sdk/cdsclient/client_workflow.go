@@ -203,6 +203,29 @@ func (c *client) WorkflowRunFromManual(projectKey string, workflowName string, m
 	return run, nil
 }
 
+func (c *client) WorkflowRunReplay(projectKey string, workflowName string, replay sdk.WorkflowRunReplay) (*sdk.WorkflowRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs", projectKey, workflowName)
+	content := sdk.WorkflowRunPostHandlerOption{Replay: &replay}
+	run := &sdk.WorkflowRun{}
+	code, err := c.PostJSON(url, &content, run)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 300 {
+		return nil, fmt.Errorf("Cannot replay workflow run %d. HTTP code error: %d", replay.Number, code)
+	}
+	return run, nil
+}
+
+func (c *client) WorkflowRunDiff(projectKey string, workflowName string, from, to int64) (*sdk.WorkflowRunDiff, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/diff/%d", projectKey, workflowName, from, to)
+	diff := sdk.WorkflowRunDiff{}
+	if _, err := c.GetJSON(url, &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
 func (c *client) WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error) {
 	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/stop", projectKey, workflowName, number)
 
@@ -233,6 +256,20 @@ func (c *client) WorkflowNodeStop(projectKey string, workflowName string, number
 	return nodeRun, nil
 }
 
+func (c *client) WorkflowNodeRunApprove(projectKey string, workflowName string, number, nodeRunID int64, comment string) (*sdk.WorkflowNodeRun, error) {
+	url := fmt.Sprintf("/project/%s/workflows/%s/runs/%d/nodes/%d/approve", projectKey, workflowName, number, nodeRunID)
+
+	nodeRun := &sdk.WorkflowNodeRun{}
+	code, err := c.PostJSON(url, sdk.WorkflowNodeRunApprove{Comment: comment}, nodeRun)
+	if err != nil {
+		return nil, err
+	}
+	if code >= 300 {
+		return nil, fmt.Errorf("Cannot approve workflow node run %d. HTTP code error: %d", nodeRunID, code)
+	}
+	return nodeRun, nil
+}
+
 func (c *client) WorkflowCachePush(projectKey, ref string, tarContent io.Reader) error {
 	store := new(sdk.ArtifactsStore)
 	_, _ = c.GetJSON("/artifact/store", store)
@@ -279,9 +316,20 @@ func (c *client) workflowCachePushIndirectUpload(projectKey, ref string, tarCont
 		return fmt.Errorf("HTTP Code %d", code)
 	}
 
-	if err := c.workflowCachePushIndirectUploadPost(cacheObj.TmpURL, tarContent); err != nil {
+	body := &sdk.CountingReader{Reader: tarContent}
+	if err := c.workflowCachePushIndirectUploadPost(cacheObj.TmpURL, body); err != nil {
+		return err
+	}
+
+	// Send the size of the uploaded cache, which is unknown to the API
+	cacheObj.Size = body.N
+	code, err = c.PostJSON(url+"/callback", cacheObj, nil)
+	if err != nil {
 		return err
 	}
+	if code >= 400 {
+		return fmt.Errorf("HTTP Code %d", code)
+	}
 
 	return nil
 }
@@ -208,6 +208,7 @@ type QueueClient interface {
 	QueueJobSendSpawnInfo(isWorkflowJob bool, id int64, in []sdk.SpawnInfo) error
 	QueueSendResult(int64, sdk.Result) error
 	QueueArtifactUpload(id int64, tag, filePath string) (bool, time.Duration, error)
+	QueueArtifactLink(id int64, tag, filePath string) (bool, error)
 	QueueJobTag(jobID int64, tags []sdk.WorkflowRunTag) error
 	QueueJobIncAttempts(jobID int64) ([]int64, error)
 	QueueServiceLogs(logs []sdk.ServiceLog) error
@@ -248,6 +249,8 @@ type WorkerClient interface {
 // HookClient exposes functions used for hooks services
 type HookClient interface {
 	PollVCSEvents(uuid string, workflowID int64, vcsServer string, timestamp int64) (events sdk.RepositoryEvents, interval time.Duration, err error)
+	HookGitRefs(uuid string) (map[string]string, error)
+	HookChangedFiles(uuid, branch, base, head string) ([]string, error)
 }
 
 // WorkflowClient exposes workflows functions
@@ -261,10 +264,13 @@ type WorkflowClient interface {
 	WorkflowRunArtifacts(projectKey string, name string, number int64) ([]sdk.WorkflowNodeRunArtifact, error)
 	WorkflowRunFromHook(projectKey string, workflowName string, hook sdk.WorkflowNodeRunHookEvent) (*sdk.WorkflowRun, error)
 	WorkflowRunFromManual(projectKey string, workflowName string, manual sdk.WorkflowNodeRunManual, number, fromNodeID int64) (*sdk.WorkflowRun, error)
+	WorkflowRunReplay(projectKey string, workflowName string, replay sdk.WorkflowRunReplay) (*sdk.WorkflowRun, error)
+	WorkflowRunDiff(projectKey string, workflowName string, from, to int64) (*sdk.WorkflowRunDiff, error)
 	WorkflowRunNumberGet(projectKey string, workflowName string) (*sdk.WorkflowRunNumber, error)
 	WorkflowRunNumberSet(projectKey string, workflowName string, number int64) error
 	WorkflowStop(projectKey string, workflowName string, number int64) (*sdk.WorkflowRun, error)
 	WorkflowNodeStop(projectKey string, workflowName string, number, fromNodeID int64) (*sdk.WorkflowNodeRun, error)
+	WorkflowNodeRunApprove(projectKey string, workflowName string, number, nodeRunID int64, comment string) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRun(projectKey string, name string, number int64, nodeRunID int64) (*sdk.WorkflowNodeRun, error)
 	WorkflowNodeRunArtifactDownload(projectKey string, name string, a sdk.WorkflowNodeRunArtifact, w io.Writer) error
 	WorkflowNodeRunJobStep(projectKey string, workflowName string, number int64, nodeRunID, job int64, step int) (*sdk.BuildState, error)
@@ -282,6 +288,11 @@ type MonitoringClient interface {
 	MonDBMigrate() ([]sdk.MonDBMigrate, error)
 }
 
+// EventsClient exposes events functions
+type EventsClient interface {
+	EventsListen(ctx context.Context, projectKey, workflowName string, runNumber int64, events chan<- sdk.Event) error
+}
+
 // PlatformClient exposes platform functions
 type PlatformClient interface {
 	PlatformModelList() ([]sdk.PlatformModel, error)
@@ -300,6 +311,7 @@ type Interface interface {
 	ConfigUser() (map[string]string, error)
 	DownloadClient
 	EnvironmentClient
+	EventsClient
 	ExportImportInterface
 	GroupClient
 	GRPCPluginsClient
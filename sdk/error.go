@@ -156,6 +156,11 @@ var (
 	ErrIconBadFormat                          = Error{ID: 141, Status: http.StatusBadRequest}
 	ErrIconBadSize                            = Error{ID: 142, Status: http.StatusBadRequest}
 	ErrWorkflowConditionBadOperator           = Error{ID: 143, Status: http.StatusBadRequest}
+	ErrWorkflowConditionBadExpression         = Error{ID: 144, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunApprovalNotPending      = Error{ID: 145, Status: http.StatusBadRequest}
+	ErrWorkflowNodeRunApprovalForbidden       = Error{ID: 146, Status: http.StatusForbidden}
+	ErrWorkflowTemplateNotFound               = Error{ID: 147, Status: http.StatusNotFound}
+	ErrWorkflowTemplateNoAdmin                = Error{ID: 148, Status: http.StatusForbidden}
 )
 
 var errorsAmericanEnglish = map[int]string{
@@ -300,6 +305,11 @@ var errorsAmericanEnglish = map[int]string{
 	ErrIconBadFormat.ID:                          "Bad icon format. Must be an image",
 	ErrIconBadSize.ID:                            "Bad icon size. Must be lower than 100Ko",
 	ErrWorkflowConditionBadOperator.ID:           "Your run conditions have bad operator",
+	ErrWorkflowConditionBadExpression.ID:         "Your run conditions expression is invalid",
+	ErrWorkflowNodeRunApprovalNotPending.ID:      "This pipeline is not waiting for approval",
+	ErrWorkflowNodeRunApprovalForbidden.ID:       "You are not allowed to approve this pipeline",
+	ErrWorkflowTemplateNotFound.ID:               "Workflow template not found",
+	ErrWorkflowTemplateNoAdmin.ID:                "Forbidden: you are neither a CDS administrator or the administrator of the group of the workflow template",
 }
 
 var errorsFrench = map[int]string{
@@ -444,6 +454,11 @@ var errorsFrench = map[int]string{
 	ErrIconBadFormat.ID:                          "Mauvais format d'icône, doit être une image",
 	ErrIconBadSize.ID:                            "Taille de l'icône trop importante. (max 100Ko)",
 	ErrWorkflowConditionBadOperator.ID:           "Opérateur de condition de lancement incorrect",
+	ErrWorkflowConditionBadExpression.ID:         "Expression de condition de lancement incorrecte",
+	ErrWorkflowNodeRunApprovalNotPending.ID:      "Ce pipeline n'est pas en attente d'approbation",
+	ErrWorkflowNodeRunApprovalForbidden.ID:       "Vous n'êtes pas autorisé à approuver ce pipeline",
+	ErrWorkflowTemplateNotFound.ID:               "Modèle de workflow non trouvé",
+	ErrWorkflowTemplateNoAdmin.ID:                "Accès refusé: vous n'êtes ni un administrateur CDS ni un administrateur du groupe du modèle de workflow",
 }
 
 var errorsLanguages = []map[int]string{
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// Headers sent with the events delivered by webhook
+const (
+	WebhookHeaderEventType = "X-Cds-Event-Type"
+	WebhookHeaderTimestamp = "X-Cds-Timestamp"
+	WebhookHeaderSignature = "X-Cds-Signature"
+)
+
+// SignWebhookPayload returns the signature of an event delivered by webhook:
+// the hex encoded HMAC-SHA256 of "<timestamp>.<body>" with the secret of the webhook
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookPayload checks the signature of an event delivered by webhook
+func VerifyWebhookPayload(secret, timestamp, signature string, body []byte) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	if !hmac.Equal([]byte(SignWebhookPayload(secret, ts, body)), []byte(signature)) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
@@ -74,6 +74,12 @@ func newSteps(a sdk.Action) []Step {
 		if act.AlwaysExecuted {
 			s["always_executed"] = act.AlwaysExecuted
 		}
+		if act.RetryPolicy != nil {
+			s["retry"] = act.RetryPolicy
+		}
+		if act.Timeout != "" {
+			s["timeout"] = act.Timeout
+		}
 
 		switch act.Type {
 		case sdk.BuiltinAction:
@@ -630,6 +636,46 @@ func (s Step) IsFlagged(flag string) (bool, error) {
 	return bS, nil
 }
 
+// RetryPolicy returns the retry policy of the step if exist
+func (s Step) RetryPolicy() (*sdk.RetryPolicy, error) {
+	bI, ok := s["retry"]
+	if !ok {
+		return nil, nil
+	}
+	if p, ok := bI.(*sdk.RetryPolicy); ok {
+		return p, nil
+	}
+
+	p := new(sdk.RetryPolicy)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "yaml", Result: p})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(bI); err != nil {
+		return nil, fmt.Errorf("Malformatted Step : invalid retry: %v", err)
+	}
+	if err := p.IsValid(); err != nil {
+		return nil, fmt.Errorf("Malformatted Step : invalid retry: %v", err)
+	}
+	return p, nil
+}
+
+// Timeout returns the timeout of the step if exist
+func (s Step) Timeout() (string, error) {
+	tI, ok := s["timeout"]
+	if !ok {
+		return "", nil
+	}
+	t, ok := tI.(string)
+	if !ok {
+		return "", fmt.Errorf("Malformatted Step : timeout must be a string")
+	}
+	if err := sdk.IsValidTimeout(t); err != nil {
+		return "", fmt.Errorf("Malformatted Step : invalid timeout: %v", err)
+	}
+	return t, nil
+}
+
 // Name returns true the step name if exist
 func (s Step) Name() (string, error) {
 	if stepAttr, ok := s["name"]; ok {
@@ -19,6 +19,7 @@ type Application struct {
 	VCSUser              string                              `json:"vcs_user,omitempty" yaml:"vcs_user,omitempty"`
 	VCSPassword          string                              `json:"vcs_password,omitempty" yaml:"vcs_password,omitempty"`
 	VCSPGPKey            string                              `json:"vcs_pgp_key,omitempty" yaml:"vcs_pgp_key,omitempty"`
+	VCSPullRequestReport bool                                `json:"vcs_pull_request_report,omitempty" yaml:"vcs_pull_request_report,omitempty"`
 	DeploymentStrategies map[string]map[string]VariableValue `json:"deployments,omitempty" yaml:"deployments,omitempty"`
 }
 
@@ -82,6 +83,7 @@ func NewApplication(app sdk.Application, withPermissions bool, keys []EncryptedK
 	a.VCSSSHKey = app.RepositoryStrategy.SSHKey
 	a.VCSUser = app.RepositoryStrategy.User
 	a.VCSPassword = app.RepositoryStrategy.Password
+	a.VCSPullRequestReport = app.RepositoryStrategy.PullRequestComment
 
 	a.DeploymentStrategies = make(map[string]map[string]VariableValue, len(app.DeploymentStrategies))
 	for name, config := range app.DeploymentStrategies {
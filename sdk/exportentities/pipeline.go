@@ -44,21 +44,27 @@ type Pipeline struct {
 
 // Stage represents exported sdk.Stage
 type Stage struct {
-	Enabled    *bool             `json:"enabled,omitempty" yaml:"enabled,omitempty"`
-	Jobs       map[string]Job    `json:"jobs,omitempty" yaml:"jobs,omitempty"`
-	Conditions map[string]string `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Enabled       *bool                       `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Jobs          map[string]Job              `json:"jobs,omitempty" yaml:"jobs,omitempty"`
+	Conditions    map[string]string           `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Needs         []string                    `json:"needs,omitempty" yaml:"needs,omitempty"`
+	RunConditions *sdk.WorkflowNodeConditions `json:"run_conditions,omitempty" yaml:"run_conditions,omitempty"`
 }
 
 // Job represents exported sdk.Job
 type Job struct {
-	Name           string        `json:"job,omitempty" yaml:"job,omitempty"`     //This will ONLY be set with Pipelinev1
-	Stage          string        `json:"stage,omitempty" yaml:"stage,omitempty"` //This will ONLY be set with Pipelinev1
-	Description    string        `json:"description,omitempty" yaml:"description,omitempty"`
-	Enabled        *bool         `json:"enabled,omitempty" yaml:"enabled,omitempty"`
-	Steps          []Step        `json:"steps,omitempty" yaml:"steps,omitempty"`
-	Requirements   []Requirement `json:"requirements,omitempty" yaml:"requirements,omitempty"`
-	Optional       *bool         `json:"optional,omitempty" yaml:"optional,omitempty"`
-	AlwaysExecuted *bool         `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Name           string           `json:"job,omitempty" yaml:"job,omitempty"`     //This will ONLY be set with Pipelinev1
+	Stage          string           `json:"stage,omitempty" yaml:"stage,omitempty"` //This will ONLY be set with Pipelinev1
+	Description    string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Enabled        *bool            `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Steps          []Step           `json:"steps,omitempty" yaml:"steps,omitempty"`
+	Requirements   []Requirement    `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+	Optional       *bool            `json:"optional,omitempty" yaml:"optional,omitempty"`
+	AlwaysExecuted *bool            `json:"always_executed,omitempty" yaml:"always_executed,omitempty"`
+	Matrix         sdk.JobMatrix    `json:"matrix,omitempty" yaml:"matrix,omitempty"`
+	Retry          *sdk.RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+	Priority       int              `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Timeout        string           `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // Step represents exported step used in a job
@@ -68,7 +74,7 @@ type Step map[string]interface{}
 func (s Step) IsValid() bool {
 	keys := []string{}
 	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
+		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" && k != "retry" && k != "timeout" {
 			keys = append(keys, k)
 		}
 	}
@@ -78,7 +84,7 @@ func (s Step) IsValid() bool {
 func (s Step) key() string {
 	keys := []string{}
 	for k := range s {
-		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" {
+		if k != "enabled" && k != "optional" && k != "always_executed" && k != "name" && k != "retry" && k != "timeout" {
 			keys = append(keys, k)
 		}
 	}
@@ -87,13 +93,15 @@ func (s Step) key() string {
 
 // Requirement represents an exported sdk.Requirement
 type Requirement struct {
-	Binary   string             `json:"binary,omitempty" yaml:"binary,omitempty"`
-	Network  string             `json:"network,omitempty" yaml:"network,omitempty"`
-	Model    string             `json:"model,omitempty" yaml:"model,omitempty"`
-	Hostname string             `json:"hostname,omitempty" yaml:"hostname,omitempty"`
-	Plugin   string             `json:"plugin,omitempty" yaml:"plugin,omitempty"`
-	Service  ServiceRequirement `json:"service,omitempty" yaml:"service,omitempty"`
-	Memory   string             `json:"memory,omitempty" yaml:"memory,omitempty"`
+	Binary        string             `json:"binary,omitempty" yaml:"binary,omitempty"`
+	Network       string             `json:"network,omitempty" yaml:"network,omitempty"`
+	Model         string             `json:"model,omitempty" yaml:"model,omitempty"`
+	Hostname      string             `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Plugin        string             `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	Service       ServiceRequirement `json:"service,omitempty" yaml:"service,omitempty"`
+	Memory        string             `json:"memory,omitempty" yaml:"memory,omitempty"`
+	CPU           string             `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	ResourceClass string             `json:"resource_class,omitempty" yaml:"resource_class,omitempty"`
 }
 
 // ServiceRequirement represents an exported sdk.Requirement of type ServiceRequirement
@@ -165,6 +173,11 @@ func newStagesForPipelineV1(stages []sdk.Stage) ([]string, map[string]Stage) {
 		for _, r := range s.Prerequisites {
 			st.Conditions[r.Parameter] = r.ExpectedValue
 		}
+		if len(s.Needs) > 0 || s.RunConditions != nil {
+			st.Needs = s.Needs
+			st.RunConditions = s.RunConditions
+			hasOptions = true
+		}
 		if hasOptions == true {
 			opts[s.Name] = st
 		}
@@ -213,7 +226,7 @@ func NewPipeline(pip sdk.Pipeline, withPermission bool) (p *Pipeline) {
 	case 0:
 		return
 	case 1:
-		if len(pip.Stages[0].Prerequisites) == 0 {
+		if len(pip.Stages[0].Prerequisites) == 0 && pip.Stages[0].RunConditions == nil {
 			switch len(pip.Stages[0].Jobs) {
 			case 0:
 				return
@@ -253,6 +266,8 @@ func newStages(stages []sdk.Stage, withJobs bool) map[string]Stage {
 		for _, r := range s.Prerequisites {
 			st.Conditions[r.Parameter] = r.ExpectedValue
 		}
+		st.Needs = s.Needs
+		st.RunConditions = s.RunConditions
 		st.Jobs = newJobs(s.Jobs)
 		res[fmt.Sprintf("%d|%s", order, s.Name)] = st
 	}
@@ -280,6 +295,10 @@ func newRequirements(req []sdk.Requirement) []Requirement {
 			res = append(res, Requirement{Service: ServiceRequirement{Name: r.Name, Value: r.Value}})
 		case sdk.MemoryRequirement:
 			res = append(res, Requirement{Memory: r.Value})
+		case sdk.CPURequirement:
+			res = append(res, Requirement{CPU: r.Value})
+		case sdk.ResourceClassRequirement:
+			res = append(res, Requirement{ResourceClass: r.Value})
 		}
 	}
 	return res
@@ -293,6 +312,10 @@ func newJob(j sdk.Job) Job {
 	jo.Steps = newSteps(j.Action)
 	jo.Description = j.Action.Description
 	jo.Requirements = newRequirements(j.Action.Requirements)
+	jo.Matrix = j.Matrix
+	jo.Retry = j.RetryPolicy
+	jo.Priority = j.Priority
+	jo.Timeout = j.Timeout
 	return jo
 }
 
@@ -430,6 +453,8 @@ func (p *Pipeline) Pipeline() (*sdk.Pipeline, error) {
 					ExpectedValue: c,
 				})
 			}
+			s.Needs = p.Stages[stageName].Needs
+			s.RunConditions = p.Stages[stageName].RunConditions
 
 			//Compute jobs
 			s.Jobs = make([]sdk.Job, 0, len(p.Stages[stageName].Jobs))
@@ -445,9 +470,26 @@ func (p *Pipeline) Pipeline() (*sdk.Pipeline, error) {
 		}
 	}
 
+	if err := checkStages(pip.Stages); err != nil {
+		return nil, err
+	}
+
 	return pip, nil
 }
 
+// checkStages checks the needs and the run conditions of the imported stages
+func checkStages(stages []sdk.Stage) error {
+	if err := sdk.CheckStageGraph(stages); err != nil {
+		return err
+	}
+	for i := range stages {
+		if err := stages[i].IsValidRunConditions(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func computeSteps(steps []Step) ([]sdk.Action, error) {
 	res := make([]sdk.Action, len(steps))
 	for i, s := range steps {
@@ -455,6 +497,14 @@ func computeSteps(steps []Step) ([]sdk.Action, error) {
 		if err != nil {
 			return nil, err
 		}
+		a.RetryPolicy, err = s.RetryPolicy()
+		if err != nil {
+			return nil, err
+		}
+		a.Timeout, err = s.Timeout()
+		if err != nil {
+			return nil, err
+		}
 		res[i] = *a
 	}
 	return res, nil
@@ -547,6 +597,14 @@ func computeJobRequirements(req []Requirement) []sdk.Requirement {
 			name = r.Service.Name
 			val = r.Service.Value
 			tpe = sdk.ServiceRequirement
+		} else if r.CPU != "" {
+			name = "cpu"
+			val = r.CPU
+			tpe = sdk.CPURequirement
+		} else if r.ResourceClass != "" {
+			name = "resource-class"
+			val = r.ResourceClass
+			tpe = sdk.ResourceClassRequirement
 		}
 		res[i] = sdk.Requirement{
 			Name:  name,
@@ -573,6 +631,30 @@ func computeJob(name string, j Job) (*sdk.Job, error) {
 	job.Action.Enabled = job.Enabled
 	job.Action.Requirements = computeJobRequirements(j.Requirements)
 
+	if len(j.Matrix) > 0 {
+		if err := j.Matrix.IsValid(); err != nil {
+			return nil, fmt.Errorf("Invalid matrix on job %s: %v", name, err)
+		}
+		job.Matrix = j.Matrix
+	}
+
+	if j.Retry != nil {
+		if err := j.Retry.IsValid(); err != nil {
+			return nil, fmt.Errorf("Invalid retry on job %s: %v", name, err)
+		}
+		job.RetryPolicy = j.Retry
+	}
+
+	if err := sdk.IsValidJobPriority(j.Priority); err != nil {
+		return nil, fmt.Errorf("Invalid priority on job %s: %v", name, err)
+	}
+	job.Priority = j.Priority
+
+	if err := sdk.IsValidTimeout(j.Timeout); err != nil {
+		return nil, fmt.Errorf("Invalid timeout on job %s: %v", name, err)
+	}
+	job.Timeout = j.Timeout
+
 	//Compute steps for the jobs
 	children, err := computeSteps(j.Steps)
 	if err != nil {
@@ -641,6 +723,8 @@ func (p PipelineV1) Pipeline() (pip *sdk.Pipeline, err error) {
 				ExpectedValue: c,
 			})
 		}
+		mapStages[s].Needs = opt.Needs
+		mapStages[s].RunConditions = opt.RunConditions
 	}
 
 	//Compute Jobs
@@ -673,5 +757,9 @@ func (p PipelineV1) Pipeline() (pip *sdk.Pipeline, err error) {
 		return pip.Stages[i].BuildOrder < pip.Stages[j].BuildOrder
 	})
 
+	if err := checkStages(pip.Stages); err != nil {
+		return pip, err
+	}
+
 	return pip, nil
 }
@@ -538,6 +538,189 @@ steps:
 
 }
 
+func TestPipelineV1_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		// check is run on the imported pipeline, and on the pipeline exported then imported again
+		check func(t *testing.T, p *sdk.Pipeline)
+		// invalid makes the imported payload invalid
+		invalid func(payload *PipelineV1)
+	}{
+		{
+			name: "matrix",
+			in: `version: v1.0
+name: build
+jobs:
+- job: test
+  matrix:
+    go: ["1.10", "1.11"]
+    os: [linux/amd64, linux/arm64]
+  requirements:
+  - model: golang:{{.cds.matrix.go}}
+  steps:
+  - script: GOOS_ARCH={{.cds.matrix.os}} go test ./...
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				job := p.Stages[0].Jobs[0]
+				assert.Equal(t, sdk.JobMatrix{"go": {"1.10", "1.11"}, "os": {"linux/amd64", "linux/arm64"}}, job.Matrix)
+				assert.Len(t, job.Matrix.Cells(), 4)
+			},
+			invalid: func(payload *PipelineV1) {
+				payload.Jobs[0].Matrix["go"] = []string{"1.10", "1.10"}
+			},
+		},
+		{
+			name: "retry",
+			in: `version: v1.0
+name: build
+jobs:
+- job: test
+  retry:
+    max_attempts: 3
+    backoff: 30s
+    on_worker_lost: true
+  steps:
+  - script: make test
+    retry:
+      max_attempts: 2
+      exit_codes: [137]
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				job := p.Stages[0].Jobs[0]
+				assert.Equal(t, &sdk.RetryPolicy{MaxAttempts: 3, Backoff: "30s", OnWorkerLost: true}, job.RetryPolicy)
+				assert.Equal(t, &sdk.RetryPolicy{MaxAttempts: 2, ExitCodes: []int{137}}, job.Action.Actions[0].RetryPolicy)
+			},
+			invalid: func(payload *PipelineV1) {
+				payload.Jobs[0].Steps[0]["retry"] = map[interface{}]interface{}{"max_attempts": 20}
+			},
+		},
+		{
+			name: "priority",
+			in: `version: v1.0
+name: build
+jobs:
+- job: deploy
+  priority: 5
+  steps:
+  - script: make deploy
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				assert.Equal(t, 5, p.Stages[0].Jobs[0].Priority)
+			},
+			invalid: func(payload *PipelineV1) {
+				payload.Jobs[0].Priority = sdk.JobPriorityMax + 1
+			},
+		},
+		{
+			name: "resource class",
+			in: `version: v1.0
+name: build
+jobs:
+- job: compile
+  requirements:
+  - cpu: "4"
+  - resource_class: large
+  steps:
+  - script: make
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				reqs := p.Stages[0].Jobs[0].Action.Requirements
+				assert.Len(t, reqs, 2)
+				assert.Contains(t, reqs, sdk.Requirement{Name: "cpu", Type: sdk.CPURequirement, Value: "4"})
+				assert.Contains(t, reqs, sdk.Requirement{Name: "resource-class", Type: sdk.ResourceClassRequirement, Value: "large"})
+			},
+		},
+		{
+			name: "timeout",
+			in: `version: v1.0
+name: build
+jobs:
+- job: test
+  timeout: 30m
+  steps:
+  - script: make test
+    timeout: 10m
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				job := p.Stages[0].Jobs[0]
+				assert.Equal(t, "30m", job.Timeout)
+				assert.Equal(t, "10m", job.Action.Actions[0].Timeout)
+			},
+			invalid: func(payload *PipelineV1) {
+				payload.Jobs[0].Steps[0]["timeout"] = "forever"
+			},
+		},
+		{
+			name: "stage graph",
+			in: `version: v1.0
+name: build
+stages:
+- build
+- lint
+- test
+- deploy
+options:
+  lint:
+    needs:
+    - build
+  test:
+    needs:
+    - build
+  deploy:
+    needs:
+    - lint
+    - test
+    run_conditions:
+      check:
+      - variable: git.branch
+        operator: regex
+        value: ^master$
+jobs:
+- job: compile
+  stage: build
+  steps:
+  - script: make
+`,
+			check: func(t *testing.T, p *sdk.Pipeline) {
+				assert.Len(t, p.Stages, 4)
+				assert.Equal(t, []string{"build"}, p.Stages[1].Needs)
+				assert.Equal(t, []string{"lint", "test"}, p.Stages[3].Needs)
+				assert.Equal(t, "regex", p.Stages[3].RunConditions.PlainConditions[0].Operator)
+			},
+			invalid: func(payload *PipelineV1) {
+				opt := payload.StageOptions["build"]
+				opt.Needs = []string{"deploy"}
+				payload.StageOptions["build"] = opt
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &PipelineV1{}
+			test.NoError(t, yaml.Unmarshal([]byte(tt.in), payload))
+			p, err := payload.Pipeline()
+			test.NoError(t, err)
+			tt.check(t, p)
+
+			b, err := yaml.Marshal(NewPipelineV1(*p, false))
+			test.NoError(t, err)
+			reimported := &PipelineV1{}
+			test.NoError(t, yaml.Unmarshal(b, reimported))
+			p2, err := reimported.Pipeline()
+			test.NoError(t, err)
+			tt.check(t, p2)
+
+			if tt.invalid != nil {
+				tt.invalid(payload)
+				_, err = payload.Pipeline()
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func Test_ImportPipelineWithGitClone(t *testing.T) {
 	in := `name: build-all-images
 requirements:
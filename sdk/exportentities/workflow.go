@@ -30,10 +30,13 @@ type Workflow struct {
 	EnvironmentName     string                      `json:"environment,omitempty" yaml:"environment,omitempty"`
 	ProjectPlatformName string                      `json:"platform,omitempty" yaml:"platform,omitempty"`
 	PipelineHooks       []HookEntry                 `json:"pipeline_hooks,omitempty" yaml:"pipeline_hooks,omitempty"`
+	Approval            *sdk.WorkflowNodeApproval   `json:"approval,omitempty" yaml:"approval,omitempty"`
+	Concurrency         *sdk.WorkflowConcurrency    `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
 	Permissions         map[string]int              `json:"permissions,omitempty" yaml:"permissions,omitempty"`
 	Metadata            map[string]string           `json:"metadata,omitempty" yaml:"metadata,omitempty" db:"-"`
 	PurgeTags           []string                    `json:"purge_tags,omitempty" yaml:"purge_tags,omitempty" db:"-"`
 	HistoryLength       int64                       `json:"history_length,omitempty" yaml:"history_length,omitempty" db:"-"`
+	CancelSuperseded    bool                        `json:"cancel_superseded_runs,omitempty" yaml:"cancel_superseded_runs,omitempty"`
 }
 
 // NodeEntry represents a node as code
@@ -47,6 +50,8 @@ type NodeEntry struct {
 	EnvironmentName     string                      `json:"environment,omitempty" yaml:"environment,omitempty"`
 	ProjectPlatformName string                      `json:"platform,omitempty" yaml:"platform,omitempty"`
 	OneAtATime          *bool                       `json:"one_at_a_time,omitempty" yaml:"one_at_a_time,omitempty"`
+	Approval            *sdk.WorkflowNodeApproval   `json:"approval,omitempty" yaml:"approval,omitempty"`
+	Concurrency         *sdk.WorkflowConcurrency    `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
 	Payload             map[string]interface{}      `json:"payload,omitempty" yaml:"payload,omitempty"`
 	Parameters          map[string]string           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 }
@@ -88,6 +93,8 @@ func NewWorkflow(w sdk.Workflow, withPermission bool) (Workflow, error) {
 	}
 
 	exportedWorkflow.PurgeTags = w.PurgeTags
+	exportedWorkflow.Concurrency = w.Concurrency
+	exportedWorkflow.CancelSuperseded = w.CancelSupersededRuns
 	nodes := w.Nodes(false)
 
 	if withPermission {
@@ -128,9 +135,10 @@ func NewWorkflow(w sdk.Workflow, withPermission bool) (Workflow, error) {
 			}
 		}
 
-		if len(conditions) > 0 || n.Context.Conditions.LuaScript != "" {
+		if len(conditions) > 0 || n.Context.Conditions.Expression != "" || n.Context.Conditions.LuaScript != "" {
 			entry.Conditions = &sdk.WorkflowNodeConditions{
 				PlainConditions: conditions,
+				Expression:      n.Context.Conditions.Expression,
 				LuaScript:       n.Context.Conditions.LuaScript,
 			}
 		}
@@ -149,6 +157,9 @@ func NewWorkflow(w sdk.Workflow, withPermission bool) (Workflow, error) {
 			entry.OneAtATime = &n.Context.Mutex
 		}
 
+		entry.Approval = n.Context.Approval
+		entry.Concurrency = n.Context.Concurrency
+
 		if n.Context.HasDefaultPayload() {
 			enc := dump.NewDefaultEncoder(nil)
 			enc.ExtraFields.DetailedMap = false
@@ -186,7 +197,12 @@ func NewWorkflow(w sdk.Workflow, withPermission bool) (Workflow, error) {
 		exportedWorkflow.EnvironmentName = entry.EnvironmentName
 		exportedWorkflow.ProjectPlatformName = entry.ProjectPlatformName
 		exportedWorkflow.DependsOn = entry.DependsOn
-		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.LuaScript != "") {
+		exportedWorkflow.Approval = entry.Approval
+		// With a single pipeline, the concurrency group of the pipeline is the one of the workflow
+		if exportedWorkflow.Concurrency == nil {
+			exportedWorkflow.Concurrency = entry.Concurrency
+		}
+		if entry.Conditions != nil && (len(entry.Conditions.PlainConditions) > 0 || entry.Conditions.Expression != "" || entry.Conditions.LuaScript != "") {
 			exportedWorkflow.When = entry.When
 			exportedWorkflow.Conditions = entry.Conditions
 		}
@@ -247,6 +263,7 @@ func (w Workflow) Entries() map[string]NodeEntry {
 		When:                w.When,
 		Payload:             w.Payload,
 		Parameters:          w.Parameters,
+		Approval:            w.Approval,
 	}
 	return map[string]NodeEntry{
 		w.PipelineName: singleEntry,
@@ -285,6 +302,9 @@ func (w Workflow) checkValidity() error {
 		if len(w.PipelineHooks) != 0 {
 			mError.Append(fmt.Errorf("Error: wrong usage: pipeline_hooks not allowed here"))
 		}
+		if w.Approval != nil {
+			mError.Append(fmt.Errorf("Error: wrong usage: approval not allowed here"))
+		}
 	} else {
 		if len(w.Hooks) > 0 {
 			mError.Append(fmt.Errorf("Error: wrong usage: hooks not allowed here"))
@@ -346,6 +366,13 @@ func (w Workflow) GetWorkflow() (*sdk.Workflow, error) {
 		return nil, err
 	}
 	wf.PurgeTags = w.PurgeTags
+	wf.CancelSupersededRuns = w.CancelSuperseded
+	if w.Concurrency != nil {
+		if err := w.Concurrency.IsValid(); err != nil {
+			return nil, fmt.Errorf("Invalid concurrency: %v", err)
+		}
+		wf.Concurrency = w.Concurrency
+	}
 	if len(w.Metadata) > 0 {
 		wf.Metadata = make(map[string]string, len(w.Metadata))
 		for k, v := range w.Metadata {
@@ -429,6 +456,9 @@ func (e *NodeEntry) getNode(name string) (*sdk.WorkflowNode, error) {
 	}
 
 	if e.Conditions != nil {
+		if err := sdk.WorkflowNodeConditionsIsValid(*e.Conditions); err != nil {
+			return nil, fmt.Errorf("Invalid conditions expression on %s: %v", name, err)
+		}
 		if node.Context == nil {
 			node.Context = new(sdk.WorkflowNodeContext)
 		}
@@ -478,6 +508,20 @@ func (e *NodeEntry) getNode(name string) (*sdk.WorkflowNode, error) {
 		node.Context.Mutex = *e.OneAtATime
 	}
 
+	if e.Approval != nil {
+		if err := e.Approval.IsValid(); err != nil {
+			return nil, fmt.Errorf("Invalid approval on %s: %v", name, err)
+		}
+		node.Context.Approval = e.Approval
+	}
+
+	if e.Concurrency != nil {
+		if err := e.Concurrency.IsValid(); err != nil {
+			return nil, fmt.Errorf("Invalid concurrency on %s: %v", name, err)
+		}
+		node.Context.Concurrency = e.Concurrency
+	}
+
 	return node, nil
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/fsamin/go-dump"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 
 	"github.com/ovh/cds/sdk"
 )
@@ -614,3 +615,168 @@ func TestWorkflow_GetWorkflow(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflow_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		// check is run on the imported workflow, and on the workflow exported then imported again
+		check func(t *testing.T, wf *sdk.Workflow)
+		// exported is a line of the exported yaml
+		exported string
+		// invalid replaces a string of the yaml to make it invalid
+		invalid [2]string
+	}{
+		{
+			name: "conditions expression",
+			in: `name: w
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    pipeline: deploy
+    depends_on:
+    - build
+    when:
+    - success
+    conditions:
+      expression: git.branch == "master" || git.branch matches "release/*"
+`,
+			check: func(t *testing.T, wf *sdk.Workflow) {
+				deploy := wf.GetNodeByName("deploy")
+				if !assert.NotNil(t, deploy) {
+					return
+				}
+				assert.Equal(t, `git.branch == "master" || git.branch matches "release/*"`, deploy.Context.Conditions.Expression)
+				assert.Len(t, deploy.Context.Conditions.PlainConditions, 1)
+			},
+			exported: `expression: git.branch == "master" || git.branch matches "release/*"`,
+			invalid:  [2]string{`"release/*"`, `"release/*`},
+		},
+		{
+			name: "approval",
+			in: `name: w
+version: v1.0
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    pipeline: deploy
+    depends_on:
+    - build
+    approval:
+      groups:
+      - ops
+      min_approvals: 2
+      expiry: 24h
+      on_expiry: skip
+`,
+			check: func(t *testing.T, wf *sdk.Workflow) {
+				deploy := wf.GetNodeByName("deploy")
+				if !assert.NotNil(t, deploy) || !assert.NotNil(t, deploy.Context.Approval) {
+					return
+				}
+				assert.Equal(t, []string{"ops"}, deploy.Context.Approval.Groups)
+				assert.Equal(t, 2, deploy.Context.Approval.MinApprovals)
+				assert.Nil(t, wf.GetNodeByName("build").Context.Approval)
+			},
+			exported: "on_expiry: skip",
+			invalid:  [2]string{"24h", "one day"},
+		},
+		{
+			name: "concurrency",
+			in: `name: w
+version: v1.0
+concurrency:
+  group: release-eu
+workflow:
+  build:
+    pipeline: build
+  deploy:
+    pipeline: deploy
+    depends_on:
+    - build
+    concurrency:
+      group: deploy-prod-eu
+      policy: cancel-previous
+`,
+			check: func(t *testing.T, wf *sdk.Workflow) {
+				if !assert.NotNil(t, wf.Concurrency) {
+					return
+				}
+				assert.Equal(t, "release-eu", wf.Concurrency.Group)
+				deploy := wf.GetNodeByName("deploy")
+				if !assert.NotNil(t, deploy) || !assert.NotNil(t, deploy.Context.Concurrency) {
+					return
+				}
+				assert.Equal(t, "deploy-prod-eu", deploy.Context.Concurrency.Group)
+				assert.True(t, deploy.Context.Concurrency.CancelPrevious())
+				assert.Nil(t, wf.GetNodeByName("build").Context.Concurrency)
+			},
+			exported: "policy: cancel-previous",
+			invalid:  [2]string{"cancel-previous", "drop"},
+		},
+		{
+			name: "cancel superseded runs",
+			in: `name: w
+version: v1.0
+cancel_superseded_runs: true
+pipeline: build
+`,
+			check: func(t *testing.T, wf *sdk.Workflow) {
+				assert.True(t, wf.CancelSupersededRuns)
+			},
+			exported: "cancel_superseded_runs: true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w Workflow
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.in), &w))
+			wf, err := w.GetWorkflow()
+			assert.NoError(t, err)
+			tt.check(t, wf)
+
+			setNodeIDs(wf)
+			exported, err := NewWorkflow(*wf, false)
+			assert.NoError(t, err)
+			b, err := yaml.Marshal(exported)
+			assert.NoError(t, err)
+			assert.Contains(t, string(b), tt.exported)
+
+			var reimported Workflow
+			assert.NoError(t, yaml.Unmarshal(b, &reimported))
+			wf2, err := reimported.GetWorkflow()
+			assert.NoError(t, err)
+			tt.check(t, wf2)
+
+			if tt.invalid[0] != "" {
+				var invalid Workflow
+				assert.NoError(t, yaml.Unmarshal([]byte(strings.Replace(tt.in, tt.invalid[0], tt.invalid[1], 1)), &invalid))
+				_, err = invalid.GetWorkflow()
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// setNodeIDs numbers the nodes of an imported workflow as if it was saved: the ancestors of the exported nodes are found by ID
+func setNodeIDs(wf *sdk.Workflow) {
+	var id int64
+	var set func(n *sdk.WorkflowNode)
+	set = func(n *sdk.WorkflowNode) {
+		id++
+		n.ID = id
+		for i := range n.Triggers {
+			set(&n.Triggers[i].WorkflowDestNode)
+		}
+	}
+	set(wf.Root)
+	for i := range wf.Joins {
+		for j := range wf.Joins[i].Triggers {
+			set(&wf.Joins[i].Triggers[j].WorkflowDestNode)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package hatchery
+
+import (
+	"github.com/ovh/cds/sdk"
+)
+
+// Unlimited is the value of a resource which is not limited
+const Unlimited = -1
+
+// Resources are the resources needed by a worker, or the free resources of a host of a hatchery.
+// Memory is in MB. A negative value means that the resource is not limited.
+type Resources struct {
+	Memory    int64
+	CPU       float64
+	Instances int
+}
+
+// Fits returns true if the needed resources are available
+func (r Resources) Fits(need Resources) bool {
+	if r.Memory >= 0 && need.Memory > r.Memory {
+		return false
+	}
+	if r.CPU >= 0 && need.CPU > r.CPU {
+		return false
+	}
+	if r.Instances >= 0 && need.Instances > r.Instances {
+		return false
+	}
+	return true
+}
+
+func (r *Resources) reserve(need Resources) {
+	if r.Memory >= 0 {
+		r.Memory -= need.Memory
+	}
+	if r.CPU >= 0 {
+		r.CPU -= need.CPU
+	}
+	if r.Instances >= 0 {
+		r.Instances -= need.Instances
+	}
+}
+
+// InterfaceWithCapacity is implemented by the hatcheries able to report the free resources of their infrastructure
+// FreeResources returns the free resources of each host on which a worker can be started
+// NeededResources returns the resources needed by a worker of the model for the requirements of a job
+type InterfaceWithCapacity interface {
+	Interface
+	FreeResources() ([]Resources, error)
+	NeededResources(model *sdk.Model, requirements []sdk.Requirement) Resources
+}
+
+// capacity is the free resources of a hatchery during a scheduling round.
+// A nil capacity is the capacity of a hatchery which does not report its free resources.
+type capacity struct {
+	h     InterfaceWithCapacity
+	hosts []Resources
+}
+
+func loadCapacity(h Interface) (*capacity, error) {
+	hc, ok := h.(InterfaceWithCapacity)
+	if !ok {
+		return nil, nil
+	}
+	hosts, err := hc.FreeResources()
+	if err != nil {
+		return nil, err
+	}
+	return &capacity{h: hc, hosts: hosts}, nil
+}
+
+// reserve books the resources needed by a worker on the first host where they fit. It returns false
+// if no host has enough free resources.
+func (c *capacity) reserve(model *sdk.Model, requirements []sdk.Requirement) bool {
+	if c == nil {
+		return true
+	}
+	need := c.h.NeededResources(model, requirements)
+	for i := range c.hosts {
+		if c.hosts[i].Fits(need) {
+			c.hosts[i].reserve(need)
+			return true
+		}
+	}
+	return false
+}
@@ -89,11 +89,13 @@ func Create(h Interface) error {
 	tickerProvision := time.NewTicker(time.Duration(h.Configuration().Provision.Frequency) * time.Second)
 	tickerRegister := time.NewTicker(time.Duration(h.Configuration().Provision.RegisterFrequency) * time.Second)
 	tickerGetModels := time.NewTicker(10 * time.Second)
+	tickerSchedule := time.NewTicker(time.Second)
 
 	defer func() {
 		tickerProvision.Stop()
 		tickerRegister.Stop()
 		tickerGetModels.Stop()
+		tickerSchedule.Stop()
 	}()
 
 	pbjobs := make(chan sdk.PipelineBuildJob, 10)
@@ -104,6 +106,9 @@ func Create(h Interface) error {
 	// purges expired items every minute
 	spawnIDs := cache.New(10*time.Second, 60*time.Second)
 
+	// The scheduler orders the jobs received from the queue before requesting their workers
+	sched := newScheduler()
+
 	// hatchery is now fully Initialized
 	h.SetInitialized()
 
@@ -135,6 +140,9 @@ func Create(h Interface) error {
 	sdk.GoRoutine("checkStarterResult",
 		func() {
 			for startWorkerRes := range workerStartResultChan {
+				if startWorkerRes.request.projectID != 0 {
+					sched.done(startWorkerRes.request.projectID)
+				}
 				if startWorkerRes.err != nil {
 					errs <- startWorkerRes.err
 				}
@@ -163,6 +171,118 @@ func Create(h Interface) error {
 		PanicDump(h),
 	)
 
+	// startWorkflowJob requests a worker for a job if a model can run it on the free resources of the hatchery.
+	// It returns false if the hatchery is not able to start a new worker.
+	startWorkflowJob := func(j sdk.WorkflowNodeJobRun, capa *capacity) bool {
+		t0 := time.Now()
+
+		//Check if the jobs is concerned by a pending worker creation
+		if _, exist := spawnIDs.Get(strconv.FormatInt(j.ID, 10)); exist {
+			log.Debug("job %d already spawned in previous routine", j.ID)
+			sched.remove(j.ID)
+			return true
+		}
+
+		//Check if hatchery if able to start a new worker
+		if !checkCapacities(h) {
+			log.Info("hatchery %s is not able to provision new worker", h.Hatchery().Name)
+			return false
+		}
+
+		var traceEnded *struct{}
+		currentCtx, currentCancel := context.WithTimeout(ctx, 10*time.Minute)
+		currentCtx = WithTags(currentCtx, h)
+		if val, has := j.Header.Get(tracingutils.SampledHeader); has && val == "1" {
+			currentCtx, _ = observability.New(currentCtx, h.ServiceName(), "hatchery.JobReceive", trace.AlwaysSample(), trace.SpanKindServer)
+
+			r, _ := j.Header.Get(sdk.WorkflowRunHeader)
+			w, _ := j.Header.Get(sdk.WorkflowHeader)
+			p, _ := j.Header.Get(sdk.ProjectKeyHeader)
+
+			observability.Current(currentCtx,
+				observability.Tag(observability.TagWorkflow, w),
+				observability.Tag(observability.TagWorkflowRun, r),
+				observability.Tag(observability.TagProjectKey, p),
+				observability.Tag(observability.TagWorkflowNodeJobRun, j.ID),
+			)
+		}
+		endTrace := func(reason string) {
+			if reason != "" {
+				observability.Current(currentCtx,
+					observability.Tag("reason", reason),
+				)
+			}
+			observability.End(currentCtx, nil, nil) // nolint
+			var T struct{}
+			traceEnded = &T
+			currentCancel()
+		}
+		go func() {
+			<-currentCtx.Done()
+			if traceEnded == nil {
+				endTrace(currentCtx.Err().Error())
+			}
+		}()
+
+		workerRequest := workerStarterRequest{
+			ctx:               currentCtx,
+			cancel:            endTrace,
+			id:                j.ID,
+			isWorkflowJob:     true,
+			execGroups:        j.ExecGroups,
+			requirements:      j.Job.Action.Requirements,
+			hostname:          hostname,
+			timestamp:         time.Now().Unix(),
+			spawnAttempts:     j.SpawnAttempts,
+			workflowNodeRunID: j.WorkflowNodeRunID,
+		}
+
+		// Check at least one worker model can match, with enough free resources
+		var chosenModel *sdk.Model
+		var canRun bool
+		for i := range models {
+			if canRunJob(h, workerRequest, models[i]) {
+				canRun = true
+				if capa.reserve(&models[i], workerRequest.requirements) {
+					chosenModel = &models[i]
+					break
+				}
+			}
+		}
+
+		// A model can run the job but resources are missing, let's keep the job for the next round
+		if chosenModel == nil && canRun {
+			log.Debug("hatchery> not enough free resources for job %d", j.ID)
+			endTrace("no free resources")
+			return true
+		}
+
+		//Before doing anything, push in cache
+		spawnIDs.SetDefault(strconv.FormatInt(j.ID, 10), j.ID)
+		sched.remove(j.ID)
+
+		// No model has been found, let's send a failing result
+		if chosenModel == nil {
+			workerStartResultChan <- workerStarterResult{
+				request:      workerRequest,
+				isRun:        false,
+				temptToSpawn: true,
+			}
+			endTrace("no model or service ratio reached")
+			return true
+		}
+
+		//We got a model, let's start a worker
+		workerRequest.model = *chosenModel
+		workerRequest.projectID = j.ProjectID
+		sched.started(j.ProjectID)
+
+		//Ask to start
+		log.Debug("hatchery> Request a worker for job %d (%.3f seconds elapsed)", j.ID, time.Since(t0).Seconds())
+		workersStartChan <- workerRequest
+		return true
+	}
+
 	// the main goroutine
 	for {
 		select {
@@ -237,119 +357,53 @@ func Create(h Interface) error {
 			workersStartChan <- workerRequest
 
 		case j := <-wjobs:
-			t0 := time.Now()
 			if j.ID == 0 {
 				continue
 			}
 
-			var traceEnded *struct{}
-			currentCtx, currentCancel := context.WithTimeout(ctx, 10*time.Minute)
-			currentCtx = WithTags(currentCtx, h)
-			if val, has := j.Header.Get(tracingutils.SampledHeader); has && val == "1" {
-				currentCtx, _ = observability.New(currentCtx, h.ServiceName(), "hatchery.JobReceive", trace.AlwaysSample(), trace.SpanKindServer)
-
-				r, _ := j.Header.Get(sdk.WorkflowRunHeader)
-				w, _ := j.Header.Get(sdk.WorkflowHeader)
-				p, _ := j.Header.Get(sdk.ProjectKeyHeader)
-
-				observability.Current(currentCtx,
-					observability.Tag(observability.TagWorkflow, w),
-					observability.Tag(observability.TagWorkflowRun, r),
-					observability.Tag(observability.TagProjectKey, p),
-					observability.Tag(observability.TagWorkflowNodeJobRun, j.ID),
-				)
-			}
-			endTrace := func(reason string) {
-				if reason != "" {
-					observability.Current(currentCtx,
-						observability.Tag("reason", reason),
-					)
-				}
-				observability.End(currentCtx, nil, nil) // nolint
-				var T struct{}
-				traceEnded = &T
-				currentCancel()
-			}
-			go func() {
-				<-currentCtx.Done()
-				if traceEnded == nil {
-					endTrace(currentCtx.Err().Error())
-				}
-			}()
-
-			stats.Record(currentCtx, h.Stats().Jobs.M(1))
+			stats.Record(WithTags(ctx, h), h.Stats().Jobs.M(1))
 
 			//Check if the jobs is concerned by a pending worker creation
 			if _, exist := spawnIDs.Get(strconv.FormatInt(j.ID, 10)); exist {
 				log.Debug("job %d already spawned in previous routine", j.ID)
-				endTrace("already spawned")
 				continue
 			}
 
 			//Check gracetime
 			if j.QueuedSeconds < int64(h.Configuration().Provision.GraceTimeQueued) {
 				log.Debug("job %d is too fresh, queued since %d seconds, let existing waiting worker check it", j.ID, j.QueuedSeconds)
-				endTrace("too fresh")
 				continue
 			}
 
-			//Before doing anything, push in cache
-			spawnIDs.SetDefault(strconv.FormatInt(j.ID, 10), j.ID)
-
 			//Check bookedBy current hatchery
 			if j.BookedBy.ID != 0 && j.BookedBy.ID != h.ID() {
 				log.Debug("hatchery> job %d is booked by someone else (%d / %d)", j.ID, j.BookedBy.ID, h.ID())
-				endTrace("booked by someone else")
+				sched.remove(j.ID)
 				continue
 			}
 
-			//Check if hatchery if able to start a new worker
-			if !checkCapacities(h) {
-				log.Info("hatchery %s is not able to provision new worker", h.Hatchery().Name)
-				endTrace("no capacities")
+			//The worker will be requested by the scheduler, according to the priority of the job and the fair share between projects
+			sched.push(j)
+
+		case <-tickerSchedule.C:
+			queue := sched.next()
+			if len(queue) == 0 {
 				continue
 			}
 
-			workerRequest := workerStarterRequest{
-				ctx:               currentCtx,
-				cancel:            endTrace,
-				id:                j.ID,
-				isWorkflowJob:     true,
-				execGroups:        j.ExecGroups,
-				requirements:      j.Job.Action.Requirements,
-				hostname:          hostname,
-				timestamp:         time.Now().Unix(),
-				spawnAttempts:     j.SpawnAttempts,
-				workflowNodeRunID: j.WorkflowNodeRunID,
+			// When the free resources are unknown, the workers are only limited by maxWorker
+			capa, errC := loadCapacity(h)
+			if errC != nil {
+				log.Error("hatchery> Unable to load free resources of %s: %v", h.Hatchery().Name, errC)
+				capa = nil
 			}
 
-			// Check at least one worker model can match
-			var chosenModel *sdk.Model
-			for i := range models {
-				if canRunJob(h, workerRequest, models[i]) {
-					chosenModel = &models[i]
+			for _, j := range queue {
+				if !startWorkflowJob(j, capa) {
 					break
 				}
 			}
 
-			// No model has been found, let's send a failing result
-			if chosenModel == nil {
-				workerStartResultChan <- workerStarterResult{
-					request:      workerRequest,
-					isRun:        false,
-					temptToSpawn: true,
-				}
-				endTrace("no model or service ratio reached")
-				continue
-			}
-
-			//We got a model, let's start a worker
-			workerRequest.model = *chosenModel
-
-			//Ask to start
-			log.Debug("hatchery> Request a worker for job %d (%.3f seconds elapsed)", j.ID, time.Since(t0).Seconds())
-			workersStartChan <- workerRequest
-
 		case err := <-errs:
 			log.Error("%v", err)
 
@@ -450,6 +504,18 @@ func canRunJob(h Interface, j workerStarterRequest, model sdk.Model) bool {
 			continue
 		}
 
+		// cpu and resource class requirements are honoured by the hatchery in CanSpawn
+		if r.Type == sdk.CPURequirement {
+			if _, err := sdk.ParseCPURequirement(r.Value); err != nil {
+				log.Debug("canRunJob> %d - job %d - %v", j.timestamp, j.id, err)
+				return false
+			}
+			continue
+		}
+		if r.Type == sdk.ResourceClassRequirement {
+			continue
+		}
+
 		if r.Type == sdk.OSArchRequirement && model.RegisteredOS != "" && model.RegisteredArch != "" && r.Value != (model.RegisteredOS+"/"+model.RegisteredArch) {
 			log.Debug("canRunJob> %d - job %d - job with OSArch requirement: cannot spawn on this OSArch. current model: %s/%s", j.timestamp, j.id, model.RegisteredOS, model.RegisteredArch)
 			return false
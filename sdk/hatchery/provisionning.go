@@ -75,7 +75,7 @@ func provisioning(h Interface, models []sdk.Model) {
 		}
 		if models[k].Type == h.ModelType() {
 			existing := h.WorkersStartedByModel(&models[k])
-			for i := existing; i < int(models[k].Provision); i++ {
+			for i := existing; i < int(provisionTarget(models[k])); i++ {
 				go func(m sdk.Model) {
 					if name, errSpawn := h.SpawnWorker(context.Background(), SpawnArguments{Model: m, IsWorkflowJob: false, JobID: 0, Requirements: nil, LogInfo: "spawn for provision"}); errSpawn != nil {
 						log.Warning("provisioning> cannot spawn worker %s with model %s for provisioning: %s", name, m.Name, errSpawn)
@@ -88,3 +88,12 @@ func provisioning(h Interface, models []sdk.Model) {
 		}
 	}
 }
+
+// provisionTarget returns the number of workers to keep started for a model: its provision, or the size
+// of its pool of reusable workers computed by the api from the queue history if it is larger
+func provisionTarget(m sdk.Model) int64 {
+	if m.Reuse.Enabled() && m.PoolSize > m.Provision {
+		return m.PoolSize
+	}
+	return m.Provision
+}
@@ -0,0 +1,50 @@
+package hatchery
+
+import (
+	"fmt"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// ResourceClass is the size of the workers spawned by a hatchery for the jobs with a resource class requirement.
+// CPUs and Memory (in MB) are used by the Swarm, Kubernetes and vSphere hatcheries, Flavor by the OpenStack hatchery.
+type ResourceClass struct {
+	CPUs   float64 `mapstructure:"cpus" toml:"cpus" json:"cpus,omitempty"`
+	Memory int64   `mapstructure:"memory" toml:"memory" json:"memory,omitempty"`
+	Flavor string  `mapstructure:"flavor" toml:"flavor" json:"flavor,omitempty"`
+}
+
+// WorkerResources are the cpus and the resource class required by a job
+type WorkerResources struct {
+	CPUs  float64
+	Class string
+	ResourceClass
+}
+
+// ComputeWorkerResources returns the cpus and the resource class required by a job, from the resource classes of
+// a hatchery. A cpu requirement overrides the cpus of the class. It returns an error if a cpu requirement is invalid
+// or if the resource class is unknown by the hatchery.
+func ComputeWorkerResources(classes map[string]ResourceClass, requirements []sdk.Requirement) (WorkerResources, error) {
+	var res WorkerResources
+	for _, r := range requirements {
+		switch r.Type {
+		case sdk.CPURequirement:
+			cpu, err := sdk.ParseCPURequirement(r.Value)
+			if err != nil {
+				return res, err
+			}
+			res.CPUs = cpu
+		case sdk.ResourceClassRequirement:
+			c, ok := classes[r.Value]
+			if !ok {
+				return res, fmt.Errorf("unknown resource class %s", r.Value)
+			}
+			res.Class = r.Value
+			res.ResourceClass = c
+		}
+	}
+	if res.CPUs == 0 {
+		res.CPUs = res.ResourceClass.CPUs
+	}
+	return res, nil
+}
@@ -0,0 +1,42 @@
+package hatchery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ovh/cds/sdk"
+)
+
+func TestComputeWorkerResources(t *testing.T) {
+	classes := map[string]ResourceClass{
+		"small": {CPUs: 1, Memory: 1024, Flavor: "b2-7"},
+		"large": {CPUs: 8, Memory: 16384, Flavor: "b2-60"},
+	}
+
+	res, err := ComputeWorkerResources(classes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, WorkerResources{}, res)
+
+	res, err = ComputeWorkerResources(classes, []sdk.Requirement{{Type: sdk.ResourceClassRequirement, Value: "large"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "large", res.Class)
+	assert.Equal(t, float64(8), res.CPUs)
+	assert.Equal(t, int64(16384), res.Memory)
+	assert.Equal(t, "b2-60", res.Flavor)
+
+	res, err = ComputeWorkerResources(classes, []sdk.Requirement{
+		{Type: sdk.ResourceClassRequirement, Value: "small"},
+		{Type: sdk.CPURequirement, Value: "2.5"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, res.CPUs)
+	assert.Equal(t, int64(1024), res.Memory)
+
+	_, err = ComputeWorkerResources(classes, []sdk.Requirement{{Type: sdk.ResourceClassRequirement, Value: "xlarge"}})
+	assert.Error(t, err)
+	for _, v := range []string{"-1", "0", "NaN", "Inf", "+Inf", "-Inf"} {
+		_, err = ComputeWorkerResources(nil, []sdk.Requirement{{Type: sdk.CPURequirement, Value: v}})
+		assert.Error(t, err, "cpu requirement %s should be refused", v)
+	}
+}
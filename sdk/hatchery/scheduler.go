@@ -0,0 +1,80 @@
+package hatchery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// pendingJobTTL is the delay after which a job which is not sent anymore by the queue polling is forgotten
+const pendingJobTTL = 30 * time.Second
+
+type pendingJob struct {
+	job      sdk.WorkflowNodeJobRun
+	received time.Time
+}
+
+// scheduler keeps the jobs received from the queue until their workers are requested, and gives them
+// in the order of their priority, their waiting time and the fair share between the projects
+type scheduler struct {
+	mutex    sync.Mutex
+	pending  map[int64]pendingJob
+	starting map[int64]int
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{
+		pending:  map[int64]pendingJob{},
+		starting: map[int64]int{},
+	}
+}
+
+// push adds a job to the pending jobs, or refreshes it
+func (s *scheduler) push(j sdk.WorkflowNodeJobRun) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pending[j.ID] = pendingJob{job: j, received: time.Now()}
+}
+
+// remove removes a job from the pending jobs
+func (s *scheduler) remove(id int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.pending, id)
+}
+
+// next returns the pending jobs in the order in which their workers should be requested
+func (s *scheduler) next() sdk.WorkflowQueue {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	q := make(sdk.WorkflowQueue, 0, len(s.pending))
+	for id, p := range s.pending {
+		if time.Since(p.received) > pendingJobTTL {
+			delete(s.pending, id)
+			continue
+		}
+		q = append(q, p.job)
+	}
+	q.SortFairShare(s.starting)
+	return q
+}
+
+// started counts a worker being started for a project
+func (s *scheduler) started(projectID int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.starting[projectID]++
+}
+
+// done uncounts a worker being started for a project
+func (s *scheduler) done(projectID int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.starting[projectID] <= 1 {
+		delete(s.starting, projectID)
+		return
+	}
+	s.starting[projectID]--
+}
@@ -28,6 +28,7 @@ type workerStarterRequest struct {
 	timestamp           int64
 	spawnAttempts       []int64
 	workflowNodeRunID   int64
+	projectID           int64
 	registerWorkerModel *sdk.Model
 }
 
@@ -6,6 +6,7 @@ const (
 	RepositoryWebHookModelName    = "RepositoryWebHook"
 	SchedulerModelName            = "Scheduler"
 	GitPollerModelName            = "Git Repository Poller"
+	GitRefPollerModelName         = "Git Ref Poller"
 	KafkaHookModelName            = "Kafka hook"
 	RabbitMQHookModelName         = "RabbitMQ hook"
 	HookConfigProject             = "project"
@@ -13,6 +14,7 @@ const (
 	HookConfigWorkflowID          = "workflow_id"
 	WebHookModelConfigMethod      = "method"
 	RepositoryWebHookModelMethod  = "method"
+	RepositoryWebHookModelEvents  = "events"
 	SchedulerModelCron            = "cron"
 	SchedulerModelTimezone        = "timezone"
 	SchedulerModelPayload         = "payload"
@@ -24,6 +26,20 @@ const (
 	RabbitMQHookModelExchangeType = "exchange_type"
 	RabbitMQHookModelExchangeName = "exchange_name"
 	RabbitMQHookModelConsumerTag  = "consumer_tag"
+	GitRefPollerModelURL          = "url"
+	GitRefPollerModelKey          = "key"
+	GitRefPollerModelRefs         = "refs"
+	GitRefPollerModelInterval     = "interval"
+	GitRefPollerModelPayload      = "payload"
+	HookConfigIncludePaths        = "include_paths"
+	HookConfigExcludePaths        = "exclude_paths"
+)
+
+// These are the events which can trigger a RepositoryWebHook, the config RepositoryWebHookModelEvents is a comma separated list of them
+const (
+	RepositoryWebHookEventPush            = "push"
+	RepositoryWebHookEventPullRequest     = "pull_request"
+	RepositoryWebHookEventForkPullRequest = "fork_pull_request"
 )
 
 // KafkaHookModel is the builtin hooks
@@ -120,6 +136,21 @@ var (
 				Configurable: false,
 				Type:         HookConfigTypeString,
 			},
+			RepositoryWebHookModelEvents: {
+				Value:        RepositoryWebHookEventPush,
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigIncludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigExcludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -135,6 +166,64 @@ var (
 				Configurable: true,
 				Type:         HookConfigTypeString,
 			},
+			HookConfigIncludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigExcludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+		},
+	}
+
+	// GitRefPollerModel polls the branches and the tags of any git repository with git ls-remote.
+	// The key is the name of a SSH key of the project, refs is a comma separated list of patterns of references.
+	// The path filters are applied to the branches, with the repository of the application of the node or else with the polled repository
+	GitRefPollerModel = WorkflowHookModel{
+		Author:     "CDS",
+		Type:       WorkflowHookModelBuiltin,
+		Identifier: "github.com/ovh/cds/hook/builtin/gitrefpoller",
+		Name:       GitRefPollerModelName,
+		Icon:       "git square",
+		DefaultConfig: WorkflowNodeHookConfig{
+			GitRefPollerModelURL: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitRefPollerModelKey: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitRefPollerModelRefs: {
+				Value:        "refs/heads/*,refs/tags/*",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitRefPollerModelInterval: {
+				Value:        "5m",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			GitRefPollerModelPayload: {
+				Value:        "{}",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigIncludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
+			HookConfigExcludePaths: {
+				Value:        "",
+				Configurable: true,
+				Type:         HookConfigTypeString,
+			},
 		},
 	}
 
@@ -189,6 +278,8 @@ func GetDefaultHookModel(modelName string) WorkflowHookModel {
 		return WebHookModel
 	case GitPollerModelName:
 		return GitPollerModel
+	case GitRefPollerModelName:
+		return GitRefPollerModel
 	}
 
 	return WebHookModel
@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+)
+
+// HookPathFilter filters the events of a repository hook on the files changed by the pushed commits.
+// It is read from the configurations HookConfigIncludePaths and HookConfigExcludePaths, which are comma separated lists of patterns
+type HookPathFilter struct {
+	Includes []string
+	Excludes []string
+}
+
+// NewHookPathFilter returns the path filter of the configuration of a hook
+func NewHookPathFilter(config WorkflowNodeHookConfig) HookPathFilter {
+	return HookPathFilter{
+		Includes: splitPathPatterns(config[HookConfigIncludePaths].Value),
+		Excludes: splitPathPatterns(config[HookConfigExcludePaths].Value),
+	}
+}
+
+func splitPathPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// IsEmpty returns true if the filter has no pattern, then all the events trigger the workflow
+func (f HookPathFilter) IsEmpty() bool {
+	return len(f.Includes) == 0 && len(f.Excludes) == 0
+}
+
+// Match returns true if one of the files is matched by an include pattern and by none of the exclude patterns.
+// Without include pattern, all the files are included
+func (f HookPathFilter) Match(files []string) bool {
+	for _, file := range files {
+		if f.match(file) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f HookPathFilter) match(file string) bool {
+	included := len(f.Includes) == 0
+	for _, p := range f.Includes {
+		if MatchPath(p, file) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, p := range f.Excludes {
+		if MatchPath(p, file) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchPath checks if the path of a file, relative to the root of the repository, is matched by a pattern.
+// In a pattern, * matches any sequence of characters except /, ? matches one character except / and ** matches any number of directories.
+// A pattern matching a directory also matches all the files of this directory, like services/api or services/*
+func MatchPath(pattern, file string) bool {
+	pattern = strings.Trim(strings.TrimPrefix(strings.TrimSpace(pattern), "./"), "/")
+	file = strings.TrimPrefix(strings.TrimPrefix(file, "./"), "/")
+	if pattern == "" {
+		return false
+	}
+
+	var expr string
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr += "(.*/)?"
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr += ".*"
+			i++
+		case pattern[i] == '*':
+			expr += "[^/]*"
+		case pattern[i] == '?':
+			expr += "[^/]"
+		default:
+			expr += regexp.QuoteMeta(pattern[i : i+1])
+		}
+	}
+
+	ok, err := regexp.MatchString("^"+expr+"(/.*)?$", file)
+	return err == nil && ok
+}
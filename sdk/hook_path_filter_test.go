@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		match   bool
+	}{
+		{"services/api", "services/api/main.go", true},
+		{"services/api/", "services/api/handlers/get.go", true},
+		{"./services/api", "services/api/main.go", true},
+		{"services/api", "services/api2/main.go", false},
+		{"services/*", "services/front/index.html", true},
+		{"services/*.go", "services/main.go", true},
+		{"services/*.go", "services/api/main.go", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/content/index.md", true},
+		{"docs/**/index.md", "docs/index.md", true},
+		{"docs/**/index.md", "docs/content/hosting/index.md", true},
+		{"docs/**", "docs/content/index.md", true},
+		{"v?/main.go", "v1/main.go", true},
+		{"v?/main.go", "v10/main.go", false},
+		{"lib/c++", "lib/c++/main.cpp", true},
+		{"", "main.go", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, MatchPath(tt.pattern, tt.file), "pattern %s, file %s", tt.pattern, tt.file)
+	}
+}
+
+func TestHookPathFilter(t *testing.T) {
+	f := NewHookPathFilter(WorkflowNodeHookConfig{
+		HookConfigIncludePaths: {Value: "services/api, libs/**"},
+		HookConfigExcludePaths: {Value: "**/*.md"},
+	})
+	assert.Equal(t, []string{"services/api", "libs/**"}, f.Includes)
+	assert.False(t, f.IsEmpty())
+
+	assert.True(t, f.Match([]string{"services/front/main.go", "services/api/main.go"}))
+	assert.True(t, f.Match([]string{"libs/log/log.go"}))
+	assert.False(t, f.Match([]string{"services/front/main.go"}))
+	assert.False(t, f.Match([]string{"services/api/README.md"}))
+	assert.False(t, f.Match(nil))
+
+	// Without include pattern, all the files which are not excluded match
+	f = NewHookPathFilter(WorkflowNodeHookConfig{HookConfigExcludePaths: {Value: "docs"}})
+	assert.True(t, f.Match([]string{"docs/index.md", "main.go"}))
+	assert.False(t, f.Match([]string{"docs/index.md"}))
+
+	assert.True(t, NewHookPathFilter(WorkflowNodeHookConfig{HookConfigIncludePaths: {Value: " , "}}).IsEmpty())
+}
@@ -1,5 +1,11 @@
 package sdk
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // This constant are the types of the kind of job of CDS: legacy and workflow
 const (
 	JobTypePipeline     = "pipeline_build_job"
@@ -14,4 +20,109 @@ type Job struct {
 	LastModified     int64                  `json:"last_modified"`
 	Action           Action                 `json:"action"`
 	Warnings         []PipelineBuildWarning `json:"warnings"`
+	Matrix           JobMatrix              `json:"matrix,omitempty"`
+	RetryPolicy      *RetryPolicy           `json:"retry_policy,omitempty"`
+	Priority         int                    `json:"priority,omitempty"`
+	Timeout          string                 `json:"timeout,omitempty"`
+}
+
+// JobPriorityMax is the highest priority of a job. Among the waiting jobs of a project, the hatcheries
+// start the workers of the jobs with the highest priority first.
+const JobPriorityMax = 10
+
+// IsValidJobPriority checks that a job priority is between 0 and JobPriorityMax
+func IsValidJobPriority(p int) error {
+	if p < 0 || p > JobPriorityMax {
+		return fmt.Errorf("priority must be between 0 and %d", JobPriorityMax)
+	}
+	return nil
+}
+
+// JobMatrixMaxCells is the maximum number of job runs created from the matrix of a job
+const JobMatrixMaxCells = 64
+
+// JobMatrix is the matrix of a job: the job is run once for each combination of the values of the matrix.
+// The values of a combination are available in the job as {{.cds.matrix.<name>}}
+type JobMatrix map[string][]string
+
+// JobMatrixCell is a combination of the values of a job matrix
+type JobMatrixCell map[string]string
+
+// IsValid checks the names and the values of the matrix
+func (m JobMatrix) IsValid() error {
+	cells := 1
+	for k, values := range m {
+		if !NamePatternRegex.MatchString(k) {
+			return fmt.Errorf("invalid matrix name %s. It should match %s", k, NamePattern)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("matrix %s has no value", k)
+		}
+		// Count the combinations without building them, and stop as soon as there are too many
+		if len(values) > JobMatrixMaxCells || cells*len(values) > JobMatrixMaxCells {
+			return fmt.Errorf("matrix has more than %d combinations", JobMatrixMaxCells)
+		}
+		cells *= len(values)
+		found := make(map[string]bool, len(values))
+		for _, v := range values {
+			if found[v] {
+				return fmt.Errorf("matrix %s has duplicated value %s", k, v)
+			}
+			found[v] = true
+		}
+	}
+	return nil
+}
+
+// Cells returns all the combinations of the values of the matrix, ordered by names then by values
+func (m JobMatrix) Cells() []JobMatrixCell {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	cells := []JobMatrixCell{{}}
+	for _, k := range keys {
+		next := make([]JobMatrixCell, 0, len(cells)*len(m[k]))
+		for _, c := range cells {
+			for _, v := range m[k] {
+				nc := make(JobMatrixCell, len(c)+1)
+				for ck, cv := range c {
+					nc[ck] = cv
+				}
+				nc[k] = v
+				next = append(next, nc)
+			}
+		}
+		cells = next
+	}
+	return cells
+}
+
+// String returns the values of the cell ordered by names, ie: "go=1.11, os=linux"
+func (c JobMatrixCell) String() string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = k + "=" + c[k]
+	}
+	return strings.Join(values, ", ")
+}
+
+// Parameters returns the values of the cell as cds.matrix.<name> parameters
+func (c JobMatrixCell) Parameters() []Parameter {
+	params := make([]Parameter, 0, len(c))
+	for k, v := range c {
+		AddParameter(&params, "cds.matrix."+k, StringParameter, v)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params
 }
@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryPolicyMaxAttempts is the maximum number of attempts of a job or a step
+const RetryPolicyMaxAttempts = 10
+
+// retryPolicyMaxBackoff is the maximum delay between two attempts
+const retryPolicyMaxBackoff = time.Hour
+
+// RetryPolicy is the retry policy of a job or a step. A failed job or step is retried until MaxAttempts
+// is reached, waiting Backoff before the first retry then doubling it for each new retry.
+// If ExitCodes is set, only the failures with one of these exit codes are retried.
+// If OnWorkerLost is set, the job is retried when its worker disappeared.
+// Without ExitCodes nor OnWorkerLost, all the failures are retried.
+type RetryPolicy struct {
+	MaxAttempts  int    `json:"max_attempts" yaml:"max_attempts"`
+	Backoff      string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	ExitCodes    []int  `json:"exit_codes,omitempty" yaml:"exit_codes,omitempty"`
+	OnWorkerLost bool   `json:"on_worker_lost,omitempty" yaml:"on_worker_lost,omitempty"`
+}
+
+// IsValid checks the number of attempts, the backoff and the exit codes
+func (p RetryPolicy) IsValid() error {
+	if p.MaxAttempts < 2 || p.MaxAttempts > RetryPolicyMaxAttempts {
+		return fmt.Errorf("max_attempts must be between 2 and %d", RetryPolicyMaxAttempts)
+	}
+	if p.Backoff != "" {
+		d, err := time.ParseDuration(p.Backoff)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid backoff %s", p.Backoff)
+		}
+	}
+	for _, c := range p.ExitCodes {
+		if c < 1 || c > 255 {
+			return fmt.Errorf("invalid exit code %d", c)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry returns true if a failure of the given attempt with the given exit code must be retried.
+// The exit code is 0 when it is unknown.
+func (p *RetryPolicy) ShouldRetry(attempt, exitCode int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if len(p.ExitCodes) == 0 {
+		return !p.OnWorkerLost
+	}
+	for _, c := range p.ExitCodes {
+		if c == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldRetryWorkerLost returns true if the given attempt must be retried because its worker disappeared
+func (p *RetryPolicy) ShouldRetryWorkerLost(attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.OnWorkerLost || len(p.ExitCodes) == 0
+}
+
+// BackoffDuration returns the delay before retrying the given attempt
+func (p *RetryPolicy) BackoffDuration(attempt int) time.Duration {
+	if p == nil || p.Backoff == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(p.Backoff)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= retryPolicyMaxBackoff {
+			return retryPolicyMaxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyIsValid(t *testing.T) {
+	assert.NoError(t, RetryPolicy{MaxAttempts: 3, Backoff: "30s", ExitCodes: []int{1, 137}}.IsValid())
+	assert.Error(t, RetryPolicy{MaxAttempts: 1}.IsValid())
+	assert.Error(t, RetryPolicy{MaxAttempts: RetryPolicyMaxAttempts + 1}.IsValid())
+	assert.Error(t, RetryPolicy{MaxAttempts: 2, Backoff: "soon"}.IsValid())
+	assert.Error(t, RetryPolicy{MaxAttempts: 2, Backoff: "-1s"}.IsValid())
+	assert.Error(t, RetryPolicy{MaxAttempts: 2, ExitCodes: []int{0}}.IsValid())
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	var nilPolicy *RetryPolicy
+	assert.False(t, nilPolicy.ShouldRetry(1, 1))
+	assert.False(t, nilPolicy.ShouldRetryWorkerLost(1))
+
+	all := &RetryPolicy{MaxAttempts: 2}
+	assert.True(t, all.ShouldRetry(1, 1))
+	assert.True(t, all.ShouldRetry(1, 0))
+	assert.True(t, all.ShouldRetryWorkerLost(1))
+	assert.False(t, all.ShouldRetry(2, 1))
+
+	codes := &RetryPolicy{MaxAttempts: 3, ExitCodes: []int{137}}
+	assert.True(t, codes.ShouldRetry(2, 137))
+	assert.False(t, codes.ShouldRetry(1, 1))
+	assert.False(t, codes.ShouldRetryWorkerLost(1))
+
+	lost := &RetryPolicy{MaxAttempts: 3, OnWorkerLost: true}
+	assert.False(t, lost.ShouldRetry(1, 1))
+	assert.True(t, lost.ShouldRetryWorkerLost(2))
+	assert.False(t, lost.ShouldRetryWorkerLost(3))
+}
+
+func TestRetryPolicyBackoffDuration(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 10, Backoff: "10s"}
+	assert.Equal(t, 10*time.Second, p.BackoffDuration(1))
+	assert.Equal(t, 20*time.Second, p.BackoffDuration(2))
+	assert.Equal(t, 40*time.Second, p.BackoffDuration(3))
+	assert.Equal(t, time.Hour, p.BackoffDuration(10))
+	assert.Equal(t, time.Duration(0), (&RetryPolicy{MaxAttempts: 2}).BackoffDuration(1))
+}
+
+func TestWorkflowNodeJobRunRetryJSON(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: "1m", ExitCodes: []int{1, 2}, OnWorkerLost: true}
+	in := WorkflowNodeJobRun{
+		Job: ExecutedJob{
+			Job: Job{
+				RetryPolicy: policy,
+				Action: Action{
+					Actions: []Action{{Name: "script", RetryPolicy: &RetryPolicy{MaxAttempts: 2}}},
+				},
+			},
+			Attempt:    2,
+			RetriedBy:  42,
+			StepStatus: []StepStatus{{StepOrder: 0, Status: StatusFail.String(), ExitCode: 2}},
+		},
+	}
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+
+	var out WorkflowNodeJobRun
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, policy, out.Job.RetryPolicy)
+	assert.Equal(t, 2, out.Job.Action.Actions[0].RetryPolicy.MaxAttempts)
+	assert.Equal(t, 2, out.Job.Attempt)
+	assert.Equal(t, int64(42), out.Job.RetriedBy)
+	assert.Equal(t, 2, out.Job.FailedExitCode())
+}
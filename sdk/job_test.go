@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobMatrixCells(t *testing.T) {
+	m := JobMatrix{
+		"os": {"linux/amd64", "linux/arm64"},
+		"go": {"1.10", "1.11"},
+	}
+	assert.NoError(t, m.IsValid())
+
+	cells := m.Cells()
+	assert.Equal(t, []JobMatrixCell{
+		{"go": "1.10", "os": "linux/amd64"},
+		{"go": "1.10", "os": "linux/arm64"},
+		{"go": "1.11", "os": "linux/amd64"},
+		{"go": "1.11", "os": "linux/arm64"},
+	}, cells)
+	assert.Equal(t, "go=1.10, os=linux/arm64", cells[1].String())
+	assert.Equal(t, []Parameter{
+		{Name: "cds.matrix.go", Type: StringParameter, Value: "1.10"},
+		{Name: "cds.matrix.os", Type: StringParameter, Value: "linux/arm64"},
+	}, cells[1].Parameters())
+
+	assert.Nil(t, JobMatrix{}.Cells())
+}
+
+func TestJobMatrixIsValid(t *testing.T) {
+	assert.Error(t, JobMatrix{"go version": {"1.10"}}.IsValid())
+	assert.Error(t, JobMatrix{"go": {}}.IsValid())
+	assert.Error(t, JobMatrix{"go": {"1.10", "1.10"}}.IsValid())
+
+	values := make([]string, 9)
+	for i := range values {
+		values[i] = string(rune('a' + i))
+	}
+	assert.NoError(t, JobMatrix{"a": values[:8], "b": values[:8]}.IsValid())
+	assert.Error(t, JobMatrix{"a": values, "b": values}.IsValid())
+
+	// Large axes are rejected without building the combinations
+	large := make([]string, 100000)
+	for i := range large {
+		large[i] = fmt.Sprintf("v%d", i)
+	}
+	assert.Error(t, JobMatrix{"a": large, "b": large, "c": large}.IsValid())
+}
+
+func TestIsValidJobPriority(t *testing.T) {
+	assert.NoError(t, IsValidJobPriority(0))
+	assert.NoError(t, IsValidJobPriority(JobPriorityMax))
+	assert.Error(t, IsValidJobPriority(-1))
+	assert.Error(t, IsValidJobPriority(JobPriorityMax+1))
+}
+
+func TestStageComputeMatrix(t *testing.T) {
+	linux := JobMatrixCell{"os": "linux"}
+	windows := JobMatrixCell{"os": "windows"}
+	s := Stage{
+		RunJobs: []WorkflowNodeJobRun{
+			{Status: StatusSuccess.String(), Job: ExecutedJob{MatrixCell: linux}},
+			{Status: StatusFail.String(), Job: ExecutedJob{MatrixCell: windows}},
+			{Status: StatusBuilding.String(), Job: ExecutedJob{MatrixCell: linux}},
+			{Status: StatusSuccess.String(), Job: ExecutedJob{MatrixCell: windows}},
+			{Status: StatusSuccess.String()},
+		},
+	}
+	s.ComputeMatrix()
+	assert.Equal(t, []StageMatrixCell{
+		{Name: "os=linux", Values: linux, Status: StatusBuilding},
+		{Name: "os=windows", Values: windows, Status: StatusFail},
+	}, s.Matrix)
+
+	s.RunJobs[2].Status = StatusSuccess.String()
+	s.ComputeMatrix()
+	assert.Equal(t, StatusSuccess, s.Matrix[0].Status)
+}
+
+func TestWorkflowNodeJobRunMatrixJSON(t *testing.T) {
+	in := WorkflowNodeJobRun{
+		Job: ExecutedJob{
+			Job:        Job{Matrix: JobMatrix{"go": {"1.10", "1.11"}}},
+			MatrixCell: JobMatrixCell{"go": "1.11"},
+		},
+	}
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+
+	var out WorkflowNodeJobRun
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in.Job.Matrix, out.Job.Matrix)
+	assert.Equal(t, in.Job.MatrixCell, out.Job.MatrixCell)
+}
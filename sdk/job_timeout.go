@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// JobDefaultTimeout is the timeout of a job which doesn't define its own timeout
+const JobDefaultTimeout = 6 * time.Hour
+
+// JobTimeoutMax is the maximum timeout of a job or a step
+const JobTimeoutMax = 72 * time.Hour
+
+// IsValidTimeout checks that the timeout of a job or a step is empty or a positive duration lower than JobTimeoutMax
+func IsValidTimeout(timeout string) error {
+	if timeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid timeout %s", timeout)
+	}
+	if d > JobTimeoutMax {
+		return fmt.Errorf("timeout must be lower than %s", JobTimeoutMax)
+	}
+	return nil
+}
+
+// TimeoutDuration returns the duration of the timeout of a job or a step, 0 if there is no valid timeout
+func TimeoutDuration(timeout string) time.Duration {
+	if IsValidTimeout(timeout) != nil {
+		return 0
+	}
+	d, _ := time.ParseDuration(timeout)
+	return d
+}
+
+// TimeoutDuration returns the timeout of the job, JobDefaultTimeout if it has no timeout
+func (j Job) TimeoutDuration() time.Duration {
+	if d := TimeoutDuration(j.Timeout); d > 0 {
+		return d
+	}
+	return JobDefaultTimeout
+}
@@ -0,0 +1,28 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidTimeout(t *testing.T) {
+	assert.NoError(t, IsValidTimeout(""))
+	assert.NoError(t, IsValidTimeout("90s"))
+	assert.NoError(t, IsValidTimeout("72h"))
+	assert.Error(t, IsValidTimeout("ten minutes"))
+	assert.Error(t, IsValidTimeout("0s"))
+	assert.Error(t, IsValidTimeout("-1m"))
+	assert.Error(t, IsValidTimeout("73h"))
+}
+
+func TestTimeoutDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), TimeoutDuration(""))
+	assert.Equal(t, time.Duration(0), TimeoutDuration("forever"))
+	assert.Equal(t, 10*time.Minute, TimeoutDuration("10m"))
+
+	assert.Equal(t, JobDefaultTimeout, Job{}.TimeoutDuration())
+	assert.Equal(t, JobDefaultTimeout, Job{Timeout: "1000h"}.TimeoutDuration())
+	assert.Equal(t, 30*time.Minute, Job{Timeout: "30m"}.TimeoutDuration())
+}
@@ -76,6 +76,17 @@ var (
 	MsgWorkflowImportedInserted            = &Message{"MsgWorkflowImportedInserted", trad{FR: "Le workflow %s a été créé", EN: "Workflow %s has been created"}, nil}
 	MsgSpawnInfoHatcheryCannotStartJob     = &Message{"MsgSpawnInfoHatcheryCannotStart", trad{FR: "Aucune hatchery n'a pu démarrer de worker respectant vos pré-requis de job, merci de les vérifier.", EN: "No hatchery can spawn a worker corresponding your job's requirements. Please check your job's requirements."}, nil}
 	MsgWorkflowRunBranchDeleted            = &Message{"MsgWorkflowRunBranchDeleted", trad{FR: "La branche %s  a été supprimée", EN: "Branch %s has been deleted"}, nil}
+	MsgWorkflowNodeApprovalWaiting         = &Message{"MsgWorkflowNodeApprovalWaiting", trad{FR: "Le pipeline %s est en attente de %d approbation(s)", EN: "The pipeline %s is waiting for %d approval(s)"}, nil}
+	MsgWorkflowNodeApproved                = &Message{"MsgWorkflowNodeApproved", trad{FR: "Le pipeline %s a été approuvé par %s", EN: "The pipeline %s has been approved by %s"}, nil}
+	MsgWorkflowNodeApprovalExpired         = &Message{"MsgWorkflowNodeApprovalExpired", trad{FR: "Le délai d'approbation du pipeline %s a expiré", EN: "The approval of the pipeline %s has expired"}, nil}
+	MsgWorkflowNodeJobRetry                = &Message{"MsgWorkflowNodeJobRetry", trad{FR: "Nouvelle tentative %d/%d du job après l'échec de la tentative précédente (%s)", EN: "Attempt %d/%d of the job after the failure of the previous attempt (%s)"}, nil}
+	MsgSpawnInfoJobTimeout                 = &Message{"MsgSpawnInfoJobTimeout", trad{FR: "Le job a dépassé son délai d'exécution de %s, le worker %s l'a arrêté", EN: "The job exceeded its timeout of %s, worker %s stopped it"}, nil}
+	MsgSpawnInfoStepTimeout                = &Message{"MsgSpawnInfoStepTimeout", trad{FR: "L'étape %s a dépassé son délai d'exécution de %s, le worker %s l'a arrêtée", EN: "The step %s exceeded its timeout of %s, worker %s killed it"}, nil}
+	MsgSpawnInfoJobStale                   = &Message{"MsgSpawnInfoJobStale", trad{FR: "Le job est en échec : pas de nouvelles du worker (logs, signe de vie ou fin du job) dans son délai d'exécution de %s", EN: "The job failed: no news from the worker (logs, heartbeat or end of job) within its timeout of %s"}, nil}
+	MsgWorkflowNodeConcurrency             = &Message{"MsgWorkflowNodeConcurrency", trad{FR: "Le pipeline %s est mis en attente tant qu'un autre run du groupe de concurrence %s est en cours", EN: "The pipeline %s is waiting while another run of the concurrency group %s is running"}, nil}
+	MsgWorkflowNodeConcurrencyRelease      = &Message{"MsgWorkflowNodeConcurrencyRelease", trad{FR: "Lancement du pipeline %s : le groupe de concurrence %s est libre", EN: "Triggering pipeline %s: the concurrency group %s is free"}, nil}
+	MsgWorkflowNodeConcurrencyCanceled     = &Message{"MsgWorkflowNodeConcurrencyCanceled", trad{FR: "Le pipeline a été arrêté par un run plus récent du groupe de concurrence %s", EN: "The pipeline has been stopped by a newer run of the concurrency group %s"}, nil}
+	MsgWorkflowRunSuperseded               = &Message{"MsgWorkflowRunSuperseded", trad{FR: "Le run a été arrêté par le run %d sur la branche %s", EN: "The run has been stopped by the run %d on the branch %s"}, nil}
 )
 
 // Messages contains all sdk Messages
@@ -137,6 +148,17 @@ var Messages = map[string]*Message{
 	MsgWorkflowNodeMutexRelease.ID:            MsgWorkflowNodeMutexRelease,
 	MsgSpawnInfoHatcheryCannotStartJob.ID:     MsgSpawnInfoHatcheryCannotStartJob,
 	MsgWorkflowRunBranchDeleted.ID:            MsgWorkflowRunBranchDeleted,
+	MsgWorkflowNodeApprovalWaiting.ID:         MsgWorkflowNodeApprovalWaiting,
+	MsgWorkflowNodeApproved.ID:                MsgWorkflowNodeApproved,
+	MsgWorkflowNodeApprovalExpired.ID:         MsgWorkflowNodeApprovalExpired,
+	MsgWorkflowNodeJobRetry.ID:                MsgWorkflowNodeJobRetry,
+	MsgSpawnInfoJobTimeout.ID:                 MsgSpawnInfoJobTimeout,
+	MsgSpawnInfoStepTimeout.ID:                MsgSpawnInfoStepTimeout,
+	MsgSpawnInfoJobStale.ID:                   MsgSpawnInfoJobStale,
+	MsgWorkflowNodeConcurrency.ID:             MsgWorkflowNodeConcurrency,
+	MsgWorkflowNodeConcurrencyRelease.ID:      MsgWorkflowNodeConcurrencyRelease,
+	MsgWorkflowNodeConcurrencyCanceled.ID:     MsgWorkflowNodeConcurrencyCanceled,
+	MsgWorkflowRunSuperseded.ID:               MsgWorkflowRunSuperseded,
 }
 
 //Message represent a struc format translated messages
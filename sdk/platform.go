@@ -12,6 +12,7 @@ var (
 		&WebHookModel,
 		&RepositoryWebHookModel,
 		&GitPollerModel,
+		&GitRefPollerModel,
 		&SchedulerModel,
 		&KafkaHookModel,
 		&RabbitMQHookModel,
@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProjectWebhook is an HTTP endpoint receiving the events of a project.
+// Events can be filtered by type (ie. EventRunWorkflowNode) and by workflow name, empty filters match all the events.
+type ProjectWebhook struct {
+	ID            int64     `json:"id" cli:"id,key"`
+	ProjectID     int64     `json:"project_id" cli:"-"`
+	Name          string    `json:"name" cli:"name"`
+	URL           string    `json:"url" cli:"url"`
+	Secret        string    `json:"secret,omitempty" cli:"-"`
+	EventTypes    []string  `json:"event_types,omitempty" cli:"event_types"`
+	WorkflowNames []string  `json:"workflow_names,omitempty" cli:"workflow_names"`
+	Enabled       bool      `json:"enabled" cli:"enabled"`
+	Created       time.Time `json:"created" cli:"-"`
+	LastModified  time.Time `json:"last_modified" cli:"-"`
+}
+
+// IsValid checks the name and the URL of the webhook
+func (w ProjectWebhook) IsValid() error {
+	if w.Name == "" {
+		return ErrWrongRequest
+	}
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrWrongRequest
+	}
+	// The webhooks are called by CDS API, they must not target its own network. Hostnames are checked again when the API connects
+	if !IsPublicHost(u.Hostname()) {
+		return ErrWrongRequest
+	}
+	return nil
+}
+
+// IsPublicHost returns false for localhost and for the loopback, link-local and private addresses. The hostnames are not resolved
+func IsPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return false
+	}
+	return true
+}
+
+var privateNetworks = func() []*net.IPNet {
+	var res []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, n, _ := net.ParseCIDR(cidr)
+		res = append(res, n)
+	}
+	return res
+}()
+
+// IsPublicIP returns false for the loopback, link-local, private and unspecified addresses
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match returns true if the event has to be sent to the webhook
+func (w ProjectWebhook) Match(e Event) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.EventTypes) > 0 {
+		var found bool
+		for _, t := range w.EventTypes {
+			if strings.TrimPrefix(t, "sdk.") == strings.TrimPrefix(e.EventType, "sdk.") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(w.WorkflowNames) > 0 && !IsInArray(e.WorkflowName, w.WorkflowNames) {
+		return false
+	}
+	return true
+}
+
+// Status of a webhook delivery
+const (
+	ProjectWebhookDeliverySuccess = "Success"
+	ProjectWebhookDeliveryFail    = "Fail"
+)
+
+// ProjectWebhookDelivery is an entry of the delivery log of a project webhook
+type ProjectWebhookDelivery struct {
+	ID           int64     `json:"id" cli:"id,key"`
+	WebhookID    int64     `json:"webhook_id" cli:"-"`
+	EventType    string    `json:"event_type" cli:"event_type"`
+	WorkflowName string    `json:"workflow_name,omitempty" cli:"workflow_name"`
+	Payload      string    `json:"payload,omitempty" cli:"-"`
+	Status       string    `json:"status" cli:"status"`
+	Attempts     int       `json:"attempts" cli:"attempts"`
+	ResponseCode int       `json:"response_code,omitempty" cli:"response_code"`
+	Error        string    `json:"error,omitempty" cli:"error"`
+	Redelivery   bool      `json:"redelivery" cli:"redelivery"`
+	Created      time.Time `json:"created" cli:"created"`
+}
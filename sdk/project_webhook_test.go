@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectWebhookMatch(t *testing.T) {
+	w := ProjectWebhook{Enabled: true}
+	e := Event{EventType: "sdk.EventRunWorkflowNode", WorkflowName: "build"}
+	assert.True(t, w.Match(e))
+
+	w.EventTypes = []string{"EventRunWorkflowJob", "sdk.EventRunWorkflowNode"}
+	assert.True(t, w.Match(e))
+	w.EventTypes = []string{"EventRunWorkflowJob"}
+	assert.False(t, w.Match(e))
+
+	w.EventTypes = []string{"EventRunWorkflowNode"}
+	w.WorkflowNames = []string{"build"}
+	assert.True(t, w.Match(e))
+	w.WorkflowNames = []string{"deploy"}
+	assert.False(t, w.Match(e))
+
+	w.WorkflowNames = nil
+	w.Enabled = false
+	assert.False(t, w.Match(e))
+}
+
+func TestProjectWebhookIsValid(t *testing.T) {
+	assert.NoError(t, ProjectWebhook{Name: "ci", URL: "https://example.com/hook"}.IsValid())
+	assert.Error(t, ProjectWebhook{Name: "ci", URL: "ftp://example.com/hook"}.IsValid())
+	assert.Error(t, ProjectWebhook{URL: "https://example.com/hook"}.IsValid())
+
+	// Internal addresses are rejected
+	for _, u := range []string{"http://localhost:8081/", "http://127.0.0.1/", "http://[::1]/", "http://169.254.169.254/latest", "http://10.1.2.3/", "http://192.168.1.1/", "http://0.0.0.0/"} {
+		assert.Error(t, ProjectWebhook{Name: "ci", URL: u}.IsValid(), u)
+	}
+	assert.NoError(t, ProjectWebhook{Name: "ci", URL: "http://8.8.8.8/hook"}.IsValid())
+}
@@ -299,6 +299,17 @@ type VCSPullRequest struct {
 	Base VCSPushEvent `json:"base"`
 }
 
+//VCSPullRequestComment represents a comment on a pull request
+type VCSPullRequestComment struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	Author string `json:"author"`
+	// Bot is true if the author of the comment is the user who posts the comments of CDS on the repository manager
+	Bot bool `json:"bot"`
+	// Version is needed by bitbucket to update a comment
+	Version int `json:"version,omitempty"`
+}
+
 //VCSPushEvent represents a push events for polling
 type VCSPushEvent struct {
 	Repo     string    `json:"repo"`
@@ -2,9 +2,17 @@ package sdk
 
 import (
 	"encoding/base64"
+	"regexp"
 	"time"
 )
 
+var gitHashRegexp = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// IsGitHash checks that the string is a full or abbreviated commit hash
+func IsGitHash(s string) bool {
+	return gitHashRegexp.MatchString(s)
+}
+
 // Operation is the main business object use in repositories service
 type Operation struct {
 	UUID               string                   `json:"uuid"`
@@ -14,6 +22,8 @@ type Operation struct {
 	RepositoryStrategy RepositoryStrategy       `json:"strategy,omitempty"`
 	Setup              OperationSetup           `json:"setup,omitempty"`
 	LoadFiles          OperationLoadFiles       `json:"load_files,omitempty"`
+	Diff               OperationDiff            `json:"diff,omitempty"`
+	LsRemote           *OperationLsRemote       `json:"ls_remote,omitempty"`
 	Status             OperationStatus          `json:"status"`
 	Error              string                   `json:"error,omitempty"`
 	RepositoryInfo     *OperationRepositoryInfo `json:"repository_info,omitempty"`
@@ -38,6 +48,19 @@ type OperationLoadFiles struct {
 	Results map[string][]byte `json:"results,omitempty"`
 }
 
+// OperationDiff represents the list of the files changed between two commits
+type OperationDiff struct {
+	Base  string   `json:"base,omitempty"`
+	Head  string   `json:"head,omitempty"`
+	Files []string `json:"files,omitempty"`
+}
+
+// OperationLsRemote represents the branches and the tags of a remote repository, listed without clone.
+// Refs contains the hash of the commit of each reference, indexed by the name of the reference
+type OperationLsRemote struct {
+	Refs map[string]string `json:"refs,omitempty"`
+}
+
 // OperationCheckout represents a smart git checkout
 type OperationCheckout struct {
 	Branch string `json:"branch,omitempty"`
@@ -0,0 +1,17 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGitHash(t *testing.T) {
+	assert.True(t, IsGitHash("eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c"))
+	assert.True(t, IsGitHash("eb8b87a"))
+	assert.True(t, IsGitHash("0000000000000000000000000000000000000000"))
+	assert.False(t, IsGitHash(""))
+	assert.False(t, IsGitHash("HEAD"))
+	assert.False(t, IsGitHash("--output=/tmp/diff"))
+	assert.False(t, IsGitHash("eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c0"))
+}
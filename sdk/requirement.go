@@ -1,5 +1,11 @@
 package sdk
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
 const (
 	//BinaryRequirement refers to the need to a specific binary on host running the action
 	BinaryRequirement = "binary"
@@ -19,8 +25,21 @@ const (
 	VolumeRequirement = "volume"
 	// OSArchRequirement checks the 'dist' of a worker eg {GOOS}/{GOARCH}
 	OSArchRequirement = "os-architecture"
+	// CPURequirement sets the number of cpus of a worker, eg 2 or 0.5
+	CPURequirement = "cpu"
+	// ResourceClassRequirement sets the size of a worker from the resource classes of the hatcheries, eg small, medium or large
+	ResourceClassRequirement = "resource-class"
 )
 
+// ParseCPURequirement returns the number of cpus of a cpu requirement value
+func ParseCPURequirement(value string) (float64, error) {
+	cpu, err := strconv.ParseFloat(value, 64)
+	if err != nil || !(cpu > 0) || math.IsInf(cpu, 0) {
+		return 0, fmt.Errorf("invalid cpu requirement %s", value)
+	}
+	return cpu, nil
+}
+
 // RequirementList is a list of requirement
 type RequirementList []Requirement
 
@@ -45,6 +64,8 @@ var (
 		MemoryRequirement,
 		VolumeRequirement,
 		OSArchRequirement,
+		CPURequirement,
+		ResourceClassRequirement,
 	}
 
 	// OSArchRequirementValues comes from go tool dist list
@@ -8,18 +8,75 @@ import (
 
 // Stage Pipeline step that parallelize actions by order
 type Stage struct {
-	ID                int64                  `json:"id" yaml:"pipeline_stage_id"`
-	Name              string                 `json:"name"`
-	PipelineID        int64                  `json:"-" yaml:"-"`
-	BuildOrder        int                    `json:"build_order"`
-	Enabled           bool                   `json:"enabled"`
-	PipelineBuildJobs []PipelineBuildJob     `json:"builds"`
-	RunJobs           []WorkflowNodeJobRun   `json:"run_jobs"`
-	Prerequisites     []Prerequisite         `json:"prerequisites"`
-	LastModified      int64                  `json:"last_modified"`
-	Jobs              []Job                  `json:"jobs"`
-	Status            Status                 `json:"status"`
-	Warnings          []PipelineBuildWarning `json:"warnings"`
+	ID                int64                   `json:"id" yaml:"pipeline_stage_id"`
+	Name              string                  `json:"name"`
+	PipelineID        int64                   `json:"-" yaml:"-"`
+	BuildOrder        int                     `json:"build_order"`
+	Enabled           bool                    `json:"enabled"`
+	PipelineBuildJobs []PipelineBuildJob      `json:"builds"`
+	RunJobs           []WorkflowNodeJobRun    `json:"run_jobs"`
+	Prerequisites     []Prerequisite          `json:"prerequisites"`
+	LastModified      int64                   `json:"last_modified"`
+	Jobs              []Job                   `json:"jobs"`
+	Status            Status                  `json:"status"`
+	Warnings          []PipelineBuildWarning  `json:"warnings"`
+	Matrix            []StageMatrixCell       `json:"matrix,omitempty"`
+	Needs             []string                `json:"needs,omitempty"`
+	RunConditions     *WorkflowNodeConditions `json:"run_conditions,omitempty"`
+}
+
+// StageMatrixCell is the result of the job runs of a stage for a combination of the values of the jobs matrix
+type StageMatrixCell struct {
+	Name   string        `json:"name"`
+	Values JobMatrixCell `json:"values"`
+	Status Status        `json:"status"`
+}
+
+// ComputeMatrix aggregates the status of the job runs of the stage by matrix cell
+func (s *Stage) ComputeMatrix() {
+	s.Matrix = nil
+	index := map[string]int{}
+	for _, rj := range s.RunJobs {
+		// Attempts replaced by a retry are not part of the matrix status
+		if len(rj.Job.MatrixCell) == 0 || rj.Job.RetriedBy != 0 {
+			continue
+		}
+		name := rj.Job.MatrixCell.String()
+		i, ok := index[name]
+		if !ok {
+			i = len(s.Matrix)
+			index[name] = i
+			s.Matrix = append(s.Matrix, StageMatrixCell{Name: name, Values: rj.Job.MatrixCell})
+		}
+		s.Matrix[i].Status = matrixCellStatus(s.Matrix[i].Status, StatusFromString(rj.Status))
+	}
+}
+
+// matrixCellStatus merges the status of a job run into the status of a matrix cell: a failed job
+// fails the cell, a running job keeps it building
+func matrixCellStatus(current, job Status) Status {
+	rank := func(st Status) int {
+		switch st {
+		case StatusFail:
+			return 5
+		case StatusBuilding, StatusWaiting:
+			return 4
+		case StatusStopped:
+			return 3
+		case StatusSuccess:
+			return 2
+		case StatusSkipped:
+			return 1
+		}
+		return 0
+	}
+	if job == StatusWaiting {
+		job = StatusBuilding
+	}
+	if current == "" || rank(job) > rank(current) {
+		return job
+	}
+	return current
 }
 
 // StageSummary is a light representation of stage for CDS event
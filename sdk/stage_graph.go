@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"fmt"
+)
+
+// IsStageGraph returns true if a stage declares needs: the stages of the pipeline are then run as a graph,
+// each stage starting when the stages it needs are over, instead of one after the other
+func IsStageGraph(stages []Stage) bool {
+	for _, s := range stages {
+		if len(s.Needs) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckStageGraph checks that the needs of the stages are known stages of the pipeline, without cycle
+func CheckStageGraph(stages []Stage) error {
+	index := make(map[string]*Stage, len(stages))
+	for i := range stages {
+		if _, ok := index[stages[i].Name]; ok && len(stages[i].Needs) > 0 {
+			return fmt.Errorf("duplicate stage %s", stages[i].Name)
+		}
+		index[stages[i].Name] = &stages[i]
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(stages))
+	var visit func(s *Stage) error
+	visit = func(s *Stage) error {
+		switch state[s.Name] {
+		case visiting:
+			return fmt.Errorf("cycle in the needs of stage %s", s.Name)
+		case visited:
+			return nil
+		}
+		state[s.Name] = visiting
+		for _, n := range s.Needs {
+			need, ok := index[n]
+			if !ok {
+				return fmt.Errorf("stage %s needs unknown stage %s", s.Name, n)
+			}
+			if err := visit(need); err != nil {
+				return err
+			}
+		}
+		state[s.Name] = visited
+		return nil
+	}
+
+	for i := range stages {
+		if err := visit(&stages[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsValidRunConditions checks the operators of the plain conditions and the syntax of the expression of the stage
+func (s *Stage) IsValidRunConditions() error {
+	if s.RunConditions == nil {
+		return nil
+	}
+	for _, c := range s.RunConditions.PlainConditions {
+		if _, ok := WorkflowConditionsOperators[c.Operator]; !ok {
+			return fmt.Errorf("unknown operator %s in the conditions of stage %s", c.Operator, s.Name)
+		}
+	}
+	if s.RunConditions.Expression != "" {
+		return WorkflowConditionsExpressionIsValid(s.RunConditions.Expression)
+	}
+	return nil
+}
+
+// NeedsStatus returns whether the stages needed by the given stage are over, and if so
+// whether the stage has to be skipped because one of them hasn't succeeded
+func NeedsStatus(stages []Stage, s *Stage) (over bool, skip bool) {
+	for _, n := range s.Needs {
+		for i := range stages {
+			if stages[i].Name != n {
+				continue
+			}
+			switch stages[i].Status {
+			case StatusSuccess, StatusSkipped, StatusDisabled:
+			case StatusFail, StatusStopped:
+				skip = true
+			default:
+				return false, false
+			}
+		}
+	}
+	return true, skip
+}
@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStageGraph(t *testing.T) {
+	stages := []Stage{
+		{Name: "build"},
+		{Name: "lint"},
+		{Name: "test", Needs: []string{"build"}},
+		{Name: "package", Needs: []string{"test", "lint"}},
+	}
+	assert.True(t, IsStageGraph(stages))
+	assert.NoError(t, CheckStageGraph(stages))
+	assert.False(t, IsStageGraph(stages[:2]))
+
+	stages[0].Needs = []string{"package"}
+	assert.Error(t, CheckStageGraph(stages))
+
+	stages[0].Needs = []string{"deploy"}
+	assert.Error(t, CheckStageGraph(stages))
+
+	stages[0].Needs = []string{"build"}
+	assert.Error(t, CheckStageGraph(stages))
+}
+
+func TestNeedsStatus(t *testing.T) {
+	stages := []Stage{
+		{Name: "build", Status: StatusSuccess},
+		{Name: "lint", Status: StatusBuilding},
+		{Name: "test", Needs: []string{"build"}},
+		{Name: "package", Needs: []string{"build", "lint"}},
+	}
+	over, skip := NeedsStatus(stages, &stages[2])
+	assert.True(t, over)
+	assert.False(t, skip)
+
+	over, _ = NeedsStatus(stages, &stages[3])
+	assert.False(t, over)
+
+	stages[1].Status = StatusFail
+	over, skip = NeedsStatus(stages, &stages[3])
+	assert.True(t, over)
+	assert.True(t, skip)
+}
+
+func TestStageIsValidRunConditions(t *testing.T) {
+	s := Stage{Name: "deploy"}
+	assert.NoError(t, s.IsValidRunConditions())
+
+	s.RunConditions = &WorkflowNodeConditions{
+		PlainConditions: []WorkflowNodeCondition{{Variable: "git.branch", Operator: WorkflowConditionsOperatorEquals, Value: "master"}},
+		Expression:      `git.branch == "master"`,
+	}
+	assert.NoError(t, s.IsValidRunConditions())
+
+	s.RunConditions.PlainConditions[0].Operator = "like"
+	assert.Error(t, s.IsValidRunConditions())
+}
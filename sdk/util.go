@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"io"
 	"math/rand"
 	"time"
 )
@@ -46,3 +47,15 @@ func DeleteEmptyValueFromArray(array []string) []string {
 	}
 	return out
 }
+
+// CountingReader counts the bytes read from the wrapped reader
+type CountingReader struct {
+	io.Reader
+	N int64
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.N += int64(n)
+	return n, err
+}
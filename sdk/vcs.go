@@ -45,6 +45,8 @@ type VCSAuthorizedClient interface {
 	// PullRequests
 	PullRequests(context.Context, string) ([]VCSPullRequest, error)
 	PullRequestComment(context.Context, string, int, string) error
+	PullRequestComments(context.Context, string, int) ([]VCSPullRequestComment, error)
+	PullRequestCommentUpdate(context.Context, string, int, VCSPullRequestComment) error
 
 	//Hooks
 	CreateHook(ctx context.Context, repo string, hook *VCSHook) error
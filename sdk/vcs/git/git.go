@@ -89,11 +89,24 @@ func runGitCommandsOverSSH(commands []cmd, auth *AuthOpts, output *OutputOpts) e
 		return fmt.Errorf("Authentication is required for git over ssh")
 	}
 
+	wrapperPath, err := writeGitSSHWrapper(auth)
+	if err != nil {
+		return err
+	}
+
+	return runGitCommandRaw(commands, output, "GIT_SSH="+wrapperPath)
+}
+
+// writeGitSSHWrapper writes the script used as GIT_SSH next to the private key, with the given ssh options
+func writeGitSSHWrapper(auth *AuthOpts, sshOpts ...string) (string, error) {
 	keyDir := filepath.Dir(auth.PrivateKey.Filename)
 
 	gitSSHCmd := exec.Command("ssh").Path
 	gitSSHCmd += " -i " + auth.PrivateKey.Filename
 	gitSSHCmd += " -o StrictHostKeyChecking=no"
+	for _, o := range sshOpts {
+		gitSSHCmd += " -o " + o
+	}
 
 	var wrapper string
 	if runtime.GOOS == "windows" {
@@ -107,10 +120,9 @@ func runGitCommandsOverSSH(commands []cmd, auth *AuthOpts, output *OutputOpts) e
 
 	wrapperPath := filepath.Join(keyDir, "gitwrapper")
 	if err := ioutil.WriteFile(wrapperPath, []byte(wrapper), os.FileMode(0700)); err != nil {
-		return err
+		return "", err
 	}
-
-	return runGitCommandRaw(commands, output, "GIT_SSH="+wrapperPath)
+	return wrapperPath, nil
 }
 
 func runGitCommandRaw(cmds cmds, output *OutputOpts, envs ...string) error {
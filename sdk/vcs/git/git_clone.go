@@ -19,6 +19,9 @@ type CloneOpts struct {
 	Quiet                   bool
 	CheckoutCommit          string
 	NoStrictHostKeyChecking bool
+	// FetchRef is a ref fetched after the clone, like the ref of a pull request. The branch is then ignored
+	// and the repository is reset to CheckoutCommit, or to the fetched ref
+	FetchRef string
 }
 
 // Clone make a git clone
@@ -60,12 +63,10 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 			gitcmd.args = append(gitcmd.args, "--depth", fmt.Sprintf("%d", opts.Depth))
 		}
 
-		if opts.Branch != "" || (opts.Tag != "" && opts.Tag != sdk.DefaultGitCloneParameterTagValue) {
-			if opts.Tag != "" && opts.Tag != sdk.DefaultGitCloneParameterTagValue {
-				gitcmd.args = append(gitcmd.args, "--branch", opts.Tag)
-			} else {
-				gitcmd.args = append(gitcmd.args, "--branch", opts.Branch)
-			}
+		if opts.Tag != "" && opts.Tag != sdk.DefaultGitCloneParameterTagValue {
+			gitcmd.args = append(gitcmd.args, "--branch", opts.Tag)
+		} else if opts.Branch != "" && opts.FetchRef == "" {
+			gitcmd.args = append(gitcmd.args, "--branch", opts.Branch)
 		} else if opts.SingleBranch {
 			gitcmd.args = append(gitcmd.args, "--single-branch")
 		}
@@ -84,20 +85,38 @@ func prepareGitCloneCommands(repo string, path string, opts *CloneOpts) (string,
 
 	allCmd = append(allCmd, gitcmd)
 
-	if opts != nil && opts.CheckoutCommit != "" && opts.Tag == "" {
-		resetCmd := cmd{
+	//Locate the next git commands to the right directory
+	dir := path
+	if dir == "" {
+		t := strings.Split(repo, "/")
+		dir = strings.TrimSuffix(t[len(t)-1], ".git")
+	}
+
+	if opts != nil && opts.FetchRef != "" && opts.Tag == "" {
+		fetchCmd := cmd{
 			cmd:  "git",
-			args: []string{"reset", "--hard", opts.CheckoutCommit},
+			args: []string{"fetch"},
+			dir:  dir,
 		}
-		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
-		//Locate the git reset cmd to the right directory
-		if path == "" {
-			t := strings.Split(repo, "/")
-			resetCmd.dir = strings.TrimSuffix(t[len(t)-1], ".git")
-		} else {
-			resetCmd.dir = path
+		if opts.Depth != 0 {
+			fetchCmd.args = append(fetchCmd.args, "--depth", fmt.Sprintf("%d", opts.Depth))
 		}
+		fetchCmd.args = append(fetchCmd.args, "origin", opts.FetchRef)
+		userLogCommand += "\n\rExecuting: git " + strings.Join(fetchCmd.args, " ")
+		allCmd = append(allCmd, fetchCmd)
+	}
 
+	if opts != nil && (opts.CheckoutCommit != "" || opts.FetchRef != "") && opts.Tag == "" {
+		commit := opts.CheckoutCommit
+		if commit == "" {
+			commit = "FETCH_HEAD"
+		}
+		resetCmd := cmd{
+			cmd:  "git",
+			args: []string{"reset", "--hard", commit},
+			dir:  dir,
+		}
+		userLogCommand += "\n\rExecuting: git " + strings.Join(resetCmd.args, " ")
 		allCmd = append(allCmd, resetCmd)
 	}
 
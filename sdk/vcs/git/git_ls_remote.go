@@ -0,0 +1,211 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ovh/cds/sdk"
+)
+
+// lsRemoteTimeout is the maximum duration of git ls-remote
+const lsRemoteTimeout = 30 * time.Second
+
+// scpLikeURLRegexp matches the scp-like syntax of ssh urls, like git@github.com:ovh/cds.git
+var scpLikeURLRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9][A-Za-z0-9.-]*:[^\s]+$`)
+
+// CheckRemoteURL checks the url of a remote repository listed with LsRemote. Only https urls without key,
+// and ssh urls with a key, are allowed. The urls must not target localhost or a private address
+func CheckRemoteURL(repo string, withKey bool) error {
+	if strings.HasPrefix(repo, "-") {
+		return fmt.Errorf("Invalid git url %s", repo)
+	}
+	host, _, err := remoteHost(repo)
+	if err != nil {
+		return err
+	}
+	if !sdk.IsPublicHost(host) {
+		return fmt.Errorf("The url %s targets a private address", repo)
+	}
+	if strings.HasPrefix(repo, "https://") {
+		if withKey {
+			return fmt.Errorf("A ssh key can not be used with the https url %s", repo)
+		}
+		return nil
+	}
+	if !withKey {
+		return fmt.Errorf("A ssh key is required for the url %s", repo)
+	}
+	return nil
+}
+
+// remoteHost returns the host and the port of an https url, an ssh url or an scp-like url
+func remoteHost(repo string) (string, string, error) {
+	if strings.HasPrefix(repo, "https://") || strings.HasPrefix(repo, "ssh://") {
+		u, err := url.Parse(repo)
+		if err != nil || u.Hostname() == "" || strings.HasPrefix(u.Hostname(), "-") {
+			return "", "", fmt.Errorf("Invalid git url %s", repo)
+		}
+		port := u.Port()
+		if port == "" && u.Scheme == "https" {
+			port = "443"
+		} else if port == "" {
+			port = "22"
+		}
+		return u.Hostname(), port, nil
+	}
+	if !scpLikeURLRegexp.MatchString(repo) {
+		return "", "", fmt.Errorf("Git protocol not supported: %s", repo)
+	}
+	host := strings.SplitN(strings.SplitN(repo, "@", 2)[1], ":", 2)[0]
+	return host, "22", nil
+}
+
+// LsRemote lists the branches and the tags of a remote repository. It returns the hash of the commit of each reference, indexed by the name of the reference.
+// The command is not run through a shell and the environment variables are not expanded in the url
+func LsRemote(ctx context.Context, repo string, auth *AuthOpts, output *OutputOpts) (map[string]string, error) {
+	if err := CheckRemoteURL(repo, auth != nil); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lsRemoteTimeout)
+	defer cancel()
+
+	ip, err := resolvePublicHost(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return lsRemote(ctx, repo, ip, auth, output)
+}
+
+// resolvePublicHost resolves the host of an url and checks that all its addresses are public.
+// It returns the checked address, which git must connect to instead of resolving the host again
+func resolvePublicHost(ctx context.Context, repo string) (net.IP, error) {
+	host, _, err := remoteHost(repo)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to resolve %s: %v", host, err)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("Unable to resolve %s", host)
+	}
+	for _, addr := range addrs {
+		if !sdk.IsPublicIP(addr.IP) {
+			return nil, fmt.Errorf("The url %s targets a private address", repo)
+		}
+	}
+	return addrs[0].IP, nil
+}
+
+// lsRemote runs git ls-remote without checking the url. When ip is set, git connects to this address instead of resolving the host:
+// with the curl resolve option for https urls, with the HostName option of ssh for ssh urls. The http redirections are not followed,
+// and the proxies of the environment and the proxy commands of ssh are not used: the address checked by the caller must be the one git connects to
+func lsRemote(ctx context.Context, repo string, ip net.IP, auth *AuthOpts, output *OutputOpts) (map[string]string, error) {
+	var resolve string
+	sshOpts := []string{"BatchMode=yes", "ConnectTimeout=10", "ServerAliveInterval=10", "ServerAliveCountMax=3", "ProxyCommand=none"}
+	if ip != nil {
+		if u, err := url.Parse(repo); err == nil && strings.HasPrefix(u.Scheme, "http") {
+			port := u.Port()
+			if port == "" && u.Scheme == "https" {
+				port = "443"
+			} else if port == "" {
+				port = "80"
+			}
+			addr := ip.String()
+			if ip.To4() == nil {
+				addr = "[" + addr + "]"
+			}
+			resolve = u.Hostname() + ":" + port + ":" + addr
+		} else {
+			sshOpts = append(sshOpts, "HostName="+ip.String())
+		}
+	}
+
+	c := prepareGitLsRemoteCommands(repo, resolve)[0]
+	cmd := exec.CommandContext(ctx, c.cmd, c.args...)
+	cmd.Env = append(environWithoutProxy(), "GIT_TERMINAL_PROMPT=0")
+	if auth != nil {
+		wrapperPath, err := writeGitSSHWrapper(auth, sshOpts...)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Env = append(cmd.Env, "GIT_SSH="+wrapperPath)
+	}
+
+	stdout := new(bytes.Buffer)
+	cmd.Stdout = stdout
+	if output != nil {
+		cmd.Stderr = output.Stderr
+	}
+
+	if verbose {
+		LogFunc("Executing Command %s", c)
+	}
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("git ls-remote did not answer in %s", lsRemoteTimeout)
+		}
+		return nil, err
+	}
+	return parseLsRemote(stdout.String()), nil
+}
+
+// environWithoutProxy returns the environment without the proxy variables read by git and curl
+func environWithoutProxy() []string {
+	env := []string{}
+	for _, e := range os.Environ() {
+		name := strings.ToLower(strings.SplitN(e, "=", 2)[0])
+		if name == "http_proxy" || name == "https_proxy" || name == "all_proxy" || name == "no_proxy" {
+			continue
+		}
+		env = append(env, e)
+	}
+	return env
+}
+
+func prepareGitLsRemoteCommands(repo, resolve string) cmds {
+	allCmd := []cmd{}
+
+	args := []string{"-c", "http.followRedirects=false", "-c", "http.proxy="}
+	if resolve != "" {
+		args = append(args, "-c", "http.curloptResolve="+resolve)
+	}
+	gitcmd := cmd{
+		cmd:  "git",
+		args: append(args, "ls-remote", "--heads", "--tags", "--", repo),
+	}
+
+	allCmd = append(allCmd, gitcmd)
+	return cmds(allCmd)
+}
+
+// parseLsRemote reads the output of git ls-remote. The hash of an annotated tag is replaced by the hash of its commit
+func parseLsRemote(out string) map[string]string {
+	refs := map[string]string{}
+	peeled := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.HasSuffix(fields[1], "^{}") {
+			peeled[strings.TrimSuffix(fields[1], "^{}")] = fields[0]
+			continue
+		}
+		refs[fields[1]] = fields[0]
+	}
+	for name, hash := range peeled {
+		refs[name] = hash
+	}
+	return refs
+}
@@ -2,6 +2,12 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"os/user"
 	"reflect"
@@ -210,6 +216,24 @@ func Test_gitCommand(t *testing.T) {
 				"git reset --hard eb8b87a",
 			},
 		},
+		{
+			name: "Clone public repo over http and checkout the commit of a pull request",
+			args: args{
+				repo: "https://github.com/ovh/cds.git",
+				path: "/tmp/Test_gitCommand-4",
+				opts: &CloneOpts{
+					Branch:         "fix/typo",
+					Depth:          50,
+					CheckoutCommit: "eb8b87a",
+					FetchRef:       "refs/pull/42/head",
+				},
+			},
+			want: []string{
+				"git clone --depth 50 https://github.com/ovh/cds.git /tmp/Test_gitCommand-4",
+				"git fetch --depth 50 origin refs/pull/42/head",
+				"git reset --hard eb8b87a",
+			},
+		},
 	}
 	for _, tt := range tests {
 		os.RemoveAll(tt.args.path)
@@ -218,3 +242,86 @@ func Test_gitCommand(t *testing.T) {
 		}
 	}
 }
+
+func Test_parseLsRemote(t *testing.T) {
+	out := `eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c	refs/heads/master
+1f3d5a8e4c7b2d9e0a6f8c1b3e5d7a9c2b4e6f80	refs/heads/feat/poller
+0a1b2c3d4e5f60718293a4b5c6d7e8f901234567	refs/tags/v1.0.0
+eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c	refs/tags/v1.0.0^{}
+`
+	want := map[string]string{
+		"refs/heads/master":      "eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c",
+		"refs/heads/feat/poller": "1f3d5a8e4c7b2d9e0a6f8c1b3e5d7a9c2b4e6f80",
+		"refs/tags/v1.0.0":       "eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c",
+	}
+	if got := parseLsRemote(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseLsRemote() = %v, want %v", got, want)
+	}
+	if got := prepareGitLsRemoteCommands("https://github.com/ovh/cds.git", "github.com:443:140.82.121.3").Strings(); !reflect.DeepEqual(got, []string{"git -c http.followRedirects=false -c http.proxy= -c http.curloptResolve=github.com:443:140.82.121.3 ls-remote --heads --tags -- https://github.com/ovh/cds.git"}) {
+		t.Errorf("prepareGitLsRemoteCommands() = %v", got)
+	}
+}
+
+func Test_lsRemoteDoesNotFollowRedirects(t *testing.T) {
+	var internalHit bool
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		internalHit = true
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c\trefs/heads/master\n")
+	}))
+	defer internal.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL+r.URL.RequestURI(), http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	refs, err := lsRemote(context.Background(), internal.URL+"/ovh/cds.git", nil, nil, nil)
+	if err != nil || refs["refs/heads/master"] != "eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c" {
+		t.Fatalf("lsRemote() on the internal server = %v, %v", refs, err)
+	}
+
+	// The host is pinned to the checked address, git does not resolve it again
+	u, _ := url.Parse(internal.URL)
+	refs, err = lsRemote(context.Background(), "http://cds.invalid:"+u.Port()+"/ovh/cds.git", net.ParseIP("127.0.0.1"), nil, nil)
+	if err != nil || refs["refs/heads/master"] != "eb8b87a6d6e2a4ba5c7d5de9c3e7bb2a4e6e7a5c" {
+		t.Fatalf("lsRemote() on the pinned host = %v, %v", refs, err)
+	}
+
+	internalHit = false
+	if refs, err := lsRemote(context.Background(), redirect.URL+"/ovh/cds.git", nil, nil, nil); err == nil {
+		t.Errorf("lsRemote() followed the redirection: %v", refs)
+	}
+	if internalHit {
+		t.Errorf("lsRemote() requested the target of the redirection")
+	}
+}
+
+func TestCheckRemoteURL(t *testing.T) {
+	tests := []struct {
+		repo    string
+		withKey bool
+		wantErr bool
+	}{
+		{repo: "https://github.com/ovh/cds.git"},
+		{repo: "https://github.com/ovh/cds.git", withKey: true, wantErr: true},
+		{repo: "ssh://git@github.com/ovh/cds.git", withKey: true},
+		{repo: "git@github.com:ovh/cds.git", withKey: true},
+		{repo: "git@github.com:ovh/cds.git", wantErr: true},
+		{repo: "http://github.com/ovh/cds.git", wantErr: true},
+		{repo: "file:///etc", withKey: true, wantErr: true},
+		{repo: "/var/lib/cds", withKey: true, wantErr: true},
+		{repo: "--upload-pack=touch /tmp/pwned", withKey: true, wantErr: true},
+		{repo: "ssh://-oProxyCommand=touch/ovh/cds.git", withKey: true, wantErr: true},
+		{repo: "git@-oProxyCommand=touch:ovh/cds.git", withKey: true, wantErr: true},
+		{repo: "https://localhost/ovh/cds.git", wantErr: true},
+		{repo: "https://192.168.1.10/ovh/cds.git", wantErr: true},
+		{repo: "ssh://git@10.0.0.5:22/ovh/cds.git", withKey: true, wantErr: true},
+		{repo: "ssh://git@localhost/ovh/cds.git", withKey: true, wantErr: true},
+		{repo: "git@127.0.0.1:ovh/cds.git", withKey: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := CheckRemoteURL(tt.repo, tt.withKey); (err != nil) != tt.wantErr {
+			t.Errorf("CheckRemoteURL(%q, %v) error = %v, wantErr %v", tt.repo, tt.withKey, err, tt.wantErr)
+		}
+	}
+}
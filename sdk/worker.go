@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 	"time"
 )
@@ -112,6 +113,36 @@ type Model struct {
 	IsDeprecated           bool                `json:"is_deprecated" db:"is_deprecated" cli:"deprecated"`
 	IsOfficial             bool                `json:"is_official" db:"-" cli:"official"`
 	PatternName            string              `json:"pattern_name,omitempty" db:"-" cli:"-"`
+	Reuse                  *ModelReuse         `json:"reuse,omitempty" db:"-" cli:"-"`
+	PoolSize               int64               `json:"pool_size,omitempty" db:"-" cli:"-"`
+}
+
+// ModelReuse is the configuration of the reusable workers of a model. After a job, a reusable worker cleans its
+// workspace and takes another job of the same project, until it has run MaxJobs jobs or it has lived TTL minutes.
+// The hatcheries keep a pool of reusable workers sized from the queue history of the model, up to MaxPool workers.
+type ModelReuse struct {
+	MaxJobs int   `json:"max_jobs,omitempty"`
+	TTL     int   `json:"ttl,omitempty"`
+	MaxPool int64 `json:"max_pool,omitempty"`
+}
+
+// Enabled returns true if the workers of the model take more than one job
+func (r *ModelReuse) Enabled() bool {
+	return r != nil && r.MaxJobs > 1
+}
+
+// IsValid checks the limits of the reuse configuration
+func (r ModelReuse) IsValid() error {
+	if r.MaxJobs < 0 {
+		return fmt.Errorf("reuse: max_jobs must be positive")
+	}
+	if r.TTL < 0 {
+		return fmt.Errorf("reuse: ttl must be positive")
+	}
+	if r.MaxPool < 0 {
+		return fmt.Errorf("reuse: max_pool must be positive")
+	}
+	return nil
 }
 
 // ModelVirtualMachine for openstack or vsphere
@@ -125,11 +156,54 @@ type ModelVirtualMachine struct {
 
 // ModelDocker for swarm, marathon and kubernetes
 type ModelDocker struct {
-	Image  string            `json:"image,omitempty"`
-	Memory int64             `json:"memory,omitempty"`
-	Envs   map[string]string `json:"envs,omitempty"`
-	Shell  string            `json:"shell,omitempty"`
-	Cmd    string            `json:"cmd,omitempty"`
+	Image       string            `json:"image,omitempty"`
+	Memory      int64             `json:"memory,omitempty"`
+	Envs        map[string]string `json:"envs,omitempty"`
+	Shell       string            `json:"shell,omitempty"`
+	Cmd         string            `json:"cmd,omitempty"`
+	PodTemplate *ModelPodTemplate `json:"pod_template,omitempty"`
+}
+
+// Operators of the tolerations of a pod template
+const (
+	ModelPodTolerationOperatorEqual  = "Equal"
+	ModelPodTolerationOperatorExists = "Exists"
+)
+
+// ModelPodTemplate is used by the kubernetes hatchery to spawn the pods of a docker model
+type ModelPodTemplate struct {
+	NodeSelector       map[string]string    `json:"node_selector,omitempty"`
+	Tolerations        []ModelPodToleration `json:"tolerations,omitempty"`
+	ServiceAccountName string               `json:"service_account_name,omitempty"`
+}
+
+// ModelPodToleration allows the pods of a model to be scheduled on nodes with matching taints
+type ModelPodToleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// IsValid checks the operators and the effects of the tolerations
+func (t ModelPodTemplate) IsValid() error {
+	for _, tol := range t.Tolerations {
+		switch tol.Operator {
+		case "", ModelPodTolerationOperatorEqual:
+		case ModelPodTolerationOperatorExists:
+			if tol.Value != "" {
+				return fmt.Errorf("toleration %s: value must be empty with operator %s", tol.Key, tol.Operator)
+			}
+		default:
+			return fmt.Errorf("toleration %s: invalid operator %s", tol.Key, tol.Operator)
+		}
+		switch tol.Effect {
+		case "", "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			return fmt.Errorf("toleration %s: invalid effect %s", tol.Key, tol.Effect)
+		}
+	}
+	return nil
 }
 
 // ModelPattern represent patterns for users and admin when creating a worker model
@@ -162,6 +236,7 @@ type WorkerArgs struct {
 	PipelineBuildJobID int64  `json:"pipeline_build_job_id"`
 	WorkflowJobID      int64  `json:"workflow_job_id"`
 	TTL                int    `json:"ttl"`
+	MaxJobs            int    `json:"max_jobs"`
 	FromWorkerImage    bool   `json:"from_worker_image"`
 	//Graylog params
 	GraylogHost       string `json:"graylog_host"`
@@ -173,6 +248,17 @@ type WorkerArgs struct {
 	GrpcInsecure bool   `json:"grpc_insecure"`
 }
 
+// ApplyReuse sets the max number of jobs and the ttl of the workers of a model with reusable workers
+func (a *WorkerArgs) ApplyReuse(m Model) {
+	if !m.Reuse.Enabled() {
+		return
+	}
+	a.MaxJobs = m.Reuse.MaxJobs
+	if m.Reuse.TTL > 0 {
+		a.TTL = m.Reuse.TTL
+	}
+}
+
 // TemplateEnvs return envs interpolated with worker arguments
 func TemplateEnvs(args WorkerArgs, envs map[string]string) (map[string]string, error) {
 	for name, value := range envs {
@@ -603,6 +603,8 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk4(in *jlexer.Lexer, out *WorkerArgs
 			out.WorkflowJobID = int64(in.Int64())
 		case "ttl":
 			out.TTL = int(in.Int())
+		case "max_jobs":
+			out.MaxJobs = int(in.Int())
 		case "from_worker_image":
 			out.FromWorkerImage = bool(in.Bool())
 		case "graylog_host":
@@ -741,6 +743,16 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk4(out *jwriter.Writer, in WorkerArg
 		}
 		out.Int(int(in.TTL))
 	}
+	{
+		const prefix string = ",\"max_jobs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.MaxJobs))
+	}
 	{
 		const prefix string = ",\"from_worker_image\":"
 		if first {
@@ -2666,6 +2678,8 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk19(in *jlexer.Lexer, out *Action) {
 			out.ID = int64(in.Int64())
 		case "name":
 			out.Name = string(in.String())
+		case "step_name":
+			out.StepName = string(in.String())
 		case "type":
 			out.Type = string(in.String())
 		case "description":
@@ -2749,6 +2763,18 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk19(in *jlexer.Lexer, out *Action) {
 			out.Optional = bool(in.Bool())
 		case "always_executed":
 			out.AlwaysExecuted = bool(in.Bool())
+		case "retry_policy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetryPolicy = nil
+			} else {
+				if out.RetryPolicy == nil {
+					out.RetryPolicy = new(RetryPolicy)
+				}
+				easyjson82a45abeDecodeGithubComOvhCdsSdk20(in, &*out.RetryPolicy)
+			}
+		case "timeout":
+			out.Timeout = string(in.String())
 		case "last_modified":
 			out.LastModified = int64(in.Int64())
 		default:
@@ -2785,6 +2811,16 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk19(out *jwriter.Writer, in Action)
 		}
 		out.String(string(in.Name))
 	}
+	if in.StepName != "" {
+		const prefix string = ",\"step_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.StepName))
+	}
 	{
 		const prefix string = ",\"type\":"
 		if first {
@@ -2908,6 +2944,26 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk19(out *jwriter.Writer, in Action)
 		}
 		out.Bool(bool(in.AlwaysExecuted))
 	}
+	if in.RetryPolicy != nil {
+		const prefix string = ",\"retry_policy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson82a45abeEncodeGithubComOvhCdsSdk20(out, *in.RetryPolicy)
+	}
+	if in.Timeout != "" {
+		const prefix string = ",\"timeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Timeout))
+	}
 	{
 		const prefix string = ",\"last_modified\":"
 		if first {
@@ -2920,6 +2976,119 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk19(out *jwriter.Writer, in Action)
 	}
 	out.RawByte('}')
 }
+func easyjson82a45abeDecodeGithubComOvhCdsSdk20(in *jlexer.Lexer, out *RetryPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "max_attempts":
+			out.MaxAttempts = int(in.Int())
+		case "backoff":
+			out.Backoff = string(in.String())
+		case "exit_codes":
+			if in.IsNull() {
+				in.Skip()
+				out.ExitCodes = nil
+			} else {
+				in.Delim('[')
+				if out.ExitCodes == nil {
+					if !in.IsDelim(']') {
+						out.ExitCodes = make([]int, 0, 8)
+					} else {
+						out.ExitCodes = []int{}
+					}
+				} else {
+					out.ExitCodes = (out.ExitCodes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v55 int
+					v55 = int(in.Int())
+					out.ExitCodes = append(out.ExitCodes, v55)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "on_worker_lost":
+			out.OnWorkerLost = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson82a45abeEncodeGithubComOvhCdsSdk20(out *jwriter.Writer, in RetryPolicy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"max_attempts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.MaxAttempts))
+	}
+	if in.Backoff != "" {
+		const prefix string = ",\"backoff\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Backoff))
+	}
+	if len(in.ExitCodes) != 0 {
+		const prefix string = ",\"exit_codes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v56, v57 := range in.ExitCodes {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v57))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.OnWorkerLost {
+		const prefix string = ",\"on_worker_lost\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.OnWorkerLost))
+	}
+	out.RawByte('}')
+}
 func easyjson82a45abeDecodeGithubComOvhCdsSdk10(in *jlexer.Lexer, out *Parameter) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -3062,9 +3231,9 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk9(in *jlexer.Lexer, out *ExecutedJo
 					out.StepStatus = (out.StepStatus)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v55 StepStatus
-					easyjson82a45abeDecodeGithubComOvhCdsSdk20(in, &v55)
-					out.StepStatus = append(out.StepStatus, v55)
+					var v58 StepStatus
+					easyjson82a45abeDecodeGithubComOvhCdsSdk21(in, &v58)
+					out.StepStatus = append(out.StepStatus, v58)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3075,6 +3244,30 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk9(in *jlexer.Lexer, out *ExecutedJo
 			out.WorkerName = string(in.String())
 		case "worker_id":
 			out.WorkerID = string(in.String())
+		case "matrix_cell":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.MatrixCell = make(JobMatrixCell)
+				} else {
+					out.MatrixCell = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v59 string
+					v59 = string(in.String())
+					(out.MatrixCell)[key] = v59
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "attempt":
+			out.Attempt = int(in.Int())
+		case "retried_by":
+			out.RetriedBy = int64(in.Int64())
 		case "pipeline_action_id":
 			out.PipelineActionID = int64(in.Int64())
 		case "pipeline_stage_id":
@@ -3101,13 +3294,68 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk9(in *jlexer.Lexer, out *ExecutedJo
 					out.Warnings = (out.Warnings)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v56 PipelineBuildWarning
-					easyjson82a45abeDecodeGithubComOvhCdsSdk11(in, &v56)
-					out.Warnings = append(out.Warnings, v56)
+					var v60 PipelineBuildWarning
+					easyjson82a45abeDecodeGithubComOvhCdsSdk11(in, &v60)
+					out.Warnings = append(out.Warnings, v60)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "matrix":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Matrix = make(JobMatrix)
+				} else {
+					out.Matrix = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v61 []string
+					if in.IsNull() {
+						in.Skip()
+						v61 = nil
+					} else {
+						in.Delim('[')
+						if v61 == nil {
+							if !in.IsDelim(']') {
+								v61 = make([]string, 0, 4)
+							} else {
+								v61 = []string{}
+							}
+						} else {
+							v61 = (v61)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v62 string
+							v62 = string(in.String())
+							v61 = append(v61, v62)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Matrix)[key] = v61
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "retry_policy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetryPolicy = nil
+			} else {
+				if out.RetryPolicy == nil {
+					out.RetryPolicy = new(RetryPolicy)
+				}
+				easyjson82a45abeDecodeGithubComOvhCdsSdk20(in, &*out.RetryPolicy)
+			}
+		case "priority":
+			out.Priority = int(in.Int())
+		case "timeout":
+			out.Timeout = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3134,11 +3382,11 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk9(out *jwriter.Writer, in ExecutedJ
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v57, v58 := range in.StepStatus {
-				if v57 > 0 {
+			for v63, v64 := range in.StepStatus {
+				if v63 > 0 {
 					out.RawByte(',')
 				}
-				easyjson82a45abeEncodeGithubComOvhCdsSdk20(out, v58)
+				easyjson82a45abeEncodeGithubComOvhCdsSdk21(out, v64)
 			}
 			out.RawByte(']')
 		}
@@ -3173,6 +3421,50 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk9(out *jwriter.Writer, in ExecutedJ
 		}
 		out.String(string(in.WorkerID))
 	}
+	if len(in.MatrixCell) != 0 {
+		const prefix string = ",\"matrix_cell\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v65First := true
+			for v65Name, v65Value := range in.MatrixCell {
+				if v65First {
+					v65First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v65Name))
+				out.RawByte(':')
+				out.String(string(v65Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Attempt != 0 {
+		const prefix string = ",\"attempt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Attempt))
+	}
+	if in.RetriedBy != 0 {
+		const prefix string = ",\"retried_by\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.RetriedBy))
+	}
 	{
 		const prefix string = ",\"pipeline_action_id\":"
 		if first {
@@ -3235,18 +3527,83 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk9(out *jwriter.Writer, in ExecutedJ
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v59, v60 := range in.Warnings {
-				if v59 > 0 {
+			for v66, v67 := range in.Warnings {
+				if v66 > 0 {
 					out.RawByte(',')
 				}
-				easyjson82a45abeEncodeGithubComOvhCdsSdk11(out, v60)
+				easyjson82a45abeEncodeGithubComOvhCdsSdk11(out, v67)
 			}
 			out.RawByte(']')
 		}
 	}
+	if len(in.Matrix) != 0 {
+		const prefix string = ",\"matrix\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v68First := true
+			for v68Name, v68Value := range in.Matrix {
+				if v68First {
+					v68First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v68Name))
+				out.RawByte(':')
+				if v68Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v69, v70 := range v68Value {
+						if v69 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v70))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.RetryPolicy != nil {
+		const prefix string = ",\"retry_policy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjson82a45abeEncodeGithubComOvhCdsSdk20(out, *in.RetryPolicy)
+	}
+	if in.Priority != 0 {
+		const prefix string = ",\"priority\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Priority))
+	}
+	if in.Timeout != "" {
+		const prefix string = ",\"timeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Timeout))
+	}
 	out.RawByte('}')
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk20(in *jlexer.Lexer, out *StepStatus) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk21(in *jlexer.Lexer, out *StepStatus) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3277,6 +3634,8 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk20(in *jlexer.Lexer, out *StepStatu
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.Done).UnmarshalJSON(data))
 			}
+		case "exit_code":
+			out.ExitCode = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -3287,7 +3646,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk20(in *jlexer.Lexer, out *StepStatu
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk20(out *jwriter.Writer, in StepStatus) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk21(out *jwriter.Writer, in StepStatus) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3331,9 +3690,19 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk20(out *jwriter.Writer, in StepStat
 		}
 		out.Raw((in.Done).MarshalJSON())
 	}
+	if in.ExitCode != 0 {
+		const prefix string = ",\"exit_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.ExitCode))
+	}
 	out.RawByte('}')
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk21(in *jlexer.Lexer, out *ModelVirtualMachine) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk22(in *jlexer.Lexer, out *ModelVirtualMachine) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3372,7 +3741,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk21(in *jlexer.Lexer, out *ModelVirt
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk21(out *jwriter.Writer, in ModelVirtualMachine) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk22(out *jwriter.Writer, in ModelVirtualMachine) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3432,27 +3801,27 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk21(out *jwriter.Writer, in ModelVir
 // MarshalJSON supports json.Marshaler interface
 func (v ModelVirtualMachine) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson82a45abeEncodeGithubComOvhCdsSdk21(&w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ModelVirtualMachine) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson82a45abeEncodeGithubComOvhCdsSdk21(w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ModelVirtualMachine) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson82a45abeDecodeGithubComOvhCdsSdk21(&r, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ModelVirtualMachine) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson82a45abeDecodeGithubComOvhCdsSdk21(l, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk22(l, v)
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk22(in *jlexer.Lexer, out *ModelPattern) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk23(in *jlexer.Lexer, out *ModelReuse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3471,16 +3840,12 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk22(in *jlexer.Lexer, out *ModelPatt
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = int64(in.Int64())
-		case "name":
-			out.Name = string(in.String())
-		case "type":
-			out.Type = string(in.String())
-		case "model":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Model).UnmarshalJSON(data))
-			}
+		case "max_jobs":
+			out.MaxJobs = int(in.Int())
+		case "ttl":
+			out.TTL = int(in.Int())
+		case "max_pool":
+			out.MaxPool = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -3491,32 +3856,397 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk22(in *jlexer.Lexer, out *ModelPatt
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk22(out *jwriter.Writer, in ModelPattern) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk23(out *jwriter.Writer, in ModelReuse) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"id\":"
+	if in.MaxJobs != 0 {
+		const prefix string = ",\"max_jobs\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Int64(int64(in.ID))
+		out.Int(int(in.MaxJobs))
 	}
-	{
-		const prefix string = ",\"name\":"
+	if in.TTL != 0 {
+		const prefix string = ",\"ttl\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Name))
+		out.Int(int(in.TTL))
 	}
-	{
-		const prefix string = ",\"type\":"
+	if in.MaxPool != 0 {
+		const prefix string = ",\"max_pool\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.MaxPool))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModelReuse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson82a45abeEncodeGithubComOvhCdsSdk23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModelReuse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson82a45abeEncodeGithubComOvhCdsSdk23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModelReuse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson82a45abeDecodeGithubComOvhCdsSdk23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModelReuse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson82a45abeDecodeGithubComOvhCdsSdk23(l, v)
+}
+func easyjson82a45abeDecodeGithubComOvhCdsSdk24(in *jlexer.Lexer, out *ModelPodToleration) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "key":
+			out.Key = string(in.String())
+		case "operator":
+			out.Operator = string(in.String())
+		case "value":
+			out.Value = string(in.String())
+		case "effect":
+			out.Effect = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson82a45abeEncodeGithubComOvhCdsSdk24(out *jwriter.Writer, in ModelPodToleration) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Key != "" {
+		const prefix string = ",\"key\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Key))
+	}
+	if in.Operator != "" {
+		const prefix string = ",\"operator\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Operator))
+	}
+	if in.Value != "" {
+		const prefix string = ",\"value\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Value))
+	}
+	if in.Effect != "" {
+		const prefix string = ",\"effect\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Effect))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModelPodToleration) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson82a45abeEncodeGithubComOvhCdsSdk24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModelPodToleration) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson82a45abeEncodeGithubComOvhCdsSdk24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModelPodToleration) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson82a45abeDecodeGithubComOvhCdsSdk24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModelPodToleration) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson82a45abeDecodeGithubComOvhCdsSdk24(l, v)
+}
+func easyjson82a45abeDecodeGithubComOvhCdsSdk25(in *jlexer.Lexer, out *ModelPodTemplate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "node_selector":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.NodeSelector = make(map[string]string)
+				} else {
+					out.NodeSelector = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v71 string
+					v71 = string(in.String())
+					(out.NodeSelector)[key] = v71
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "tolerations":
+			if in.IsNull() {
+				in.Skip()
+				out.Tolerations = nil
+			} else {
+				in.Delim('[')
+				if out.Tolerations == nil {
+					if !in.IsDelim(']') {
+						out.Tolerations = make([]ModelPodToleration, 0, 1)
+					} else {
+						out.Tolerations = []ModelPodToleration{}
+					}
+				} else {
+					out.Tolerations = (out.Tolerations)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v72 ModelPodToleration
+					if data := in.Raw(); in.Ok() {
+						in.AddError((v72).UnmarshalJSON(data))
+					}
+					out.Tolerations = append(out.Tolerations, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "service_account_name":
+			out.ServiceAccountName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson82a45abeEncodeGithubComOvhCdsSdk25(out *jwriter.Writer, in ModelPodTemplate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.NodeSelector) != 0 {
+		const prefix string = ",\"node_selector\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v73First := true
+			for v73Name, v73Value := range in.NodeSelector {
+				if v73First {
+					v73First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v73Name))
+				out.RawByte(':')
+				out.String(string(v73Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Tolerations) != 0 {
+		const prefix string = ",\"tolerations\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v74, v75 := range in.Tolerations {
+				if v74 > 0 {
+					out.RawByte(',')
+				}
+				out.Raw((v75).MarshalJSON())
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ServiceAccountName != "" {
+		const prefix string = ",\"service_account_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ServiceAccountName))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ModelPodTemplate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson82a45abeEncodeGithubComOvhCdsSdk25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ModelPodTemplate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson82a45abeEncodeGithubComOvhCdsSdk25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ModelPodTemplate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson82a45abeDecodeGithubComOvhCdsSdk25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ModelPodTemplate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson82a45abeDecodeGithubComOvhCdsSdk25(l, v)
+}
+func easyjson82a45abeDecodeGithubComOvhCdsSdk26(in *jlexer.Lexer, out *ModelPattern) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "model":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Model).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson82a45abeEncodeGithubComOvhCdsSdk26(out *jwriter.Writer, in ModelPattern) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"type\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -3541,27 +4271,27 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk22(out *jwriter.Writer, in ModelPat
 // MarshalJSON supports json.Marshaler interface
 func (v ModelPattern) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson82a45abeEncodeGithubComOvhCdsSdk22(&w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ModelPattern) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson82a45abeEncodeGithubComOvhCdsSdk22(w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ModelPattern) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson82a45abeDecodeGithubComOvhCdsSdk22(&r, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ModelPattern) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson82a45abeDecodeGithubComOvhCdsSdk22(l, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk26(l, v)
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk23(in *jlexer.Lexer, out *ModelDocker) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk27(in *jlexer.Lexer, out *ModelDocker) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3597,9 +4327,9 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk23(in *jlexer.Lexer, out *ModelDock
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v61 string
-					v61 = string(in.String())
-					(out.Envs)[key] = v61
+					var v76 string
+					v76 = string(in.String())
+					(out.Envs)[key] = v76
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -3608,6 +4338,18 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk23(in *jlexer.Lexer, out *ModelDock
 			out.Shell = string(in.String())
 		case "cmd":
 			out.Cmd = string(in.String())
+		case "pod_template":
+			if in.IsNull() {
+				in.Skip()
+				out.PodTemplate = nil
+			} else {
+				if out.PodTemplate == nil {
+					out.PodTemplate = new(ModelPodTemplate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.PodTemplate).UnmarshalJSON(data))
+				}
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3618,7 +4360,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk23(in *jlexer.Lexer, out *ModelDock
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk23(out *jwriter.Writer, in ModelDocker) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk27(out *jwriter.Writer, in ModelDocker) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3652,16 +4394,16 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk23(out *jwriter.Writer, in ModelDoc
 		}
 		{
 			out.RawByte('{')
-			v62First := true
-			for v62Name, v62Value := range in.Envs {
-				if v62First {
-					v62First = false
+			v77First := true
+			for v77Name, v77Value := range in.Envs {
+				if v77First {
+					v77First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v62Name))
+				out.String(string(v77Name))
 				out.RawByte(':')
-				out.String(string(v62Value))
+				out.String(string(v77Value))
 			}
 			out.RawByte('}')
 		}
@@ -3686,33 +4428,43 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk23(out *jwriter.Writer, in ModelDoc
 		}
 		out.String(string(in.Cmd))
 	}
+	if in.PodTemplate != nil {
+		const prefix string = ",\"pod_template\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.PodTemplate).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ModelDocker) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson82a45abeEncodeGithubComOvhCdsSdk23(&w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk27(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ModelDocker) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson82a45abeEncodeGithubComOvhCdsSdk23(w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk27(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ModelDocker) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson82a45abeDecodeGithubComOvhCdsSdk23(&r, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk27(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ModelDocker) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson82a45abeDecodeGithubComOvhCdsSdk23(l, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk27(l, v)
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk24(in *jlexer.Lexer, out *ModelCmds) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk28(in *jlexer.Lexer, out *ModelCmds) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3744,9 +4496,9 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk24(in *jlexer.Lexer, out *ModelCmds
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v63 string
-					v63 = string(in.String())
-					(out.Envs)[key] = v63
+					var v78 string
+					v78 = string(in.String())
+					(out.Envs)[key] = v78
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -3769,7 +4521,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk24(in *jlexer.Lexer, out *ModelCmds
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk24(out *jwriter.Writer, in ModelCmds) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk28(out *jwriter.Writer, in ModelCmds) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3783,16 +4535,16 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk24(out *jwriter.Writer, in ModelCmd
 		}
 		{
 			out.RawByte('{')
-			v64First := true
-			for v64Name, v64Value := range in.Envs {
-				if v64First {
-					v64First = false
+			v79First := true
+			for v79Name, v79Value := range in.Envs {
+				if v79First {
+					v79First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v64Name))
+				out.String(string(v79Name))
 				out.RawByte(':')
-				out.String(string(v64Value))
+				out.String(string(v79Value))
 			}
 			out.RawByte('}')
 		}
@@ -3843,27 +4595,27 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk24(out *jwriter.Writer, in ModelCmd
 // MarshalJSON supports json.Marshaler interface
 func (v ModelCmds) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson82a45abeEncodeGithubComOvhCdsSdk24(&w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk28(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ModelCmds) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson82a45abeEncodeGithubComOvhCdsSdk24(w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk28(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ModelCmds) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson82a45abeDecodeGithubComOvhCdsSdk24(&r, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk28(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ModelCmds) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson82a45abeDecodeGithubComOvhCdsSdk24(l, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk28(l, v)
 }
-func easyjson82a45abeDecodeGithubComOvhCdsSdk25(in *jlexer.Lexer, out *Model) {
+func easyjson82a45abeDecodeGithubComOvhCdsSdk29(in *jlexer.Lexer, out *Model) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3922,11 +4674,11 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk25(in *jlexer.Lexer, out *Model) {
 					out.RegisteredCapabilities = (out.RegisteredCapabilities)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v65 Requirement
+					var v80 Requirement
 					if data := in.Raw(); in.Ok() {
-						in.AddError((v65).UnmarshalJSON(data))
+						in.AddError((v80).UnmarshalJSON(data))
 					}
-					out.RegisteredCapabilities = append(out.RegisteredCapabilities, v65)
+					out.RegisteredCapabilities = append(out.RegisteredCapabilities, v80)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3977,6 +4729,20 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk25(in *jlexer.Lexer, out *Model) {
 			out.IsOfficial = bool(in.Bool())
 		case "pattern_name":
 			out.PatternName = string(in.String())
+		case "reuse":
+			if in.IsNull() {
+				in.Skip()
+				out.Reuse = nil
+			} else {
+				if out.Reuse == nil {
+					out.Reuse = new(ModelReuse)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Reuse).UnmarshalJSON(data))
+				}
+			}
+		case "pool_size":
+			out.PoolSize = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -3987,7 +4753,7 @@ func easyjson82a45abeDecodeGithubComOvhCdsSdk25(in *jlexer.Lexer, out *Model) {
 		in.Consumed()
 	}
 }
-func easyjson82a45abeEncodeGithubComOvhCdsSdk25(out *jwriter.Writer, in Model) {
+func easyjson82a45abeEncodeGithubComOvhCdsSdk29(out *jwriter.Writer, in Model) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4103,11 +4869,11 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk25(out *jwriter.Writer, in Model) {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v66, v67 := range in.RegisteredCapabilities {
-				if v66 > 0 {
+			for v81, v82 := range in.RegisteredCapabilities {
+				if v81 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v67).MarshalJSON())
+				out.Raw((v82).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -4276,29 +5042,49 @@ func easyjson82a45abeEncodeGithubComOvhCdsSdk25(out *jwriter.Writer, in Model) {
 		}
 		out.String(string(in.PatternName))
 	}
+	if in.Reuse != nil {
+		const prefix string = ",\"reuse\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.Reuse).MarshalJSON())
+	}
+	if in.PoolSize != 0 {
+		const prefix string = ",\"pool_size\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.PoolSize))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Model) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson82a45abeEncodeGithubComOvhCdsSdk25(&w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk29(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Model) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson82a45abeEncodeGithubComOvhCdsSdk25(w, v)
+	easyjson82a45abeEncodeGithubComOvhCdsSdk29(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Model) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson82a45abeDecodeGithubComOvhCdsSdk25(&r, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk29(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Model) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson82a45abeDecodeGithubComOvhCdsSdk25(l, v)
+	easyjson82a45abeDecodeGithubComOvhCdsSdk29(l, v)
 }
@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelPodTemplateIsValid(t *testing.T) {
+	assert.NoError(t, ModelPodTemplate{Tolerations: []ModelPodToleration{{Key: "a", Operator: "Exists", Effect: "NoExecute"}}}.IsValid())
+	assert.Error(t, ModelPodTemplate{Tolerations: []ModelPodToleration{{Key: "a", Operator: "Exists", Value: "b"}}}.IsValid())
+	assert.Error(t, ModelPodTemplate{Tolerations: []ModelPodToleration{{Key: "a", Operator: "In"}}}.IsValid())
+	assert.Error(t, ModelPodTemplate{Tolerations: []ModelPodToleration{{Key: "a", Effect: "Never"}}}.IsValid())
+}
+
+func TestModelDockerPodTemplateJSON(t *testing.T) {
+	in := Model{
+		ModelDocker: ModelDocker{
+			Image: "golang:1.11",
+			PodTemplate: &ModelPodTemplate{
+				NodeSelector:       map[string]string{"pool": "ci"},
+				Tolerations:        []ModelPodToleration{{Key: "dedicated", Operator: "Equal", Value: "ci", Effect: "NoSchedule"}},
+				ServiceAccountName: "cds-worker",
+			},
+		},
+	}
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+
+	var out Model
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in.ModelDocker, out.ModelDocker)
+}
@@ -39,6 +39,8 @@ type Workflow struct {
 	Pipelines               map[int64]Pipeline     `json:"pipelines" db:"-" cli:"-"  mapstructure:"-"`
 	ToDelete                bool                   `json:"to_delete" db:"to_delete" cli:"-"`
 	Favorite                bool                   `json:"favorite" db:"-" cli:"favorite"`
+	Concurrency             *WorkflowConcurrency   `json:"concurrency,omitempty" db:"-" cli:"-"`
+	CancelSupersededRuns    bool                   `json:"cancel_superseded_runs" db:"cancel_superseded_runs" cli:"-"`
 }
 
 // WorkflowNotification represents notifications on a workflow
@@ -865,9 +867,10 @@ type WorkflowNodeTrigger struct {
 	WorkflowDestNode   WorkflowNode `json:"workflow_dest_node" db:"-"`
 }
 
-//WorkflowNodeConditions is either an array of WorkflowNodeCondition or a lua script
+//WorkflowNodeConditions is either an array of WorkflowNodeCondition and an expression, all of them must be true, or a lua script
 type WorkflowNodeConditions struct {
 	PlainConditions []WorkflowNodeCondition `json:"plain,omitempty" yaml:"check,omitempty"`
+	Expression      string                  `json:"expression,omitempty" yaml:"expression,omitempty"`
 	LuaScript       string                  `json:"lua_script,omitempty" yaml:"script,omitempty"`
 }
 
@@ -892,6 +895,8 @@ type WorkflowNodeContext struct {
 	DefaultPipelineParameters []Parameter            `json:"default_pipeline_parameters,omitempty" db:"-"`
 	Conditions                WorkflowNodeConditions `json:"conditions,omitempty" db:"-"`
 	Mutex                     bool                   `json:"mutex"`
+	Approval                  *WorkflowNodeApproval  `json:"approval,omitempty" db:"-"`
+	Concurrency               *WorkflowConcurrency   `json:"concurrency,omitempty" db:"-"`
 }
 
 // HasDefaultPayload returns true if the node has a default payload
@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+)
+
+// Actions done when an approval gate expires
+const (
+	WorkflowNodeApprovalExpiryFail = "fail"
+	WorkflowNodeApprovalExpirySkip = "skip"
+)
+
+// Status of the approval gate of a node run
+const (
+	WorkflowNodeRunApprovalWaiting  = "Waiting"
+	WorkflowNodeRunApprovalApproved = "Approved"
+	WorkflowNodeRunApprovalExpired  = "Expired"
+)
+
+// WorkflowNodeApproval is an approval gate on a workflow node: the node run waits for the approvals
+// of the given users or members of the given groups before starting its stages.
+// If there is no user and no group, every user allowed to execute the workflow can approve.
+type WorkflowNodeApproval struct {
+	Groups       []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Users        []string `json:"users,omitempty" yaml:"users,omitempty"`
+	MinApprovals int      `json:"min_approvals,omitempty" yaml:"min_approvals,omitempty"`
+	Expiry       string   `json:"expiry,omitempty" yaml:"expiry,omitempty"`
+	OnExpiry     string   `json:"on_expiry,omitempty" yaml:"on_expiry,omitempty"`
+}
+
+// IsValid checks the expiry and the number of approvals. When only users are listed, they must be able to give all the approvals
+func (a WorkflowNodeApproval) IsValid() error {
+	if a.MinApprovals < 0 {
+		return fmt.Errorf("min_approvals must be positive")
+	}
+	if len(a.Users) > 0 && len(a.Groups) == 0 {
+		users := map[string]bool{}
+		for _, u := range a.Users {
+			users[u] = true
+		}
+		if a.MinApprovals > len(users) {
+			return fmt.Errorf("min_approvals must not be greater than the number of users")
+		}
+	}
+	if a.Expiry != "" {
+		d, err := time.ParseDuration(a.Expiry)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid expiry %s", a.Expiry)
+		}
+	}
+	switch a.OnExpiry {
+	case "", WorkflowNodeApprovalExpiryFail, WorkflowNodeApprovalExpirySkip:
+	default:
+		return fmt.Errorf("on_expiry must be %s or %s", WorkflowNodeApprovalExpiryFail, WorkflowNodeApprovalExpirySkip)
+	}
+	return nil
+}
+
+// RequiredApprovals returns the number of approvals needed to start the node run
+func (a WorkflowNodeApproval) RequiredApprovals() int {
+	if a.MinApprovals < 1 {
+		return 1
+	}
+	return a.MinApprovals
+}
+
+// ExpiredStatus returns the status of a node run when its approval gate has expired
+func (a WorkflowNodeApproval) ExpiredStatus() string {
+	if a.OnExpiry == WorkflowNodeApprovalExpirySkip {
+		return StatusSkipped.String()
+	}
+	return StatusFail.String()
+}
+
+// IsApprover returns true if the user is one of the approvers or a member of one of the approvers groups
+func (a WorkflowNodeApproval) IsApprover(u *User) bool {
+	if len(a.Users) == 0 && len(a.Groups) == 0 {
+		return true
+	}
+	if IsInArray(u.Username, a.Users) {
+		return true
+	}
+	for _, g := range u.Groups {
+		if IsInArray(g.Name, a.Groups) {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkflowNodeRunApproval is the state of the approval gate of a node run, Approvals is the audit of who approved
+type WorkflowNodeRunApproval struct {
+	Gate      WorkflowNodeApproval     `json:"gate"`
+	Status    string                   `json:"status"`
+	ExpireAt  *time.Time               `json:"expire_at,omitempty"`
+	Approvals []WorkflowNodeRunApprove `json:"approvals,omitempty"`
+}
+
+// WorkflowNodeRunApprove is an approval given by a user on a node run
+type WorkflowNodeRunApprove struct {
+	Username string    `json:"username"`
+	Fullname string    `json:"fullname"`
+	Comment  string    `json:"comment,omitempty"`
+	Date     time.Time `json:"date"`
+}
+
+// NewWorkflowNodeRunApproval initializes the approval gate of a node run started at the given time
+func NewWorkflowNodeRunApproval(gate WorkflowNodeApproval, start time.Time) *WorkflowNodeRunApproval {
+	a := &WorkflowNodeRunApproval{
+		Gate:   gate,
+		Status: WorkflowNodeRunApprovalWaiting,
+	}
+	if d, err := time.ParseDuration(gate.Expiry); err == nil && d > 0 {
+		expireAt := start.Add(d)
+		a.ExpireAt = &expireAt
+	}
+	return a
+}
+
+// IsWaiting returns true if the node run is waiting for approvals
+func (a *WorkflowNodeRunApproval) IsWaiting() bool {
+	return a != nil && a.Status == WorkflowNodeRunApprovalWaiting
+}
+
+// IsExpired returns true if the node run is still waiting for approvals after the expiry
+func (a *WorkflowNodeRunApproval) IsExpired(now time.Time) bool {
+	return a.IsWaiting() && a.ExpireAt != nil && now.After(*a.ExpireAt)
+}
+
+// Approve adds the approval of a user. The user must be an approver and can approve only once,
+// the user who triggered the node run can't approve it.
+func (a *WorkflowNodeRunApproval) Approve(u *User, triggeredBy, comment string, now time.Time) error {
+	if !a.IsWaiting() || a.IsExpired(now) {
+		return ErrWorkflowNodeRunApprovalNotPending
+	}
+	if !a.Gate.IsApprover(u) || u.Username == triggeredBy {
+		return ErrWorkflowNodeRunApprovalForbidden
+	}
+	for _, ap := range a.Approvals {
+		if ap.Username == u.Username {
+			return ErrWorkflowNodeRunApprovalForbidden
+		}
+	}
+	a.Approvals = append(a.Approvals, WorkflowNodeRunApprove{
+		Username: u.Username,
+		Fullname: u.Fullname,
+		Comment:  comment,
+		Date:     now,
+	})
+	if len(a.Approvals) >= a.Gate.RequiredApprovals() {
+		a.Status = WorkflowNodeRunApprovalApproved
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeApprovalIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowNodeApproval{}.IsValid())
+	assert.NoError(t, WorkflowNodeApproval{MinApprovals: 2, Expiry: "1h30m", OnExpiry: WorkflowNodeApprovalExpirySkip}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{MinApprovals: -1}.IsValid())
+	assert.NoError(t, WorkflowNodeApproval{Users: []string{"alice", "bob"}, MinApprovals: 2}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{Users: []string{"alice", "bob"}, MinApprovals: 3}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{Users: []string{"alice", "alice"}, MinApprovals: 2}.IsValid())
+	assert.NoError(t, WorkflowNodeApproval{Users: []string{"alice"}, Groups: []string{"ops"}, MinApprovals: 3}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{Expiry: "tomorrow"}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{Expiry: "-1h"}.IsValid())
+	assert.Error(t, WorkflowNodeApproval{OnExpiry: "retry"}.IsValid())
+}
+
+func TestWorkflowNodeApprovalIsApprover(t *testing.T) {
+	alice := &User{Username: "alice", Groups: []Group{{Name: "ops"}}}
+	bob := &User{Username: "bob"}
+
+	assert.True(t, WorkflowNodeApproval{}.IsApprover(bob))
+	assert.True(t, WorkflowNodeApproval{Groups: []string{"ops"}}.IsApprover(alice))
+	assert.False(t, WorkflowNodeApproval{Groups: []string{"ops"}}.IsApprover(bob))
+	assert.True(t, WorkflowNodeApproval{Groups: []string{"ops"}, Users: []string{"bob"}}.IsApprover(bob))
+}
+
+func TestWorkflowNodeRunApprovalApprove(t *testing.T) {
+	start := time.Now()
+	a := NewWorkflowNodeRunApproval(WorkflowNodeApproval{Users: []string{"alice", "bob", "carol"}, MinApprovals: 2, Expiry: "1h"}, start)
+	assert.True(t, a.IsWaiting())
+	assert.Equal(t, start.Add(time.Hour), *a.ExpireAt)
+
+	now := start.Add(time.Minute)
+	assert.Equal(t, ErrWorkflowNodeRunApprovalForbidden, a.Approve(&User{Username: "dave"}, "", "", now))
+	assert.Equal(t, ErrWorkflowNodeRunApprovalForbidden, a.Approve(&User{Username: "carol"}, "carol", "", now))
+
+	assert.NoError(t, a.Approve(&User{Username: "alice"}, "carol", "lgtm", now))
+	assert.True(t, a.IsWaiting())
+	assert.Equal(t, ErrWorkflowNodeRunApprovalForbidden, a.Approve(&User{Username: "alice"}, "carol", "", now))
+
+	assert.NoError(t, a.Approve(&User{Username: "bob"}, "carol", "", now))
+	assert.Equal(t, WorkflowNodeRunApprovalApproved, a.Status)
+	assert.Len(t, a.Approvals, 2)
+	assert.Equal(t, "lgtm", a.Approvals[0].Comment)
+
+	assert.Equal(t, ErrWorkflowNodeRunApprovalNotPending, a.Approve(&User{Username: "carol"}, "", "", now))
+}
+
+func TestWorkflowNodeRunApprovalExpiry(t *testing.T) {
+	start := time.Now()
+	a := NewWorkflowNodeRunApproval(WorkflowNodeApproval{Expiry: "10m"}, start)
+	assert.False(t, a.IsExpired(start.Add(5*time.Minute)))
+	assert.True(t, a.IsExpired(start.Add(11*time.Minute)))
+	assert.Equal(t, ErrWorkflowNodeRunApprovalNotPending, a.Approve(&User{Username: "alice"}, "", "", start.Add(11*time.Minute)))
+	assert.Equal(t, StatusFail.String(), a.Gate.ExpiredStatus())
+
+	noExpiry := NewWorkflowNodeRunApproval(WorkflowNodeApproval{OnExpiry: WorkflowNodeApprovalExpirySkip}, start)
+	assert.Nil(t, noExpiry.ExpireAt)
+	assert.False(t, noExpiry.IsExpired(start.Add(24*time.Hour)))
+	assert.Equal(t, StatusSkipped.String(), noExpiry.Gate.ExpiredStatus())
+
+	var none *WorkflowNodeRunApproval
+	assert.False(t, none.IsWaiting())
+}
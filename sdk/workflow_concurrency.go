@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Policies of a concurrency group
+const (
+	ConcurrencyPolicyQueue          = "queue"
+	ConcurrencyPolicyCancelPrevious = "cancel-previous"
+)
+
+var concurrencyGroupPattern = regexp.MustCompile("^[a-zA-Z0-9._-]+$")
+
+// WorkflowConcurrency is a named concurrency group shared by the pipelines and the workflows of a project,
+// or of several projects if the group is a SharedConcurrencyGroup: only one run of the group can be building at a time. With the queue policy, the other runs wait for their turn;
+// with the cancel-previous policy, a new run stops the previous runs of the group which have the cancel-previous policy.
+type WorkflowConcurrency struct {
+	Group  string `json:"group" yaml:"group"`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty"`
+}
+
+// IsValid checks the group name and the policy
+func (c WorkflowConcurrency) IsValid() error {
+	if !concurrencyGroupPattern.MatchString(c.Group) {
+		return fmt.Errorf("invalid concurrency group %s", c.Group)
+	}
+	switch c.Policy {
+	case "", ConcurrencyPolicyQueue, ConcurrencyPolicyCancelPrevious:
+	default:
+		return fmt.Errorf("concurrency policy must be %s or %s", ConcurrencyPolicyQueue, ConcurrencyPolicyCancelPrevious)
+	}
+	return nil
+}
+
+// CancelPrevious returns true if a new run of the group stops the previous ones
+func (c WorkflowConcurrency) CancelPrevious() bool {
+	return c.Policy == ConcurrencyPolicyCancelPrevious
+}
+
+// WorkflowNodeRunConcurrency is the concurrency group of a node run. WorkflowLevel is true when the group
+// is set on the workflow: the group is then held by the whole workflow run, until it's over.
+// Shared is true when the group is a shared group that the project of the node run is allowed to use.
+// Blocked is true while the node run is held back, waiting for the group to be free.
+type WorkflowNodeRunConcurrency struct {
+	WorkflowConcurrency
+	WorkflowLevel bool `json:"workflow_level,omitempty"`
+	Shared        bool `json:"shared,omitempty"`
+	Blocked       bool `json:"blocked,omitempty"`
+}
+
+// NewWorkflowNodeRunConcurrency returns the concurrency group of a node run: the group of the pipeline if any,
+// else the group of the workflow
+func NewWorkflowNodeRunConcurrency(w *Workflow, n *WorkflowNode) *WorkflowNodeRunConcurrency {
+	if n != nil && n.Context != nil && n.Context.Concurrency != nil {
+		return &WorkflowNodeRunConcurrency{WorkflowConcurrency: *n.Context.Concurrency}
+	}
+	if w != nil && w.Concurrency != nil {
+		return &WorkflowNodeRunConcurrency{WorkflowConcurrency: *w.Concurrency, WorkflowLevel: true}
+	}
+	return nil
+}
+
+// SharedConcurrencyGroup is a concurrency group declared by an administrator to be shared across the projects.
+// It's owned by a group: the group must have the read-write-execute permission on a project to share the concurrency
+// group with the workflows of the project, and only the members of the group can add it to a workflow.
+type SharedConcurrencyGroup struct {
+	ID        int64  `json:"id" db:"id"`
+	Name      string `json:"name" db:"name"`
+	GroupID   int64  `json:"group_id" db:"group_id"`
+	GroupName string `json:"group_name" db:"group_name"`
+}
+
+// IsValid checks the name of the shared group
+func (g SharedConcurrencyGroup) IsValid() error {
+	if !concurrencyGroupPattern.MatchString(g.Name) {
+		return fmt.Errorf("invalid concurrency group %s", g.Name)
+	}
+	return nil
+}
+
+// ConcurrencyGroups returns the names of the concurrency groups used by the workflow and its pipelines
+func (w *Workflow) ConcurrencyGroups() []string {
+	var groups []string
+	add := func(c *WorkflowConcurrency) {
+		if c == nil {
+			return
+		}
+		for _, g := range groups {
+			if g == c.Group {
+				return
+			}
+		}
+		groups = append(groups, c.Group)
+	}
+	add(w.Concurrency)
+	for _, n := range w.Nodes(true) {
+		if n.Context != nil {
+			add(n.Context.Concurrency)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowConcurrencyIsValid(t *testing.T) {
+	assert.NoError(t, WorkflowConcurrency{Group: "deploy-prod-eu"}.IsValid())
+	assert.NoError(t, WorkflowConcurrency{Group: "deploy.prod_eu", Policy: ConcurrencyPolicyCancelPrevious}.IsValid())
+	assert.Error(t, WorkflowConcurrency{}.IsValid())
+	assert.Error(t, WorkflowConcurrency{Group: "deploy prod"}.IsValid())
+	assert.Error(t, WorkflowConcurrency{Group: "deploy", Policy: "drop"}.IsValid())
+}
+
+func TestNewWorkflowNodeRunConcurrency(t *testing.T) {
+	w := &Workflow{}
+	n := &WorkflowNode{Context: &WorkflowNodeContext{}}
+	assert.Nil(t, NewWorkflowNodeRunConcurrency(w, n))
+
+	w.Concurrency = &WorkflowConcurrency{Group: "deploy-prod-eu", Policy: ConcurrencyPolicyCancelPrevious}
+	c := NewWorkflowNodeRunConcurrency(w, n)
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "deploy-prod-eu", c.Group)
+		assert.True(t, c.CancelPrevious())
+		assert.True(t, c.WorkflowLevel)
+	}
+
+	n.Context.Concurrency = &WorkflowConcurrency{Group: "deploy-prod-us"}
+	c = NewWorkflowNodeRunConcurrency(w, n)
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "deploy-prod-us", c.Group)
+		assert.False(t, c.CancelPrevious())
+		assert.False(t, c.WorkflowLevel)
+	}
+}
+
+func TestWorkflowConcurrencyGroups(t *testing.T) {
+	w := &Workflow{
+		Concurrency: &WorkflowConcurrency{Group: "deploy-prod-eu"},
+		Root: &WorkflowNode{
+			Context: &WorkflowNodeContext{},
+			Triggers: []WorkflowNodeTrigger{
+				{WorkflowDestNode: WorkflowNode{Context: &WorkflowNodeContext{Concurrency: &WorkflowConcurrency{Group: "deploy-prod-us"}}}},
+				{WorkflowDestNode: WorkflowNode{Context: &WorkflowNodeContext{Concurrency: &WorkflowConcurrency{Group: "deploy-prod-eu"}}}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"deploy-prod-eu", "deploy-prod-us"}, w.ConcurrencyGroups())
+	assert.NoError(t, SharedConcurrencyGroup{Name: "deploy-prod-eu"}.IsValid())
+	assert.Error(t, SharedConcurrencyGroup{Name: "deploy prod"}.IsValid())
+}
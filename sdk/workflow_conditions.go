@@ -34,13 +34,9 @@ var (
 
 //WorkflowCheckConditions checks conditions given a list of parameters
 func WorkflowCheckConditions(conditions []WorkflowNodeCondition, params []Parameter) (bool, error) {
-	mapParams := ParametersToMap(params)
-	for k, v := range mapParams {
-		var err error
-		mapParams[k], err = interpolate.Do(v, mapParams)
-		if err != nil {
-			return false, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
-		}
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
 	}
 
 	var conditionsOK = true
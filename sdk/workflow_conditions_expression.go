@@ -0,0 +1,506 @@
+package sdk
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/blang/semver"
+
+	"github.com/ovh/cds/sdk/interpolate"
+)
+
+// Workflow conditions expressions are boolean expressions on the run variables, ie:
+//
+//   git.branch == "master" || git.branch matches "release/*"
+//   !(cds.dest.environment in ["prod", "preprod"]) && semver(git.tag) >= "1.2.0"
+//
+// Operands are variable names or quoted strings, strings are interpolated.
+// Operators are ==, !=, <, <=, >, >=, =~ (regex), matches (glob), in and not in (lists).
+// Comparisons are done on strings, or on versions if an operand is wrapped in semver().
+// <, <=, > and >= compare numbers if both operands are numbers.
+// Expressions are combined with && (and), || (or), ! (not) and parentheses.
+// A variable alone is true if its value is "true".
+
+// WorkflowCheckConditionsExpression evaluates a conditions expression given a list of parameters
+func WorkflowCheckConditionsExpression(expression string, params []Parameter) (bool, error) {
+	expr, err := parseConditionsExpression(expression)
+	if err != nil {
+		return false, err
+	}
+	mapParams, err := interpolateConditionsParameters(params)
+	if err != nil {
+		return false, err
+	}
+	return expr.eval(mapParams)
+}
+
+// WorkflowConditionsExpressionIsValid checks the syntax of a conditions expression
+func WorkflowConditionsExpressionIsValid(expression string) error {
+	if _, err := parseConditionsExpression(expression); err != nil {
+		return NewError(ErrWorkflowConditionBadExpression, err)
+	}
+	return nil
+}
+
+// WorkflowNodeConditionsIsValid checks the expression of run conditions. An expression can't be used
+// with a lua script, which would ignore it
+func WorkflowNodeConditionsIsValid(c WorkflowNodeConditions) error {
+	if c.Expression == "" {
+		return nil
+	}
+	if c.LuaScript != "" {
+		return NewError(ErrWorkflowConditionBadExpression, fmt.Errorf("A conditions expression can't be used with a lua script"))
+	}
+	return WorkflowConditionsExpressionIsValid(c.Expression)
+}
+
+func interpolateConditionsParameters(params []Parameter) (map[string]string, error) {
+	mapParams := ParametersToMap(params)
+	for k, v := range mapParams {
+		var err error
+		mapParams[k], err = interpolate.Do(v, mapParams)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to interpolate %s (%v)", v, err)
+		}
+	}
+	return mapParams, nil
+}
+
+type conditionExpr interface {
+	eval(vars map[string]string) (bool, error)
+}
+
+type conditionOr struct{ left, right conditionExpr }
+
+func (c conditionOr) eval(vars map[string]string) (bool, error) {
+	ok, err := c.left.eval(vars)
+	if err != nil || ok {
+		return ok, err
+	}
+	return c.right.eval(vars)
+}
+
+type conditionAnd struct{ left, right conditionExpr }
+
+func (c conditionAnd) eval(vars map[string]string) (bool, error) {
+	ok, err := c.left.eval(vars)
+	if err != nil || !ok {
+		return ok, err
+	}
+	return c.right.eval(vars)
+}
+
+type conditionNot struct{ expr conditionExpr }
+
+func (c conditionNot) eval(vars map[string]string) (bool, error) {
+	ok, err := c.expr.eval(vars)
+	return !ok, err
+}
+
+type conditionOperand struct {
+	variable string
+	literal  string
+	semver   bool
+}
+
+func (o conditionOperand) value(vars map[string]string) (string, error) {
+	if o.variable != "" {
+		return vars[o.variable], nil
+	}
+	v, err := interpolate.Do(o.literal, vars)
+	if err != nil {
+		return "", fmt.Errorf("Unable to interpolate %s (%v)", o.literal, err)
+	}
+	return v, nil
+}
+
+type conditionBool struct{ operand conditionOperand }
+
+func (c conditionBool) eval(vars map[string]string) (bool, error) {
+	v, err := c.operand.value(vars)
+	return v == "true", err
+}
+
+type conditionCompare struct {
+	operator    string
+	left, right conditionOperand
+}
+
+func (c conditionCompare) eval(vars map[string]string) (bool, error) {
+	left, err := c.left.value(vars)
+	if err != nil {
+		return false, err
+	}
+	right, err := c.right.value(vars)
+	if err != nil {
+		return false, err
+	}
+
+	switch c.operator {
+	case "=~":
+		match, err := regexp.MatchString(right, left)
+		if err != nil {
+			return false, fmt.Errorf("Unable to match string with regex %s (%v)", right, err)
+		}
+		return match, nil
+	case "matches":
+		match, err := path.Match(right, left)
+		if err != nil {
+			return false, fmt.Errorf("Unable to match string with pattern %s (%v)", right, err)
+		}
+		return match, nil
+	}
+
+	var cmp int
+	if c.left.semver || c.right.semver {
+		// A value which is not a version can't satisfy a version comparison
+		vLeft, errL := semver.ParseTolerant(left)
+		vRight, errR := semver.ParseTolerant(right)
+		if errL != nil || errR != nil {
+			return false, nil
+		}
+		cmp = vLeft.Compare(vRight)
+	} else if fLeft, fRight, ok := conditionNumbers(left, right); ok && c.operator != "==" && c.operator != "!=" {
+		switch {
+		case fLeft < fRight:
+			cmp = -1
+		case fLeft > fRight:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(left, right)
+	}
+
+	switch c.operator {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", c.operator)
+}
+
+// conditionNumbers parses both operands of a comparison as numbers
+func conditionNumbers(left, right string) (float64, float64, bool) {
+	fLeft, errL := strconv.ParseFloat(strings.TrimSpace(left), 64)
+	fRight, errR := strconv.ParseFloat(strings.TrimSpace(right), 64)
+	if errL != nil || errR != nil || math.IsNaN(fLeft) || math.IsNaN(fRight) {
+		return 0, 0, false
+	}
+	return fLeft, fRight, true
+}
+
+type conditionIn struct {
+	not     bool
+	operand conditionOperand
+	list    []conditionOperand
+}
+
+func (c conditionIn) eval(vars map[string]string) (bool, error) {
+	v, err := c.operand.value(vars)
+	if err != nil {
+		return false, err
+	}
+	for _, o := range c.list {
+		item, err := o.value(vars)
+		if err != nil {
+			return false, err
+		}
+		if item == v {
+			return !c.not, nil
+		}
+	}
+	return c.not, nil
+}
+
+// Tokens of a conditions expression
+const (
+	conditionTokenEOF = iota
+	conditionTokenIdent
+	conditionTokenString
+	conditionTokenOperator
+)
+
+type conditionToken struct {
+	kind  int
+	value string
+	pos   int
+}
+
+var conditionOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "<", ">", "!", "(", ")", "[", "]", ","}
+
+func lexConditionsExpression(s string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	i := 0
+next:
+	for i < len(s) {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '"' || c == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(s); i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+					b.WriteByte(s[i])
+					continue
+				}
+				if rune(s[i]) == c {
+					i++
+					tokens = append(tokens, conditionToken{kind: conditionTokenString, value: b.String(), pos: start})
+					continue next
+				}
+				b.WriteByte(s[i])
+			}
+			return nil, fmt.Errorf("unterminated string at position %d", start)
+		case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			start := i
+			for i < len(s) && (s[i] == '_' || s[i] == '.' || s[i] == '-' || s[i] == '+' || unicode.IsLetter(rune(s[i])) || unicode.IsDigit(rune(s[i]))) {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: conditionTokenIdent, value: s[start:i], pos: start})
+			continue
+		}
+		for _, op := range conditionOperators {
+			if strings.HasPrefix(s[i:], op) {
+				tokens = append(tokens, conditionToken{kind: conditionTokenOperator, value: op, pos: i})
+				i += len(op)
+				continue next
+			}
+		}
+		return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+	}
+	return append(tokens, conditionToken{kind: conditionTokenEOF, pos: len(s)}), nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func parseConditionsExpression(s string) (conditionExpr, error) {
+	tokens, err := lexConditionsExpression(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &conditionParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != conditionTokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+	}
+	return expr, nil
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	t := p.tokens[p.pos]
+	if t.kind != conditionTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators or keywords
+func (p *conditionParser) accept(values ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != conditionTokenOperator && t.kind != conditionTokenIdent {
+		return "", false
+	}
+	for _, v := range values {
+		if t.value == v {
+			p.pos++
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (p *conditionParser) expect(value string) error {
+	if _, ok := p.accept(value); !ok {
+		t := p.peek()
+		return fmt.Errorf("expected %q at position %d", value, t.pos)
+	}
+	return nil
+}
+
+func (p *conditionParser) parseOr() (conditionExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = conditionOr{left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseAnd() (conditionExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = conditionAnd{left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseUnary() (conditionExpr, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return conditionNot{expr: expr}, nil
+	}
+	if _, ok := p.accept("("); ok {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if op, ok := p.accept("==", "!=", "<=", ">=", "<", ">", "=~", "matches"); ok {
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if (op == "=~" || op == "matches") && (left.semver || right.semver) {
+			return nil, fmt.Errorf("operator %s can't be used with semver", op)
+		}
+		if op == "=~" && right.variable == "" {
+			if _, err := regexp.Compile(right.literal); err != nil {
+				return nil, fmt.Errorf("invalid regex %s: %v", right.literal, err)
+			}
+		}
+		if op == "matches" && right.variable == "" {
+			if _, err := path.Match(right.literal, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %v", right.literal, err)
+			}
+		}
+		return conditionCompare{operator: op, left: left, right: right}, nil
+	}
+
+	var not bool
+	if t := p.peek(); t.kind == conditionTokenIdent && t.value == "not" && p.tokens[p.pos+1].value == "in" {
+		p.next()
+		not = true
+	}
+	if _, ok := p.accept("in"); ok {
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return conditionIn{not: not, operand: left, list: list}, nil
+	}
+
+	return conditionBool{operand: left}, nil
+}
+
+func (p *conditionParser) parseList() ([]conditionOperand, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	var list []conditionOperand
+	if _, ok := p.accept("]"); ok {
+		return list, nil
+	}
+	for {
+		o, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, o)
+		if _, ok := p.accept(","); ok {
+			continue
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		return list, nil
+	}
+}
+
+func (p *conditionParser) parseOperand() (conditionOperand, error) {
+	t := p.next()
+	switch t.kind {
+	case conditionTokenString:
+		return conditionOperand{literal: t.value}, nil
+	case conditionTokenIdent:
+		if t.value == "semver" && p.peek().value == "(" {
+			p.next()
+			o, err := p.parseOperand()
+			if err != nil {
+				return o, err
+			}
+			if err := p.expect(")"); err != nil {
+				return o, err
+			}
+			if o.variable == "" && !strings.Contains(o.literal, "{{") {
+				if _, err := semver.ParseTolerant(o.literal); err != nil {
+					return o, fmt.Errorf("invalid version %s: %v", o.literal, err)
+				}
+			}
+			o.semver = true
+			return o, nil
+		}
+		switch t.value {
+		case "and", "or", "not", "in", "matches":
+			return conditionOperand{}, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+		}
+		// Numbers and booleans are literals, other identifiers are variables
+		if unicode.IsDigit(rune(t.value[0])) || t.value == "true" || t.value == "false" {
+			return conditionOperand{literal: t.value}, nil
+		}
+		return conditionOperand{variable: t.value}, nil
+	case conditionTokenEOF:
+		return conditionOperand{}, fmt.Errorf("unexpected end of expression")
+	}
+	return conditionOperand{}, fmt.Errorf("unexpected %q at position %d", t.value, t.pos)
+}
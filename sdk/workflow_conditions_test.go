@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowCheckConditionsExpression(t *testing.T) {
+	params := []Parameter{
+		{Name: "git.branch", Type: StringParameter, Value: "release/1.2"},
+		{Name: "git.tag", Type: StringParameter, Value: "v1.2.3"},
+		{Name: "cds.manual", Type: StringParameter, Value: "true"},
+		{Name: "cds.dest.environment", Type: StringParameter, Value: "prod"},
+		{Name: "cds.env.branch", Type: StringParameter, Value: "{{.git.branch}}"},
+		{Name: "cds.version", Type: StringParameter, Value: "9"},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`git.branch == "master" || git.branch matches "release/*"`, true},
+		{`git.branch == "master" or git.branch matches "feat/*"`, false},
+		{`!(git.branch == "master")`, true},
+		{`not git.branch == "release/1.2"`, false},
+		{`cds.dest.environment in ["prod", "preprod"]`, true},
+		{`cds.dest.environment not in ["prod", "preprod"]`, false},
+		{`cds.dest.environment in []`, false},
+		{`semver(git.tag) >= "1.2.0" && semver(git.tag) < "2.0.0"`, true},
+		{`semver(git.tag) > "1.10.0"`, false},
+		{`git.tag > "v1.10.0"`, true},
+		{`semver(git.branch) > "1.0.0"`, false},
+		{`git.branch =~ "^release/[0-9.]+$"`, true},
+		{`cds.manual && cds.dest.environment == 'prod'`, true},
+		{`cds.env.branch == git.branch`, true},
+		{`unknown.variable == ""`, true},
+		{`git.branch == "{{.cds.env.branch}}"`, true},
+		{`cds.version < "10"`, true},
+		{`cds.version >= "10.5"`, false},
+		{`cds.version > "-1"`, true},
+		{`cds.version == "9.0"`, false},
+		{`cds.version < "abc"`, true},
+		{`(git.branch == "master" || cds.manual) && !(cds.dest.environment in ["dev"])`, true},
+	}
+	for _, tt := range tests {
+		got, err := WorkflowCheckConditionsExpression(tt.expression, params)
+		assert.NoError(t, err, tt.expression)
+		assert.Equal(t, tt.want, got, tt.expression)
+	}
+}
+
+func TestWorkflowConditionsExpressionIsValid(t *testing.T) {
+	for _, e := range []string{
+		`git.branch ==`,
+		`git.branch == "master`,
+		`(git.branch == "master"`,
+		`git.branch in ["a" "b"]`,
+		`git.branch =~ "("`,
+		`semver(git.tag) > semver("not a version")`,
+		`semver(git.tag) matches "1.*"`,
+		`git.branch == "master" git.hash`,
+		`git.branch ; "master"`,
+	} {
+		assert.Error(t, WorkflowConditionsExpressionIsValid(e), e)
+	}
+	assert.NoError(t, WorkflowConditionsExpressionIsValid(`git.branch == "master" || git.branch matches "release/*"`))
+
+	assert.Error(t, WorkflowNodeConditionsIsValid(WorkflowNodeConditions{Expression: `git.branch == "master"`, LuaScript: `return true`}))
+	assert.Error(t, WorkflowNodeConditionsIsValid(WorkflowNodeConditions{Expression: `git.branch ==`}))
+	assert.NoError(t, WorkflowNodeConditionsIsValid(WorkflowNodeConditions{LuaScript: `return true`}))
+	assert.NoError(t, WorkflowNodeConditionsIsValid(WorkflowNodeConditions{Expression: `git.branch == "master"`}))
+}
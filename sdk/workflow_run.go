@@ -70,6 +70,7 @@ type WorkflowRunPostHandlerOption struct {
 	Manual      *WorkflowNodeRunManual    `json:"manual,omitempty"`
 	Number      *int64                    `json:"number,omitempty"`
 	FromNodeIDs []int64                   `json:"from_nodes,omitempty"`
+	Replay      *WorkflowRunReplay        `json:"replay,omitempty"`
 }
 
 //WorkflowRunNumber contains a workflow run number
@@ -176,6 +177,8 @@ type WorkflowNodeRun struct {
 	VCSServer             string                             `json:"vcs_server"`
 	CanBeRun              bool                               `json:"can_be_run"`
 	Header                WorkflowRunHeaders                 `json:"header,omitempty"`
+	Approval              *WorkflowNodeRunApproval           `json:"approval,omitempty"`
+	Concurrency           *WorkflowNodeRunConcurrency        `json:"concurrency,omitempty"`
 }
 
 // WorkflowNodeRunVulnerabilityReport represents vulnerabilities report for the current node run
@@ -246,6 +249,7 @@ type WorkflowNodeRunArtifact struct {
 	MD5sum            string    `json:"md5sum,omitempty" db:"md5sum" cli:"-"`
 	SHA512sum         string    `json:"sha512sum,omitempty" db:"sha512sum" cli:"sha512sum"`
 	ObjectPath        string    `json:"object_path,omitempty" db:"object_path"`
+	BlobKey           string    `json:"blob_key,omitempty" db:"blob_key" cli:"-"`
 	Created           time.Time `json:"created,omitempty" db:"created"`
 	TempURL           string    `json:"temp_url,omitempty" db:"-"`
 	TempURLSecretKey  string    `json:"-" db:"-"`
@@ -354,11 +358,17 @@ type WorkflowNodeRunManual struct {
 
 //GetName returns the name the artifact
 func (w *WorkflowNodeRunArtifact) GetName() string {
+	if w.BlobKey != "" {
+		return w.BlobKey
+	}
 	return w.Name
 }
 
-//GetPath returns the path of the artifact
+//GetPath returns the path of the artifact, artifacts stored as a blob share the same container
 func (w *WorkflowNodeRunArtifact) GetPath() string {
+	if w.BlobKey != "" {
+		return ArtifactBlobContainer
+	}
 	ref := w.Ref
 	if ref == "" {
 		ref = w.Tag
@@ -408,19 +418,47 @@ func (nr WorkflowNodeRun) Report() (string, error) {
 
 type WorkflowQueue []WorkflowNodeJobRun
 
+// Sort sorts the queue with a fair share between the projects, see SortFairShare
 func (q WorkflowQueue) Sort() {
-	//Count the number of WorkflowNodeJobRun per project_id
-	n := make(map[int64]int, len(q))
+	q.SortFairShare(nil)
+}
+
+// SortFairShare sorts the queue in the order in which the workers of the jobs should be started.
+// The jobs of a project are ranked by priority then by waiting time, and the projects take turns: the first
+// job of each project comes first, then the second one, and so on. The workers being started for a project
+// (running, indexed by project id) delay its turn, so a project flooding the queue cannot starve the others.
+// Within a turn, the job with the highest priority comes first, then the job of the project with the fewest
+// waiting jobs, then the oldest one.
+func (q WorkflowQueue) SortFairShare(running map[int64]int) {
+	before := func(a, b WorkflowNodeJobRun) bool {
+		if a.Job.Priority != b.Job.Priority {
+			return a.Job.Priority > b.Job.Priority
+		}
+		return a.Queued.Before(b.Queued)
+	}
+	sort.SliceStable(q, func(i, j int) bool {
+		return before(q[i], q[j])
+	})
+
+	turns := make(map[int64]int, len(q))
+	nbJobs := make(map[int64]int, len(q))
 	for _, j := range q {
-		nb := n[j.ProjectID]
-		nb++
-		n[j.ProjectID] = nb
+		turns[j.ID] = running[j.ProjectID] + nbJobs[j.ProjectID]
+		nbJobs[j.ProjectID]++
 	}
 
-	sort.Slice(q, func(i, j int) bool {
-		p1 := n[q[i].ProjectID]
-		p2 := n[q[j].ProjectID]
-		return p1 < p2
+	sort.SliceStable(q, func(i, j int) bool {
+		t1, t2 := turns[q[i].ID], turns[q[j].ID]
+		if t1 != t2 {
+			return t1 < t2
+		}
+		if q[i].Job.Priority != q[j].Job.Priority {
+			return q[i].Job.Priority > q[j].Job.Priority
+		}
+		n1, n2 := nbJobs[q[i].ProjectID], nbJobs[q[j].ProjectID]
+		if n1 != n2 {
+			return n1 < n2
+		}
+		return q[i].Queued.Before(q[j].Queued)
 	})
-
 }
@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WorkflowRunDiffIgnoredParameters are the parameters which change on every run, they are not compared
+var WorkflowRunDiffIgnoredParameters = []string{"cds.version", "cds.run", "cds.run.number", "cds.run.subnumber"}
+
+// WorkflowRunDiff is the difference between two runs of a workflow
+type WorkflowRunDiff struct {
+	From       int64                  `json:"from"`
+	To         int64                  `json:"to"`
+	Parameters []WorkflowRunDiffValue `json:"parameters"`
+	Commits    []WorkflowRunDiffValue `json:"commits"`
+	Workflow   []WorkflowRunDiffValue `json:"workflow"`
+	Nodes      []WorkflowRunDiffNode  `json:"nodes"`
+}
+
+// WorkflowRunDiffValue is a value which differs between two runs
+type WorkflowRunDiffValue struct {
+	Name string `json:"name" cli:"name"`
+	From string `json:"from" cli:"from"`
+	To   string `json:"to" cli:"to"`
+}
+
+// WorkflowRunDiffNode compares the status and the duration of the last run of a node in two runs
+type WorkflowRunDiffNode struct {
+	Name         string        `json:"name"`
+	FromStatus   string        `json:"from_status"`
+	ToStatus     string        `json:"to_status"`
+	FromDuration time.Duration `json:"from_duration"`
+	ToDuration   time.Duration `json:"to_duration"`
+}
+
+// NewWorkflowRunDiff compares two runs of a workflow: the build parameters of the root node, the commits
+// and the definition of each node, and the status and the duration of each node
+func NewWorkflowRunDiff(from, to *WorkflowRun) WorkflowRunDiff {
+	d := WorkflowRunDiff{From: from.Number, To: to.Number}
+
+	fromRoot, toRoot := from.RootNodeRun(), to.RootNodeRun()
+	var fromParams, toParams map[string]string
+	if fromRoot != nil {
+		fromParams = ParametersToMap(fromRoot.BuildParameters)
+	}
+	if toRoot != nil {
+		toParams = ParametersToMap(toRoot.BuildParameters)
+	}
+	for _, k := range WorkflowRunDiffIgnoredParameters {
+		delete(fromParams, k)
+		delete(toParams, k)
+	}
+	d.Parameters = diffValues(fromParams, toParams)
+
+	fromNodeRuns, toNodeRuns := from.lastNodeRunsByName(), to.lastNodeRunsByName()
+	d.Commits = diffValues(nodeRunsCommits(fromNodeRuns), nodeRunsCommits(toNodeRuns))
+	d.Workflow = diffValues(workflowDefinition(&from.Workflow), workflowDefinition(&to.Workflow))
+
+	names := make(map[string]struct{}, len(fromNodeRuns)+len(toNodeRuns))
+	for n := range fromNodeRuns {
+		names[n] = struct{}{}
+	}
+	for n := range toNodeRuns {
+		names[n] = struct{}{}
+	}
+	for n := range names {
+		node := WorkflowRunDiffNode{Name: n}
+		if nr, ok := fromNodeRuns[n]; ok {
+			node.FromStatus, node.FromDuration = nr.Status, nr.duration()
+		}
+		if nr, ok := toNodeRuns[n]; ok {
+			node.ToStatus, node.ToDuration = nr.Status, nr.duration()
+		}
+		d.Nodes = append(d.Nodes, node)
+	}
+	sort.Slice(d.Nodes, func(i, j int) bool { return d.Nodes[i].Name < d.Nodes[j].Name })
+
+	return d
+}
+
+// RootNodeRun returns the first run of the root node of the workflow run
+func (r *WorkflowRun) RootNodeRun() *WorkflowNodeRun {
+	var root *WorkflowNodeRun
+	nodeRuns := r.WorkflowNodeRuns[r.Workflow.RootID]
+	for i := range nodeRuns {
+		if root == nil || nodeRuns[i].SubNumber < root.SubNumber {
+			root = &nodeRuns[i]
+		}
+	}
+	return root
+}
+
+func (r *WorkflowRun) lastNodeRunsByName() map[string]WorkflowNodeRun {
+	res := make(map[string]WorkflowNodeRun, len(r.WorkflowNodeRuns))
+	for _, nodeRuns := range r.WorkflowNodeRuns {
+		for _, nr := range nodeRuns {
+			if last, ok := res[nr.WorkflowNodeName]; !ok || nr.SubNumber > last.SubNumber {
+				res[nr.WorkflowNodeName] = nr
+			}
+		}
+	}
+	return res
+}
+
+func (nr *WorkflowNodeRun) duration() time.Duration {
+	if nr.Start.IsZero() || nr.Done.IsZero() {
+		return 0
+	}
+	return nr.Done.Sub(nr.Start)
+}
+
+func nodeRunsCommits(nodeRuns map[string]WorkflowNodeRun) map[string]string {
+	res := make(map[string]string, len(nodeRuns)*4)
+	for n, nr := range nodeRuns {
+		res[n+".repository"] = nr.VCSRepository
+		res[n+".branch"] = nr.VCSBranch
+		res[n+".tag"] = nr.VCSTag
+		res[n+".hash"] = nr.VCSHash
+	}
+	return res
+}
+
+func workflowDefinition(w *Workflow) map[string]string {
+	res := map[string]string{}
+	for _, n := range w.Nodes(true) {
+		pip := n.PipelineName
+		if p, ok := w.Pipelines[n.PipelineID]; ok {
+			pip = p.Name
+		}
+		res[n.Name+".pipeline"] = pip
+		if app, ok := n.Application(); ok {
+			res[n.Name+".application"] = app.Name
+		}
+		if env, ok := n.Environment(); ok {
+			res[n.Name+".environment"] = env.Name
+		}
+		if pf, ok := n.ProjectPlatform(); ok {
+			res[n.Name+".platform"] = pf.Name
+		}
+		triggers := make([]string, 0, len(n.Triggers))
+		for _, t := range n.Triggers {
+			triggers = append(triggers, t.WorkflowDestNode.Name)
+		}
+		sort.Strings(triggers)
+		res[n.Name+".triggers"] = strings.Join(triggers, ",")
+		if n.Context != nil {
+			if b, err := json.Marshal(n.Context.Conditions); err == nil {
+				res[n.Name+".conditions"] = string(b)
+			}
+		}
+	}
+	return res
+}
+
+func diffValues(from, to map[string]string) []WorkflowRunDiffValue {
+	res := []WorkflowRunDiffValue{}
+	for k, v := range from {
+		if v != to[k] {
+			res = append(res, WorkflowRunDiffValue{Name: k, From: v, To: to[k]})
+		}
+	}
+	for k, v := range to {
+		if _, ok := from[k]; !ok && v != "" {
+			res = append(res, WorkflowRunDiffValue{Name: k, To: v})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkflowRunDiff(t *testing.T) {
+	start := time.Now()
+	newRun := func(number int64, branch, hash, status string, duration time.Duration, test string) *WorkflowRun {
+		build := WorkflowNode{ID: 2, Name: "build", PipelineName: "build"}
+		if test != "" {
+			build.Triggers = []WorkflowNodeTrigger{{WorkflowDestNode: WorkflowNode{ID: 3, Name: test, PipelineName: test}}}
+		}
+		run := &WorkflowRun{
+			Number: number,
+			Workflow: Workflow{
+				RootID: 1,
+				Root: &WorkflowNode{
+					ID:           1,
+					Name:         "root",
+					PipelineName: "checkout",
+					Triggers:     []WorkflowNodeTrigger{{WorkflowDestNode: build}},
+				},
+			},
+			WorkflowNodeRuns: map[int64][]WorkflowNodeRun{
+				1: {{
+					WorkflowNodeID:   1,
+					WorkflowNodeName: "root",
+					Status:           StatusSuccess.String(),
+					VCSBranch:        branch,
+					VCSHash:          hash,
+					Start:            start,
+					Done:             start.Add(time.Minute),
+					BuildParameters: []Parameter{
+						{Name: "git.branch", Value: branch},
+						{Name: "cds.version", Value: fmt.Sprint(number)},
+					},
+				}},
+				2: {
+					{WorkflowNodeID: 2, WorkflowNodeName: "build", SubNumber: 0, Status: StatusFail.String()},
+					{WorkflowNodeID: 2, WorkflowNodeName: "build", SubNumber: 1, Status: status, Start: start, Done: start.Add(duration)},
+				},
+			},
+		}
+		return run
+	}
+
+	from := newRun(1, "master", "abc", StatusSuccess.String(), time.Minute, "")
+	to := newRun(2, "feat/a", "def", StatusFail.String(), 2*time.Minute, "test")
+
+	d := NewWorkflowRunDiff(from, to)
+	assert.Equal(t, int64(1), d.From)
+	assert.Equal(t, int64(2), d.To)
+	assert.Equal(t, []WorkflowRunDiffValue{{Name: "git.branch", From: "master", To: "feat/a"}}, d.Parameters)
+	assert.Equal(t, []WorkflowRunDiffValue{
+		{Name: "root.branch", From: "master", To: "feat/a"},
+		{Name: "root.hash", From: "abc", To: "def"},
+	}, d.Commits)
+	assert.Equal(t, []WorkflowRunDiffValue{
+		{Name: "build.triggers", From: "", To: "test"},
+		{Name: "test.pipeline", To: "test"},
+	}, d.Workflow)
+	assert.Equal(t, []WorkflowRunDiffNode{
+		{Name: "build", FromStatus: StatusSuccess.String(), ToStatus: StatusFail.String(), FromDuration: time.Minute, ToDuration: 2 * time.Minute},
+		{Name: "root", FromStatus: StatusSuccess.String(), ToStatus: StatusSuccess.String(), FromDuration: time.Minute, ToDuration: time.Minute},
+	}, d.Nodes)
+}
+
+func TestNewWorkflowNodeRunManualFromReplay(t *testing.T) {
+	nodeRun := WorkflowNodeRun{
+		PipelineParameters: []Parameter{
+			{Name: "region", Type: StringParameter, Value: "{{.git.branch}}"},
+			{Name: "dry-run", Type: BooleanParameter, Value: "false"},
+		},
+		BuildParameters: []Parameter{
+			{Name: "cds.pip.region", Value: "eu"},
+			{Name: "cds.pip.dry-run", Value: "false"},
+			{Name: "cds.version", Value: "12"},
+			{Name: "workflow.root.status", Value: "Success"},
+			{Name: "git.branch", Value: "master"},
+			{Name: "git.hash", Value: "abc"},
+			{Name: "git.pr.fork", Value: "false"},
+			{Name: "proj.password", Value: "secret"},
+			{Name: "app.name", Value: "api"},
+			{Name: "env.region", Value: "eu"},
+			{Name: "param1", Value: "foo"},
+		},
+		Payload: map[string]interface{}{"param1": "foo", "git.branch": "master"},
+	}
+
+	m, err := NewWorkflowNodeRunManualFromReplay(nodeRun, []Parameter{
+		{Name: "dry-run", Value: "true"},
+		{Name: "git.branch", Value: "release"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Parameter{
+		{Name: "region", Type: StringParameter, Value: "eu"},
+		{Name: "dry-run", Type: BooleanParameter, Value: "true"},
+	}, m.PipelineParameters)
+	assert.Equal(t, map[string]string{"git.branch": "release", "git.hash": "abc", "param1": "foo"}, m.Payload)
+}
+
+func TestNewWorkflowNodeRunManualFromReplayWithNestedPayload(t *testing.T) {
+	nodeRun := WorkflowNodeRun{
+		BuildParameters: []Parameter{
+			{Name: "git.branch", Value: "master"},
+			{Name: "git.hash", Value: "abc"},
+		},
+		Payload: map[string]interface{}{
+			"deploy": map[string]interface{}{
+				"Region": "eu",
+				"hosts":  []interface{}{"a", "b"},
+			},
+			"git.branch": "master",
+		},
+	}
+
+	m, err := NewWorkflowNodeRunManualFromReplay(nodeRun, []Parameter{{Name: "deploy.region", Value: "us"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"deploy.region":       "us",
+		"deploy.hosts.hosts0": "a",
+		"deploy.hosts.hosts1": "b",
+		"git.branch":          "master",
+		"git.hash":            "abc",
+	}, m.Payload)
+}
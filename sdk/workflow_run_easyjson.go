@@ -1892,6 +1892,30 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 			out.WorkerName = string(in.String())
 		case "worker_id":
 			out.WorkerID = string(in.String())
+		case "matrix_cell":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.MatrixCell = make(JobMatrixCell)
+				} else {
+					out.MatrixCell = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v52 string
+					v52 = string(in.String())
+					(out.MatrixCell)[key] = v52
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "attempt":
+			out.Attempt = int(in.Int())
+		case "retried_by":
+			out.RetriedBy = int64(in.Int64())
 		case "pipeline_action_id":
 			out.PipelineActionID = int64(in.Int64())
 		case "pipeline_stage_id":
@@ -1918,13 +1942,68 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk1(in *jlexer.Lexer, out *ExecutedJo
 					out.Warnings = (out.Warnings)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v52 PipelineBuildWarning
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk13(in, &v52)
-					out.Warnings = append(out.Warnings, v52)
+					var v53 PipelineBuildWarning
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk13(in, &v53)
+					out.Warnings = append(out.Warnings, v53)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "matrix":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Matrix = make(JobMatrix)
+				} else {
+					out.Matrix = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v54 []string
+					if in.IsNull() {
+						in.Skip()
+						v54 = nil
+					} else {
+						in.Delim('[')
+						if v54 == nil {
+							if !in.IsDelim(']') {
+								v54 = make([]string, 0, 4)
+							} else {
+								v54 = []string{}
+							}
+						} else {
+							v54 = (v54)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v55 string
+							v55 = string(in.String())
+							v54 = append(v54, v55)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					(out.Matrix)[key] = v54
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "retry_policy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetryPolicy = nil
+			} else {
+				if out.RetryPolicy == nil {
+					out.RetryPolicy = new(RetryPolicy)
+				}
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in, &*out.RetryPolicy)
+			}
+		case "priority":
+			out.Priority = int(in.Int())
+		case "timeout":
+			out.Timeout = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1951,11 +2030,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJ
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v53, v54 := range in.StepStatus {
-				if v53 > 0 {
+			for v56, v57 := range in.StepStatus {
+				if v56 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk11(out, v54)
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk11(out, v57)
 			}
 			out.RawByte(']')
 		}
@@ -1990,6 +2069,50 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJ
 		}
 		out.String(string(in.WorkerID))
 	}
+	if len(in.MatrixCell) != 0 {
+		const prefix string = ",\"matrix_cell\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v58First := true
+			for v58Name, v58Value := range in.MatrixCell {
+				if v58First {
+					v58First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v58Name))
+				out.RawByte(':')
+				out.String(string(v58Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.Attempt != 0 {
+		const prefix string = ",\"attempt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Attempt))
+	}
+	if in.RetriedBy != 0 {
+		const prefix string = ",\"retried_by\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.RetriedBy))
+	}
 	{
 		const prefix string = ",\"pipeline_action_id\":"
 		if first {
@@ -2052,15 +2175,193 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk1(out *jwriter.Writer, in ExecutedJ
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v55, v56 := range in.Warnings {
-				if v55 > 0 {
+			for v59, v60 := range in.Warnings {
+				if v59 > 0 {
+					out.RawByte(',')
+				}
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk13(out, v60)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Matrix) != 0 {
+		const prefix string = ",\"matrix\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v61First := true
+			for v61Name, v61Value := range in.Matrix {
+				if v61First {
+					v61First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v61Name))
+				out.RawByte(':')
+				if v61Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v62, v63 := range v61Value {
+						if v62 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v63))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.RetryPolicy != nil {
+		const prefix string = ",\"retry_policy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, *in.RetryPolicy)
+	}
+	if in.Priority != 0 {
+		const prefix string = ",\"priority\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.Priority))
+	}
+	if in.Timeout != "" {
+		const prefix string = ",\"timeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Timeout))
+	}
+	out.RawByte('}')
+}
+func easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in *jlexer.Lexer, out *RetryPolicy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeString()
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "max_attempts":
+			out.MaxAttempts = int(in.Int())
+		case "backoff":
+			out.Backoff = string(in.String())
+		case "exit_codes":
+			if in.IsNull() {
+				in.Skip()
+				out.ExitCodes = nil
+			} else {
+				in.Delim('[')
+				if out.ExitCodes == nil {
+					if !in.IsDelim(']') {
+						out.ExitCodes = make([]int, 0, 8)
+					} else {
+						out.ExitCodes = []int{}
+					}
+				} else {
+					out.ExitCodes = (out.ExitCodes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v64 int
+					v64 = int(in.Int())
+					out.ExitCodes = append(out.ExitCodes, v64)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "on_worker_lost":
+			out.OnWorkerLost = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out *jwriter.Writer, in RetryPolicy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"max_attempts\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.MaxAttempts))
+	}
+	if in.Backoff != "" {
+		const prefix string = ",\"backoff\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Backoff))
+	}
+	if len(in.ExitCodes) != 0 {
+		const prefix string = ",\"exit_codes\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v65, v66 := range in.ExitCodes {
+				if v65 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk13(out, v56)
+				out.Int(int(v66))
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.OnWorkerLost {
+		const prefix string = ",\"on_worker_lost\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.OnWorkerLost))
+	}
 	out.RawByte('}')
 }
 func easyjsonD7860c2dDecodeGithubComOvhCdsSdk13(in *jlexer.Lexer, out *PipelineBuildWarning) {
@@ -2167,11 +2468,11 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in *jlexer.Lexer, out *Action) {
 					out.Requirements = (out.Requirements)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v57 Requirement
+					var v67 Requirement
 					if data := in.Raw(); in.Ok() {
-						in.AddError((v57).UnmarshalJSON(data))
+						in.AddError((v67).UnmarshalJSON(data))
 					}
-					out.Requirements = append(out.Requirements, v57)
+					out.Requirements = append(out.Requirements, v67)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2192,9 +2493,9 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in *jlexer.Lexer, out *Action) {
 					out.Parameters = (out.Parameters)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v58 Parameter
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk2(in, &v58)
-					out.Parameters = append(out.Parameters, v58)
+					var v68 Parameter
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk2(in, &v68)
+					out.Parameters = append(out.Parameters, v68)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2215,9 +2516,9 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in *jlexer.Lexer, out *Action) {
 					out.Actions = (out.Actions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v59 Action
-					easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in, &v59)
-					out.Actions = append(out.Actions, v59)
+					var v69 Action
+					easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in, &v69)
+					out.Actions = append(out.Actions, v69)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2230,6 +2531,18 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk12(in *jlexer.Lexer, out *Action) {
 			out.Optional = bool(in.Bool())
 		case "always_executed":
 			out.AlwaysExecuted = bool(in.Bool())
+		case "retry_policy":
+			if in.IsNull() {
+				in.Skip()
+				out.RetryPolicy = nil
+			} else {
+				if out.RetryPolicy == nil {
+					out.RetryPolicy = new(RetryPolicy)
+				}
+				easyjsonD7860c2dDecodeGithubComOvhCdsSdk14(in, &*out.RetryPolicy)
+			}
+		case "timeout":
+			out.Timeout = string(in.String())
 		case "last_modified":
 			out.LastModified = int64(in.Int64())
 		default:
@@ -2308,11 +2621,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out *jwriter.Writer, in Action)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v60, v61 := range in.Requirements {
-				if v60 > 0 {
+			for v70, v71 := range in.Requirements {
+				if v70 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v61).MarshalJSON())
+				out.Raw((v71).MarshalJSON())
 			}
 			out.RawByte(']')
 		}
@@ -2329,11 +2642,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out *jwriter.Writer, in Action)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v62, v63 := range in.Parameters {
-				if v62 > 0 {
+			for v72, v73 := range in.Parameters {
+				if v72 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk2(out, v63)
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk2(out, v73)
 			}
 			out.RawByte(']')
 		}
@@ -2350,11 +2663,11 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out *jwriter.Writer, in Action)
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v64, v65 := range in.Actions {
-				if v64 > 0 {
+			for v74, v75 := range in.Actions {
+				if v74 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out, v65)
+				easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out, v75)
 			}
 			out.RawByte(']')
 		}
@@ -2399,6 +2712,26 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk12(out *jwriter.Writer, in Action)
 		}
 		out.Bool(bool(in.AlwaysExecuted))
 	}
+	if in.RetryPolicy != nil {
+		const prefix string = ",\"retry_policy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		easyjsonD7860c2dEncodeGithubComOvhCdsSdk14(out, *in.RetryPolicy)
+	}
+	if in.Timeout != "" {
+		const prefix string = ",\"timeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Timeout))
+	}
 	{
 		const prefix string = ",\"last_modified\":"
 		if first {
@@ -2442,6 +2775,8 @@ func easyjsonD7860c2dDecodeGithubComOvhCdsSdk11(in *jlexer.Lexer, out *StepStatu
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.Done).UnmarshalJSON(data))
 			}
+		case "exit_code":
+			out.ExitCode = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -2496,5 +2831,15 @@ func easyjsonD7860c2dEncodeGithubComOvhCdsSdk11(out *jwriter.Writer, in StepStat
 		}
 		out.Raw((in.Done).MarshalJSON())
 	}
+	if in.ExitCode != 0 {
+		const prefix string = ",\"exit_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.ExitCode))
+	}
 	out.RawByte('}')
 }
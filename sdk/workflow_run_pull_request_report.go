@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/sguiheux/go-coverage"
+)
+
+// PullRequestReportMaxItems is the maximum number of failed tests and of vulnerabilities listed in a pull request report
+const PullRequestReportMaxItems = 10
+
+// WorkflowNodeRunPullRequestReport is the summary of a node run posted as a comment on the pull request of its branch.
+// There is only one comment for a node of a workflow, updated by each run
+type WorkflowNodeRunPullRequestReport struct {
+	ProjectKey   string
+	WorkflowName string
+	NodeRun      WorkflowNodeRun
+	URL          string
+	// TargetBranch is the target branch of the pull request, and TargetCoverage the latest coverage report on this branch
+	TargetBranch   string
+	TargetCoverage *coverage.Report
+	// NewVulnerabilities are the vulnerabilities of the node run which are not found on the target branch
+	NewVulnerabilities []Vulnerability
+}
+
+// Title is the first line of the comment, it is used to find the comment to update
+func (r WorkflowNodeRunPullRequestReport) Title() string {
+	return fmt.Sprintf("**CDS report** %s/%s/%s", r.ProjectKey, r.WorkflowName, r.NodeRun.WorkflowNodeName)
+}
+
+// IsComment returns true if the comment is the report of the same node, posted by the user of CDS
+func (r WorkflowNodeRunPullRequestReport) IsComment(c VCSPullRequestComment) bool {
+	return c.Bot && strings.HasPrefix(c.Body, r.Title()+"\n")
+}
+
+// FailedTests returns the names of the failed test cases, at most PullRequestReportMaxItems
+func (r WorkflowNodeRunPullRequestReport) FailedTests() []string {
+	res := []string{}
+	if r.NodeRun.Tests == nil {
+		return res
+	}
+	for _, ts := range r.NodeRun.Tests.TestSuites {
+		for _, tc := range ts.TestCases {
+			if len(tc.Errors) == 0 && len(tc.Failures) == 0 {
+				continue
+			}
+			if len(res) == PullRequestReportMaxItems {
+				return res
+			}
+			res = append(res, ts.Name+" / "+tc.Name)
+		}
+	}
+	return res
+}
+
+// Coverage returns the percentage of covered lines of the node run, and its difference with the target branch.
+// It returns an empty string if there is no coverage report
+func (r WorkflowNodeRunPullRequestReport) Coverage() string {
+	current := r.NodeRun.Coverage.Report
+	if current.TotalLines == 0 {
+		return ""
+	}
+	percent := coveragePercent(current)
+	if r.TargetCoverage == nil || r.TargetCoverage.TotalLines == 0 {
+		return fmt.Sprintf("%.2f%%", percent)
+	}
+	return fmt.Sprintf("%.2f%% (%+.2f%% against %s)", percent, percent-coveragePercent(*r.TargetCoverage), r.TargetBranch)
+}
+
+func coveragePercent(r coverage.Report) float64 {
+	return float64(r.CoveredLines) * 100 / float64(r.TotalLines)
+}
+
+// Vulnerabilities returns the new vulnerabilities, at most PullRequestReportMaxItems
+func (r WorkflowNodeRunPullRequestReport) Vulnerabilities() []Vulnerability {
+	if len(r.NewVulnerabilities) > PullRequestReportMaxItems {
+		return r.NewVulnerabilities[:PullRequestReportMaxItems]
+	}
+	return r.NewVulnerabilities
+}
+
+const workflowNodeRunPullRequestReport = `{{.Title}}
+{{ with .NodeRun }}
+{{ if eq .Status "Success" -}} ✔ {{ else }}{{ if eq .Status "Fail" -}} ✘ {{ else }}- {{ end }}{{ end -}}
+{{- if $.URL }}[{{.WorkflowNodeName}} #{{.Number}}.{{.SubNumber}}]({{$.URL}}){{ else }}{{.WorkflowNodeName}} #{{.Number}}.{{.SubNumber}}{{ end }}: {{.Status}}
+{{- if .Tests }}
+
+**Tests**: {{.Tests.TotalOK}} passed, {{.Tests.TotalKO}} failed, {{.Tests.TotalSkipped}} skipped
+{{- range $.FailedTests }}
+* {{.}} ✘
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .Coverage }}
+
+**Coverage**: {{.}}
+{{- end }}
+{{- if .NewVulnerabilities }}
+
+**New vulnerabilities**: {{len .NewVulnerabilities}}
+{{- range .Vulnerabilities }}
+* {{.Severity}}: {{.Component}} {{.Version}}{{ if .CVE }} ({{.CVE}}){{ end }}{{ if .FixIn }}, fixed in {{.FixIn}}{{ end }}
+{{- end }}
+{{- end }}
+`
+
+// String computes the body of the comment
+func (r WorkflowNodeRunPullRequestReport) String() (string, error) {
+	t, err := template.New("").Parse(workflowNodeRunPullRequestReport)
+	if err != nil {
+		return "", err
+	}
+	out := new(bytes.Buffer)
+	if err := t.Execute(out, r); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// NewVulnerabilities returns the vulnerabilities which are not ignored and not found in the others
+func NewVulnerabilities(vulns, others []Vulnerability) []Vulnerability {
+	key := func(v Vulnerability) string {
+		return v.Component + "|" + v.Version + "|" + v.CVE + "|" + v.Title
+	}
+	known := make(map[string]struct{}, len(others))
+	for _, v := range others {
+		known[key(v)] = struct{}{}
+	}
+	res := []Vulnerability{}
+	for _, v := range vulns {
+		if _, ok := known[key(v)]; !ok && !v.Ignored {
+			res = append(res, v)
+		}
+	}
+	return res
+}
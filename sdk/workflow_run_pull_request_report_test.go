@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/ovh/venom"
+	"github.com/sguiheux/go-coverage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowNodeRunPullRequestReport(t *testing.T) {
+	r := WorkflowNodeRunPullRequestReport{
+		ProjectKey:   "KEY",
+		WorkflowName: "my-workflow",
+		URL:          "http://cds.local/project/KEY/workflow/my-workflow/run/12/node/5?name=my-workflow",
+		NodeRun: WorkflowNodeRun{
+			WorkflowNodeName: "build",
+			Number:           12,
+			Status:           StatusFail.String(),
+			Tests: &venom.Tests{
+				TotalOK: 1,
+				TotalKO: 1,
+				TestSuites: []venom.TestSuite{{
+					Name: "api",
+					TestCases: []venom.TestCase{
+						{Name: "TestGet"},
+						{Name: "TestPost", Failures: []venom.Failure{{}}},
+					},
+				}},
+			},
+			Coverage: WorkflowNodeRunCoverage{Report: coverage.Report{TotalLines: 200, CoveredLines: 150}},
+		},
+		TargetBranch:   "master",
+		TargetCoverage: &coverage.Report{TotalLines: 100, CoveredLines: 80},
+		NewVulnerabilities: []Vulnerability{
+			{Component: "lodash", Version: "4.17.4", CVE: "CVE-2018-3721", Severity: "high", FixIn: "4.17.5"},
+		},
+	}
+
+	assert.Equal(t, []string{"api / TestPost"}, r.FailedTests())
+	assert.Equal(t, "75.00% (-5.00% against master)", r.Coverage())
+
+	s, err := r.String()
+	assert.NoError(t, err)
+	assert.Equal(t, `**CDS report** KEY/my-workflow/build
+
+✘ [build #12.0](http://cds.local/project/KEY/workflow/my-workflow/run/12/node/5?name=my-workflow): Fail
+
+**Tests**: 1 passed, 1 failed, 0 skipped
+* api / TestPost ✘
+
+**Coverage**: 75.00% (-5.00% against master)
+
+**New vulnerabilities**: 1
+* high: lodash 4.17.4 (CVE-2018-3721), fixed in 4.17.5
+`, s)
+
+	assert.True(t, r.IsComment(VCSPullRequestComment{Body: s, Author: "cds", Bot: true}))
+	// A comment of another user starting with the title is not the report
+	assert.False(t, r.IsComment(VCSPullRequestComment{Body: s, Author: "john"}))
+	r.NodeRun.WorkflowNodeName = "deploy"
+	assert.False(t, r.IsComment(VCSPullRequestComment{Body: s, Author: "cds", Bot: true}))
+
+	// Without tests, coverage and vulnerabilities only the status is reported
+	r = WorkflowNodeRunPullRequestReport{
+		ProjectKey:   "KEY",
+		WorkflowName: "my-workflow",
+		NodeRun:      WorkflowNodeRun{WorkflowNodeName: "build", Number: 13, Status: StatusSuccess.String()},
+	}
+	assert.Empty(t, r.Coverage())
+	s, err = r.String()
+	assert.NoError(t, err)
+	assert.Equal(t, "**CDS report** KEY/my-workflow/build\n\n✔ build #13.0: Success\n", s)
+}
+
+func TestNewVulnerabilities(t *testing.T) {
+	vulns := []Vulnerability{
+		{Component: "lodash", Version: "4.17.4", CVE: "CVE-2018-3721"},
+		{Component: "jquery", Version: "1.9.0", CVE: "CVE-2015-9251"},
+		{Component: "moment", Version: "2.18.0", Title: "ReDoS", Ignored: true},
+	}
+	others := []Vulnerability{
+		{Component: "jquery", Version: "1.9.0", CVE: "CVE-2015-9251"},
+	}
+	res := NewVulnerabilities(vulns, others)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "lodash", res[0].Component)
+	}
+	assert.Len(t, NewVulnerabilities(vulns, nil), 2)
+}
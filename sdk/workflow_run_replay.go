@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/fsamin/go-dump"
+)
+
+// WorkflowRunReplay asks for a new run of a workflow with the parameters of a previous run
+type WorkflowRunReplay struct {
+	Number    int64       `json:"number"`
+	Overrides []Parameter `json:"overrides,omitempty"`
+}
+
+// replayedGitParameters are the git build parameters replayed when they are not in the payload, so that the replay runs the same commit
+var replayedGitParameters = []string{"git.branch", "git.tag", "git.hash"}
+
+// NewWorkflowNodeRunManualFromReplay computes a manual run from a node run: the cds.pip.* build parameters are the pipeline
+// parameters and the payload is the payload of the node run, with the branch, the tag and the commit of the node run.
+// The variables of the project, the application and the environment are not replayed, the new run uses their current values.
+// The payload is flattened like the payload of a run, an override replaces the pipeline parameter with the same name, or else a value of the payload.
+func NewWorkflowNodeRunManualFromReplay(nodeRun WorkflowNodeRun, overrides []Parameter) (*WorkflowNodeRunManual, error) {
+	m := &WorkflowNodeRunManual{}
+
+	for _, p := range nodeRun.BuildParameters {
+		if !strings.HasPrefix(p.Name, "cds.pip.") {
+			continue
+		}
+		name := strings.TrimPrefix(p.Name, "cds.pip.")
+		param := ParameterFind(&nodeRun.PipelineParameters, name)
+		if param == nil {
+			continue
+		}
+		AddParameter(&m.PipelineParameters, name, param.Type, p.Value)
+	}
+
+	payload := map[string]string{}
+	if nodeRun.Payload != nil {
+		e := dump.NewDefaultEncoder(new(bytes.Buffer))
+		e.Formatters = []dump.KeyFormatterFunc{dump.WithDefaultLowerCaseFormatter()}
+		e.ExtraFields.DetailedMap = false
+		e.ExtraFields.DetailedStruct = false
+		e.ExtraFields.Len = false
+		e.ExtraFields.Type = false
+		m, err := e.ToStringMap(nodeRun.Payload)
+		if err != nil {
+			return nil, WrapError(err, "NewWorkflowNodeRunManualFromReplay> Unable to compute payload")
+		}
+		payload = m
+	}
+	for _, name := range replayedGitParameters {
+		if _, ok := payload[name]; ok {
+			continue
+		}
+		if v := ParameterValue(nodeRun.BuildParameters, name); v != "" {
+			payload[name] = v
+		}
+	}
+
+	for _, o := range overrides {
+		if p := ParameterFind(&m.PipelineParameters, o.Name); p != nil {
+			p.Value = o.Value
+			continue
+		}
+		payload[o.Name] = o.Value
+	}
+	m.Payload = payload
+	return m, nil
+}
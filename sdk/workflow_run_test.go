@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ovh/venom"
 	"github.com/stretchr/testify/assert"
@@ -168,3 +169,42 @@ func TestWorkflowQueue_Sort(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowQueue_SortFairShare(t *testing.T) {
+	now := time.Now()
+	q := WorkflowQueue{
+		{ID: 1, ProjectID: 1, Queued: now.Add(-5 * time.Minute)},
+		{ID: 2, ProjectID: 1, Queued: now.Add(-4 * time.Minute)},
+		{ID: 3, ProjectID: 1, Queued: now.Add(-3 * time.Minute), Job: ExecutedJob{Job: Job{Priority: 5}}},
+		{ID: 4, ProjectID: 2, Queued: now.Add(-1 * time.Minute)},
+		{ID: 5, ProjectID: 3, Queued: now.Add(-2 * time.Minute)},
+	}
+
+	q.SortFairShare(nil)
+	ids := make([]int64, len(q))
+	for i := range q {
+		ids[i] = q[i].ID
+	}
+	assert.Equal(t, []int64{3, 5, 4, 1, 2}, ids)
+
+	// Workers being started for the project 3 delay its next job
+	q.SortFairShare(map[int64]int{3: 2})
+	for i := range q {
+		ids[i] = q[i].ID
+	}
+	assert.Equal(t, []int64{3, 4, 1, 5, 2}, ids)
+}
+
+func TestWorkflowNodeRunArtifactBlobPath(t *testing.T) {
+	art := WorkflowNodeRunArtifact{WorkflowID: 1, WorkflowNodeRunID: 2, Name: "bin", Ref: "ref"}
+	assert.Equal(t, "1-2-ref", art.GetPath())
+	assert.Equal(t, "bin", art.GetName())
+
+	// Artifacts with the same content in the same project share the same object
+	art.BlobKey = ArtifactBlobKey(42, "abcdef")
+	other := WorkflowNodeRunArtifact{WorkflowID: 3, WorkflowNodeRunID: 4, Name: "other", Ref: "ref", BlobKey: ArtifactBlobKey(42, "abcdef")}
+	assert.Equal(t, other.GetPath(), art.GetPath())
+	assert.Equal(t, other.GetName(), art.GetName())
+	assert.NotEqual(t, ArtifactBlobKey(43, "abcdef"), art.GetName())
+	assert.Equal(t, "", ArtifactBlobKey(42, ""))
+}
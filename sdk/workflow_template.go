@@ -0,0 +1,162 @@
+package sdk
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Workflow template parameter types
+const (
+	WorkflowTemplateParameterTypeString  = "string"
+	WorkflowTemplateParameterTypeBoolean = "boolean"
+	WorkflowTemplateParameterTypeNumber  = "number"
+)
+
+// WorkflowTemplateParameterTypes are the available types for a workflow template parameter
+var WorkflowTemplateParameterTypes = []string{
+	WorkflowTemplateParameterTypeString,
+	WorkflowTemplateParameterTypeBoolean,
+	WorkflowTemplateParameterTypeNumber,
+}
+
+var workflowTemplateNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// WorkflowTemplate is stored by the API and generates a workflow, with its pipelines, applications and environments,
+// from the values of its parameters. The sources are the yaml files of the exported entities, templated with
+// [[ ]] delimiters to not conflict with the CDS variables: [[.name]] is the name of the workflow and
+// [[.params.key]] the value of a parameter.
+type WorkflowTemplate struct {
+	ID           int64                       `json:"id" cli:"id,key"`
+	GroupID      int64                       `json:"group_id" cli:"-"`
+	Group        *Group                      `json:"group,omitempty" cli:"-"`
+	Name         string                      `json:"name" cli:"name"`
+	Description  string                      `json:"description" cli:"description"`
+	Version      int64                       `json:"version" cli:"version"`
+	Updated      time.Time                   `json:"updated" cli:"updated"`
+	Parameters   []WorkflowTemplateParameter `json:"parameters" cli:"-"`
+	Workflow     string                      `json:"workflow" cli:"-"`
+	Pipelines    []string                    `json:"pipelines" cli:"-"`
+	Applications []string                    `json:"applications" cli:"-"`
+	Environments []string                    `json:"environments" cli:"-"`
+}
+
+// WorkflowTemplateParameter is a typed parameter of a workflow template
+type WorkflowTemplateParameter struct {
+	Key      string `json:"key"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// WorkflowTemplateRequest contains the values of the parameters to instantiate a workflow template in a project
+type WorkflowTemplateRequest struct {
+	WorkflowName string            `json:"workflow_name"`
+	Parameters   map[string]string `json:"parameters"`
+}
+
+// WorkflowTemplateInstance is a workflow generated by a workflow template. It remembers the request and the
+// version of the template, to upgrade the workflow when the template changes.
+type WorkflowTemplateInstance struct {
+	ID                      int64                   `json:"id" cli:"id,key"`
+	WorkflowTemplateID      int64                   `json:"workflow_template_id" cli:"-"`
+	WorkflowTemplateVersion int64                   `json:"workflow_template_version" cli:"version"`
+	ProjectID               int64                   `json:"project_id" cli:"-"`
+	ProjectKey              string                  `json:"project_key" cli:"project"`
+	WorkflowID              *int64                  `json:"workflow_id,omitempty" cli:"-"`
+	Request                 WorkflowTemplateRequest `json:"request" cli:"-"`
+}
+
+// WorkflowTemplateBulkResult is the result of the upgrade of an instance of a workflow template
+type WorkflowTemplateBulkResult struct {
+	InstanceID   int64  `json:"instance_id" cli:"id,key"`
+	ProjectKey   string `json:"project_key" cli:"project"`
+	WorkflowName string `json:"workflow_name" cli:"workflow"`
+	Error        string `json:"error,omitempty" cli:"error"`
+}
+
+// IsValid checks the name and the parameters of the workflow template
+func (t *WorkflowTemplate) IsValid() error {
+	if !workflowTemplateNamePattern.MatchString(t.Name) {
+		return NewError(ErrWrongRequest, fmt.Errorf("invalid workflow template name %s", t.Name))
+	}
+	if t.GroupID == 0 {
+		return NewError(ErrWrongRequest, fmt.Errorf("a workflow template must belong to a group"))
+	}
+	if t.Workflow == "" {
+		return NewError(ErrWrongRequest, fmt.Errorf("a workflow template must define a workflow"))
+	}
+
+	keys := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !workflowTemplateNamePattern.MatchString(p.Key) {
+			return NewError(ErrWrongRequest, fmt.Errorf("invalid parameter key %s", p.Key))
+		}
+		if _, ok := keys[p.Key]; ok {
+			return NewError(ErrWrongRequest, fmt.Errorf("duplicate parameter %s", p.Key))
+		}
+		keys[p.Key] = struct{}{}
+
+		var known bool
+		for _, typ := range WorkflowTemplateParameterTypes {
+			if p.Type == typ {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return NewError(ErrWrongRequest, fmt.Errorf("invalid type %s for parameter %s", p.Type, p.Key))
+		}
+	}
+	return nil
+}
+
+// CheckParameters checks the values of the request against the parameters of the template,
+// and returns the typed values to execute the template
+func (t *WorkflowTemplate) CheckParameters(r WorkflowTemplateRequest) (map[string]interface{}, error) {
+	if !workflowTemplateNamePattern.MatchString(r.WorkflowName) {
+		return nil, NewError(ErrWrongRequest, fmt.Errorf("invalid workflow name %s", r.WorkflowName))
+	}
+
+	values := make(map[string]interface{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		v := r.Parameters[p.Key]
+		if v == "" && p.Required {
+			return nil, NewError(ErrWrongRequest, fmt.Errorf("parameter %s is required", p.Key))
+		}
+
+		switch p.Type {
+		case WorkflowTemplateParameterTypeBoolean:
+			b, err := strconv.ParseBool(v)
+			if err != nil && v != "" {
+				return nil, NewError(ErrWrongRequest, fmt.Errorf("parameter %s must be a boolean", p.Key))
+			}
+			values[p.Key] = b
+		case WorkflowTemplateParameterTypeNumber:
+			var n float64
+			if v != "" {
+				var err error
+				n, err = strconv.ParseFloat(v, 64)
+				if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+					return nil, NewError(ErrWrongRequest, fmt.Errorf("parameter %s must be a number", p.Key))
+				}
+			}
+			values[p.Key] = n
+		default:
+			if strings.IndexFunc(v, unicode.IsControl) >= 0 {
+				return nil, NewError(ErrWrongRequest, fmt.Errorf("parameter %s must not contain control characters", p.Key))
+			}
+			values[p.Key] = v
+		}
+	}
+
+	for k := range r.Parameters {
+		if _, ok := values[k]; !ok {
+			return nil, NewError(ErrWrongRequest, fmt.Errorf("unknown parameter %s", k))
+		}
+	}
+	return values, nil
+}
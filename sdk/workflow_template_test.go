@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowTemplateIsValid(t *testing.T) {
+	tmpl := WorkflowTemplate{
+		Name:     "go-service",
+		GroupID:  1,
+		Workflow: "name: [[.name]]",
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "repo", Type: WorkflowTemplateParameterTypeString, Required: true},
+			{Key: "with-deploy", Type: WorkflowTemplateParameterTypeBoolean},
+		},
+	}
+	assert.NoError(t, tmpl.IsValid())
+
+	tmpl.Parameters = append(tmpl.Parameters, WorkflowTemplateParameter{Key: "repo", Type: WorkflowTemplateParameterTypeString})
+	assert.Error(t, tmpl.IsValid())
+
+	tmpl.Parameters[2] = WorkflowTemplateParameter{Key: "replicas", Type: "integer"}
+	assert.Error(t, tmpl.IsValid())
+
+	tmpl.Parameters = nil
+	tmpl.Name = "go service"
+	assert.Error(t, tmpl.IsValid())
+}
+
+func TestWorkflowTemplateCheckParameters(t *testing.T) {
+	tmpl := WorkflowTemplate{
+		Parameters: []WorkflowTemplateParameter{
+			{Key: "repo", Type: WorkflowTemplateParameterTypeString, Required: true},
+			{Key: "with-deploy", Type: WorkflowTemplateParameterTypeBoolean},
+			{Key: "replicas", Type: WorkflowTemplateParameterTypeNumber},
+		},
+	}
+
+	values, err := tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds", "with-deploy": "true", "replicas": "3"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"repo": "ovh/cds", "with-deploy": true, "replicas": float64(3)}, values)
+
+	values, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, false, values["with-deploy"])
+
+	_, err = tmpl.CheckParameters(WorkflowTemplateRequest{WorkflowName: "my-service"})
+	assert.Error(t, err, "repo is required")
+
+	_, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds", "replicas": "three"},
+	})
+	assert.Error(t, err)
+
+	for _, replicas := range []string{"NaN", "Inf", "-Inf"} {
+		_, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+			WorkflowName: "my-service",
+			Parameters:   map[string]string{"repo": "ovh/cds", "replicas": replicas},
+		})
+		assert.Error(t, err, replicas)
+	}
+
+	_, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds\nvcs_ssh_key: proj-admin"},
+	})
+	assert.Error(t, err)
+
+	_, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my-service",
+		Parameters:   map[string]string{"repo": "ovh/cds", "region": "eu"},
+	})
+	assert.Error(t, err)
+
+	_, err = tmpl.CheckParameters(WorkflowTemplateRequest{
+		WorkflowName: "my service",
+		Parameters:   map[string]string{"repo": "ovh/cds"},
+	})
+	assert.Error(t, err)
+}